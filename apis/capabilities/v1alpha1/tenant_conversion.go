@@ -0,0 +1,190 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+const (
+	// TenantV1beta1SpecAnnotation keeps the v1beta1 only spec fields of tenants served as v1alpha1,
+	// so they survive the round trip between versions
+	TenantV1beta1SpecAnnotation = "tenant.capabilities.3scale.net/v1beta1-spec"
+	// TenantV1beta1StatusAnnotation keeps the v1beta1 only status fields of tenants served as v1alpha1
+	TenantV1beta1StatusAnnotation = "tenant.capabilities.3scale.net/v1beta1-status"
+)
+
+// tenantV1beta1Spec are the v1beta1 spec fields not available in v1alpha1
+type tenantV1beta1Spec struct {
+	Suspended       *bool   `json:"suspended,omitempty"`
+	AccountPlan     *string `json:"accountPlan,omitempty"`
+	AdminDomain     *string `json:"adminDomain,omitempty"`
+	DeveloperDomain *string `json:"developerDomain,omitempty"`
+
+	AccessTokens *capabilitiesv1beta1.TenantAccessTokensSpec `json:"accessTokens,omitempty"`
+	Deletion     *capabilitiesv1beta1.TenantDeletionSpec     `json:"deletion,omitempty"`
+}
+
+// tenantV1beta1Status are the v1beta1 status fields not available in v1alpha1
+type tenantV1beta1Status struct {
+	State           string `json:"state,omitempty"`
+	AccountPlan     string `json:"accountPlan,omitempty"`
+	AdminDomain     string `json:"adminDomain,omitempty"`
+	DeveloperDomain string `json:"developerDomain,omitempty"`
+
+	AccessTokens *capabilitiesv1beta1.TenantAccessTokensStatus `json:"accessTokens,omitempty"`
+	DeletionTime *metav1.Time                                  `json:"deletionTime,omitempty"`
+}
+
+var _ conversion.Convertible = &Tenant{}
+
+// ConvertTo converts this Tenant to the Hub version (v1beta1)
+func (t *Tenant) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*capabilitiesv1beta1.Tenant)
+	if !ok {
+		return fmt.Errorf("%T is not a *v1beta1.Tenant", dstRaw)
+	}
+
+	dst.ObjectMeta = *t.ObjectMeta.DeepCopy()
+
+	dst.Spec.Username = t.Spec.Username
+	dst.Spec.Email = t.Spec.Email
+	dst.Spec.OrganizationName = t.Spec.OrganizationName
+	dst.Spec.SystemMasterUrl = t.Spec.SystemMasterUrl
+	dst.Spec.TenantSecretRef = t.Spec.TenantSecretRef
+	dst.Spec.PasswordCredentialsRef = t.Spec.PasswordCredentialsRef
+	dst.Spec.MasterCredentialsRef = t.Spec.MasterCredentialsRef
+	dst.Spec.FromEmail = t.Spec.FromEmail
+	dst.Spec.SupportEmail = t.Spec.SupportEmail
+	dst.Spec.FinanceSupportEmail = t.Spec.FinanceSupportEmail
+	dst.Spec.SiteAccessCode = t.Spec.SiteAccessCode
+
+	dst.Status.TenantId = t.Status.TenantId
+	dst.Status.AdminId = t.Status.AdminId
+	dst.Status.Conditions = t.Status.DeepCopy().Conditions
+
+	if value, ok := dst.Annotations[TenantV1beta1SpecAnnotation]; ok {
+		spec := &tenantV1beta1Spec{}
+		if err := json.Unmarshal([]byte(value), spec); err != nil {
+			return fmt.Errorf("failed to read annotation %s: %w", TenantV1beta1SpecAnnotation, err)
+		}
+		dst.Spec.Suspended = spec.Suspended
+		dst.Spec.AccountPlan = spec.AccountPlan
+		dst.Spec.AdminDomain = spec.AdminDomain
+		dst.Spec.DeveloperDomain = spec.DeveloperDomain
+		dst.Spec.AccessTokens = spec.AccessTokens
+		dst.Spec.Deletion = spec.Deletion
+		delete(dst.Annotations, TenantV1beta1SpecAnnotation)
+	}
+
+	if value, ok := dst.Annotations[TenantV1beta1StatusAnnotation]; ok {
+		status := &tenantV1beta1Status{}
+		if err := json.Unmarshal([]byte(value), status); err != nil {
+			return fmt.Errorf("failed to read annotation %s: %w", TenantV1beta1StatusAnnotation, err)
+		}
+		dst.Status.State = status.State
+		dst.Status.AccountPlan = status.AccountPlan
+		dst.Status.AdminDomain = status.AdminDomain
+		dst.Status.DeveloperDomain = status.DeveloperDomain
+		dst.Status.AccessTokens = status.AccessTokens
+		dst.Status.DeletionTime = status.DeletionTime
+		delete(dst.Annotations, TenantV1beta1StatusAnnotation)
+	}
+
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (t *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*capabilitiesv1beta1.Tenant)
+	if !ok {
+		return fmt.Errorf("%T is not a *v1beta1.Tenant", srcRaw)
+	}
+
+	t.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	t.Spec.Username = src.Spec.Username
+	t.Spec.Email = src.Spec.Email
+	t.Spec.OrganizationName = src.Spec.OrganizationName
+	t.Spec.SystemMasterUrl = src.Spec.SystemMasterUrl
+	t.Spec.TenantSecretRef = src.Spec.TenantSecretRef
+	t.Spec.PasswordCredentialsRef = src.Spec.PasswordCredentialsRef
+	t.Spec.MasterCredentialsRef = src.Spec.MasterCredentialsRef
+	t.Spec.FromEmail = src.Spec.FromEmail
+	t.Spec.SupportEmail = src.Spec.SupportEmail
+	t.Spec.FinanceSupportEmail = src.Spec.FinanceSupportEmail
+	t.Spec.SiteAccessCode = src.Spec.SiteAccessCode
+
+	t.Status.TenantId = src.Status.TenantId
+	t.Status.AdminId = src.Status.AdminId
+	t.Status.Conditions = src.Status.DeepCopy().Conditions
+
+	spec := tenantV1beta1Spec{
+		Suspended:       src.Spec.Suspended,
+		AccountPlan:     src.Spec.AccountPlan,
+		AdminDomain:     src.Spec.AdminDomain,
+		DeveloperDomain: src.Spec.DeveloperDomain,
+		AccessTokens:    src.Spec.AccessTokens.DeepCopy(),
+		Deletion:        src.Spec.Deletion.DeepCopy(),
+	}
+	if !reflect.DeepEqual(spec, tenantV1beta1Spec{}) {
+		if err := t.setAnnotationJSON(TenantV1beta1SpecAnnotation, spec); err != nil {
+			return err
+		}
+	}
+
+	status := tenantV1beta1Status{
+		State:           src.Status.State,
+		AccountPlan:     src.Status.AccountPlan,
+		AdminDomain:     src.Status.AdminDomain,
+		DeveloperDomain: src.Status.DeveloperDomain,
+		AccessTokens:    src.Status.AccessTokens.DeepCopy(),
+		DeletionTime:    src.Status.DeletionTime.DeepCopy(),
+	}
+	if !reflect.DeepEqual(status, tenantV1beta1Status{}) {
+		if err := t.setAnnotationJSON(TenantV1beta1StatusAnnotation, status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Tenant) setAnnotationJSON(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if t.Annotations == nil {
+		t.Annotations = map[string]string{}
+	}
+	t.Annotations[key] = string(data)
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+func getTestTenantV1beta1() *capabilitiesv1beta1.Tenant {
+	return &capabilitiesv1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example",
+			Namespace:   "test",
+			Annotations: map[string]string{"tenantID": "3"},
+		},
+		Spec: capabilitiesv1beta1.TenantSpec{
+			Username:               "admin",
+			Email:                  "admin@example.com",
+			OrganizationName:       "Example",
+			SystemMasterUrl:        "https://master.example.com",
+			TenantSecretRef:        corev1.SecretReference{Name: "example-tenant", Namespace: "test"},
+			PasswordCredentialsRef: corev1.SecretReference{Name: "admin-password"},
+			MasterCredentialsRef:   corev1.SecretReference{Name: "system-seed"},
+			SupportEmail:           ptr.To("support@example.com"),
+			Suspended:              ptr.To(true),
+			AccountPlan:            ptr.To("enterprise"),
+			AdminDomain:            ptr.To("admin.example.com"),
+			DeveloperDomain:        ptr.To("developer.example.com"),
+			AccessTokens: &capabilitiesv1beta1.TenantAccessTokensSpec{
+				Scoped: []capabilitiesv1beta1.TenantScopedAccessTokenSpec{
+					{Name: "readOnly", Permission: "ro", Scopes: []capabilitiesv1beta1.TenantAccessTokenScope{"account_management"}},
+				},
+			},
+			Deletion: &capabilitiesv1beta1.TenantDeletionSpec{Policy: ptr.To(capabilitiesv1beta1.TenantDeletionPolicySuspend)},
+		},
+		Status: capabilitiesv1beta1.TenantStatus{
+			TenantId:        3,
+			AdminId:         4,
+			State:           capabilitiesv1beta1.TenantStateSuspended,
+			AccountPlan:     "enterprise",
+			AdminDomain:     "admin.example.com",
+			DeveloperDomain: "developer.example.com",
+			AccessTokens: &capabilitiesv1beta1.TenantAccessTokensStatus{
+				Tokens: []capabilitiesv1beta1.TenantAccessTokenStatus{{Name: "readOnly", ID: 5, Permission: "ro"}},
+			},
+			Conditions: common.Conditions{
+				{Type: TenantReadyConditionType, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func TestTenantConversionRoundTrip(t *testing.T) {
+	hub := getTestTenantV1beta1()
+
+	spoke := &Tenant{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() error = %v", err)
+	}
+
+	if spoke.Spec.SupportEmail == nil || *spoke.Spec.SupportEmail != "support@example.com" {
+		t.Errorf("support email not converted: %v", spoke.Spec.SupportEmail)
+	}
+	if _, ok := spoke.Annotations[TenantV1beta1SpecAnnotation]; !ok {
+		t.Errorf("v1beta1 spec annotation missing: %v", spoke.Annotations)
+	}
+	if _, ok := hub.Annotations[TenantV1beta1SpecAnnotation]; ok {
+		t.Errorf("hub annotations modified: %v", hub.Annotations)
+	}
+
+	converted := &capabilitiesv1beta1.Tenant{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(hub, converted) {
+		t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v", converted, hub)
+	}
+}
+
+func TestTenantConversionTo(t *testing.T) {
+	spoke := &Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "test"},
+		Spec: TenantSpec{
+			Username:         "admin",
+			OrganizationName: "Example",
+		},
+		Status: TenantStatus{TenantId: 3, AdminId: 4},
+	}
+
+	hub := &capabilitiesv1beta1.Tenant{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+
+	if hub.Spec.OrganizationName != "Example" || hub.Status.TenantId != 3 {
+		t.Errorf("unexpected conversion: %+v", hub)
+	}
+	if hub.IsSuspended() || hub.Spec.AccountPlan != nil || hub.Annotations != nil {
+		t.Errorf("unexpected v1beta1 fields: %+v", hub)
+	}
+}
@@ -79,6 +79,7 @@ type TenantStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:deprecatedversion:warning="capabilities.3scale.net/v1alpha1 Tenant is deprecated, use capabilities.3scale.net/v1beta1 Tenant"
 
 // Tenant is the Schema for the tenants API
 // +kubebuilder:resource:path=tenants,scope=Namespaced
@@ -0,0 +1,238 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	AccountPlanKind = "AccountPlan"
+
+	// AccountPlanInvalidConditionType represents that the combination of configuration
+	// in the AccountPlanSpec is not supported. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	AccountPlanInvalidConditionType common.ConditionType = "Invalid"
+
+	// AccountPlanReadyConditionType indicates the account plan has been successfully synchronized.
+	// Steady state
+	AccountPlanReadyConditionType common.ConditionType = "Ready"
+
+	// AccountPlanFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	AccountPlanFailedConditionType common.ConditionType = "Failed"
+)
+
+var accountPlanSystemNameRegexp = regexp.MustCompile("[^a-zA-Z0-9_]+")
+
+// FeatureSpec defines the desired state of a plan feature
+type FeatureSpec struct {
+	// Name is human readable name for the feature
+	Name string `json:"name"`
+
+	// Description is a human readable text of the feature
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// AccountPlanSpec defines the desired state of AccountPlan
+type AccountPlanSpec struct {
+	// Name is human readable name for the account plan
+	Name string `json:"name"`
+
+	// SystemName identifies uniquely the account plan within the account provider
+	// Default value will be sanitized Name
+	// +optional
+	SystemName string `json:"systemName,omitempty"`
+
+	// Set whether or not developer accounts can be created on demand
+	// or if approval is required from you before they are activated.
+	// +optional
+	ApprovalRequired *bool `json:"approvalRequired,omitempty"`
+
+	// Trial Period (days)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TrialPeriod *int `json:"trialPeriod,omitempty"`
+
+	// Setup fee (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	SetupFee *string `json:"setupFee,omitempty"`
+
+	// Cost per Month (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	CostMonth *string `json:"costMonth,omitempty"`
+
+	// Controls whether the account plan is published. If not specified it is
+	// hidden by default
+	// +optional
+	Published *bool `json:"published,omitempty"`
+
+	// Default sets the account plan as the default one of the provider account.
+	// New developer accounts are signed up in the default account plan.
+	// +optional
+	Default *bool `json:"default,omitempty"`
+
+	// Features enabled in the plan
+	// Map: system_name -> Feature Spec
+	// Account features are shared by all the account plans of the provider account.
+	// Missing features are created, existing features are updated.
+	// +optional
+	Features map[string]FeatureSpec `json:"features,omitempty"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+}
+
+func (s *AccountPlanSpec) IsPublished() bool {
+	return s.Published != nil && *s.Published
+}
+
+func (s *AccountPlanSpec) IsDefault() bool {
+	return s.Default != nil && *s.Default
+}
+
+// AccountPlanStatus defines the observed state of AccountPlan
+type AccountPlanStatus struct {
+	// +optional
+	ID *int64 `json:"accountPlanID,omitempty"`
+
+	// +optional
+	State *string `json:"state,omitempty"`
+
+	// ProviderAccountHost contains the 3scale account's provider URL
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed AccountPlan Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the account plan resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (s *AccountPlanStatus) Equals(other *AccountPlanStatus, logger logr.Logger) bool {
+	if !reflect.DeepEqual(s.ID, other.ID) {
+		diff := cmp.Diff(s.ID, other.ID)
+		logger.V(1).Info("ID not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.State, other.State) {
+		diff := cmp.Diff(s.State, other.State)
+		logger.V(1).Info("State not equal", "difference", diff)
+		return false
+	}
+
+	if s.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(s.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := s.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.providerAccountHost",name="Provider Account",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.accountPlanID",name="3scale ID",type=integer
+
+// AccountPlan is the Schema for the accountplans API
+type AccountPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountPlanSpec   `json:"spec,omitempty"`
+	Status AccountPlanStatus `json:"status,omitempty"`
+}
+
+func (a *AccountPlan) SetDefaults() bool {
+	updated := false
+
+	// Respect 3scale API defaults
+	if a.Spec.SystemName == "" {
+		a.Spec.SystemName = accountPlanSystemNameRegexp.ReplaceAllString(a.Spec.Name, "")
+		updated = true
+	}
+
+	return updated
+}
+
+func (a *AccountPlan) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	specFldPath := field.NewPath("spec")
+	if a.Spec.SystemName == "" {
+		errors = append(errors, field.Required(specFldPath.Child("systemName"), "system name cannot be empty"))
+	}
+
+	return errors
+}
+
+func (a *AccountPlan) IsReady() bool {
+	return a.Status.Conditions.IsTrueFor(AccountPlanReadyConditionType)
+}
+
+// +kubebuilder:object:root=true
+
+// AccountPlanList contains a list of AccountPlan
+type AccountPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccountPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AccountPlan{}, &AccountPlanList{})
+}
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/go-logr/logr"
@@ -68,6 +69,38 @@ type ActiveDocOpenAPIRefSpec struct {
 	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
 	// +optional
 	URL *string `json:"url,omitempty"`
+
+	// ConfigMapRef refers to the ConfigMap key that contains the OpenAPI Document
+	// +optional
+	ConfigMapRef *OpenAPIConfigMapRefSpec `json:"configMapRef,omitempty"`
+
+	// Git refers to an OpenAPI Document in a git repository
+	// +optional
+	Git *OpenAPIGitSourceSpec `json:"git,omitempty"`
+
+	// OCI refers to an OpenAPI Document published as an OCI artifact
+	// +optional
+	OCI *OpenAPIOCISourceSpec `json:"oci,omitempty"`
+
+	// RefreshInterval is the interval between reads of the URL, Git and OCI sources.
+	// ConfigMap sources are read again when they change.
+	// Defaults to 5 minutes
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// Polled returns true when the OpenAPI Document is read periodically
+func (o *ActiveDocOpenAPIRefSpec) Polled() bool {
+	return o.SecretRef == nil && o.ConfigMapRef == nil
+}
+
+// RefreshIntervalDuration returns the interval between reads of polled sources
+func (o *ActiveDocOpenAPIRefSpec) RefreshIntervalDuration() time.Duration {
+	if o.RefreshInterval == nil || o.RefreshInterval.Duration <= 0 {
+		return DefaultOpenAPIRefreshInterval
+	}
+
+	return o.RefreshInterval.Duration
 }
 
 // ActiveDocSpec defines the desired state of ActiveDoc
@@ -124,6 +157,27 @@ type ActiveDocStatus struct {
 	// +optional
 	ProductResourceName *corev1.LocalObjectReference `json:"productResourceName,omitempty"`
 
+	// SourceRevision is the revision of the last read OpenAPI Document:
+	// the git commit hash, the OCI manifest digest or the ConfigMap resource version
+	// +optional
+	SourceRevision string `json:"sourceRevision,omitempty"`
+
+	// SourceHash is the content hash of the last read OpenAPI Document
+	// +optional
+	SourceHash string `json:"sourceHash,omitempty"`
+
+	// SourceFetchTime is the time the OpenAPI Document content with SourceHash was first read
+	// +optional
+	SourceFetchTime *metav1.Time `json:"sourceFetchTime,omitempty"`
+
+	// SourceDocumentVersion is the swagger or openapi version declared by the last read OpenAPI Document
+	// +optional
+	SourceDocumentVersion string `json:"sourceDocumentVersion,omitempty"`
+
+	// SourceConversions lists the conversions applied to the last read OpenAPI Document to import it as OpenAPI 3.0
+	// +optional
+	SourceConversions []string `json:"sourceConversions,omitempty"`
+
 	// ObservedGeneration reflects the generation of the most recently observed Backend Spec.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -155,6 +209,36 @@ func (o *ActiveDocStatus) Equals(other *ActiveDocStatus, logger logr.Logger) boo
 		return false
 	}
 
+	if o.SourceRevision != other.SourceRevision {
+		diff := cmp.Diff(o.SourceRevision, other.SourceRevision)
+		logger.V(1).Info("SourceRevision not equal", "difference", diff)
+		return false
+	}
+
+	if o.SourceHash != other.SourceHash {
+		diff := cmp.Diff(o.SourceHash, other.SourceHash)
+		logger.V(1).Info("SourceHash not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(o.SourceFetchTime, other.SourceFetchTime) {
+		diff := cmp.Diff(o.SourceFetchTime, other.SourceFetchTime)
+		logger.V(1).Info("SourceFetchTime not equal", "difference", diff)
+		return false
+	}
+
+	if o.SourceDocumentVersion != other.SourceDocumentVersion {
+		diff := cmp.Diff(o.SourceDocumentVersion, other.SourceDocumentVersion)
+		logger.V(1).Info("SourceDocumentVersion not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(o.SourceConversions, other.SourceConversions) {
+		diff := cmp.Diff(o.SourceConversions, other.SourceConversions)
+		logger.V(1).Info("SourceConversions not equal", "difference", diff)
+		return false
+	}
+
 	if o.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(o.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
@@ -206,6 +290,11 @@ func (a *ActiveDoc) SetDefaults(logger logr.Logger) bool {
 		updated = true
 	}
 
+	if a.Spec.ActiveDocOpenAPIRef.ConfigMapRef != nil && a.Spec.ActiveDocOpenAPIRef.ConfigMapRef.Namespace == "" {
+		a.Spec.ActiveDocOpenAPIRef.ConfigMapRef.Namespace = a.GetNamespace()
+		updated = true
+	}
+
 	return updated
 }
 
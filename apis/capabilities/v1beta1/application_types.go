@@ -29,7 +29,10 @@ import (
 )
 
 const (
-	ApplicationReadyConditionType common.ConditionType = "Ready"
+	ApplicationKind = "Application"
+
+	ApplicationReadyConditionType              common.ConditionType = "Ready"
+	ApplicationInvalidExtraFieldsConditionType common.ConditionType = "InvalidExtraFields"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -47,9 +50,16 @@ type ApplicationSpec struct {
 	// ProductCRName of product custom resource from which the application plan will be used
 	ProductCR *corev1.LocalObjectReference `json:"productCR"`
 
-	// ApplicationPlanName name of application plan that the application will use
+	// ApplicationPlanName name of application plan that the application will use.
+	// Changing it on an existing application changes the plan in place, keeping the application credentials.
 	ApplicationPlanName string `json:"applicationPlanName"`
 
+	// ForceApprovalRequiredPlanChange changes the plan of an existing application
+	// to an application plan that requires approval, bypassing the approval.
+	// By default, plan changes to plans that require approval are rejected.
+	//+optional
+	ForceApprovalRequiredPlanChange bool `json:"forceApprovalRequiredPlanChange,omitempty"`
+
 	// Name identifies the application uniquely within the account
 	Name string `json:"name"`
 
@@ -64,6 +74,18 @@ type ApplicationSpec struct {
 	// used only once when creating a new application
 	//+optional
 	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef"`
+
+	// ReferrerFilters domains or IP addresses allowed to use the application credentials.
+	// Referrer filtering must be enabled in the product.
+	// When not set, referrer filters created from the 3scale UI are not managed
+	// +kubebuilder:validation:MaxItems=5
+	//+optional
+	ReferrerFilters []string `json:"referrerFilters,omitempty"`
+
+	// ExtraFields custom application fields defined by the tenant fields definitions.
+	// Only the specified fields are managed. Unknown fields are reported in the InvalidExtraFields condition
+	//+optional
+	ExtraFields map[string]string `json:"extraFields,omitempty"`
 }
 
 // ApplicationStatus defines the observed state of Application
@@ -81,6 +103,19 @@ type ApplicationStatus struct {
 	// +optional
 	State string `json:"state,omitempty"`
 
+	// ApplicationPlan system name of the current application plan of the application
+	// +optional
+	ApplicationPlan string `json:"applicationPlan,omitempty"`
+
+	// PreviousApplicationPlan system name of the application plan the application was using before the last plan change
+	// +optional
+	PreviousApplicationPlan string `json:"previousApplicationPlan,omitempty"`
+
+	// ManagedReferrerFilters are the referrer filters of the application managed by the operator.
+	// Managed referrer filters are deleted when removed from the spec, even when no referrer filter is left
+	// +optional
+	ManagedReferrerFilters []string `json:"managedReferrerFilters,omitempty"`
+
 	// ObservedGeneration reflects the generation of the most recently observed Application Spec.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -121,6 +156,24 @@ func (b *ApplicationStatus) Equals(annotationID string, other *ApplicationStatus
 		return false
 	}
 
+	if b.ApplicationPlan != other.ApplicationPlan {
+		diff := cmp.Diff(b.ApplicationPlan, other.ApplicationPlan)
+		logger.V(1).Info("ApplicationPlan not equal", "difference", diff)
+		return false
+	}
+
+	if b.PreviousApplicationPlan != other.PreviousApplicationPlan {
+		diff := cmp.Diff(b.PreviousApplicationPlan, other.PreviousApplicationPlan)
+		logger.V(1).Info("PreviousApplicationPlan not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(b.ManagedReferrerFilters, other.ManagedReferrerFilters) {
+		diff := cmp.Diff(b.ManagedReferrerFilters, other.ManagedReferrerFilters)
+		logger.V(1).Info("ManagedReferrerFilters not equal", "difference", diff)
+		return false
+	}
+
 	if b.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(b.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
@@ -17,6 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"reflect"
+	"time"
+
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -28,10 +31,87 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 const (
-	ApplicationAuthReadyConditionType  common.ConditionType = "Ready"
-	ApplicationAuthFailedConditionType common.ConditionType = "Failed"
+	ApplicationAuthReadyConditionType             common.ConditionType = "Ready"
+	ApplicationAuthFailedConditionType            common.ConditionType = "Failed"
+	ApplicationAuthKeyRotationFailedConditionType common.ConditionType = "KeyRotationFailed"
+)
+
+const (
+	// ApplicationAuthRotateKeyAnnotation triggers an application key rotation every time its value changes
+	ApplicationAuthRotateKeyAnnotation = "applicationauth.capabilities.3scale.net/rotate-key"
+
+	// DefaultApplicationKeyRotationGracePeriod is the default time both the new and the old application keys are valid
+	DefaultApplicationKeyRotationGracePeriod = 24 * time.Hour
 )
 
+// ApplicationKeyRotationSpec defines the rotation of the application key
+type ApplicationKeyRotationSpec struct {
+	// Interval between scheduled rotations. For example: 720h.
+	// When not set, keys are only rotated on demand using the rotate-key annotation
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// GracePeriod time both the new and the old application keys are valid after a rotation.
+	// The old key is deleted when the grace period ends. Defaults to 24h
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+func (s *ApplicationKeyRotationSpec) GracePeriodDuration() time.Duration {
+	if s.GracePeriod == nil {
+		return DefaultApplicationKeyRotationGracePeriod
+	}
+
+	return s.GracePeriod.Duration
+}
+
+// ApplicationKeyRotationPendingStatus records a rotation before the new application key is created.
+// The rotation completes when the new key is found in the auth secret, otherwise the new key is deleted
+type ApplicationKeyRotationPendingStatus struct {
+	// KeyID identifier of the new application key
+	KeyID string `json:"keyID"`
+
+	// RetiringKeyID identifier of the application key replaced by the new one
+	// +optional
+	RetiringKeyID string `json:"retiringKeyID,omitempty"`
+
+	// RotationTrigger value of the rotate-key annotation handled by the rotation
+	// +optional
+	RotationTrigger string `json:"rotationTrigger,omitempty"`
+}
+
+// ApplicationKeyRotationStatus defines the observed state of the application key rotation
+type ApplicationKeyRotationStatus struct {
+	// ActiveKeyIDs identifiers of the application keys currently valid.
+	// Key identifiers are fingerprints of the key values, the values are not exposed
+	// +optional
+	ActiveKeyIDs []string `json:"activeKeyIDs,omitempty"`
+
+	// RetiringKeyID identifier of the replaced application key, deleted when the grace period ends
+	// +optional
+	RetiringKeyID string `json:"retiringKeyID,omitempty"`
+
+	// RetiringKeyDeletionTime time the replaced application key is deleted
+	// +optional
+	RetiringKeyDeletionTime *metav1.Time `json:"retiringKeyDeletionTime,omitempty"`
+
+	// LastRotationTime time of the last rotation
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// NextRotationTime time of the next scheduled rotation
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+
+	// LastRotationTrigger value of the rotate-key annotation handled by the last rotation
+	// +optional
+	LastRotationTrigger string `json:"lastRotationTrigger,omitempty"`
+
+	// Pending rotation, not known to be written to the auth secret
+	// +optional
+	Pending *ApplicationKeyRotationPendingStatus `json:"pending,omitempty"`
+}
+
 // ApplicationAuthSpec defines the desired state of ApplicationAuth
 type ApplicationAuthSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -50,6 +130,12 @@ type ApplicationAuthSpec struct {
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+
+	// KeyRotation enables the rotation of the application key.
+	// A new key is added alongside the old one and the auth secret is updated.
+	// The old key is deleted when the grace period ends
+	// +optional
+	KeyRotation *ApplicationKeyRotationSpec `json:"keyRotation,omitempty"`
 }
 
 // ApplicationAuthStatus defines the observed state of ApplicationAuth
@@ -63,6 +149,10 @@ type ApplicationAuthStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// KeyRotation observed state of the application key rotation
+	// +optional
+	KeyRotation *ApplicationKeyRotationStatus `json:"keyRotation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -100,5 +190,11 @@ func (a *ApplicationAuthStatus) Equals(other *ApplicationAuthStatus, logger logr
 		return false
 	}
 
+	if !reflect.DeepEqual(a.KeyRotation, other.KeyRotation) {
+		diff := cmp.Diff(a.KeyRotation, other.KeyRotation)
+		logger.V(1).Info("KeyRotation not equal", "difference", diff)
+		return false
+	}
+
 	return true
 }
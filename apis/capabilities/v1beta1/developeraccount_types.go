@@ -51,8 +51,59 @@ const (
 	// DeveloperAccountFailedConditionType indicates that an error occurred during synchronization.
 	// The operator will retry.
 	DeveloperAccountFailedConditionType common.ConditionType = "Failed"
+
+	// DeveloperAccountInvalidCustomFieldsConditionType indicates that some custom fields
+	// are not defined in the provider account fields definitions or their values are not valid.
+	// The valid custom fields are synchronized.
+	DeveloperAccountInvalidCustomFieldsConditionType common.ConditionType = "InvalidCustomFields"
+
+	// DeveloperAccountStateApproved is the state of active developer accounts
+	DeveloperAccountStateApproved = "approved"
+
+	// DeveloperAccountStatePending is the state of developer accounts waiting for approval
+	DeveloperAccountStatePending = "pending"
+
+	// DeveloperAccountStateRejected is the state of rejected developer accounts
+	DeveloperAccountStateRejected = "rejected"
+
+	// DeveloperAccountStateSuspended is the state of suspended developer accounts
+	DeveloperAccountStateSuspended = "suspended"
+
+	// DeveloperAccountMaxStateTransitions is the number of state transitions kept in the status
+	DeveloperAccountMaxStateTransitions = 10
 )
 
+// DeveloperAccountBillingAddressSpec defines the billing address of the developer account
+type DeveloperAccountBillingAddressSpec struct {
+	// Company name
+	Company string `json:"company"`
+
+	// Address first line
+	Address1 string `json:"address1"`
+
+	// Address second line
+	// +optional
+	Address2 string `json:"address2,omitempty"`
+
+	// PhoneNumber of the billing contact
+	// +optional
+	PhoneNumber string `json:"phoneNumber,omitempty"`
+
+	// City
+	City string `json:"city"`
+
+	// State or region
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Zip code
+	// +optional
+	Zip string `json:"zip,omitempty"`
+
+	// Country
+	Country string `json:"country"`
+}
+
 // DeveloperAccountSpec defines the desired state of DeveloperAccount
 type DeveloperAccountSpec struct {
 	// OrgName is the organization name
@@ -66,22 +117,69 @@ type DeveloperAccountSpec struct {
 	// +optional
 	MonthlyChargingEnabled *bool `json:"monthlyChargingEnabled,omitempty"`
 
+	// VatCode is the VAT code of the organization. Not managed when empty
+	// +optional
+	VatCode string `json:"vatCode,omitempty"`
+
+	// BillingAddress is the address used in invoices. Not managed when unset
+	// +optional
+	BillingAddress *DeveloperAccountBillingAddressSpec `json:"billingAddress,omitempty"`
+
+	// CustomFields are the values of the account fields defined by the tenant fields definitions,
+	// like the extra fields. Only the specified fields are managed.
+	// Unknown fields are reported in the InvalidCustomFields condition
+	// +optional
+	CustomFields map[string]string `json:"customFields,omitempty"`
+
+	// AccountPlan is the system name of the account plan the account is signed up in.
+	// Only used when the account is created. Defaults to the default account plan
+	// +optional
+	AccountPlan string `json:"accountPlan,omitempty"`
+
+	// State is the desired state of the account.
+	// When not set, the state is not managed, i.e. it depends on the account plan approval
+	// +kubebuilder:validation:Enum=approved;rejected;pending;suspended
+	// +optional
+	State *string `json:"state,omitempty"`
+
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
 }
 
+// DeveloperAccountStateTransition defines a state change made by the operator
+type DeveloperAccountStateTransition struct {
+	// From is the state before the transition
+	From string `json:"from"`
+
+	// To is the state after the transition
+	To string `json:"to"`
+
+	// Event is the 3scale state event, e.g. approve or make_pending
+	Event string `json:"event"`
+
+	// TransitionTime is the time of the transition
+	TransitionTime metav1.Time `json:"transitionTime"`
+}
+
 // DeveloperAccountStatus defines the observed state of DeveloperAccount
 type DeveloperAccountStatus struct {
 	// +optional
 	ID *int64 `json:"accountID,omitempty"`
 
+	// AccountState is the state of the account in 3scale, e.g. approved or pending
 	// +optional
 	AccountState *string `json:"accountState,omitempty"`
 
+	// CreditCardStored tells whether the account has credit card details stored
 	// +optional
 	CreditCardStored *bool `json:"creditCardStored,omitempty"`
 
+	// StateTransitions is the history of the state transitions made by the operator, most recent last.
+	// Only the last transitions are kept
+	// +optional
+	StateTransitions []DeveloperAccountStateTransition `json:"stateTransitions,omitempty"`
+
 	// ProviderAccountHost contains the 3scale account's provider URL
 	// +optional
 	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
@@ -123,6 +221,12 @@ func (s *DeveloperAccountStatus) Equals(other *DeveloperAccountStatus, logger lo
 		return false
 	}
 
+	if !reflect.DeepEqual(s.StateTransitions, other.StateTransitions) {
+		diff := cmp.Diff(s.StateTransitions, other.StateTransitions)
+		logger.V(1).Info("StateTransitions not equal", "difference", diff)
+		return false
+	}
+
 	if s.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
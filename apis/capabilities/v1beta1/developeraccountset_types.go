@@ -0,0 +1,292 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	DeveloperAccountSetKind = "DeveloperAccountSet"
+
+	// DeveloperAccountSetInvalidConditionType represents that the combination of configuration
+	// in the spec is not supported, or the source rows are not valid. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	DeveloperAccountSetInvalidConditionType common.ConditionType = "Invalid"
+
+	// DeveloperAccountSetReadyConditionType indicates the custom resources of every row are ready.
+	// Steady state
+	DeveloperAccountSetReadyConditionType common.ConditionType = "Ready"
+
+	// DeveloperAccountSetFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	DeveloperAccountSetFailedConditionType common.ConditionType = "Failed"
+
+	// DefaultDeveloperAccountSetRefreshInterval is the default interval between reads of the rows source
+	DefaultDeveloperAccountSetRefreshInterval = 5 * time.Minute
+
+	// DeveloperAccountSetMaxNotReadyRows is the maximum number of rows not ready reported in the status
+	DeveloperAccountSetMaxNotReadyRows = 20
+
+	// DefaultDeveloperAccountSetMaxDeletions is the default maximum number of rows deleted in one synchronization
+	DefaultDeveloperAccountSetMaxDeletions int32 = 10
+
+	// Formats of the ConfigMap rows
+	DeveloperAccountSetCSVFormat  = "csv"
+	DeveloperAccountSetYAMLFormat = "yaml"
+)
+
+// DeveloperAccountSetConfigMapSourceSpec refers to the ConfigMap key with the rows
+type DeveloperAccountSetConfigMapSourceSpec struct {
+	// Name of the ConfigMap
+	Name string `json:"name"`
+
+	// Key of the ConfigMap with the rows
+	Key string `json:"key"`
+
+	// Format of the rows: csv, with a header line naming the columns, or yaml, a list of objects.
+	// Defaults to the extension of the key
+	// +kubebuilder:validation:Enum=csv;yaml
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// DeveloperAccountSetLDAPGroupSourceSpec refers to the directory group whose members are the rows
+type DeveloperAccountSetLDAPGroupSourceSpec struct {
+	// GroupDN is the distinguished name of the group
+	GroupDN string `json:"groupDN"`
+
+	// DirectoryRef refers to the ConfigMap key with the LDIF export of the directory.
+	// The export is the local stand-in of the LDAP server
+	DirectoryRef corev1.ConfigMapKeySelector `json:"directoryRef"`
+
+	// Attributes maps row columns to attributes of the member entries, e.g. orgName: o.
+	// Every attribute of the member entries is also a column, named in lower case.
+	// The name column defaults to the uid attribute
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// DeveloperAccountSetSourceSpec defines where the rows are read from. Only one source is allowed
+type DeveloperAccountSetSourceSpec struct {
+	// ConfigMap refers to the ConfigMap key with the rows, in CSV or YAML format
+	// +optional
+	ConfigMap *DeveloperAccountSetConfigMapSourceSpec `json:"configMap,omitempty"`
+
+	// LDAPGroup reads one row per member of a directory group
+	// +optional
+	LDAPGroup *DeveloperAccountSetLDAPGroupSourceSpec `json:"ldapGroup,omitempty"`
+}
+
+// DeveloperAccountSetTemplateSpec defines the custom resources generated for every row.
+// Templates are YAML documents of the spec, rendered by Go text/template with the row columns,
+// e.g. orgName: {{ quote .orgName }}
+type DeveloperAccountSetTemplateSpec struct {
+	// DeveloperAccount is the template of the DeveloperAccount spec
+	DeveloperAccount string `json:"developerAccount"`
+
+	// DeveloperUser is the template of the DeveloperUser spec of the account admin user.
+	// The developerAccountRef is set to the generated account and the role defaults to admin
+	DeveloperUser string `json:"developerUser"`
+
+	// Application is the template of the Application spec. No application is generated when not set.
+	// The accountCR is set to the generated account
+	// +optional
+	Application string `json:"application,omitempty"`
+}
+
+// DeveloperAccountSetSpec defines the desired state of DeveloperAccountSet
+type DeveloperAccountSetSpec struct {
+	// Source of the rows. Every row must have a unique name column
+	Source DeveloperAccountSetSourceSpec `json:"source"`
+
+	// Template of the custom resources generated for every row
+	Template DeveloperAccountSetTemplateSpec `json:"template"`
+
+	// RefreshInterval is the interval between reads of the source. Defaults to 5 minutes
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// Prune deletes the custom resources of the rows removed from the source,
+	// and the 3scale accounts, users and applications they manage. Defaults to "true"
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+
+	// MaxDeletions is the maximum number of rows deleted in one synchronization.
+	// When more rows are removed from the source, nothing is deleted and the rows are reported pending deletion.
+	// Defaults to 10
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletions *int32 `json:"maxDeletions,omitempty"`
+
+	// ProviderAccountRef references account provider credentials.
+	// It is set in the generated developer accounts and users
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+}
+
+// DeveloperAccountSetStatus defines the observed state of DeveloperAccountSet
+type DeveloperAccountSetStatus struct {
+	// Revision of the synchronized source: the ConfigMap resource version
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Rows is the number of rows read from the source
+	// +optional
+	Rows int32 `json:"rows,omitempty"`
+
+	// ReadyRows is the number of rows whose custom resources are all ready
+	// +optional
+	ReadyRows int32 `json:"readyRows,omitempty"`
+
+	// NotReadyRows are the names of the rows not ready, sorted. At most 20 rows are reported
+	// +optional
+	NotReadyRows []string `json:"notReadyRows,omitempty"`
+
+	// PendingDeletions is the number of rows removed from the source whose custom resources are not deleted,
+	// because pruning is disabled or more than maxDeletions rows were removed
+	// +optional
+	PendingDeletions int32 `json:"pendingDeletions,omitempty"`
+
+	// PendingDeletionRows are the names of the rows pending deletion, sorted. At most 20 rows are reported
+	// +optional
+	PendingDeletionRows []string `json:"pendingDeletionRows,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed DeveloperAccountSet Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the developer account set resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (s *DeveloperAccountSetStatus) Equals(other *DeveloperAccountSetStatus, logger logr.Logger) bool {
+	if s.Revision != other.Revision {
+		diff := cmp.Diff(s.Revision, other.Revision)
+		logger.V(1).Info("Revision not equal", "difference", diff)
+		return false
+	}
+
+	if s.Rows != other.Rows || s.ReadyRows != other.ReadyRows {
+		logger.V(1).Info("Rows not equal", "rows", other.Rows, "readyRows", other.ReadyRows)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.NotReadyRows, other.NotReadyRows) {
+		diff := cmp.Diff(s.NotReadyRows, other.NotReadyRows)
+		logger.V(1).Info("NotReadyRows not equal", "difference", diff)
+		return false
+	}
+
+	if s.PendingDeletions != other.PendingDeletions || !reflect.DeepEqual(s.PendingDeletionRows, other.PendingDeletionRows) {
+		diff := cmp.Diff(s.PendingDeletionRows, other.PendingDeletionRows)
+		logger.V(1).Info("PendingDeletionRows not equal", "pendingDeletions", other.PendingDeletions, "difference", diff)
+		return false
+	}
+
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := s.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.readyRows",name="Ready Rows",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.rows",name=Rows,type=integer
+
+// DeveloperAccountSet is the Schema for the developeraccountsets API
+type DeveloperAccountSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeveloperAccountSetSpec   `json:"spec,omitempty"`
+	Status DeveloperAccountSetStatus `json:"status,omitempty"`
+}
+
+// RefreshIntervalDuration returns the interval between reads of the source
+func (d *DeveloperAccountSet) RefreshIntervalDuration() time.Duration {
+	if d.Spec.RefreshInterval == nil || d.Spec.RefreshInterval.Duration <= 0 {
+		return DefaultDeveloperAccountSetRefreshInterval
+	}
+
+	return d.Spec.RefreshInterval.Duration
+}
+
+// IsPruneEnabled returns whether the custom resources of the rows removed from the source are deleted
+func (d *DeveloperAccountSet) IsPruneEnabled() bool {
+	return d.Spec.Prune == nil || *d.Spec.Prune
+}
+
+// MaxDeletions returns the maximum number of rows deleted in one synchronization
+func (d *DeveloperAccountSet) MaxDeletions() int32 {
+	if d.Spec.MaxDeletions == nil {
+		return DefaultDeveloperAccountSetMaxDeletions
+	}
+
+	return *d.Spec.MaxDeletions
+}
+
+func (d *DeveloperAccountSet) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	sourceFldPath := field.NewPath("spec").Child("source")
+	if (d.Spec.Source.ConfigMap == nil) == (d.Spec.Source.LDAPGroup == nil) {
+		errors = append(errors, field.Invalid(sourceFldPath, d.Spec.Source, "one and only one of configMap or ldapGroup must be set"))
+	}
+
+	return errors
+}
+
+// +kubebuilder:object:root=true
+
+// DeveloperAccountSetList contains a list of DeveloperAccountSet
+type DeveloperAccountSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeveloperAccountSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeveloperAccountSet{}, &DeveloperAccountSetList{})
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	DeveloperPortalContentKind = "DeveloperPortalContent"
+
+	// DeveloperPortalContentInvalidConditionType represents that the combination of configuration
+	// in the spec is not supported, or the source content is not valid. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	DeveloperPortalContentInvalidConditionType common.ConditionType = "Invalid"
+
+	// DeveloperPortalContentReadyConditionType indicates the content has been successfully synchronized.
+	// Steady state
+	DeveloperPortalContentReadyConditionType common.ConditionType = "Ready"
+
+	// DeveloperPortalContentFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	DeveloperPortalContentFailedConditionType common.ConditionType = "Failed"
+
+	// DefaultDeveloperPortalContentRefreshInterval is the default interval between reads of the content source
+	DefaultDeveloperPortalContentRefreshInterval = 5 * time.Minute
+
+	// DefaultDeveloperPortalContentMaxDeletions is the default maximum number of items pruned in one synchronization
+	DefaultDeveloperPortalContentMaxDeletions int32 = 10
+
+	// Kinds of developer portal content items
+	DeveloperPortalContentSectionKind = "section"
+	DeveloperPortalContentLayoutKind  = "layout"
+	DeveloperPortalContentPartialKind = "partial"
+	DeveloperPortalContentPageKind    = "page"
+	DeveloperPortalContentFileKind    = "file"
+)
+
+// DeveloperPortalContentGitSourceSpec refers to a directory of a git repository
+type DeveloperPortalContentGitSourceSpec struct {
+	// URL of the git repository. The repository is fetched using the git smart HTTP protocol
+	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit hash to read. Defaults to the repository HEAD
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Path of the content directory in the repository. Defaults to the repository root
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// CredentialsSecretRef refers to the secret with the repository credentials: the username and password fields.
+	// Access tokens are set in the password field
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// DeveloperPortalContentSourceSpec defines where the content directory is read from. Only one source is allowed
+type DeveloperPortalContentSourceSpec struct {
+	// ConfigMapRef refers to the ConfigMap with the content files.
+	// Keys are the file paths, with "__" as directory separator. Files are read from data and binaryData
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// SecretRef refers to the Secret with the content files.
+	// Keys are the file paths, with "__" as directory separator
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Git refers to the content directory of a git repository
+	// +optional
+	Git *DeveloperPortalContentGitSourceSpec `json:"git,omitempty"`
+}
+
+// DeveloperPortalContentSpec defines the desired state of DeveloperPortalContent
+type DeveloperPortalContentSpec struct {
+	// Source of the content directory: sections.yaml, layouts, partials, pages and files
+	Source DeveloperPortalContentSourceSpec `json:"source"`
+
+	// Publish publishes the synchronized layouts, partials and pages.
+	// When false, content is only saved as draft
+	// +optional
+	Publish bool `json:"publish,omitempty"`
+
+	// Prune deletes the content synchronized before and removed from the source.
+	// Content not synchronized by this resource is never deleted
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+
+	// MaxDeletions is the maximum number of items pruned in one synchronization.
+	// When more items are removed from the source, nothing is pruned and the items are reported pending deletion.
+	// Defaults to 10
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletions *int32 `json:"maxDeletions,omitempty"`
+
+	// RefreshInterval is the interval between reads of the source. Defaults to 5 minutes
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+}
+
+// DeveloperPortalContentItemStatus defines the observed state of one synchronized content item
+type DeveloperPortalContentItemStatus struct {
+	// Kind of the item: section, layout, partial, page or file
+	Kind string `json:"kind"`
+
+	// Name of the item: the system name of sections, layouts and partials and the path of pages and files
+	Name string `json:"name"`
+
+	// ID of the item in 3scale
+	ID int64 `json:"id"`
+
+	// Hash of the uploaded content. Only reported for files
+	// +optional
+	Hash string `json:"hash,omitempty"`
+}
+
+// DeveloperPortalContentStatus defines the observed state of DeveloperPortalContent
+type DeveloperPortalContentStatus struct {
+	// 3scale control plane host
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// Revision of the synchronized source: the git commit hash or the ConfigMap and Secret resource version
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Items synchronized from the source. Pruned when removed from the source
+	// +optional
+	Items []DeveloperPortalContentItemStatus `json:"items,omitempty"`
+
+	// PendingDeletions is the number of items removed from the source and not pruned,
+	// because more than maxDeletions items were removed
+	// +optional
+	PendingDeletions int32 `json:"pendingDeletions,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed DeveloperPortalContent Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the developer portal content resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+// Item returns the status of the item of the given kind and name, nil when not found
+func (s *DeveloperPortalContentStatus) Item(kind, name string) *DeveloperPortalContentItemStatus {
+	for idx := range s.Items {
+		if s.Items[idx].Kind == kind && s.Items[idx].Name == name {
+			return &s.Items[idx]
+		}
+	}
+
+	return nil
+}
+
+func (s *DeveloperPortalContentStatus) Equals(other *DeveloperPortalContentStatus, logger logr.Logger) bool {
+	if s.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(s.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if s.Revision != other.Revision {
+		diff := cmp.Diff(s.Revision, other.Revision)
+		logger.V(1).Info("Revision not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Items, other.Items) {
+		diff := cmp.Diff(s.Items, other.Items)
+		logger.V(1).Info("Items not equal", "difference", diff)
+		return false
+	}
+
+	if s.PendingDeletions != other.PendingDeletions {
+		diff := cmp.Diff(s.PendingDeletions, other.PendingDeletions)
+		logger.V(1).Info("PendingDeletions not equal", "difference", diff)
+		return false
+	}
+
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := s.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.providerAccountHost",name="Provider Account",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.revision",name=Revision,type=string
+
+// DeveloperPortalContent is the Schema for the developerportalcontents API
+type DeveloperPortalContent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeveloperPortalContentSpec   `json:"spec,omitempty"`
+	Status DeveloperPortalContentStatus `json:"status,omitempty"`
+}
+
+// RefreshIntervalDuration returns the interval between reads of the source
+func (d *DeveloperPortalContent) RefreshIntervalDuration() time.Duration {
+	if d.Spec.RefreshInterval == nil || d.Spec.RefreshInterval.Duration <= 0 {
+		return DefaultDeveloperPortalContentRefreshInterval
+	}
+
+	return d.Spec.RefreshInterval.Duration
+}
+
+// MaxDeletions returns the maximum number of items pruned in one synchronization
+func (d *DeveloperPortalContent) MaxDeletions() int32 {
+	if d.Spec.MaxDeletions == nil {
+		return DefaultDeveloperPortalContentMaxDeletions
+	}
+
+	return *d.Spec.MaxDeletions
+}
+
+func (d *DeveloperPortalContent) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	sourceFldPath := field.NewPath("spec").Child("source")
+	sources := 0
+	if d.Spec.Source.ConfigMapRef != nil {
+		sources++
+	}
+	if d.Spec.Source.SecretRef != nil {
+		sources++
+	}
+	if d.Spec.Source.Git != nil {
+		sources++
+	}
+
+	if sources != 1 {
+		errors = append(errors, field.Invalid(sourceFldPath, d.Spec.Source, "one and only one of configMapRef, secretRef or git must be set"))
+	}
+
+	return errors
+}
+
+// +kubebuilder:object:root=true
+
+// DeveloperPortalContentList contains a list of DeveloperPortalContent
+type DeveloperPortalContentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeveloperPortalContent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeveloperPortalContent{}, &DeveloperPortalContentList{})
+}
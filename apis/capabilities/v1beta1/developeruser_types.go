@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/3scale/3scale-operator/pkg/apispkg/helper"
@@ -57,8 +58,54 @@ const (
 
 	// DeveloperUserPasswordSecretField indicates the secret field name with developer user's password
 	DeveloperUserPasswordSecretField = "password"
+
+	// DeveloperUserInvitationStatePending is the state of invitations not accepted yet
+	DeveloperUserInvitationStatePending = "pending"
+
+	// DeveloperUserInvitationStateAccepted is the state of accepted invitations
+	DeveloperUserInvitationStateAccepted = "accepted"
+
+	// DeveloperUserInvitationStateExpired is the state of invitations not accepted in time
+	DeveloperUserInvitationStateExpired = "expired"
+)
+
+var (
+	// DeveloperUserInvitationDefaultExpiresAfter is the default duration invitations can be accepted
+	DeveloperUserInvitationDefaultExpiresAfter = 7 * 24 * time.Hour
 )
 
+// DeveloperUserInvitationSpec defines the invitation sent to the developer user
+type DeveloperUserInvitationSpec struct {
+	// ExpiresAfter is the duration after which invitations not accepted expire, e.g. 72h. Defaults to 168h
+	// +optional
+	ExpiresAfter *metav1.Duration `json:"expiresAfter,omitempty"`
+
+	// ResendExpired sends a new invitation when the invitation expires. Defaults to "false"
+	// +optional
+	ResendExpired bool `json:"resendExpired,omitempty"`
+}
+
+// DeveloperUserInvitationStatus defines the observed state of the invitation
+type DeveloperUserInvitationStatus struct {
+	// ID of the invitation in 3scale
+	ID int64 `json:"id"`
+
+	// State of the invitation: pending, accepted or expired
+	State string `json:"state"`
+
+	// SentTime is the time the invitation email was sent
+	// +optional
+	SentTime *metav1.Time `json:"sentTime,omitempty"`
+
+	// AcceptedTime is the time the invitation was accepted
+	// +optional
+	AcceptedTime *metav1.Time `json:"acceptedTime,omitempty"`
+
+	// ExpirationTime is the time the invitation expires when not accepted
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
 // DeveloperUserSpec defines the desired state of DeveloperUser
 type DeveloperUserSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -71,7 +118,14 @@ type DeveloperUserSpec struct {
 	Email string `json:"email"`
 
 	// Password
-	PasswordCredentialsRef corev1.SecretReference `json:"passwordCredentialsRef"`
+	// One and only one of passwordCredentialsRef or invitation must be set
+	// +optional
+	PasswordCredentialsRef *corev1.SecretReference `json:"passwordCredentialsRef,omitempty"`
+
+	// Invitation sends an invitation email to the user instead of setting a password.
+	// 3scale creates the user when the invitation is accepted
+	// +optional
+	Invitation *DeveloperUserInvitationSpec `json:"invitation,omitempty"`
 
 	// DeveloperAccountRef is the reference to the parent developer account
 	DeveloperAccountRef corev1.LocalObjectReference `json:"developerAccountRef"`
@@ -101,6 +155,10 @@ type DeveloperUserStatus struct {
 	// +optional
 	DeveloperUserState *string `json:"developerUserState,omitempty"`
 
+	// Invitation sent to the user, when the user is invited
+	// +optional
+	Invitation *DeveloperUserInvitationStatus `json:"invitation,omitempty"`
+
 	// 3scale control plane host
 	// +optional
 	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
@@ -142,6 +200,12 @@ func (a *DeveloperUserStatus) Equals(other *DeveloperUserStatus, logger logr.Log
 		return false
 	}
 
+	if !reflect.DeepEqual(a.Invitation, other.Invitation) {
+		diff := cmp.Diff(a.Invitation, other.Invitation)
+		logger.V(1).Info("Invitation not equal", "difference", diff)
+		return false
+	}
+
 	if a.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(a.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
@@ -176,6 +240,25 @@ func (s *DeveloperUser) IsOrphan() bool {
 	return s.Status.Conditions.IsTrueFor(DeveloperUserOrphanConditionType)
 }
 
+// IsInvited returns true when the user is created from an invitation
+func (s *DeveloperUser) IsInvited() bool {
+	return s.Spec.Invitation != nil
+}
+
+// IsInvitationPending returns true when the invitation has been sent and not accepted yet
+func (s *DeveloperUser) IsInvitationPending() bool {
+	return s.Status.Invitation != nil && s.Status.Invitation.State == DeveloperUserInvitationStatePending
+}
+
+// InvitationExpiresAfter returns the duration invitations can be accepted
+func (s *DeveloperUser) InvitationExpiresAfter() time.Duration {
+	if s.Spec.Invitation == nil || s.Spec.Invitation.ExpiresAfter == nil {
+		return DeveloperUserInvitationDefaultExpiresAfter
+	}
+
+	return s.Spec.Invitation.ExpiresAfter.Duration
+}
+
 func (s *DeveloperUser) IsAdmin() bool {
 	// Role defaults to member
 	return s.Spec.Role != nil && *s.Spec.Role == "admin"
@@ -190,6 +273,19 @@ func (s *DeveloperUser) Validate() field.ErrorList {
 		errors = append(errors, field.Invalid(emailFldPath, s.Spec.Email, "Email address not valid"))
 	}
 
+	// One and only one of password or invitation
+	specFldPath := field.NewPath("spec")
+	if s.Spec.PasswordCredentialsRef == nil && s.Spec.Invitation == nil {
+		errors = append(errors, field.Required(specFldPath.Child("passwordCredentialsRef"), "one of passwordCredentialsRef or invitation must be set"))
+	}
+	if s.Spec.PasswordCredentialsRef != nil && s.Spec.Invitation != nil {
+		errors = append(errors, field.Invalid(specFldPath.Child("invitation"), s.Spec.Invitation, "passwordCredentialsRef and invitation are mutually exclusive"))
+	}
+
+	if s.Spec.Invitation != nil && s.Spec.Invitation.ExpiresAfter != nil && s.Spec.Invitation.ExpiresAfter.Duration <= 0 {
+		errors = append(errors, field.Invalid(specFldPath.Child("invitation").Child("expiresAfter"), s.Spec.Invitation.ExpiresAfter.Duration.String(), "must be positive"))
+	}
+
 	return errors
 }
 
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/go-logr/logr"
@@ -46,8 +47,59 @@ const (
 	// OpenAPIFailedConditionType indicates that an error occurred during reconcilliation.
 	// The operator will retry.
 	OpenAPIFailedConditionType common.ConditionType = "Failed"
+
+	// DefaultOpenAPIRefreshInterval is the default interval between reads of polled OpenAPI sources
+	DefaultOpenAPIRefreshInterval = 5 * time.Minute
 )
 
+// OpenAPIConfigMapRefSpec refers to the ConfigMap key that contains the OpenAPI Document
+type OpenAPIConfigMapRefSpec struct {
+	// Name of the ConfigMap
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap. Defaults to the namespace of the resource
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Key that contains the OpenAPI Document. Optional when the ConfigMap has one single key
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// OpenAPIGitSourceSpec refers to an OpenAPI Document in a git repository
+type OpenAPIGitSourceSpec struct {
+	// URL of the git repository. The repository is fetched using the git smart HTTP protocol
+	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit hash to read. Defaults to the repository HEAD
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Path of the OpenAPI Document in the repository
+	Path string `json:"path"`
+
+	// CredentialsSecretRef refers to the secret with the repository credentials: the username and password fields.
+	// Access tokens are set in the password field
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// OpenAPIOCISourceSpec refers to an OpenAPI Document published as an OCI artifact
+type OpenAPIOCISourceSpec struct {
+	// Image is the artifact reference: registry/repository:tag or registry/repository@digest
+	Image string `json:"image"`
+
+	// Path of the OpenAPI Document: the title of the artifact layer or the file path in tar layers.
+	// Optional when the artifact has one single layer
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PullSecretRef refers to the dockerconfigjson secret with the registry credentials
+	// +optional
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+}
+
 // OpenAPIRefSpec Reference to the OpenAPI Specification
 type OpenAPIRefSpec struct {
 	// SecretRef refers to the secret object that contains the OpenAPI Document
@@ -58,6 +110,72 @@ type OpenAPIRefSpec struct {
 	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
 	// +optional
 	URL *string `json:"url,omitempty"`
+
+	// ConfigMapRef refers to the ConfigMap key that contains the OpenAPI Document
+	// +optional
+	ConfigMapRef *OpenAPIConfigMapRefSpec `json:"configMapRef,omitempty"`
+
+	// Git refers to an OpenAPI Document in a git repository
+	// +optional
+	Git *OpenAPIGitSourceSpec `json:"git,omitempty"`
+
+	// OCI refers to an OpenAPI Document published as an OCI artifact
+	// +optional
+	OCI *OpenAPIOCISourceSpec `json:"oci,omitempty"`
+
+	// RefreshInterval is the interval between reads of the URL, Git and OCI sources.
+	// Secret and ConfigMap sources are read again when they change.
+	// Defaults to 5 minutes
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// Polled returns true when the OpenAPI Document is read periodically instead of on source change events
+func (o *OpenAPIRefSpec) Polled() bool {
+	return o.SecretRef == nil && o.ConfigMapRef == nil
+}
+
+// RefreshIntervalDuration returns the interval between reads of polled sources
+func (o *OpenAPIRefSpec) RefreshIntervalDuration() time.Duration {
+	if o.RefreshInterval == nil || o.RefreshInterval.Duration <= 0 {
+		return DefaultOpenAPIRefreshInterval
+	}
+
+	return o.RefreshInterval.Duration
+}
+
+// OpenAPIActiveDocSpec defines the ActiveDoc generated from the OpenAPI document
+type OpenAPIActiveDocSpec struct {
+	// Name is human readable name for the activedoc
+	// Defaults to the OpenAPI document title
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Description is a human readable text of the activedoc
+	// Defaults to the OpenAPI document description
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Published switch to publish the activedoc
+	// +optional
+	Published *bool `json:"published,omitempty"`
+
+	// SkipSwaggerValidations switch to skip OpenAPI validation
+	// +optional
+	SkipSwaggerValidations *bool `json:"skipSwaggerValidations,omitempty"`
+}
+
+// OpenAPIRequestValidationSpec defines the request validation policy generated from the OpenAPI document.
+// APIcast has no builtin request validation policy: the policy code must be deployed in APIcast as a custom policy
+type OpenAPIRequestValidationSpec struct {
+	// PolicyName is the name of the custom APIcast policy validating the requests
+	// +kubebuilder:validation:MinLength=1
+	PolicyName string `json:"policyName"`
+
+	// PolicyVersion is the version of the custom APIcast policy validating the requests
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self != 'builtin'",message="builtin is reserved for the APIcast builtin policies"
+	PolicyVersion string `json:"policyVersion"`
 }
 
 // OpenAPISpec defines the desired state of OpenAPI
@@ -104,6 +222,45 @@ type OpenAPISpec struct {
 
 	// +optional
 	OIDC *OIDCSpec `json:"oidc,omitempty"`
+
+	// ActiveDoc enables the ActiveDoc generated from the OpenAPI document and linked to the product.
+	// The servers of the document are rewritten to the product public base URLs
+	// and the security schemes to the product authentication mode
+	// +optional
+	ActiveDoc *OpenAPIActiveDocSpec `json:"activeDoc,omitempty"`
+
+	// RequestValidation enables the policy validating the requests against the parameters and request bodies of the OpenAPI operations
+	// +optional
+	RequestValidation *OpenAPIRequestValidationSpec `json:"requestValidation,omitempty"`
+}
+
+// OpenAPIChange is a change of the OpenAPI document from the previously applied one
+type OpenAPIChange struct {
+	// Type of the change
+	Type string `json:"type"`
+
+	// Breaking changes break the API consumers
+	Breaking bool `json:"breaking"`
+
+	// Location of the change in the OpenAPI document
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Message describing the change
+	Message string `json:"message"`
+}
+
+// OpenAPIAuthenticationStatus describes the product authentication read from the OpenAPI security requirements
+type OpenAPIAuthenticationStatus struct {
+	// Mode of the product authentication: UserKey, AppIDAppKey or OIDC
+	Mode string `json:"mode"`
+
+	// SecuritySchemes of the OpenAPI document the authentication is read from
+	// +optional
+	SecuritySchemes []string `json:"securitySchemes,omitempty"`
+
+	// Reason describes the rule the authentication mode was chosen by
+	Reason string `json:"reason"`
 }
 
 // OpenAPIStatus defines the observed state of OpenAPI
@@ -123,6 +280,43 @@ type OpenAPIStatus struct {
 	// +optional
 	BackendResourceNames []corev1.LocalObjectReference `json:"backendResourceNames,omitempty"`
 
+	// ActiveDocResourceName references the managed 3scale activedoc
+	// +optional
+	ActiveDocResourceName *corev1.LocalObjectReference `json:"activeDocResourceName,omitempty"`
+
+	// SourceRevision is the revision of the last read OpenAPI Document:
+	// the git commit hash, the OCI manifest digest or the ConfigMap resource version
+	// +optional
+	SourceRevision string `json:"sourceRevision,omitempty"`
+
+	// SourceHash is the content hash of the last read OpenAPI Document
+	// +optional
+	SourceHash string `json:"sourceHash,omitempty"`
+
+	// SourceFetchTime is the time the OpenAPI Document content with SourceHash was first read
+	// +optional
+	SourceFetchTime *metav1.Time `json:"sourceFetchTime,omitempty"`
+
+	// SourceDocumentVersion is the swagger or openapi version declared by the last read OpenAPI Document
+	// +optional
+	SourceDocumentVersion string `json:"sourceDocumentVersion,omitempty"`
+
+	// SourceConversions lists the conversions applied to the last read OpenAPI Document to import it as OpenAPI 3.0
+	// +optional
+	SourceConversions []string `json:"sourceConversions,omitempty"`
+
+	// Authentication read from the OpenAPI Document security requirements
+	// +optional
+	Authentication *OpenAPIAuthenticationStatus `json:"authentication,omitempty"`
+
+	// AppliedSourceHash is the content hash of the OpenAPI Document applied to the managed 3scale product
+	// +optional
+	AppliedSourceHash string `json:"appliedSourceHash,omitempty"`
+
+	// Changes lists the changes of the last read OpenAPI Document from the previously applied one
+	// +optional
+	Changes []OpenAPIChange `json:"changes,omitempty"`
+
 	// ObservedGeneration reflects the generation of the most recently observed Backend Spec.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -154,6 +348,60 @@ func (o *OpenAPIStatus) Equals(other *OpenAPIStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !reflect.DeepEqual(o.Authentication, other.Authentication) {
+		diff := cmp.Diff(o.Authentication, other.Authentication)
+		logger.V(1).Info("Authentication not equal", "difference", diff)
+		return false
+	}
+
+	if o.AppliedSourceHash != other.AppliedSourceHash {
+		diff := cmp.Diff(o.AppliedSourceHash, other.AppliedSourceHash)
+		logger.V(1).Info("AppliedSourceHash not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(o.Changes, other.Changes) {
+		diff := cmp.Diff(o.Changes, other.Changes)
+		logger.V(1).Info("Changes not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(o.ActiveDocResourceName, other.ActiveDocResourceName) {
+		diff := cmp.Diff(o.ActiveDocResourceName, other.ActiveDocResourceName)
+		logger.V(1).Info("ActiveDocResourceName not equal", "difference", diff)
+		return false
+	}
+
+	if o.SourceRevision != other.SourceRevision {
+		diff := cmp.Diff(o.SourceRevision, other.SourceRevision)
+		logger.V(1).Info("SourceRevision not equal", "difference", diff)
+		return false
+	}
+
+	if o.SourceHash != other.SourceHash {
+		diff := cmp.Diff(o.SourceHash, other.SourceHash)
+		logger.V(1).Info("SourceHash not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(o.SourceFetchTime, other.SourceFetchTime) {
+		diff := cmp.Diff(o.SourceFetchTime, other.SourceFetchTime)
+		logger.V(1).Info("SourceFetchTime not equal", "difference", diff)
+		return false
+	}
+
+	if o.SourceDocumentVersion != other.SourceDocumentVersion {
+		diff := cmp.Diff(o.SourceDocumentVersion, other.SourceDocumentVersion)
+		logger.V(1).Info("SourceDocumentVersion not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(o.SourceConversions, other.SourceConversions) {
+		diff := cmp.Diff(o.SourceConversions, other.SourceConversions)
+		logger.V(1).Info("SourceConversions not equal", "difference", diff)
+		return false
+	}
+
 	if o.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(o.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
@@ -195,6 +443,11 @@ func (o *OpenAPI) SetDefaults(logger logr.Logger) bool {
 		updated = true
 	}
 
+	if o.Spec.OpenAPIRef.ConfigMapRef != nil && o.Spec.OpenAPIRef.ConfigMapRef.Namespace == "" {
+		o.Spec.OpenAPIRef.ConfigMapRef.Namespace = o.GetNamespace()
+		updated = true
+	}
+
 	return updated
 }
 
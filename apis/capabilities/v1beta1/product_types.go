@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/go-logr/logr"
@@ -58,11 +59,22 @@ const (
 	// The operator will retry.
 	ProductFailedConditionType common.ConditionType = "Failed"
 
+	// ProductOpenAPIExportFailedConditionType indicates that an error occurred exporting the synchronized product
+	// as an OpenAPI document. The synchronization is not affected.
+	// The operator will retry.
+	ProductOpenAPIExportFailedConditionType common.ConditionType = "OpenAPIExportFailed"
+
 	// ProductPolicyConfigurationPasswordSecretField indicates the secret field name with product policy configuration
 	ProductPolicyConfigurationPasswordSecretField = "configuration"
 
 	// ProductPolicyConfigurationDefault is the default for a product policy configuration
 	ProductPolicyConfigurationDefault = `{}`
+
+	// ProductFeatureScopeApplicationPlan is the scope of features enabled in application plans
+	ProductFeatureScopeApplicationPlan = "ApplicationPlan"
+
+	// ProductFeatureScopeServicePlan is the scope of features enabled in service plans
+	ProductFeatureScopeServicePlan = "ServicePlan"
 )
 
 // apicastPolicy refers to the main functionality of APIcast to work with the 3scale API manager
@@ -167,13 +179,89 @@ type ApplicationPlanSpec struct {
 	// +optional
 	Published *bool `json:"published,omitempty"`
 
-	// TODO Features
+	// Features enabled in the plan.
+	// Array: feature system_name. Features must be defined in the product with "ApplicationPlan" scope
+	// +optional
+	Features []string `json:"features,omitempty"`
 }
 
 func (a *ApplicationPlanSpec) IsPublished() bool {
 	return a.Published != nil && *a.Published
 }
 
+// ServicePlanSpec defines the desired state of Product's Service Plan
+type ServicePlanSpec struct {
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Set whether or not subscriptions can be created on demand
+	// or if approval is required from you before they are activated.
+	// +optional
+	ApprovalRequired *bool `json:"approvalRequired,omitempty"`
+
+	// Trial Period (days)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TrialPeriod *int `json:"trialPeriod,omitempty"`
+
+	// Setup fee (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	SetupFee *string `json:"setupFee,omitempty"`
+
+	// Cost per Month (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	CostMonth *string `json:"costMonth,omitempty"`
+
+	// Controls whether the service plan is published. If not specified it is
+	// hidden by default
+	// +optional
+	Published *bool `json:"published,omitempty"`
+
+	// Default sets the service plan as the default one of the product.
+	// At most one service plan can be the default one.
+	// +optional
+	Default *bool `json:"default,omitempty"`
+
+	// Features enabled in the plan.
+	// Array: feature system_name. Features must be defined in the product with "ServicePlan" scope
+	// +optional
+	Features []string `json:"features,omitempty"`
+}
+
+func (s *ServicePlanSpec) IsPublished() bool {
+	return s.Published != nil && *s.Published
+}
+
+func (s *ServicePlanSpec) IsDefault() bool {
+	return s.Default != nil && *s.Default
+}
+
+// ProductFeatureSpec defines the desired state of Product's Feature
+type ProductFeatureSpec struct {
+	// Name is human readable name for the feature
+	Name string `json:"name"`
+
+	// Description is a human readable text of the feature
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Scope defines the type of plans the feature can be enabled in.
+	// Defaults to "ApplicationPlan"
+	// +kubebuilder:validation:Enum=ApplicationPlan;ServicePlan
+	// +optional
+	Scope *string `json:"scope,omitempty"`
+}
+
+func (f *ProductFeatureSpec) FeatureScope() string {
+	if f.Scope == nil {
+		return ProductFeatureScopeApplicationPlan
+	}
+
+	return *f.Scope
+}
+
 // MethodSpec defines the desired state of Product's Method
 type MethodSpec struct {
 	Name string `json:"friendlyName"`
@@ -924,6 +1012,18 @@ type ProductSpec struct {
 	// +optional
 	ApplicationPlans map[string]ApplicationPlanSpec `json:"applicationPlans,omitempty"`
 
+	// Service Plans
+	// Map: system_name -> Service Plan Spec
+	// When no service plan is specified, service plans are not managed by the operator
+	// +optional
+	ServicePlans map[string]ServicePlanSpec `json:"servicePlans,omitempty"`
+
+	// Features
+	// Map: system_name -> Feature Spec
+	// When no feature is specified, features are not managed by the operator
+	// +optional
+	Features map[string]ProductFeatureSpec `json:"features,omitempty"`
+
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
@@ -931,6 +1031,18 @@ type ProductSpec struct {
 	// Policies holds the product's policy chain
 	// +optional
 	Policies []PolicyConfig `json:"policies,omitempty"`
+
+	// OpenAPIExport generates a ConfigMap, owned by the product, with the product exported as an OpenAPI document
+	// +optional
+	OpenAPIExport *ProductOpenAPIExportSpec `json:"openAPIExport,omitempty"`
+}
+
+// ProductOpenAPIExportSpec defines the ConfigMap the product is exported to as an OpenAPI document
+type ProductOpenAPIExportSpec struct {
+	// ConfigMapName is the name of the generated ConfigMap.
+	// Defaults to "<product name>-openapi"
+	// +optional
+	ConfigMapName *string `json:"configMapName,omitempty"`
 }
 
 func (s *ProductSpec) DeploymentOption() *string {
@@ -1087,6 +1199,11 @@ type ProductStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// ManagedFeatures are the system names of the product features managed by the operator.
+	// Managed features are deleted when removed from the spec, even when no feature is left
+	// +optional
+	ManagedFeatures []string `json:"managedFeatures,omitempty"`
+
 	// Current state of the 3scale product.
 	// Conditions represent the latest available observations of an object's state
 	// +optional
@@ -1120,6 +1237,12 @@ func (p *ProductStatus) Equals(other *ProductStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !reflect.DeepEqual(p.ManagedFeatures, other.ManagedFeatures) {
+		diff := cmp.Diff(p.ManagedFeatures, other.ManagedFeatures)
+		logger.V(1).Info("ManagedFeatures not equal", "difference", diff)
+		return false
+	}
+
 	// Marshalling sorts by condition type
 	currentMarshaledJSON, _ := p.Conditions.MarshalJSON()
 	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
@@ -1252,6 +1375,7 @@ func (product *Product) Validate() field.ErrorList {
 	metricsFldPath := specFldPath.Child("metrics")
 	mappingRulesFldPath := specFldPath.Child("mappingRules")
 	applicationPlansFldPath := specFldPath.Child("applicationPlans")
+	servicePlansFldPath := specFldPath.Child("servicePlans")
 	methodsFldPath := specFldPath.Child("methods")
 
 	// check hits metric exists
@@ -1386,6 +1510,44 @@ func (product *Product) Validate() field.ErrorList {
 		}
 	}
 
+	// Check at most one service plan is the default one
+	defaultServicePlans := []string{}
+	for planSystemName, planSpec := range product.Spec.ServicePlans {
+		if planSpec.IsDefault() {
+			defaultServicePlans = append(defaultServicePlans, planSystemName)
+		}
+	}
+	if len(defaultServicePlans) > 1 {
+		sort.Strings(defaultServicePlans)
+		errors = append(errors, field.Invalid(servicePlansFldPath, defaultServicePlans, "only one service plan can be the default one."))
+	}
+
+	// Check application plan features reference existing features with ApplicationPlan scope
+	for planSystemName, planSpec := range product.Spec.ApplicationPlans {
+		featuresFldPath := applicationPlansFldPath.Key(planSystemName).Child("features")
+		for idx, featureSystemName := range planSpec.Features {
+			featureSpec, ok := product.Spec.Features[featureSystemName]
+			if !ok {
+				errors = append(errors, field.Invalid(featuresFldPath.Index(idx), featureSystemName, "application plan feature does not have valid feature reference."))
+			} else if featureSpec.FeatureScope() != ProductFeatureScopeApplicationPlan {
+				errors = append(errors, field.Invalid(featuresFldPath.Index(idx), featureSystemName, "application plan feature does not have 'ApplicationPlan' scope."))
+			}
+		}
+	}
+
+	// Check service plan features reference existing features with ServicePlan scope
+	for planSystemName, planSpec := range product.Spec.ServicePlans {
+		featuresFldPath := servicePlansFldPath.Key(planSystemName).Child("features")
+		for idx, featureSystemName := range planSpec.Features {
+			featureSpec, ok := product.Spec.Features[featureSystemName]
+			if !ok {
+				errors = append(errors, field.Invalid(featuresFldPath.Index(idx), featureSystemName, "service plan feature does not have valid feature reference."))
+			} else if featureSpec.FeatureScope() != ProductFeatureScopeServicePlan {
+				errors = append(errors, field.Invalid(featuresFldPath.Index(idx), featureSystemName, "service plan feature does not have 'ServicePlan' scope."))
+			}
+		}
+	}
+
 	return errors
 }
 
@@ -207,6 +207,80 @@ func TestValidateProductPlanPricingRuleOverlappingRange(t *testing.T) {
 	}
 }
 
+func TestValidateProductApplicationPlanFeatureUnknownRef(t *testing.T) {
+	product := defaultTestingProduct()
+
+	product.Spec.ApplicationPlans = map[string]ApplicationPlanSpec{
+		"plan01": {Features: []string{"unknownRef"}},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "application plan feature does not have valid feature reference.") {
+		t.Error("valition passes and application plan feature does not have valid feature reference.")
+	}
+}
+
+func TestValidateProductApplicationPlanFeatureScope(t *testing.T) {
+	product := defaultTestingProduct()
+
+	scope := ProductFeatureScopeServicePlan
+	product.Spec.Features = map[string]ProductFeatureSpec{
+		"feature01": {Name: "Feature 01", Scope: &scope},
+	}
+	product.Spec.ApplicationPlans = map[string]ApplicationPlanSpec{
+		"plan01": {Features: []string{"feature01"}},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "application plan feature does not have 'ApplicationPlan' scope.") {
+		t.Error("valition passes and application plan feature does not have 'ApplicationPlan' scope.")
+	}
+}
+
+func TestValidateProductServicePlanMultipleDefaults(t *testing.T) {
+	product := defaultTestingProduct()
+
+	isDefault := true
+	product.Spec.ServicePlans = map[string]ServicePlanSpec{
+		"plan01": {Default: &isDefault},
+		"plan02": {Default: &isDefault},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "only one service plan can be the default one.") {
+		t.Error("valition passes and more than one service plan is the default one.")
+	}
+}
+
+func TestValidateProductServicePlanFeatureUnknownRef(t *testing.T) {
+	product := defaultTestingProduct()
+
+	product.Spec.ServicePlans = map[string]ServicePlanSpec{
+		"plan01": {Features: []string{"unknownRef"}},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "service plan feature does not have valid feature reference.") {
+		t.Error("valition passes and service plan feature does not have valid feature reference.")
+	}
+}
+
+func TestValidateProductServicePlanFeatureScope(t *testing.T) {
+	product := defaultTestingProduct()
+
+	product.Spec.Features = map[string]ProductFeatureSpec{
+		"feature01": {Name: "Feature 01"},
+	}
+	product.Spec.ServicePlans = map[string]ServicePlanSpec{
+		"plan01": {Features: []string{"feature01"}},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "service plan feature does not have 'ServicePlan' scope.") {
+		t.Error("valition passes and service plan feature does not have 'ServicePlan' scope.")
+	}
+}
+
 func TestValidateProductHappyPath(t *testing.T) {
 	product := defaultTestingProduct()
 
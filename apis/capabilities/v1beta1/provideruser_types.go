@@ -0,0 +1,245 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/apispkg/helper"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	ProviderUserKind = "ProviderUser"
+
+	// ProviderUserInvalidConditionType represents that the combination of configuration
+	// in the spec is not supported. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	ProviderUserInvalidConditionType common.ConditionType = "Invalid"
+
+	// ProviderUserOrphanConditionType represents that the configuration in the spec
+	// contains reference to non existing resource.
+	// This is (should be) a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	// Example: the member permissions reference a non existing product
+	ProviderUserOrphanConditionType common.ConditionType = "Orphan"
+
+	// ProviderUserReadyConditionType indicates the provider user has been successfully synchronized.
+	// Steady state
+	ProviderUserReadyConditionType common.ConditionType = "Ready"
+
+	// ProviderUserFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	ProviderUserFailedConditionType common.ConditionType = "Failed"
+
+	// ProviderUserPasswordSecretField indicates the secret field name with provider user's password
+	ProviderUserPasswordSecretField = "password"
+)
+
+// ProviderUserSection is an admin portal section member users can be given access to
+// +kubebuilder:validation:Enum=portal;finance;settings;partners;monitoring;plans;policy_registry
+type ProviderUserSection string
+
+// ProviderUserMemberPermissionsSpec defines the admin portal sections and products a member user has access to
+type ProviderUserMemberPermissionsSpec struct {
+	// AllowedSections are the admin portal sections the user has access to:
+	// portal (Developer Portal), finance (Billing), settings, partners (Developer Accounts and Applications),
+	// monitoring (Analytics), plans (Integration and Application Plans) and policy_registry.
+	// No sections when empty
+	// +listType=set
+	// +optional
+	AllowedSections []ProviderUserSection `json:"allowedSections,omitempty"`
+
+	// AllowedProducts are the system names of the products the user has access to.
+	// All products, current and future, when unset. No products when empty
+	// +listType=set
+	// +optional
+	AllowedProducts []string `json:"allowedProducts,omitempty"`
+}
+
+// ProviderUserSpec defines the desired state of ProviderUser
+type ProviderUserSpec struct {
+	// Username
+	Username string `json:"username"`
+
+	// Email
+	Email string `json:"email"`
+
+	// Password
+	PasswordCredentialsRef corev1.SecretReference `json:"passwordCredentialsRef"`
+
+	// Suspended defines the desired state. Defaults to "false", ie, active.
+	// Deleted resources suspend the user in 3scale
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Role defines the desired 3scale role. Defaults to "member"
+	// +kubebuilder:validation:Enum=admin;member
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// MemberPermissions defines the access of member users.
+	// Reset to the access of new member users when unset: no sections and all products
+	// +optional
+	MemberPermissions *ProviderUserMemberPermissionsSpec `json:"memberPermissions,omitempty"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+}
+
+// ProviderUserStatus defines the observed state of ProviderUser
+type ProviderUserStatus struct {
+	// +optional
+	ID *int64 `json:"providerUserID,omitempty"`
+
+	// +optional
+	State *string `json:"state,omitempty"`
+
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// PendingCreation is set before the user is created in 3scale and cleared once the user ID is recorded.
+	// Existing users with the same username and email are only adopted while the creation is pending
+	// +optional
+	PendingCreation bool `json:"pendingCreation,omitempty"`
+
+	// 3scale control plane host
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed ProviderUser Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the provider user resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (s *ProviderUserStatus) Equals(other *ProviderUserStatus, logger logr.Logger) bool {
+	if !reflect.DeepEqual(s.ID, other.ID) {
+		diff := cmp.Diff(s.ID, other.ID)
+		logger.V(1).Info("ID not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.State, other.State) {
+		diff := cmp.Diff(s.State, other.State)
+		logger.V(1).Info("State not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Role, other.Role) {
+		diff := cmp.Diff(s.Role, other.Role)
+		logger.V(1).Info("Role not equal", "difference", diff)
+		return false
+	}
+
+	if s.PendingCreation != other.PendingCreation {
+		diff := cmp.Diff(s.PendingCreation, other.PendingCreation)
+		logger.V(1).Info("PendingCreation not equal", "difference", diff)
+		return false
+	}
+
+	if s.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(s.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := s.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.providerAccountHost",name="Provider Account",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.state",name=State,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.providerUserID",name="3scale ID",type=integer
+
+// ProviderUser is the Schema for the providerusers API
+type ProviderUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderUserSpec   `json:"spec,omitempty"`
+	Status ProviderUserStatus `json:"status,omitempty"`
+}
+
+func (s *ProviderUser) IsAdmin() bool {
+	// Role defaults to member
+	return s.Spec.Role != nil && *s.Spec.Role == "admin"
+}
+
+func (s *ProviderUser) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	// Email validation
+	emailFldPath := field.NewPath("spec").Child("email")
+	if !helper.IsEmailValid(s.Spec.Email) {
+		errors = append(errors, field.Invalid(emailFldPath, s.Spec.Email, "Email address not valid"))
+	}
+
+	// Admin users have access to everything
+	if s.IsAdmin() && s.Spec.MemberPermissions != nil {
+		memberPermissionsFldPath := field.NewPath("spec").Child("memberPermissions")
+		errors = append(errors, field.Invalid(memberPermissionsFldPath, s.Spec.MemberPermissions, "member permissions not allowed for admin users"))
+	}
+
+	return errors
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderUserList contains a list of ProviderUser
+type ProviderUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderUser{}, &ProviderUserList{})
+}
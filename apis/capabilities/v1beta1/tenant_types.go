@@ -0,0 +1,477 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+const (
+	// TenantReadyConditionType indicates the tenant has been successfully created.
+	// Steady state
+	TenantReadyConditionType common.ConditionType = "Ready"
+
+	// TenantSuspendedConditionType indicates the tenant account is suspended
+	TenantSuspendedConditionType common.ConditionType = "Suspended"
+
+	// TenantDeletionBlockedConditionType indicates the resource has been deleted
+	// but the tenant account deletion has not been allowed
+	TenantDeletionBlockedConditionType common.ConditionType = "DeletionBlocked"
+
+	// TenantScheduledForDeletionConditionType indicates the resource has been deleted
+	// and the tenant account will be deleted in 3scale at the status deletion time
+	TenantScheduledForDeletionConditionType common.ConditionType = "ScheduledForDeletion"
+
+	// TenantStateApproved is the state of active tenant accounts
+	TenantStateApproved = "approved"
+
+	// TenantStateSuspended is the state of suspended tenant accounts
+	TenantStateSuspended = "suspended"
+
+	// TenantStateScheduledForDeletion is the state of tenant accounts scheduled for deletion
+	TenantStateScheduledForDeletion = "scheduled_for_deletion"
+
+	// TenantRotateAccessTokensAnnotation triggers a rotation of the tenant access tokens every time its value changes
+	TenantRotateAccessTokensAnnotation = "tenant.capabilities.3scale.net/rotate-access-tokens"
+
+	// TenantAllowDeletionAnnotation must be set to "true" for deleted resources to delete the tenant account in 3scale
+	TenantAllowDeletionAnnotation = "tenant.capabilities.3scale.net/allow-deletion"
+
+	// TenantDeletionPolicyDelete deletes the tenant account in 3scale when the resource is deleted
+	TenantDeletionPolicyDelete TenantDeletionPolicy = "Delete"
+
+	// TenantDeletionPolicySuspend suspends the tenant account in 3scale when the resource is deleted
+	TenantDeletionPolicySuspend TenantDeletionPolicy = "Suspend"
+)
+
+// TenantDeletionPolicy is what happens to the tenant account in 3scale when the resource is deleted
+// +kubebuilder:validation:Enum=Delete;Suspend
+type TenantDeletionPolicy string
+
+// TenantDeletionSpec defines how the tenant account is removed from 3scale when the resource is deleted
+type TenantDeletionSpec struct {
+	// Policy applied to the tenant account when the resource is deleted. Defaults to Delete.
+	// Delete schedules the tenant for deletion in 3scale, only when the allow-deletion annotation is "true".
+	// Suspend suspends the tenant account instead
+	// +optional
+	Policy *TenantDeletionPolicy `json:"policy,omitempty"`
+
+	// GracePeriod the tenant account is kept suspended before it is scheduled for deletion in 3scale.
+	// For example: 72h. Removing the allow-deletion annotation during the grace period cancels the deletion
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// TenantAccessTokenScope is the scope of an access token
+// +kubebuilder:validation:Enum=account_management;stats;policy_registry;cms
+type TenantAccessTokenScope string
+
+// TenantScopedAccessTokenSpec defines an access token of the tenant admin user with limited permission and scopes
+type TenantScopedAccessTokenSpec struct {
+	// Name of the token and of the tenant secret field the token value is written to
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +kubebuilder:validation:XValidation:rule="self != 'token' && self != 'adminURL'",message="token and adminURL are reserved tenant secret fields"
+	Name string `json:"name"`
+
+	// Permission of the token: ro (read only) or rw (read & write)
+	// +kubebuilder:validation:Enum=ro;rw
+	Permission string `json:"permission"`
+
+	// Scopes of the token
+	// +kubebuilder:validation:MinItems=1
+	Scopes []TenantAccessTokenScope `json:"scopes"`
+}
+
+// TenantAccessTokensSpec defines the access tokens of the tenant admin user managed by the operator
+type TenantAccessTokensSpec struct {
+	// Scoped access tokens generated besides the full permission token of the tenant secret.
+	// For example: read only, account management or policy registry tokens
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Scoped []TenantScopedAccessTokenSpec `json:"scoped,omitempty"`
+
+	// RotationInterval between scheduled rotations of the tenant access tokens, the full permission token included.
+	// For example: 720h. When not set, tokens are only rotated on demand using the rotate-access-tokens annotation
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+}
+
+// TenantAccessTokenStatus defines the observed state of an access token of the tenant secret
+type TenantAccessTokenStatus struct {
+	// Name of the tenant secret field with the token value
+	Name string `json:"name"`
+
+	// ID of the access token. Unknown for the token generated with the tenant
+	// +optional
+	ID int64 `json:"id,omitempty"`
+
+	// Permission of the token
+	// +optional
+	Permission string `json:"permission,omitempty"`
+
+	// Scopes of the token
+	// +optional
+	Scopes []TenantAccessTokenScope `json:"scopes,omitempty"`
+}
+
+// TenantPendingAccessTokenStatus is a token created and not known to be written to the tenant secret
+type TenantPendingAccessTokenStatus struct {
+	TenantAccessTokenStatus `json:",inline"`
+
+	// ValueHash is the SHA-256 of the token value, to find whether the token was written to the tenant secret
+	ValueHash string `json:"valueHash"`
+}
+
+// TenantPendingAccessTokensStatus records the tokens created before the tenant secret is updated.
+// Tokens found in the tenant secret replace the previous ones, the others are revoked
+type TenantPendingAccessTokensStatus struct {
+	// Tokens created and not known to be written to the tenant secret
+	Tokens []TenantPendingAccessTokenStatus `json:"tokens"`
+
+	// Rotation is true when the tokens were created by a rotation
+	// +optional
+	Rotation bool `json:"rotation,omitempty"`
+
+	// RotationTrigger value of the rotate-access-tokens annotation handled by the rotation
+	// +optional
+	RotationTrigger string `json:"rotationTrigger,omitempty"`
+}
+
+// TenantAccessTokensStatus defines the observed state of the tenant access tokens
+type TenantAccessTokensStatus struct {
+	// Tokens written to the tenant secret. The token values are not exposed
+	// +optional
+	Tokens []TenantAccessTokenStatus `json:"tokens,omitempty"`
+
+	// LastRotationTime time of the last rotation
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// NextRotationTime time of the next scheduled rotation
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+
+	// LastRotationTrigger value of the rotate-access-tokens annotation handled by the last rotation
+	// +optional
+	LastRotationTrigger string `json:"lastRotationTrigger,omitempty"`
+
+	// Pending tokens created and not known to be written to the tenant secret
+	// +optional
+	Pending *TenantPendingAccessTokensStatus `json:"pending,omitempty"`
+}
+
+// Token returns the status of the token written to the given tenant secret field, nil when not found
+func (s *TenantAccessTokensStatus) Token(name string) *TenantAccessTokenStatus {
+	for idx := range s.Tokens {
+		if s.Tokens[idx].Name == name {
+			return &s.Tokens[idx]
+		}
+	}
+
+	return nil
+}
+
+// TenantSpec defines the desired state of Tenant
+type TenantSpec struct {
+	// Username of the tenant admin user
+	Username string `json:"username"`
+
+	// Email of the tenant admin user
+	Email string `json:"email"`
+
+	// OrganizationName of the tenant account
+	OrganizationName string `json:"organizationName"`
+
+	// SystemMasterUrl is the URL of the master account
+	SystemMasterUrl string `json:"systemMasterUrl"`
+
+	// TenantSecretRef is the secret the tenant access token and admin URL are written to
+	TenantSecretRef corev1.SecretReference `json:"tenantSecretRef"`
+
+	// PasswordCredentialsRef is the secret with the tenant admin user password
+	PasswordCredentialsRef corev1.SecretReference `json:"passwordCredentialsRef"`
+
+	// MasterCredentialsRef is the secret with the master account access token
+	MasterCredentialsRef corev1.SecretReference `json:"masterCredentialsRef"`
+
+	// FromEmail is the email address notifications are sent from
+	// +optional
+	FromEmail *string `json:"fromEmail,omitempty"`
+
+	// SupportEmail is the support email address
+	// +optional
+	SupportEmail *string `json:"supportEmail,omitempty"`
+
+	// FinanceSupportEmail is the finance support email address
+	// +optional
+	FinanceSupportEmail *string `json:"financeSupportEmail,omitempty"`
+
+	// SiteAccessCode protects the developer portal with an access code
+	// +optional
+	SiteAccessCode *string `json:"siteAccessCode,omitempty"`
+
+	// Suspended suspends the tenant account. The tenant is resumed when it is unset or false
+	// +optional
+	Suspended *bool `json:"suspended,omitempty"`
+
+	// AccountPlan is the system name of the master account plan the tenant is subscribed to.
+	// When not set, the account plan is not managed
+	// +optional
+	AccountPlan *string `json:"accountPlan,omitempty"`
+
+	// AdminDomain is the domain of the tenant admin portal.
+	// When not set, the admin portal domain is not managed
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	AdminDomain *string `json:"adminDomain,omitempty"`
+
+	// DeveloperDomain is the domain of the tenant developer portal.
+	// When not set, the developer portal domain is not managed
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	DeveloperDomain *string `json:"developerDomain,omitempty"`
+
+	// AccessTokens generates scoped access tokens and rotates the tenant access tokens.
+	// New tokens are written to the tenant secret before the replaced tokens are revoked
+	// +optional
+	AccessTokens *TenantAccessTokensSpec `json:"accessTokens,omitempty"`
+
+	// Deletion defines how the tenant account is removed from 3scale when the resource is deleted.
+	// By default, the tenant is only deleted when the allow-deletion annotation is "true"
+	// +optional
+	Deletion *TenantDeletionSpec `json:"deletion,omitempty"`
+}
+
+// TenantStatus defines the observed state of Tenant
+type TenantStatus struct {
+	TenantId int64 `json:"tenantId"`
+	AdminId  int64 `json:"adminId"`
+
+	// State of the tenant account
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// AccountPlan is the system name of the account plan of the tenant
+	// +optional
+	AccountPlan string `json:"accountPlan,omitempty"`
+
+	// AdminDomain is the domain of the tenant admin portal
+	// +optional
+	AdminDomain string `json:"adminDomain,omitempty"`
+
+	// DeveloperDomain is the domain of the tenant developer portal
+	// +optional
+	DeveloperDomain string `json:"developerDomain,omitempty"`
+
+	// AccessTokens observed state of the tenant access tokens
+	// +optional
+	AccessTokens *TenantAccessTokensStatus `json:"accessTokens,omitempty"`
+
+	// DeletionTime is the date the tenant account of the deleted resource is scheduled for deletion in 3scale
+	// +optional
+	DeletionTime *metav1.Time `json:"deletionTime,omitempty"`
+
+	// Current state of the tenant resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (t *TenantStatus) StatusEqual(other *TenantStatus, logger logr.Logger) bool {
+	if t.TenantId != other.TenantId {
+		diff := cmp.Diff(t.TenantId, other.TenantId)
+		logger.V(1).Info("TenantId not equal", "difference", diff)
+		return false
+	}
+
+	if t.AdminId != other.AdminId {
+		diff := cmp.Diff(t.AdminId, other.AdminId)
+		logger.V(1).Info("AdminId not equal", "difference", diff)
+		return false
+	}
+
+	if t.State != other.State {
+		diff := cmp.Diff(t.State, other.State)
+		logger.V(1).Info("State not equal", "difference", diff)
+		return false
+	}
+
+	if t.AccountPlan != other.AccountPlan {
+		diff := cmp.Diff(t.AccountPlan, other.AccountPlan)
+		logger.V(1).Info("AccountPlan not equal", "difference", diff)
+		return false
+	}
+
+	if t.AdminDomain != other.AdminDomain {
+		diff := cmp.Diff(t.AdminDomain, other.AdminDomain)
+		logger.V(1).Info("AdminDomain not equal", "difference", diff)
+		return false
+	}
+
+	if t.DeveloperDomain != other.DeveloperDomain {
+		diff := cmp.Diff(t.DeveloperDomain, other.DeveloperDomain)
+		logger.V(1).Info("DeveloperDomain not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(t.AccessTokens, other.AccessTokens) {
+		diff := cmp.Diff(t.AccessTokens, other.AccessTokens)
+		logger.V(1).Info("AccessTokens not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(t.DeletionTime, other.DeletionTime) {
+		diff := cmp.Diff(t.DeletionTime, other.DeletionTime)
+		logger.V(1).Info("DeletionTime not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := t.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Tenant is the Schema for the tenants API
+// +kubebuilder:resource:path=tenants,scope=Namespaced
+// +kubebuilder:printcolumn:name="Tenant ID",type=integer,JSONPath=`.status.tenantId`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Admin Domain",type=string,JSONPath=`.status.adminDomain`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +operator-sdk:csv:customresourcedefinitions:displayName="Tenant"
+type Tenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantSpec   `json:"spec,omitempty"`
+	Status TenantStatus `json:"status,omitempty"`
+}
+
+// SetDefaults sets the default vaules for the tenant spec and returns true if the spec was changed
+func (t *Tenant) SetDefaults() bool {
+	changed := false
+	ts := &t.Spec
+	if ts.TenantSecretRef.Name == "" {
+		ts.TenantSecretRef.Name = fmt.Sprintf("%s-%s", strings.ToLower(t.Name), strings.ToLower(t.Spec.OrganizationName))
+		changed = true
+	}
+	if ts.TenantSecretRef.Namespace == "" {
+		ts.TenantSecretRef.Namespace = t.Namespace
+		changed = true
+	}
+	return changed
+}
+
+// IsSuspended returns true when the tenant account is desired to be suspended
+func (t *Tenant) IsSuspended() bool {
+	return t.Spec.Suspended != nil && *t.Spec.Suspended
+}
+
+// DeletionPolicy returns the policy applied to the tenant account when the resource is deleted
+func (t *Tenant) DeletionPolicy() TenantDeletionPolicy {
+	if t.Spec.Deletion == nil || t.Spec.Deletion.Policy == nil {
+		return TenantDeletionPolicyDelete
+	}
+
+	return *t.Spec.Deletion.Policy
+}
+
+// DeletionGracePeriod returns the time the tenant account is kept suspended before it is deleted in 3scale
+func (t *Tenant) DeletionGracePeriod() time.Duration {
+	if t.Spec.Deletion == nil || t.Spec.Deletion.GracePeriod == nil {
+		return 0
+	}
+
+	return t.Spec.Deletion.GracePeriod.Duration
+}
+
+// IsDeletionAllowed returns true when the allow-deletion annotation is "true"
+func (t *Tenant) IsDeletionAllowed() bool {
+	return t.GetAnnotations()[TenantAllowDeletionAnnotation] == "true"
+}
+
+func (t *Tenant) MasterSecretKey() client.ObjectKey {
+	return tenantSecretKey(t.Spec.MasterCredentialsRef, t.Namespace)
+}
+
+func (t *Tenant) AdminPassSecretKey() client.ObjectKey {
+	return tenantSecretKey(t.Spec.PasswordCredentialsRef, t.Namespace)
+}
+
+func (t *Tenant) TenantSecretKey() client.ObjectKey {
+	return tenantSecretKey(t.Spec.TenantSecretRef, t.Namespace)
+}
+
+func tenantSecretKey(ref corev1.SecretReference, defaultNamespace string) client.ObjectKey {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return client.ObjectKey{
+		Name:      ref.Name,
+		Namespace: namespace,
+	}
+}
+
+// Hub marks v1beta1 as the conversion hub of the Tenant versions
+func (t *Tenant) Hub() {}
+
+// SetupWebhookWithManager registers the Tenant conversion webhook
+func (t *Tenant) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(t).
+		Complete()
+}
+
+// +kubebuilder:object:root=true
+
+// TenantList contains a list of Tenant
+type TenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tenant{}, &TenantList{})
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	TenantMessagingKind = "TenantMessaging"
+
+	// TenantMessagingInvalidConditionType represents that the combination of configuration
+	// in the Spec is not supported. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	TenantMessagingInvalidConditionType common.ConditionType = "Invalid"
+
+	// TenantMessagingReadyConditionType indicates the messaging settings have been successfully synchronized.
+	// Steady state
+	TenantMessagingReadyConditionType common.ConditionType = "Ready"
+
+	// TenantMessagingWaitingConditionType indicates the messaging settings are waiting for
+	// some resource, like a provider user or a ConfigMap, to be created.
+	// The operator will retry.
+	TenantMessagingWaitingConditionType common.ConditionType = "Waiting"
+
+	// TenantMessagingFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	TenantMessagingFailedConditionType common.ConditionType = "Failed"
+)
+
+// TenantMessagingEmailTemplateSpec defines an email template replacing the 3scale default
+type TenantMessagingEmailTemplateSpec struct {
+	// SystemName of the email template, e.g. account_approved or application_key_created
+	SystemName string `json:"systemName"`
+
+	// Subject header. Defaults to the subject of the 3scale default template
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// From header. Defaults to the tenant from email
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// ReplyTo header
+	// +optional
+	ReplyTo string `json:"replyTo,omitempty"`
+
+	// Cc header
+	// +optional
+	Cc string `json:"cc,omitempty"`
+
+	// Bcc header
+	// +optional
+	Bcc string `json:"bcc,omitempty"`
+
+	// Body of the email template, a Liquid template
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// BodyRef refers to the ConfigMap key with the body of the email template
+	// +optional
+	BodyRef *corev1.ConfigMapKeySelector `json:"bodyRef,omitempty"`
+}
+
+// TenantMessagingNotificationPreferencesSpec defines the email notifications received by provider users
+type TenantMessagingNotificationPreferencesSpec struct {
+	// Users are the usernames of the provider users the preferences apply to.
+	// Defaults to every admin user
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Enabled are the notifications sent to the users, e.g. account_created or application_created
+	// +optional
+	Enabled []string `json:"enabled,omitempty"`
+
+	// Disabled are the notifications not sent to the users
+	// +optional
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// TenantMessagingSpec defines the desired state of TenantMessaging
+type TenantMessagingSpec struct {
+	// EmailTemplates replace the 3scale default email templates sent to developers.
+	// Templates removed from the list are restored to the 3scale default
+	// +optional
+	EmailTemplates []TenantMessagingEmailTemplateSpec `json:"emailTemplates,omitempty"`
+
+	// NotificationPreferences defines the email notifications received by provider users.
+	// Notifications not listed are not managed
+	// +optional
+	NotificationPreferences *TenantMessagingNotificationPreferencesSpec `json:"notificationPreferences,omitempty"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+}
+
+// TenantMessagingEmailTemplateStatus defines the observed state of an email template
+type TenantMessagingEmailTemplateStatus struct {
+	// SystemName of the email template
+	SystemName string `json:"systemName"`
+
+	// ID of the email template in 3scale
+	ID int64 `json:"id"`
+}
+
+// TenantMessagingStatus defines the observed state of TenantMessaging
+type TenantMessagingStatus struct {
+	// 3scale control plane host
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// EmailTemplates synchronized to 3scale
+	// +optional
+	EmailTemplates []TenantMessagingEmailTemplateStatus `json:"emailTemplates,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed TenantMessaging Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the tenant messaging resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (s *TenantMessagingStatus) Equals(other *TenantMessagingStatus, logger logr.Logger) bool {
+	if s.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(s.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.EmailTemplates, other.EmailTemplates) {
+		diff := cmp.Diff(s.EmailTemplates, other.EmailTemplates)
+		logger.V(1).Info("EmailTemplates not equal", "difference", diff)
+		return false
+	}
+
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := s.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.providerAccountHost",name="Provider Account",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+
+// TenantMessaging is the Schema for the tenantmessagings API
+type TenantMessaging struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantMessagingSpec   `json:"spec,omitempty"`
+	Status TenantMessagingStatus `json:"status,omitempty"`
+}
+
+func (t *TenantMessaging) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	emailTemplatesFldPath := field.NewPath("spec").Child("emailTemplates")
+	systemNames := map[string]bool{}
+	for idx, emailTemplate := range t.Spec.EmailTemplates {
+		emailTemplateFldPath := emailTemplatesFldPath.Index(idx)
+		if systemNames[emailTemplate.SystemName] {
+			errors = append(errors, field.Duplicate(emailTemplateFldPath.Child("systemName"), emailTemplate.SystemName))
+		}
+		systemNames[emailTemplate.SystemName] = true
+
+		if (emailTemplate.Body == "") == (emailTemplate.BodyRef == nil) {
+			errors = append(errors, field.Invalid(emailTemplateFldPath, emailTemplate.SystemName, "one and only one of body or bodyRef must be set"))
+		}
+	}
+
+	if t.Spec.NotificationPreferences != nil {
+		enabledFldPath := field.NewPath("spec").Child("notificationPreferences").Child("enabled")
+		disabled := map[string]bool{}
+		for _, notification := range t.Spec.NotificationPreferences.Disabled {
+			disabled[notification] = true
+		}
+		for idx, notification := range t.Spec.NotificationPreferences.Enabled {
+			if disabled[notification] {
+				errors = append(errors, field.Invalid(enabledFldPath.Index(idx), notification, "notification both enabled and disabled"))
+			}
+		}
+	}
+
+	return errors
+}
+
+// +kubebuilder:object:root=true
+
+// TenantMessagingList contains a list of TenantMessaging
+type TenantMessagingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantMessaging `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantMessaging{}, &TenantMessagingList{})
+}
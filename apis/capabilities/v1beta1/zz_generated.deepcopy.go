@@ -23,9 +23,158 @@ package v1beta1
 import (
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlan) DeepCopyInto(out *AccountPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlan.
+func (in *AccountPlan) DeepCopy() *AccountPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanList) DeepCopyInto(out *AccountPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccountPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanList.
+func (in *AccountPlanList) DeepCopy() *AccountPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanSpec) DeepCopyInto(out *AccountPlanSpec) {
+	*out = *in
+	if in.ApprovalRequired != nil {
+		in, out := &in.ApprovalRequired, &out.ApprovalRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrialPeriod != nil {
+		in, out := &in.TrialPeriod, &out.TrialPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.SetupFee != nil {
+		in, out := &in.SetupFee, &out.SetupFee
+		*out = new(string)
+		**out = **in
+	}
+	if in.CostMonth != nil {
+		in, out := &in.CostMonth, &out.CostMonth
+		*out = new(string)
+		**out = **in
+	}
+	if in.Published != nil {
+		in, out := &in.Published, &out.Published
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]FeatureSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanSpec.
+func (in *AccountPlanSpec) DeepCopy() *AccountPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanStatus) DeepCopyInto(out *AccountPlanStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanStatus.
+func (in *AccountPlanStatus) DeepCopy() *AccountPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveDoc) DeepCopyInto(out *ActiveDoc) {
 	*out = *in
@@ -98,6 +247,26 @@ func (in *ActiveDocOpenAPIRefSpec) DeepCopyInto(out *ActiveDocOpenAPIRefSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(OpenAPIConfigMapRefSpec)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(OpenAPIGitSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OpenAPIOCISourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveDocOpenAPIRefSpec.
@@ -169,6 +338,15 @@ func (in *ActiveDocStatus) DeepCopyInto(out *ActiveDocStatus) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.SourceFetchTime != nil {
+		in, out := &in.SourceFetchTime, &out.SourceFetchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SourceConversions != nil {
+		in, out := &in.SourceConversions, &out.SourceConversions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(common.Conditions, len(*in))
@@ -382,6 +560,11 @@ func (in *ApplicationAuthSpec) DeepCopyInto(out *ApplicationAuthSpec) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(ApplicationKeyRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationAuthSpec.
@@ -404,6 +587,11 @@ func (in *ApplicationAuthStatus) DeepCopyInto(out *ApplicationAuthStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(ApplicationKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationAuthStatus.
@@ -416,6 +604,83 @@ func (in *ApplicationAuthStatus) DeepCopy() *ApplicationAuthStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationKeyRotationPendingStatus) DeepCopyInto(out *ApplicationKeyRotationPendingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationKeyRotationPendingStatus.
+func (in *ApplicationKeyRotationPendingStatus) DeepCopy() *ApplicationKeyRotationPendingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationKeyRotationPendingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationKeyRotationSpec) DeepCopyInto(out *ApplicationKeyRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationKeyRotationSpec.
+func (in *ApplicationKeyRotationSpec) DeepCopy() *ApplicationKeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationKeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationKeyRotationStatus) DeepCopyInto(out *ApplicationKeyRotationStatus) {
+	*out = *in
+	if in.ActiveKeyIDs != nil {
+		in, out := &in.ActiveKeyIDs, &out.ActiveKeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetiringKeyDeletionTime != nil {
+		in, out := &in.RetiringKeyDeletionTime, &out.RetiringKeyDeletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = new(ApplicationKeyRotationPendingStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationKeyRotationStatus.
+func (in *ApplicationKeyRotationStatus) DeepCopy() *ApplicationKeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationList) DeepCopyInto(out *ApplicationList) {
 	*out = *in
@@ -495,6 +760,11 @@ func (in *ApplicationPlanSpec) DeepCopyInto(out *ApplicationPlanSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanSpec.
@@ -525,6 +795,18 @@ func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.ReferrerFilters != nil {
+		in, out := &in.ReferrerFilters, &out.ReferrerFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraFields != nil {
+		in, out := &in.ExtraFields, &out.ExtraFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSpec.
@@ -545,6 +827,11 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ManagedReferrerFilters != nil {
+		in, out := &in.ManagedReferrerFilters, &out.ManagedReferrerFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(common.Conditions, len(*in))
@@ -895,6 +1182,21 @@ func (in *DeveloperAccount) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperAccountBillingAddressSpec) DeepCopyInto(out *DeveloperAccountBillingAddressSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountBillingAddressSpec.
+func (in *DeveloperAccountBillingAddressSpec) DeepCopy() *DeveloperAccountBillingAddressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperAccountBillingAddressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeveloperAccountList) DeepCopyInto(out *DeveloperAccountList) {
 	*out = *in
@@ -928,93 +1230,96 @@ func (in *DeveloperAccountList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeveloperAccountSpec) DeepCopyInto(out *DeveloperAccountSpec) {
+func (in *DeveloperAccountSet) DeepCopyInto(out *DeveloperAccountSet) {
 	*out = *in
-	if in.MonthlyBillingEnabled != nil {
-		in, out := &in.MonthlyBillingEnabled, &out.MonthlyBillingEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.MonthlyChargingEnabled != nil {
-		in, out := &in.MonthlyChargingEnabled, &out.MonthlyChargingEnabled
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSet.
+func (in *DeveloperAccountSet) DeepCopy() *DeveloperAccountSet {
+	if in == nil {
+		return nil
 	}
-	if in.ProviderAccountRef != nil {
-		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	out := new(DeveloperAccountSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeveloperAccountSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSpec.
-func (in *DeveloperAccountSpec) DeepCopy() *DeveloperAccountSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperAccountSetConfigMapSourceSpec) DeepCopyInto(out *DeveloperAccountSetConfigMapSourceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSetConfigMapSourceSpec.
+func (in *DeveloperAccountSetConfigMapSourceSpec) DeepCopy() *DeveloperAccountSetConfigMapSourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeveloperAccountSpec)
+	out := new(DeveloperAccountSetConfigMapSourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeveloperAccountStatus) DeepCopyInto(out *DeveloperAccountStatus) {
+func (in *DeveloperAccountSetLDAPGroupSourceSpec) DeepCopyInto(out *DeveloperAccountSetLDAPGroupSourceSpec) {
 	*out = *in
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(int64)
-		**out = **in
+	in.DirectoryRef.DeepCopyInto(&out.DirectoryRef)
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.AccountState != nil {
-		in, out := &in.AccountState, &out.AccountState
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSetLDAPGroupSourceSpec.
+func (in *DeveloperAccountSetLDAPGroupSourceSpec) DeepCopy() *DeveloperAccountSetLDAPGroupSourceSpec {
+	if in == nil {
+		return nil
 	}
-	if in.CreditCardStored != nil {
-		in, out := &in.CreditCardStored, &out.CreditCardStored
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(common.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountStatus.
-func (in *DeveloperAccountStatus) DeepCopy() *DeveloperAccountStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(DeveloperAccountStatus)
+	out := new(DeveloperAccountSetLDAPGroupSourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeveloperUser) DeepCopyInto(out *DeveloperUser) {
+func (in *DeveloperAccountSetList) DeepCopyInto(out *DeveloperAccountSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeveloperAccountSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUser.
-func (in *DeveloperUser) DeepCopy() *DeveloperUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSetList.
+func (in *DeveloperAccountSetList) DeepCopy() *DeveloperAccountSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(DeveloperUser)
+	out := new(DeveloperAccountSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DeveloperUser) DeepCopyObject() runtime.Object {
+func (in *DeveloperAccountSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1022,45 +1327,48 @@ func (in *DeveloperUser) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeveloperUserList) DeepCopyInto(out *DeveloperUserList) {
+func (in *DeveloperAccountSetSourceSpec) DeepCopyInto(out *DeveloperAccountSetSourceSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DeveloperUser, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(DeveloperAccountSetConfigMapSourceSpec)
+		**out = **in
+	}
+	if in.LDAPGroup != nil {
+		in, out := &in.LDAPGroup, &out.LDAPGroup
+		*out = new(DeveloperAccountSetLDAPGroupSourceSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserList.
-func (in *DeveloperUserList) DeepCopy() *DeveloperUserList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSetSourceSpec.
+func (in *DeveloperAccountSetSourceSpec) DeepCopy() *DeveloperAccountSetSourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeveloperUserList)
+	out := new(DeveloperAccountSetSourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DeveloperUserList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeveloperUserSpec) DeepCopyInto(out *DeveloperUserSpec) {
+func (in *DeveloperAccountSetSpec) DeepCopyInto(out *DeveloperAccountSetSpec) {
 	*out = *in
-	out.PasswordCredentialsRef = in.PasswordCredentialsRef
-	out.DeveloperAccountRef = in.DeveloperAccountRef
-	if in.Role != nil {
-		in, out := &in.Role, &out.Role
-		*out = new(string)
+	in.Source.DeepCopyInto(&out.Source)
+	out.Template = in.Template
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int32)
 		**out = **in
 	}
 	if in.ProviderAccountRef != nil {
@@ -1070,33 +1378,28 @@ func (in *DeveloperUserSpec) DeepCopyInto(out *DeveloperUserSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserSpec.
-func (in *DeveloperUserSpec) DeepCopy() *DeveloperUserSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSetSpec.
+func (in *DeveloperAccountSetSpec) DeepCopy() *DeveloperAccountSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeveloperUserSpec)
+	out := new(DeveloperAccountSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeveloperUserStatus) DeepCopyInto(out *DeveloperUserStatus) {
+func (in *DeveloperAccountSetStatus) DeepCopyInto(out *DeveloperAccountSetStatus) {
 	*out = *in
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(int64)
-		**out = **in
-	}
-	if in.AccountID != nil {
-		in, out := &in.AccountID, &out.AccountID
-		*out = new(int64)
-		**out = **in
+	if in.NotReadyRows != nil {
+		in, out := &in.NotReadyRows, &out.NotReadyRows
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.DeveloperUserState != nil {
-		in, out := &in.DeveloperUserState, &out.DeveloperUserState
-		*out = new(string)
-		**out = **in
+	if in.PendingDeletionRows != nil {
+		in, out := &in.PendingDeletionRows, &out.PendingDeletionRows
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1107,244 +1410,1550 @@ func (in *DeveloperUserStatus) DeepCopyInto(out *DeveloperUserStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserStatus.
-func (in *DeveloperUserStatus) DeepCopy() *DeveloperUserStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSetStatus.
+func (in *DeveloperAccountSetStatus) DeepCopy() *DeveloperAccountSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DeveloperUserStatus)
+	out := new(DeveloperAccountSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayResponseSpec) DeepCopyInto(out *GatewayResponseSpec) {
+func (in *DeveloperAccountSetTemplateSpec) DeepCopyInto(out *DeveloperAccountSetTemplateSpec) {
 	*out = *in
-	if in.ErrorStatusAuthFailed != nil {
-		in, out := &in.ErrorStatusAuthFailed, &out.ErrorStatusAuthFailed
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ErrorHeadersAuthFailed != nil {
-		in, out := &in.ErrorHeadersAuthFailed, &out.ErrorHeadersAuthFailed
-		*out = new(string)
-		**out = **in
-	}
-	if in.ErrorAuthFailed != nil {
-		in, out := &in.ErrorAuthFailed, &out.ErrorAuthFailed
-		*out = new(string)
-		**out = **in
-	}
-	if in.ErrorStatusAuthMissing != nil {
-		in, out := &in.ErrorStatusAuthMissing, &out.ErrorStatusAuthMissing
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ErrorHeadersAuthMissing != nil {
-		in, out := &in.ErrorHeadersAuthMissing, &out.ErrorHeadersAuthMissing
-		*out = new(string)
-		**out = **in
-	}
-	if in.ErrorAuthMissing != nil {
-		in, out := &in.ErrorAuthMissing, &out.ErrorAuthMissing
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSetTemplateSpec.
+func (in *DeveloperAccountSetTemplateSpec) DeepCopy() *DeveloperAccountSetTemplateSpec {
+	if in == nil {
+		return nil
 	}
-	if in.ErrorStatusNoMatch != nil {
-		in, out := &in.ErrorStatusNoMatch, &out.ErrorStatusNoMatch
-		*out = new(int32)
+	out := new(DeveloperAccountSetTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperAccountSpec) DeepCopyInto(out *DeveloperAccountSpec) {
+	*out = *in
+	if in.MonthlyBillingEnabled != nil {
+		in, out := &in.MonthlyBillingEnabled, &out.MonthlyBillingEnabled
+		*out = new(bool)
 		**out = **in
 	}
-	if in.ErrorHeadersNoMatch != nil {
-		in, out := &in.ErrorHeadersNoMatch, &out.ErrorHeadersNoMatch
-		*out = new(string)
+	if in.MonthlyChargingEnabled != nil {
+		in, out := &in.MonthlyChargingEnabled, &out.MonthlyChargingEnabled
+		*out = new(bool)
 		**out = **in
 	}
-	if in.ErrorNoMatch != nil {
-		in, out := &in.ErrorNoMatch, &out.ErrorNoMatch
-		*out = new(string)
+	if in.BillingAddress != nil {
+		in, out := &in.BillingAddress, &out.BillingAddress
+		*out = new(DeveloperAccountBillingAddressSpec)
 		**out = **in
 	}
-	if in.ErrorStatusLimitsExceeded != nil {
-		in, out := &in.ErrorStatusLimitsExceeded, &out.ErrorStatusLimitsExceeded
-		*out = new(int32)
-		**out = **in
+	if in.CustomFields != nil {
+		in, out := &in.CustomFields, &out.CustomFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.ErrorHeadersLimitsExceeded != nil {
-		in, out := &in.ErrorHeadersLimitsExceeded, &out.ErrorHeadersLimitsExceeded
+	if in.State != nil {
+		in, out := &in.State, &out.State
 		*out = new(string)
 		**out = **in
 	}
-	if in.ErrorLimitsExceeded != nil {
-		in, out := &in.ErrorLimitsExceeded, &out.ErrorLimitsExceeded
-		*out = new(string)
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayResponseSpec.
-func (in *GatewayResponseSpec) DeepCopy() *GatewayResponseSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSpec.
+func (in *DeveloperAccountSpec) DeepCopy() *DeveloperAccountSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayResponseSpec)
+	out := new(DeveloperAccountSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LimitSpec) DeepCopyInto(out *LimitSpec) {
+func (in *DeveloperAccountStateTransition) DeepCopyInto(out *DeveloperAccountStateTransition) {
 	*out = *in
-	in.MetricMethodRef.DeepCopyInto(&out.MetricMethodRef)
+	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitSpec.
-func (in *LimitSpec) DeepCopy() *LimitSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountStateTransition.
+func (in *DeveloperAccountStateTransition) DeepCopy() *DeveloperAccountStateTransition {
 	if in == nil {
 		return nil
 	}
-	out := new(LimitSpec)
+	out := new(DeveloperAccountStateTransition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MappingRuleSpec) DeepCopyInto(out *MappingRuleSpec) {
+func (in *DeveloperAccountStatus) DeepCopyInto(out *DeveloperAccountStatus) {
 	*out = *in
-	if in.Last != nil {
-		in, out := &in.Last, &out.Last
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AccountState != nil {
+		in, out := &in.AccountState, &out.AccountState
+		*out = new(string)
+		**out = **in
+	}
+	if in.CreditCardStored != nil {
+		in, out := &in.CreditCardStored, &out.CreditCardStored
 		*out = new(bool)
 		**out = **in
 	}
+	if in.StateTransitions != nil {
+		in, out := &in.StateTransitions, &out.StateTransitions
+		*out = make([]DeveloperAccountStateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappingRuleSpec.
-func (in *MappingRuleSpec) DeepCopy() *MappingRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountStatus.
+func (in *DeveloperAccountStatus) DeepCopy() *DeveloperAccountStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MappingRuleSpec)
+	out := new(DeveloperAccountStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MethodSpec) DeepCopyInto(out *MethodSpec) {
+func (in *DeveloperPortalContent) DeepCopyInto(out *DeveloperPortalContent) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MethodSpec.
-func (in *MethodSpec) DeepCopy() *MethodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperPortalContent.
+func (in *DeveloperPortalContent) DeepCopy() *DeveloperPortalContent {
 	if in == nil {
 		return nil
 	}
-	out := new(MethodSpec)
+	out := new(DeveloperPortalContent)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeveloperPortalContent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricMethodRefSpec) DeepCopyInto(out *MetricMethodRefSpec) {
+func (in *DeveloperPortalContentGitSourceSpec) DeepCopyInto(out *DeveloperPortalContentGitSourceSpec) {
 	*out = *in
-	if in.BackendSystemName != nil {
-		in, out := &in.BackendSystemName, &out.BackendSystemName
-		*out = new(string)
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricMethodRefSpec.
-func (in *MetricMethodRefSpec) DeepCopy() *MetricMethodRefSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperPortalContentGitSourceSpec.
+func (in *DeveloperPortalContentGitSourceSpec) DeepCopy() *DeveloperPortalContentGitSourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricMethodRefSpec)
+	out := new(DeveloperPortalContentGitSourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
+func (in *DeveloperPortalContentItemStatus) DeepCopyInto(out *DeveloperPortalContentItemStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
-func (in *MetricSpec) DeepCopy() *MetricSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperPortalContentItemStatus.
+func (in *DeveloperPortalContentItemStatus) DeepCopy() *DeveloperPortalContentItemStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricSpec)
+	out := new(DeveloperPortalContentItemStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCAuthenticationFlowSpec) DeepCopyInto(out *OIDCAuthenticationFlowSpec) {
+func (in *DeveloperPortalContentList) DeepCopyInto(out *DeveloperPortalContentList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeveloperPortalContent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCAuthenticationFlowSpec.
-func (in *OIDCAuthenticationFlowSpec) DeepCopy() *OIDCAuthenticationFlowSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperPortalContentList.
+func (in *DeveloperPortalContentList) DeepCopy() *DeveloperPortalContentList {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCAuthenticationFlowSpec)
+	out := new(DeveloperPortalContentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeveloperPortalContentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
+func (in *DeveloperPortalContentSourceSpec) DeepCopyInto(out *DeveloperPortalContentSourceSpec) {
 	*out = *in
-	if in.IssuerEndpointRef != nil {
-		in, out := &in.IssuerEndpointRef, &out.IssuerEndpointRef
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(DeveloperPortalContentGitSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperPortalContentSourceSpec.
+func (in *DeveloperPortalContentSourceSpec) DeepCopy() *DeveloperPortalContentSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperPortalContentSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperPortalContentSpec) DeepCopyInto(out *DeveloperPortalContentSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperPortalContentSpec.
+func (in *DeveloperPortalContentSpec) DeepCopy() *DeveloperPortalContentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperPortalContentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperPortalContentStatus) DeepCopyInto(out *DeveloperPortalContentStatus) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeveloperPortalContentItemStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperPortalContentStatus.
+func (in *DeveloperPortalContentStatus) DeepCopy() *DeveloperPortalContentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperPortalContentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperUser) DeepCopyInto(out *DeveloperUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUser.
+func (in *DeveloperUser) DeepCopy() *DeveloperUser {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeveloperUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperUserInvitationSpec) DeepCopyInto(out *DeveloperUserInvitationSpec) {
+	*out = *in
+	if in.ExpiresAfter != nil {
+		in, out := &in.ExpiresAfter, &out.ExpiresAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserInvitationSpec.
+func (in *DeveloperUserInvitationSpec) DeepCopy() *DeveloperUserInvitationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperUserInvitationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperUserInvitationStatus) DeepCopyInto(out *DeveloperUserInvitationStatus) {
+	*out = *in
+	if in.SentTime != nil {
+		in, out := &in.SentTime, &out.SentTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AcceptedTime != nil {
+		in, out := &in.AcceptedTime, &out.AcceptedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserInvitationStatus.
+func (in *DeveloperUserInvitationStatus) DeepCopy() *DeveloperUserInvitationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperUserInvitationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperUserList) DeepCopyInto(out *DeveloperUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeveloperUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserList.
+func (in *DeveloperUserList) DeepCopy() *DeveloperUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeveloperUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperUserSpec) DeepCopyInto(out *DeveloperUserSpec) {
+	*out = *in
+	if in.PasswordCredentialsRef != nil {
+		in, out := &in.PasswordCredentialsRef, &out.PasswordCredentialsRef
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
-	if in.AuthenticationFlow != nil {
-		in, out := &in.AuthenticationFlow, &out.AuthenticationFlow
-		*out = new(OIDCAuthenticationFlowSpec)
+	if in.Invitation != nil {
+		in, out := &in.Invitation, &out.Invitation
+		*out = new(DeveloperUserInvitationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.DeveloperAccountRef = in.DeveloperAccountRef
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserSpec.
+func (in *DeveloperUserSpec) DeepCopy() *DeveloperUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperUserStatus) DeepCopyInto(out *DeveloperUserStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AccountID != nil {
+		in, out := &in.AccountID, &out.AccountID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeveloperUserState != nil {
+		in, out := &in.DeveloperUserState, &out.DeveloperUserState
+		*out = new(string)
+		**out = **in
+	}
+	if in.Invitation != nil {
+		in, out := &in.Invitation, &out.Invitation
+		*out = new(DeveloperUserInvitationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserStatus.
+func (in *DeveloperUserStatus) DeepCopy() *DeveloperUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
+func (in *FeatureSpec) DeepCopy() *FeatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayResponseSpec) DeepCopyInto(out *GatewayResponseSpec) {
+	*out = *in
+	if in.ErrorStatusAuthFailed != nil {
+		in, out := &in.ErrorStatusAuthFailed, &out.ErrorStatusAuthFailed
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorHeadersAuthFailed != nil {
+		in, out := &in.ErrorHeadersAuthFailed, &out.ErrorHeadersAuthFailed
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorAuthFailed != nil {
+		in, out := &in.ErrorAuthFailed, &out.ErrorAuthFailed
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorStatusAuthMissing != nil {
+		in, out := &in.ErrorStatusAuthMissing, &out.ErrorStatusAuthMissing
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorHeadersAuthMissing != nil {
+		in, out := &in.ErrorHeadersAuthMissing, &out.ErrorHeadersAuthMissing
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorAuthMissing != nil {
+		in, out := &in.ErrorAuthMissing, &out.ErrorAuthMissing
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorStatusNoMatch != nil {
+		in, out := &in.ErrorStatusNoMatch, &out.ErrorStatusNoMatch
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorHeadersNoMatch != nil {
+		in, out := &in.ErrorHeadersNoMatch, &out.ErrorHeadersNoMatch
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorNoMatch != nil {
+		in, out := &in.ErrorNoMatch, &out.ErrorNoMatch
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorStatusLimitsExceeded != nil {
+		in, out := &in.ErrorStatusLimitsExceeded, &out.ErrorStatusLimitsExceeded
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorHeadersLimitsExceeded != nil {
+		in, out := &in.ErrorHeadersLimitsExceeded, &out.ErrorHeadersLimitsExceeded
+		*out = new(string)
+		**out = **in
+	}
+	if in.ErrorLimitsExceeded != nil {
+		in, out := &in.ErrorLimitsExceeded, &out.ErrorLimitsExceeded
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayResponseSpec.
+func (in *GatewayResponseSpec) DeepCopy() *GatewayResponseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayResponseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitSpec) DeepCopyInto(out *LimitSpec) {
+	*out = *in
+	in.MetricMethodRef.DeepCopyInto(&out.MetricMethodRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitSpec.
+func (in *LimitSpec) DeepCopy() *LimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MappingRuleSpec) DeepCopyInto(out *MappingRuleSpec) {
+	*out = *in
+	if in.Last != nil {
+		in, out := &in.Last, &out.Last
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappingRuleSpec.
+func (in *MappingRuleSpec) DeepCopy() *MappingRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MappingRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MethodSpec) DeepCopyInto(out *MethodSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MethodSpec.
+func (in *MethodSpec) DeepCopy() *MethodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MethodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricMethodRefSpec) DeepCopyInto(out *MetricMethodRefSpec) {
+	*out = *in
+	if in.BackendSystemName != nil {
+		in, out := &in.BackendSystemName, &out.BackendSystemName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricMethodRefSpec.
+func (in *MetricMethodRefSpec) DeepCopy() *MetricMethodRefSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricMethodRefSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
+func (in *MetricSpec) DeepCopy() *MetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCAuthenticationFlowSpec) DeepCopyInto(out *OIDCAuthenticationFlowSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCAuthenticationFlowSpec.
+func (in *OIDCAuthenticationFlowSpec) DeepCopy() *OIDCAuthenticationFlowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCAuthenticationFlowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
+	*out = *in
+	if in.IssuerEndpointRef != nil {
+		in, out := &in.IssuerEndpointRef, &out.IssuerEndpointRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.AuthenticationFlow != nil {
+		in, out := &in.AuthenticationFlow, &out.AuthenticationFlow
+		*out = new(OIDCAuthenticationFlowSpec)
+		**out = **in
+	}
+	if in.JwtClaimWithClientID != nil {
+		in, out := &in.JwtClaimWithClientID, &out.JwtClaimWithClientID
+		*out = new(string)
+		**out = **in
+	}
+	if in.JwtClaimWithClientIDType != nil {
+		in, out := &in.JwtClaimWithClientIDType, &out.JwtClaimWithClientIDType
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsLoc != nil {
+		in, out := &in.CredentialsLoc, &out.CredentialsLoc
+		*out = new(string)
+		**out = **in
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(SecuritySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayResponse != nil {
+		in, out := &in.GatewayResponse, &out.GatewayResponse
+		*out = new(GatewayResponseSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSpec.
+func (in *OIDCSpec) DeepCopy() *OIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPI) DeepCopyInto(out *OpenAPI) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPI.
+func (in *OpenAPI) DeepCopy() *OpenAPI {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenAPI) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIActiveDocSpec) DeepCopyInto(out *OpenAPIActiveDocSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Published != nil {
+		in, out := &in.Published, &out.Published
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipSwaggerValidations != nil {
+		in, out := &in.SkipSwaggerValidations, &out.SkipSwaggerValidations
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIActiveDocSpec.
+func (in *OpenAPIActiveDocSpec) DeepCopy() *OpenAPIActiveDocSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIActiveDocSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIAuthenticationStatus) DeepCopyInto(out *OpenAPIAuthenticationStatus) {
+	*out = *in
+	if in.SecuritySchemes != nil {
+		in, out := &in.SecuritySchemes, &out.SecuritySchemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIAuthenticationStatus.
+func (in *OpenAPIAuthenticationStatus) DeepCopy() *OpenAPIAuthenticationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIAuthenticationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIChange) DeepCopyInto(out *OpenAPIChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIChange.
+func (in *OpenAPIChange) DeepCopy() *OpenAPIChange {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIConfigMapRefSpec) DeepCopyInto(out *OpenAPIConfigMapRefSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIConfigMapRefSpec.
+func (in *OpenAPIConfigMapRefSpec) DeepCopy() *OpenAPIConfigMapRefSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIConfigMapRefSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIGitSourceSpec) DeepCopyInto(out *OpenAPIGitSourceSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIGitSourceSpec.
+func (in *OpenAPIGitSourceSpec) DeepCopy() *OpenAPIGitSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIGitSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIList) DeepCopyInto(out *OpenAPIList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenAPI, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIList.
+func (in *OpenAPIList) DeepCopy() *OpenAPIList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenAPIList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIOCISourceSpec) DeepCopyInto(out *OpenAPIOCISourceSpec) {
+	*out = *in
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIOCISourceSpec.
+func (in *OpenAPIOCISourceSpec) DeepCopy() *OpenAPIOCISourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIOCISourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIRefSpec) DeepCopyInto(out *OpenAPIRefSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(OpenAPIConfigMapRefSpec)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(OpenAPIGitSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OpenAPIOCISourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIRefSpec.
+func (in *OpenAPIRefSpec) DeepCopy() *OpenAPIRefSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIRefSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIRequestValidationSpec) DeepCopyInto(out *OpenAPIRequestValidationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIRequestValidationSpec.
+func (in *OpenAPIRequestValidationSpec) DeepCopy() *OpenAPIRequestValidationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIRequestValidationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPISpec) DeepCopyInto(out *OpenAPISpec) {
+	*out = *in
+	in.OpenAPIRef.DeepCopyInto(&out.OpenAPIRef)
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ProductionPublicBaseURL != nil {
+		in, out := &in.ProductionPublicBaseURL, &out.ProductionPublicBaseURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.StagingPublicBaseURL != nil {
+		in, out := &in.StagingPublicBaseURL, &out.StagingPublicBaseURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProductSystemName != nil {
+		in, out := &in.ProductSystemName, &out.ProductSystemName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrivateBaseURL != nil {
+		in, out := &in.PrivateBaseURL, &out.PrivateBaseURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrefixMatching != nil {
+		in, out := &in.PrefixMatching, &out.PrefixMatching
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PrivateAPIHostHeader != nil {
+		in, out := &in.PrivateAPIHostHeader, &out.PrivateAPIHostHeader
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrivateAPISecretToken != nil {
+		in, out := &in.PrivateAPISecretToken, &out.PrivateAPISecretToken
+		*out = new(string)
+		**out = **in
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDoc != nil {
+		in, out := &in.ActiveDoc, &out.ActiveDoc
+		*out = new(OpenAPIActiveDocSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestValidation != nil {
+		in, out := &in.RequestValidation, &out.RequestValidation
+		*out = new(OpenAPIRequestValidationSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPISpec.
+func (in *OpenAPISpec) DeepCopy() *OpenAPISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIStatus) DeepCopyInto(out *OpenAPIStatus) {
+	*out = *in
+	if in.ProductResourceName != nil {
+		in, out := &in.ProductResourceName, &out.ProductResourceName
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.BackendResourceNames != nil {
+		in, out := &in.BackendResourceNames, &out.BackendResourceNames
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActiveDocResourceName != nil {
+		in, out := &in.ActiveDocResourceName, &out.ActiveDocResourceName
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SourceFetchTime != nil {
+		in, out := &in.SourceFetchTime, &out.SourceFetchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SourceConversions != nil {
+		in, out := &in.SourceConversions, &out.SourceConversions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(OpenAPIAuthenticationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]OpenAPIChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIStatus.
+func (in *OpenAPIStatus) DeepCopy() *OpenAPIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyConfig) DeepCopyInto(out *PolicyConfig) {
+	*out = *in
+	in.Configuration.DeepCopyInto(&out.Configuration)
+	out.ConfigurationRef = in.ConfigurationRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyConfig.
+func (in *PolicyConfig) DeepCopy() *PolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PricingRuleSpec) DeepCopyInto(out *PricingRuleSpec) {
+	*out = *in
+	in.MetricMethodRef.DeepCopyInto(&out.MetricMethodRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PricingRuleSpec.
+func (in *PricingRuleSpec) DeepCopy() *PricingRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PricingRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Product) DeepCopyInto(out *Product) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Product.
+func (in *Product) DeepCopy() *Product {
+	if in == nil {
+		return nil
+	}
+	out := new(Product)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Product) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductDeploymentSpec) DeepCopyInto(out *ProductDeploymentSpec) {
+	*out = *in
+	if in.ApicastHosted != nil {
+		in, out := &in.ApicastHosted, &out.ApicastHosted
+		*out = new(ApicastHostedSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApicastSelfManaged != nil {
+		in, out := &in.ApicastSelfManaged, &out.ApicastSelfManaged
+		*out = new(ApicastSelfManagedSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentSpec.
+func (in *ProductDeploymentSpec) DeepCopy() *ProductDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductFeatureSpec) DeepCopyInto(out *ProductFeatureSpec) {
+	*out = *in
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductFeatureSpec.
+func (in *ProductFeatureSpec) DeepCopy() *ProductFeatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductFeatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductList) DeepCopyInto(out *ProductList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Product, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductList.
+func (in *ProductList) DeepCopy() *ProductList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProductList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductOpenAPIExportSpec) DeepCopyInto(out *ProductOpenAPIExportSpec) {
+	*out = *in
+	if in.ConfigMapName != nil {
+		in, out := &in.ConfigMapName, &out.ConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductOpenAPIExportSpec.
+func (in *ProductOpenAPIExportSpec) DeepCopy() *ProductOpenAPIExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductOpenAPIExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductSpec) DeepCopyInto(out *ProductSpec) {
+	*out = *in
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(ProductDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MappingRules != nil {
+		in, out := &in.MappingRules, &out.MappingRules
+		*out = make([]MappingRuleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackendUsages != nil {
+		in, out := &in.BackendUsages, &out.BackendUsages
+		*out = make(map[string]BackendUsageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make(map[string]MetricSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make(map[string]MethodSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ApplicationPlans != nil {
+		in, out := &in.ApplicationPlans, &out.ApplicationPlans
+		*out = make(map[string]ApplicationPlanSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ServicePlans != nil {
+		in, out := &in.ServicePlans, &out.ServicePlans
+		*out = make(map[string]ServicePlanSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]ProductFeatureSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OpenAPIExport != nil {
+		in, out := &in.OpenAPIExport, &out.OpenAPIExport
+		*out = new(ProductOpenAPIExportSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductSpec.
+func (in *ProductSpec) DeepCopy() *ProductSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductStatus) DeepCopyInto(out *ProductStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.ManagedFeatures != nil {
+		in, out := &in.ManagedFeatures, &out.ManagedFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductStatus.
+func (in *ProductStatus) DeepCopy() *ProductStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderUser) DeepCopyInto(out *ProviderUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderUser.
+func (in *ProviderUser) DeepCopy() *ProviderUser {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderUserList) DeepCopyInto(out *ProviderUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProviderUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderUserList.
+func (in *ProviderUserList) DeepCopy() *ProviderUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderUserMemberPermissionsSpec) DeepCopyInto(out *ProviderUserMemberPermissionsSpec) {
+	*out = *in
+	if in.AllowedSections != nil {
+		in, out := &in.AllowedSections, &out.AllowedSections
+		*out = make([]ProviderUserSection, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedProducts != nil {
+		in, out := &in.AllowedProducts, &out.AllowedProducts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderUserMemberPermissionsSpec.
+func (in *ProviderUserMemberPermissionsSpec) DeepCopy() *ProviderUserMemberPermissionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderUserMemberPermissionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderUserSpec) DeepCopyInto(out *ProviderUserSpec) {
+	*out = *in
+	out.PasswordCredentialsRef = in.PasswordCredentialsRef
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.MemberPermissions != nil {
+		in, out := &in.MemberPermissions, &out.MemberPermissions
+		*out = new(ProviderUserMemberPermissionsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
-	if in.JwtClaimWithClientID != nil {
-		in, out := &in.JwtClaimWithClientID, &out.JwtClaimWithClientID
-		*out = new(string)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderUserSpec.
+func (in *ProviderUserSpec) DeepCopy() *ProviderUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderUserStatus) DeepCopyInto(out *ProviderUserStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
 		**out = **in
 	}
-	if in.JwtClaimWithClientIDType != nil {
-		in, out := &in.JwtClaimWithClientIDType, &out.JwtClaimWithClientIDType
+	if in.State != nil {
+		in, out := &in.State, &out.State
 		*out = new(string)
 		**out = **in
 	}
-	if in.CredentialsLoc != nil {
-		in, out := &in.CredentialsLoc, &out.CredentialsLoc
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
 		*out = new(string)
 		**out = **in
 	}
-	if in.Security != nil {
-		in, out := &in.Security, &out.Security
-		*out = new(SecuritySpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GatewayResponse != nil {
-		in, out := &in.GatewayResponse, &out.GatewayResponse
-		*out = new(GatewayResponseSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSpec.
-func (in *OIDCSpec) DeepCopy() *OIDCSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderUserStatus.
+func (in *ProviderUserStatus) DeepCopy() *ProviderUserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCSpec)
+	out := new(ProviderUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAPI) DeepCopyInto(out *OpenAPI) {
+func (in *ProxyConfigPromote) DeepCopyInto(out *ProxyConfigPromote) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1352,18 +2961,18 @@ func (in *OpenAPI) DeepCopyInto(out *OpenAPI) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPI.
-func (in *OpenAPI) DeepCopy() *OpenAPI {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromote.
+func (in *ProxyConfigPromote) DeepCopy() *ProxyConfigPromote {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAPI)
+	out := new(ProxyConfigPromote)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenAPI) DeepCopyObject() runtime.Object {
+func (in *ProxyConfigPromote) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1371,31 +2980,31 @@ func (in *OpenAPI) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAPIList) DeepCopyInto(out *OpenAPIList) {
+func (in *ProxyConfigPromoteList) DeepCopyInto(out *ProxyConfigPromoteList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OpenAPI, len(*in))
+		*out = make([]ProxyConfigPromote, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIList.
-func (in *OpenAPIList) DeepCopy() *OpenAPIList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromoteList.
+func (in *ProxyConfigPromoteList) DeepCopy() *ProxyConfigPromoteList {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAPIList)
+	out := new(ProxyConfigPromoteList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenAPIList) DeepCopyObject() runtime.Object {
+func (in *ProxyConfigPromoteList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1403,158 +3012,300 @@ func (in *OpenAPIList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAPIRefSpec) DeepCopyInto(out *OpenAPIRefSpec) {
+func (in *ProxyConfigPromoteSpec) DeepCopyInto(out *ProxyConfigPromoteSpec) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.ObjectReference)
+	if in.Production != nil {
+		in, out := &in.Production, &out.Production
+		*out = new(bool)
 		**out = **in
 	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
-		*out = new(string)
+	if in.DeleteCR != nil {
+		in, out := &in.DeleteCR, &out.DeleteCR
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIRefSpec.
-func (in *OpenAPIRefSpec) DeepCopy() *OpenAPIRefSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromoteSpec.
+func (in *ProxyConfigPromoteSpec) DeepCopy() *ProxyConfigPromoteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAPIRefSpec)
+	out := new(ProxyConfigPromoteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAPISpec) DeepCopyInto(out *OpenAPISpec) {
+func (in *ProxyConfigPromoteStatus) DeepCopyInto(out *ProxyConfigPromoteStatus) {
 	*out = *in
-	in.OpenAPIRef.DeepCopyInto(&out.OpenAPIRef)
-	if in.ProviderAccountRef != nil {
-		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.ProductionPublicBaseURL != nil {
-		in, out := &in.ProductionPublicBaseURL, &out.ProductionPublicBaseURL
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromoteStatus.
+func (in *ProxyConfigPromoteStatus) DeepCopy() *ProxyConfigPromoteStatus {
+	if in == nil {
+		return nil
 	}
-	if in.StagingPublicBaseURL != nil {
-		in, out := &in.StagingPublicBaseURL, &out.StagingPublicBaseURL
+	out := new(ProxyConfigPromoteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
+	*out = *in
+	if in.HostHeader != nil {
+		in, out := &in.HostHeader, &out.HostHeader
 		*out = new(string)
 		**out = **in
 	}
-	if in.ProductSystemName != nil {
-		in, out := &in.ProductSystemName, &out.ProductSystemName
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
 		*out = new(string)
 		**out = **in
 	}
-	if in.PrivateBaseURL != nil {
-		in, out := &in.PrivateBaseURL, &out.PrivateBaseURL
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuritySpec.
+func (in *SecuritySpec) DeepCopy() *SecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePlanSpec) DeepCopyInto(out *ServicePlanSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
 		*out = new(string)
 		**out = **in
 	}
-	if in.PrefixMatching != nil {
-		in, out := &in.PrefixMatching, &out.PrefixMatching
+	if in.ApprovalRequired != nil {
+		in, out := &in.ApprovalRequired, &out.ApprovalRequired
 		*out = new(bool)
 		**out = **in
 	}
-	if in.PrivateAPIHostHeader != nil {
-		in, out := &in.PrivateAPIHostHeader, &out.PrivateAPIHostHeader
+	if in.TrialPeriod != nil {
+		in, out := &in.TrialPeriod, &out.TrialPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.SetupFee != nil {
+		in, out := &in.SetupFee, &out.SetupFee
 		*out = new(string)
 		**out = **in
 	}
-	if in.PrivateAPISecretToken != nil {
-		in, out := &in.PrivateAPISecretToken, &out.PrivateAPISecretToken
+	if in.CostMonth != nil {
+		in, out := &in.CostMonth, &out.CostMonth
 		*out = new(string)
 		**out = **in
 	}
-	if in.OIDC != nil {
-		in, out := &in.OIDC, &out.OIDC
-		*out = new(OIDCSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Published != nil {
+		in, out := &in.Published, &out.Published
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPISpec.
-func (in *OpenAPISpec) DeepCopy() *OpenAPISpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePlanSpec.
+func (in *ServicePlanSpec) DeepCopy() *ServicePlanSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAPISpec)
+	out := new(ServicePlanSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAPIStatus) DeepCopyInto(out *OpenAPIStatus) {
+func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
-	if in.ProductResourceName != nil {
-		in, out := &in.ProductResourceName, &out.ProductResourceName
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tenant.
+func (in *Tenant) DeepCopy() *Tenant {
+	if in == nil {
+		return nil
 	}
-	if in.BackendResourceNames != nil {
-		in, out := &in.BackendResourceNames, &out.BackendResourceNames
-		*out = make([]v1.LocalObjectReference, len(*in))
+	out := new(Tenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Tenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantAccessTokenStatus) DeepCopyInto(out *TenantAccessTokenStatus) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]TenantAccessTokenScope, len(*in))
 		copy(*out, *in)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(common.Conditions, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantAccessTokenStatus.
+func (in *TenantAccessTokenStatus) DeepCopy() *TenantAccessTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantAccessTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantAccessTokensSpec) DeepCopyInto(out *TenantAccessTokensSpec) {
+	*out = *in
+	if in.Scoped != nil {
+		in, out := &in.Scoped, &out.Scoped
+		*out = make([]TenantScopedAccessTokenSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantAccessTokensSpec.
+func (in *TenantAccessTokensSpec) DeepCopy() *TenantAccessTokensSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantAccessTokensSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantAccessTokensStatus) DeepCopyInto(out *TenantAccessTokensStatus) {
+	*out = *in
+	if in.Tokens != nil {
+		in, out := &in.Tokens, &out.Tokens
+		*out = make([]TenantAccessTokenStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = new(TenantPendingAccessTokensStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIStatus.
-func (in *OpenAPIStatus) DeepCopy() *OpenAPIStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantAccessTokensStatus.
+func (in *TenantAccessTokensStatus) DeepCopy() *TenantAccessTokensStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAPIStatus)
+	out := new(TenantAccessTokensStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyConfig) DeepCopyInto(out *PolicyConfig) {
+func (in *TenantDeletionSpec) DeepCopyInto(out *TenantDeletionSpec) {
 	*out = *in
-	in.Configuration.DeepCopyInto(&out.Configuration)
-	out.ConfigurationRef = in.ConfigurationRef
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(TenantDeletionPolicy)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyConfig.
-func (in *PolicyConfig) DeepCopy() *PolicyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantDeletionSpec.
+func (in *TenantDeletionSpec) DeepCopy() *TenantDeletionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyConfig)
+	out := new(TenantDeletionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PricingRuleSpec) DeepCopyInto(out *PricingRuleSpec) {
+func (in *TenantList) DeepCopyInto(out *TenantList) {
 	*out = *in
-	in.MetricMethodRef.DeepCopyInto(&out.MetricMethodRef)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Tenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PricingRuleSpec.
-func (in *PricingRuleSpec) DeepCopy() *PricingRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantList.
+func (in *TenantList) DeepCopy() *TenantList {
 	if in == nil {
 		return nil
 	}
-	out := new(PricingRuleSpec)
+	out := new(TenantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Product) DeepCopyInto(out *Product) {
+func (in *TenantMessaging) DeepCopyInto(out *TenantMessaging) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1562,18 +3313,18 @@ func (in *Product) DeepCopyInto(out *Product) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Product.
-func (in *Product) DeepCopy() *Product {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantMessaging.
+func (in *TenantMessaging) DeepCopy() *TenantMessaging {
 	if in == nil {
 		return nil
 	}
-	out := new(Product)
+	out := new(TenantMessaging)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Product) DeepCopyObject() runtime.Object {
+func (in *TenantMessaging) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1581,56 +3332,66 @@ func (in *Product) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProductDeploymentSpec) DeepCopyInto(out *ProductDeploymentSpec) {
+func (in *TenantMessagingEmailTemplateSpec) DeepCopyInto(out *TenantMessagingEmailTemplateSpec) {
 	*out = *in
-	if in.ApicastHosted != nil {
-		in, out := &in.ApicastHosted, &out.ApicastHosted
-		*out = new(ApicastHostedSpec)
+	if in.BodyRef != nil {
+		in, out := &in.BodyRef, &out.BodyRef
+		*out = new(v1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ApicastSelfManaged != nil {
-		in, out := &in.ApicastSelfManaged, &out.ApicastSelfManaged
-		*out = new(ApicastSelfManagedSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantMessagingEmailTemplateSpec.
+func (in *TenantMessagingEmailTemplateSpec) DeepCopy() *TenantMessagingEmailTemplateSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(TenantMessagingEmailTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentSpec.
-func (in *ProductDeploymentSpec) DeepCopy() *ProductDeploymentSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantMessagingEmailTemplateStatus) DeepCopyInto(out *TenantMessagingEmailTemplateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantMessagingEmailTemplateStatus.
+func (in *TenantMessagingEmailTemplateStatus) DeepCopy() *TenantMessagingEmailTemplateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProductDeploymentSpec)
+	out := new(TenantMessagingEmailTemplateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProductList) DeepCopyInto(out *ProductList) {
+func (in *TenantMessagingList) DeepCopyInto(out *TenantMessagingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Product, len(*in))
+		*out = make([]TenantMessaging, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductList.
-func (in *ProductList) DeepCopy() *ProductList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantMessagingList.
+func (in *TenantMessagingList) DeepCopy() *TenantMessagingList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProductList)
+	out := new(TenantMessagingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProductList) DeepCopyObject() runtime.Object {
+func (in *TenantMessagingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1638,84 +3399,74 @@ func (in *ProductList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProductSpec) DeepCopyInto(out *ProductSpec) {
+func (in *TenantMessagingNotificationPreferencesSpec) DeepCopyInto(out *TenantMessagingNotificationPreferencesSpec) {
 	*out = *in
-	if in.Deployment != nil {
-		in, out := &in.Deployment, &out.Deployment
-		*out = new(ProductDeploymentSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.MappingRules != nil {
-		in, out := &in.MappingRules, &out.MappingRules
-		*out = make([]MappingRuleSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.BackendUsages != nil {
-		in, out := &in.BackendUsages, &out.BackendUsages
-		*out = make(map[string]BackendUsageSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = make(map[string]MetricSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantMessagingNotificationPreferencesSpec.
+func (in *TenantMessagingNotificationPreferencesSpec) DeepCopy() *TenantMessagingNotificationPreferencesSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Methods != nil {
-		in, out := &in.Methods, &out.Methods
-		*out = make(map[string]MethodSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out := new(TenantMessagingNotificationPreferencesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantMessagingSpec) DeepCopyInto(out *TenantMessagingSpec) {
+	*out = *in
+	if in.EmailTemplates != nil {
+		in, out := &in.EmailTemplates, &out.EmailTemplates
+		*out = make([]TenantMessagingEmailTemplateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ApplicationPlans != nil {
-		in, out := &in.ApplicationPlans, &out.ApplicationPlans
-		*out = make(map[string]ApplicationPlanSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.NotificationPreferences != nil {
+		in, out := &in.NotificationPreferences, &out.NotificationPreferences
+		*out = new(TenantMessagingNotificationPreferencesSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ProviderAccountRef != nil {
 		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
-	if in.Policies != nil {
-		in, out := &in.Policies, &out.Policies
-		*out = make([]PolicyConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductSpec.
-func (in *ProductSpec) DeepCopy() *ProductSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantMessagingSpec.
+func (in *TenantMessagingSpec) DeepCopy() *TenantMessagingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProductSpec)
+	out := new(TenantMessagingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProductStatus) DeepCopyInto(out *ProductStatus) {
+func (in *TenantMessagingStatus) DeepCopyInto(out *TenantMessagingStatus) {
 	*out = *in
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(int64)
-		**out = **in
-	}
-	if in.State != nil {
-		in, out := &in.State, &out.State
-		*out = new(string)
-		**out = **in
+	if in.EmailTemplates != nil {
+		in, out := &in.EmailTemplates, &out.EmailTemplates
+		*out = make([]TenantMessagingEmailTemplateStatus, len(*in))
+		copy(*out, *in)
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1726,143 +3477,169 @@ func (in *ProductStatus) DeepCopyInto(out *ProductStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductStatus.
-func (in *ProductStatus) DeepCopy() *ProductStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantMessagingStatus.
+func (in *TenantMessagingStatus) DeepCopy() *TenantMessagingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProductStatus)
+	out := new(TenantMessagingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyConfigPromote) DeepCopyInto(out *ProxyConfigPromote) {
+func (in *TenantPendingAccessTokenStatus) DeepCopyInto(out *TenantPendingAccessTokenStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.TenantAccessTokenStatus.DeepCopyInto(&out.TenantAccessTokenStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromote.
-func (in *ProxyConfigPromote) DeepCopy() *ProxyConfigPromote {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPendingAccessTokenStatus.
+func (in *TenantPendingAccessTokenStatus) DeepCopy() *TenantPendingAccessTokenStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyConfigPromote)
+	out := new(TenantPendingAccessTokenStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProxyConfigPromote) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyConfigPromoteList) DeepCopyInto(out *ProxyConfigPromoteList) {
+func (in *TenantPendingAccessTokensStatus) DeepCopyInto(out *TenantPendingAccessTokensStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ProxyConfigPromote, len(*in))
+	if in.Tokens != nil {
+		in, out := &in.Tokens, &out.Tokens
+		*out = make([]TenantPendingAccessTokenStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromoteList.
-func (in *ProxyConfigPromoteList) DeepCopy() *ProxyConfigPromoteList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPendingAccessTokensStatus.
+func (in *TenantPendingAccessTokensStatus) DeepCopy() *TenantPendingAccessTokensStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyConfigPromoteList)
+	out := new(TenantPendingAccessTokensStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProxyConfigPromoteList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyConfigPromoteSpec) DeepCopyInto(out *ProxyConfigPromoteSpec) {
+func (in *TenantScopedAccessTokenSpec) DeepCopyInto(out *TenantScopedAccessTokenSpec) {
 	*out = *in
-	if in.Production != nil {
-		in, out := &in.Production, &out.Production
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DeleteCR != nil {
-		in, out := &in.DeleteCR, &out.DeleteCR
-		*out = new(bool)
-		**out = **in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]TenantAccessTokenScope, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromoteSpec.
-func (in *ProxyConfigPromoteSpec) DeepCopy() *ProxyConfigPromoteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantScopedAccessTokenSpec.
+func (in *TenantScopedAccessTokenSpec) DeepCopy() *TenantScopedAccessTokenSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyConfigPromoteSpec)
+	out := new(TenantScopedAccessTokenSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyConfigPromoteStatus) DeepCopyInto(out *ProxyConfigPromoteStatus) {
+func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(common.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.TenantSecretRef = in.TenantSecretRef
+	out.PasswordCredentialsRef = in.PasswordCredentialsRef
+	out.MasterCredentialsRef = in.MasterCredentialsRef
+	if in.FromEmail != nil {
+		in, out := &in.FromEmail, &out.FromEmail
+		*out = new(string)
+		**out = **in
+	}
+	if in.SupportEmail != nil {
+		in, out := &in.SupportEmail, &out.SupportEmail
+		*out = new(string)
+		**out = **in
+	}
+	if in.FinanceSupportEmail != nil {
+		in, out := &in.FinanceSupportEmail, &out.FinanceSupportEmail
+		*out = new(string)
+		**out = **in
+	}
+	if in.SiteAccessCode != nil {
+		in, out := &in.SiteAccessCode, &out.SiteAccessCode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Suspended != nil {
+		in, out := &in.Suspended, &out.Suspended
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AccountPlan != nil {
+		in, out := &in.AccountPlan, &out.AccountPlan
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdminDomain != nil {
+		in, out := &in.AdminDomain, &out.AdminDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.DeveloperDomain != nil {
+		in, out := &in.DeveloperDomain, &out.DeveloperDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessTokens != nil {
+		in, out := &in.AccessTokens, &out.AccessTokens
+		*out = new(TenantAccessTokensSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deletion != nil {
+		in, out := &in.Deletion, &out.Deletion
+		*out = new(TenantDeletionSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromoteStatus.
-func (in *ProxyConfigPromoteStatus) DeepCopy() *ProxyConfigPromoteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSpec.
+func (in *TenantSpec) DeepCopy() *TenantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyConfigPromoteStatus)
+	out := new(TenantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
+func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 	*out = *in
-	if in.HostHeader != nil {
-		in, out := &in.HostHeader, &out.HostHeader
-		*out = new(string)
-		**out = **in
+	if in.AccessTokens != nil {
+		in, out := &in.AccessTokens, &out.AccessTokens
+		*out = new(TenantAccessTokensStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.SecretToken != nil {
-		in, out := &in.SecretToken, &out.SecretToken
-		*out = new(string)
-		**out = **in
+	if in.DeletionTime != nil {
+		in, out := &in.DeletionTime, &out.DeletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuritySpec.
-func (in *SecuritySpec) DeepCopy() *SecuritySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStatus.
+func (in *TenantStatus) DeepCopy() *TenantStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecuritySpec)
+	out := new(TenantStatus)
 	in.DeepCopyInto(out)
 	return out
 }
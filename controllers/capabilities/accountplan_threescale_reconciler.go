@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+)
+
+type AccountPlanThreescaleReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.AccountPlan
+	adminAPIClient      *controllerhelper.AdminAPIClient
+	providerAccountHost string
+	planEntity          *controllerhelper.AccountPlanEntity
+	logger              logr.Logger
+}
+
+func NewAccountPlanThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.AccountPlan, adminAPIClient *controllerhelper.AdminAPIClient, providerAccountHost string, logger logr.Logger) *AccountPlanThreescaleReconciler {
+	return &AccountPlanThreescaleReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		adminAPIClient:      adminAPIClient,
+		providerAccountHost: providerAccountHost,
+		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
+	}
+}
+
+func (s *AccountPlanThreescaleReconciler) Reconcile() (*controllerhelper.AccountPlanEntity, error) {
+	taskRunner := helper.NewTaskRunner(nil, s.logger)
+	taskRunner.AddTask("SyncAccountPlan", s.syncAccountPlan)
+	taskRunner.AddTask("SyncPlan", s.syncPlan)
+	taskRunner.AddTask("SyncAccountFeatures", s.syncAccountFeatures)
+	taskRunner.AddTask("SyncPlanFeatures", s.syncPlanFeatures)
+	taskRunner.AddTask("SyncDefault", s.syncDefault)
+
+	err := taskRunner.Run()
+
+	return s.planEntity, err
+}
+
+func (s *AccountPlanThreescaleReconciler) syncAccountPlan(_ interface{}) error {
+	existingList, err := s.adminAPIClient.ListAccountPlans()
+	if err != nil {
+		return fmt.Errorf("error sync account plan [%s]: %w", s.resource.Spec.SystemName, err)
+	}
+
+	// Look for ID. If it does not exist, look for system name
+	for _, existing := range existingList.Plans {
+		foundByID := s.resource.Status.ID != nil && existing.Element.ID == *s.resource.Status.ID
+		foundBySystemName := existing.Element.SystemName == s.resource.Spec.SystemName
+		if foundByID || foundBySystemName {
+			s.planEntity = controllerhelper.NewAccountPlanEntity(existing.Element, s.adminAPIClient, s.logger)
+			return nil
+		}
+	}
+
+	// Create Account Plan using system_name.
+	// it cannot be modified later
+	params := threescaleapi.Params{
+		"system_name": s.resource.Spec.SystemName,
+		"name":        s.resource.Spec.Name,
+	}
+	obj, err := s.adminAPIClient.CreateAccountPlan(params)
+	if err != nil {
+		return fmt.Errorf("error sync account plan [%s]: %w", s.resource.Spec.SystemName, err)
+	}
+
+	s.planEntity = controllerhelper.NewAccountPlanEntity(obj.Element, s.adminAPIClient, s.logger)
+
+	return nil
+}
+
+func (s *AccountPlanThreescaleReconciler) syncPlan(_ interface{}) error {
+	params := threescaleapi.Params{}
+
+	if s.planEntity.Name() != s.resource.Spec.Name {
+		params["name"] = s.resource.Spec.Name
+	}
+
+	if s.resource.Spec.ApprovalRequired != nil {
+		if s.planEntity.ApprovalRequired() != *s.resource.Spec.ApprovalRequired {
+			params["approval_required"] = strconv.FormatBool(*s.resource.Spec.ApprovalRequired)
+		}
+	}
+
+	if s.resource.Spec.TrialPeriod != nil {
+		if s.planEntity.TrialPeriodDays() != *s.resource.Spec.TrialPeriod {
+			params["trial_period_days"] = strconv.Itoa(*s.resource.Spec.TrialPeriod)
+		}
+	}
+
+	if s.resource.Spec.SetupFee != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*s.resource.Spec.SetupFee, 64)
+		if s.planEntity.SetupFee() != desiredValue {
+			params["setup_fee"] = *s.resource.Spec.SetupFee
+		}
+	}
+
+	if s.resource.Spec.CostMonth != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*s.resource.Spec.CostMonth, 64)
+		if s.planEntity.CostPerMonth() != desiredValue {
+			params["cost_per_month"] = *s.resource.Spec.CostMonth
+		}
+	}
+
+	planEntityStateIsPublished := s.planEntity.State() == "published" // If the state is not published then we assume it is "hidden"
+	desiredAccountPlanIsPublished := s.resource.Spec.IsPublished()
+	if planEntityStateIsPublished != desiredAccountPlanIsPublished {
+		var stateEventValue string
+		if desiredAccountPlanIsPublished {
+			stateEventValue = "publish"
+		} else {
+			stateEventValue = "hide"
+		}
+		params["state_event"] = stateEventValue
+	}
+
+	if len(params) > 0 {
+		err := s.planEntity.Update(params)
+		if err != nil {
+			return fmt.Errorf("error sync account plan [%s;%d]: %w", s.resource.Spec.SystemName, s.planEntity.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// syncAccountFeatures ensures the features referenced by the plan exist in the provider account.
+// Account features are shared between account plans, so features not referenced are never deleted.
+func (s *AccountPlanThreescaleReconciler) syncAccountFeatures(_ interface{}) error {
+	if len(s.resource.Spec.Features) == 0 {
+		return nil
+	}
+
+	existingList, err := s.adminAPIClient.ListAccountFeatures()
+	if err != nil {
+		return fmt.Errorf("error sync account plan [%s] account features: %w", s.resource.Spec.SystemName, err)
+	}
+
+	existingMap := map[string]controllerhelper.FeatureItem{}
+	for _, existing := range existingList.Features {
+		existingMap[existing.Element.SystemName] = existing.Element
+	}
+
+	for systemName, spec := range s.resource.Spec.Features {
+		existing, ok := existingMap[systemName]
+		if !ok {
+			params := threescaleapi.Params{
+				"name":        spec.Name,
+				"system_name": systemName,
+			}
+			if len(spec.Description) > 0 {
+				params["description"] = spec.Description
+			}
+			_, err := s.adminAPIClient.CreateAccountFeature(params)
+			if err != nil {
+				return fmt.Errorf("error sync account plan [%s] account feature [%s]: %w", s.resource.Spec.SystemName, systemName, err)
+			}
+			continue
+		}
+
+		params := threescaleapi.Params{}
+		if existing.Name != spec.Name {
+			params["name"] = spec.Name
+		}
+
+		if existing.Description != spec.Description {
+			params["description"] = spec.Description
+		}
+
+		if len(params) > 0 {
+			_, err := s.adminAPIClient.UpdateAccountFeature(existing.ID, params)
+			if err != nil {
+				return fmt.Errorf("error sync account plan [%s] account feature [%s]: %w", s.resource.Spec.SystemName, systemName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *AccountPlanThreescaleReconciler) syncPlanFeatures(_ interface{}) error {
+	desiredIDs := map[int64]string{}
+	if len(s.resource.Spec.Features) > 0 {
+		accountFeatures, err := s.adminAPIClient.ListAccountFeatures()
+		if err != nil {
+			return fmt.Errorf("error sync account plan [%s] features: %w", s.resource.Spec.SystemName, err)
+		}
+
+		for _, feature := range accountFeatures.Features {
+			if _, ok := s.resource.Spec.Features[feature.Element.SystemName]; ok {
+				desiredIDs[feature.Element.ID] = feature.Element.SystemName
+			}
+		}
+	}
+
+	existingList, err := s.planEntity.Features()
+	if err != nil {
+		return fmt.Errorf("error sync account plan [%s] features: %w", s.resource.Spec.SystemName, err)
+	}
+
+	existingIDs := map[int64]string{}
+	for _, existing := range existingList.Features {
+		existingIDs[existing.Element.ID] = existing.Element.SystemName
+	}
+
+	for featureID := range existingIDs {
+		if _, ok := desiredIDs[featureID]; !ok {
+			err := s.planEntity.DeleteFeature(featureID)
+			if err != nil {
+				return fmt.Errorf("error sync account plan [%s] features: %w", s.resource.Spec.SystemName, err)
+			}
+		}
+	}
+
+	for featureID := range desiredIDs {
+		if _, ok := existingIDs[featureID]; !ok {
+			err := s.planEntity.CreateFeature(featureID)
+			if err != nil {
+				return fmt.Errorf("error sync account plan [%s] features: %w", s.resource.Spec.SystemName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *AccountPlanThreescaleReconciler) syncDefault(_ interface{}) error {
+	// The default account plan can only be changed, not unset
+	if !s.resource.Spec.IsDefault() || s.planEntity.IsDefault() {
+		return nil
+	}
+
+	err := s.planEntity.SetDefault()
+	if err != nil {
+		return fmt.Errorf("error sync account plan [%s] default: %w", s.resource.Spec.SystemName, err)
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
@@ -38,6 +39,7 @@ import (
 // ActiveDocReconciler reconciles a ActiveDoc object
 type ActiveDocReconciler struct {
 	*reconcilers.BaseReconciler
+	sourceCache OpenAPISourceCache
 }
 
 // blank assignment to verify that BackendReconciler implements reconcile.Reconciler
@@ -59,6 +61,7 @@ func (r *ActiveDocReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
 			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			r.sourceCache.Delete(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -76,6 +79,7 @@ func (r *ActiveDocReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Ignore deleted resource, this can happen when foregroundDeletion is enabled
 	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if activeDocCR.DeletionTimestamp != nil {
+		r.sourceCache.Delete(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -108,6 +112,10 @@ func (r *ActiveDocReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			// On Validation error, no need to retry as spec is not valid and needs to be changed
 			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
 			r.EventRecorder().Eventf(activeDocCR, corev1.EventTypeWarning, "Invalid ActiveDoc Spec", "%v", reconcileErr)
+			if activeDocCR.Spec.ActiveDocOpenAPIRef.Polled() {
+				// The source may be fixed without changing the resource
+				return ctrl.Result{RequeueAfter: activeDocCR.Spec.ActiveDocOpenAPIRef.RefreshIntervalDuration()}, nil
+			}
 			return ctrl.Result{}, nil
 		}
 
@@ -122,39 +130,44 @@ func (r *ActiveDocReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, reconcileErr
 	}
 
+	// URL, git and OCI sources cannot be watched, read them again after the refresh interval
+	if activeDocCR.Spec.ActiveDocOpenAPIRef.Polled() {
+		return ctrl.Result{RequeueAfter: activeDocCR.Spec.ActiveDocOpenAPIRef.RefreshIntervalDuration()}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
 func (r *ActiveDocReconciler) reconcileSpec(activeDocCR *capabilitiesv1beta1.ActiveDoc, logger logr.Logger) (*ActiveDocStatusReconciler, error) {
 	err := r.validateSpec(activeDocCR)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", nil, nil, err)
 		return statusReconciler, err
 	}
 
 	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), activeDocCR.Namespace, activeDocCR.Spec.ProviderAccountRef, logger)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", nil, nil, err)
 		return statusReconciler, err
 	}
 
 	err = r.checkExternalRefs(activeDocCR, providerAccount.AdminURLStr, logger)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, nil, nil, err)
 		return statusReconciler, err
 	}
 
 	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(activeDocCR.GetAnnotations())
 	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount, insecureSkipVerify)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, nil, nil, err)
 		return statusReconciler, err
 	}
 
-	reconciler := NewActiveDocThreescaleReconciler(r.BaseReconciler, activeDocCR, threescaleAPIClient, providerAccount.AdminURLStr, logger)
+	reconciler := NewActiveDocThreescaleReconciler(r.BaseReconciler, activeDocCR, threescaleAPIClient, providerAccount.AdminURLStr, &r.sourceCache, logger)
 	activeDocObj, err := reconciler.Reconcile()
 
-	statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, activeDocObj, err)
+	statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, activeDocObj, reconciler.SourceVersion(), err)
 	return statusReconciler, err
 }
 
@@ -201,7 +214,14 @@ func (r *ActiveDocReconciler) checkExternalRefs(resource *capabilitiesv1beta1.Ac
 }
 
 func (r *ActiveDocReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	configMapToActiveDocEventMapper := &ConfigMapToActiveDocEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("configMapToActiveDocEventMapper"),
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.ActiveDoc{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(configMapToActiveDocEventMapper.Map)).
 		Complete(r)
 }
@@ -21,16 +21,18 @@ type ActiveDocStatusReconciler struct {
 	resource            *capabilitiesv1beta1.ActiveDoc
 	providerAccountHost string
 	activeDoc           *threescaleapi.ActiveDoc
+	sourceVersion       *OpenAPISourceVersion
 	reconcileError      error
 	logger              logr.Logger
 }
 
-func NewActiveDocStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ActiveDoc, providerAccountHost string, activeDoc *threescaleapi.ActiveDoc, reconcileError error) *ActiveDocStatusReconciler {
+func NewActiveDocStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ActiveDoc, providerAccountHost string, activeDoc *threescaleapi.ActiveDoc, sourceVersion *OpenAPISourceVersion, reconcileError error) *ActiveDocStatusReconciler {
 	return &ActiveDocStatusReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		providerAccountHost: providerAccountHost,
 		activeDoc:           activeDoc,
+		sourceVersion:       sourceVersion,
 		reconcileError:      reconcileError,
 		logger:              b.Logger().WithValues("Status Reconciler", resource.Name),
 	}
@@ -77,15 +79,35 @@ func (s *ActiveDocStatusReconciler) Reconcile() (reconcile.Result, error) {
 
 func (s *ActiveDocStatusReconciler) calculateStatus() (*capabilitiesv1beta1.ActiveDocStatus, error) {
 	newStatus := &capabilitiesv1beta1.ActiveDocStatus{
-		ID:                  s.resource.Status.ID,
-		ProviderAccountHost: s.providerAccountHost,
-		ObservedGeneration:  s.resource.Status.ObservedGeneration,
+		ID:                    s.resource.Status.ID,
+		ProviderAccountHost:   s.providerAccountHost,
+		SourceRevision:        s.resource.Status.SourceRevision,
+		SourceHash:            s.resource.Status.SourceHash,
+		SourceFetchTime:       s.resource.Status.SourceFetchTime,
+		SourceDocumentVersion: s.resource.Status.SourceDocumentVersion,
+		SourceConversions:     s.resource.Status.SourceConversions,
+		ObservedGeneration:    s.resource.Status.ObservedGeneration,
 	}
 
 	if s.activeDoc != nil {
 		newStatus.ID = s.activeDoc.Element.ID
 	}
 
+	// Keep the last read version when the document was not read
+	if s.sourceVersion != nil {
+		newStatus.SourceRevision = s.sourceVersion.Revision
+		newStatus.SourceDocumentVersion = s.sourceVersion.DocumentVersion
+		newStatus.SourceConversions = s.sourceVersion.Conversions
+		// The fetch time changes with the content only, so polling does not update the status
+		if s.sourceVersion.Hash != newStatus.SourceHash || newStatus.SourceFetchTime == nil {
+			if newStatus.SourceHash != "" {
+				s.logger.Info("OpenAPI document changed", "previousHash", newStatus.SourceHash, "hash", s.sourceVersion.Hash)
+			}
+			newStatus.SourceHash = s.sourceVersion.Hash
+			newStatus.SourceFetchTime = &s.sourceVersion.FetchTime
+		}
+	}
+
 	productResourceName, err := s.getReferencedProduct()
 	if err != nil {
 		return nil, err
@@ -2,23 +2,19 @@ package controllers
 
 import (
 	"errors"
-	"net/url"
 	"reflect"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
-	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
-	corev1 "k8s.io/api/core/v1"
-	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 type ActiveDocThreescaleReconciler struct {
@@ -26,19 +22,27 @@ type ActiveDocThreescaleReconciler struct {
 	resource            *capabilitiesv1beta1.ActiveDoc
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
 	providerAccountHost string
+	sourceCache         *OpenAPISourceCache
+	sourceVersion       *OpenAPISourceVersion
 	logger              logr.Logger
 }
 
-func NewActiveDocThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ActiveDoc, threescaleAPIClient *threescaleapi.ThreeScaleClient, providerAccountHost string, logger logr.Logger) *ActiveDocThreescaleReconciler {
+func NewActiveDocThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ActiveDoc, threescaleAPIClient *threescaleapi.ThreeScaleClient, providerAccountHost string, sourceCache *OpenAPISourceCache, logger logr.Logger) *ActiveDocThreescaleReconciler {
 	return &ActiveDocThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
 		providerAccountHost: providerAccountHost,
+		sourceCache:         sourceCache,
 		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
 	}
 }
 
+// SourceVersion returns the version of the OpenAPI document read from polled sources
+func (s *ActiveDocThreescaleReconciler) SourceVersion() *OpenAPISourceVersion {
+	return s.sourceVersion
+}
+
 func (s *ActiveDocThreescaleReconciler) Reconcile() (*threescaleapi.ActiveDoc, error) {
 	s.logger.V(1).Info("START")
 
@@ -62,16 +66,15 @@ func (s *ActiveDocThreescaleReconciler) Reconcile() (*threescaleapi.ActiveDoc, e
 		return nil, err
 	}
 
-	desiredOpenapiObj, err := s.getDesiredActiveDocBody()
+	_, sourceVersion, err := s.getDesiredActiveDocBody()
 	if err != nil {
 		return nil, err
 	}
+	s.sourceVersion = sourceVersion
 
-	desiredBodyRaw, err := desiredOpenapiObj.MarshalJSON()
-	if err != nil {
-		return nil, err
-	}
-	desiredBody := string(desiredBodyRaw)
+	// The document is uploaded as read from the source.
+	// Converted documents are only used to validate Swagger 2.0 and OpenAPI 3.1 documents
+	desiredBody := string(sourceVersion.Document)
 
 	if remoteActiveDoc == nil {
 		newActiveDoc := &threescaleapi.ActiveDoc{
@@ -141,16 +144,20 @@ func (s *ActiveDocThreescaleReconciler) Reconcile() (*threescaleapi.ActiveDoc, e
 		}
 	}
 
-	existingOpenapiObj, err := openapi3.NewLoader().LoadFromData([]byte(*remoteActiveDoc.Element.Body))
+	desiredDocument, err := activeDocBodyObject([]byte(desiredBody))
 	if err != nil {
 		return nil, err
 	}
 
-	// Compare parsed openapi3 objects
+	existingDocument, err := activeDocBodyObject([]byte(*remoteActiveDoc.Element.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare parsed documents
 	// Avoid detecting differences from serialization
-	if !reflect.DeepEqual(desiredOpenapiObj, existingOpenapiObj) {
-		s.logger.V(1).Info("update BODY", "Difference",
-			cmp.Diff(desiredOpenapiObj, existingOpenapiObj, cmpopts.IgnoreUnexported(openapi3.Schema{})))
+	if !reflect.DeepEqual(desiredDocument, existingDocument) {
+		s.logger.V(1).Info("update BODY", "Difference", cmp.Diff(existingDocument, desiredDocument))
 		updatedActiveDoc.Element.Body = &desiredBody
 		update = true
 	}
@@ -174,6 +181,16 @@ func (s *ActiveDocThreescaleReconciler) Reconcile() (*threescaleapi.ActiveDoc, e
 	return remoteActiveDoc, nil
 }
 
+// activeDocBodyObject parses the JSON or YAML body of an ActiveDoc
+func activeDocBodyObject(body []byte) (interface{}, error) {
+	var obj interface{}
+	if err := yaml.Unmarshal(body, &obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
 func (s *ActiveDocThreescaleReconciler) getDesiredProductIDFromCR() (*int64, error) {
 	if s.resource.Spec.ProductSystemName == nil {
 		return nil, nil
@@ -196,109 +213,24 @@ func (s *ActiveDocThreescaleReconciler) getDesiredProductIDFromCR() (*int64, err
 	return productList[idx].Status.ID, nil
 }
 
-func (s *ActiveDocThreescaleReconciler) getDesiredActiveDocBody() (*openapi3.T, error) {
+func (s *ActiveDocThreescaleReconciler) getDesiredActiveDocBody() (*openapi3.T, *OpenAPISourceVersion, error) {
+	openapiRefFldPath := field.NewPath("spec").Child("activeDocOpenAPIRef")
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(s.resource.GetAnnotations())
+	sourceReader := NewOpenAPISourceReader(s.Context(), s.Client(), s.resource.Namespace, insecureSkipVerify, s.sourceCache, client.ObjectKeyFromObject(s.resource))
+
 	// OpenAPIRef is oneOf by CRD openapiV3 validation
-	if s.resource.Spec.ActiveDocOpenAPIRef.SecretRef != nil {
-		return s.readOpenAPISecret()
+	switch {
+	case s.resource.Spec.ActiveDocOpenAPIRef.SecretRef != nil:
+		// s.resource.Spec.ActiveDocOpenAPIRef.SecretRef.Namespace set in defaults
+		return sourceReader.ReadSecret(s.resource.Spec.ActiveDocOpenAPIRef.SecretRef, openapiRefFldPath.Child("secretRef"))
+	case s.resource.Spec.ActiveDocOpenAPIRef.ConfigMapRef != nil:
+		return sourceReader.ReadConfigMap(s.resource.Spec.ActiveDocOpenAPIRef.ConfigMapRef, openapiRefFldPath.Child("configMapRef"))
+	case s.resource.Spec.ActiveDocOpenAPIRef.Git != nil:
+		return sourceReader.ReadGit(s.resource.Spec.ActiveDocOpenAPIRef.Git, openapiRefFldPath.Child("git"))
+	case s.resource.Spec.ActiveDocOpenAPIRef.OCI != nil:
+		return sourceReader.ReadOCI(s.resource.Spec.ActiveDocOpenAPIRef.OCI, openapiRefFldPath.Child("oci"))
 	}
 
 	// Must be URL
-	return s.readOpenAPIFromURL()
-}
-
-func (s *ActiveDocThreescaleReconciler) readOpenAPISecret() (*openapi3.T, error) {
-	fieldErrors := field.ErrorList{}
-	specFldPath := field.NewPath("spec")
-	openapiRefFldPath := specFldPath.Child("activeDocOpenAPIRef")
-	secretRefFldPath := openapiRefFldPath.Child("secretRef")
-
-	// s.resource.Spec.ActiveDocOpenAPIRef.SecretRef.Namespace set in defaults
-	objectKey := types.NamespacedName{Name: s.resource.Spec.ActiveDocOpenAPIRef.SecretRef.Name, Namespace: s.resource.Spec.ActiveDocOpenAPIRef.SecretRef.Namespace}
-	openapiSecretObj := &corev1.Secret{}
-
-	// Read secret
-	if err := s.Client().Get(s.Context(), objectKey, openapiSecretObj); err != nil {
-		if apimachineryerrors.IsNotFound(err) {
-			fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, s.resource.Spec.ActiveDocOpenAPIRef.SecretRef, "Secret not found"))
-			return nil, &helper.SpecFieldError{
-				ErrorType:      helper.InvalidError,
-				FieldErrorList: fieldErrors,
-			}
-		}
-
-		// unexpected error
-		return nil, err
-	}
-
-	if len(openapiSecretObj.Data) != 1 {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, s.resource.Spec.ActiveDocOpenAPIRef.SecretRef, "Secret was empty or contains too many fields. Only one is required."))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	// Get key value
-	dataByteArray := func(secret *corev1.Secret) []byte {
-		for _, v := range secret.Data {
-			return v
-		}
-		return nil
-	}(openapiSecretObj)
-
-	openapiObj, err := openapi3.NewLoader().LoadFromData(dataByteArray)
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, s.resource.Spec.ActiveDocOpenAPIRef.SecretRef, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	err = openapiObj.Validate(s.Context())
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, s.resource.Spec.ActiveDocOpenAPIRef.SecretRef, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	return openapiObj, nil
-}
-
-func (s *ActiveDocThreescaleReconciler) readOpenAPIFromURL() (*openapi3.T, error) {
-	fieldErrors := field.ErrorList{}
-	specFldPath := field.NewPath("spec")
-	openapiRefFldPath := specFldPath.Child("activeDocOpenAPIRef")
-	urlRefFldPath := openapiRefFldPath.Child("url")
-
-	openAPIURL, err := url.Parse(*s.resource.Spec.ActiveDocOpenAPIRef.URL)
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, s.resource.Spec.ActiveDocOpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	openapiObj, err := openapi3.NewLoader().LoadFromURI(openAPIURL)
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, s.resource.Spec.ActiveDocOpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	err = openapiObj.Validate(s.Context())
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, s.resource.Spec.ActiveDocOpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	return openapiObj, nil
+	return sourceReader.ReadURL(*s.resource.Spec.ActiveDocOpenAPIRef.URL, openapiRefFldPath.Child("url"))
 }
@@ -148,7 +148,12 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	applicationEntity, reconcileErr := r.applicationReconciler(application, accountResource, threescaleAPIClient)
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	applicationEntity, reconcileErr := r.applicationReconciler(application, accountResource, threescaleAPIClient, adminAPIClient)
 
 	statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, application, applicationEntity, providerAccount.AdminURLStr, reconcileErr)
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
@@ -223,7 +228,7 @@ func (r *ApplicationReconciler) reconcileMetadata(application *capabilitiesv1bet
 	return changed, nil
 }
 
-func (r *ApplicationReconciler) applicationReconciler(applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient) (*controllerhelper.ApplicationEntity, error) {
+func (r *ApplicationReconciler) applicationReconciler(applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient, adminAPIClient *controllerhelper.AdminAPIClient) (*controllerhelper.ApplicationEntity, error) {
 	// get product
 	productResource := &capabilitiesv1beta1.Product{}
 	projectMeta := types.NamespacedName{
@@ -265,7 +270,7 @@ func (r *ApplicationReconciler) applicationReconciler(applicationResource *capab
 		}
 	}
 
-	reconciler := NewApplicationReconciler(r.BaseReconciler, applicationResource, authParams, *accountResource.Status.ID, *productResource.Status.ID, threescaleAPIClient)
+	reconciler := NewApplicationReconciler(r.BaseReconciler, applicationResource, authParams, *accountResource.Status.ID, *productResource.Status.ID, threescaleAPIClient, adminAPIClient)
 	return reconciler.Reconcile()
 }
 
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"fmt"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// applicationExtraFieldsValidator validates the extra fields against the application fields definitions of the tenant
+var applicationExtraFieldsValidator = &controllerhelper.FieldsValidator{
+	Target:  controllerhelper.FieldsDefinitionTargetApplication,
+	FldPath: field.NewPath("spec").Child("extraFields"),
+	// application fields managed by other spec fields
+	BuiltinFields: map[string]string{"name": "name", "description": "description"},
+}
+
+func (t *ApplicationThreescaleReconciler) syncExtraFields(_ any) error {
+	// Extra fields are only managed when declared in the spec.
+	// Only the declared fields are updated.
+	if len(t.applicationResource.Spec.ExtraFields) == 0 {
+		return nil
+	}
+
+	definitions, err := t.adminAPIClient.ListFieldsDefinitions()
+	if err != nil {
+		return fmt.Errorf("error sync application [%s] extra fields: %w", t.applicationResource.Spec.Name, err)
+	}
+	// status reports invalid extra fields from the definitions
+	t.applicationEntity.FieldsDefinitions = definitions
+
+	existing, err := t.adminAPIClient.ApplicationFields(t.accountID, t.applicationEntity.ID())
+	if err != nil {
+		return fmt.Errorf("error sync application [%s] extra fields: %w", t.applicationResource.Spec.Name, err)
+	}
+
+	params := threescaleapi.Params{}
+	for name, value := range applicationExtraFieldsValidator.ValidFields(t.applicationResource.Spec.ExtraFields, definitions) {
+		if existing[name] != value {
+			params[name] = value
+		}
+	}
+
+	t.logger.V(1).Info("syncExtraFields", "params", params)
+	if len(params) > 0 {
+		_, err := t.threescaleAPIClient.UpdateApplication(t.accountID, t.applicationEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("error sync application [%s] extra fields: %w", t.applicationResource.Spec.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func getApplicationFieldsDefinitions() *controllerhelper.FieldsDefinitionList {
+	return &controllerhelper.FieldsDefinitionList{
+		FieldsDefinitions: []controllerhelper.FieldsDefinition{
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Cinstance", Name: "name"}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Cinstance", Name: "cost_centre"}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Cinstance", Name: "environment", Choices: []string{"dev", "prod"}}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Account", Name: "vat_code"}},
+		},
+	}
+}
+
+func TestApplicationStatusReconciler_invalidExtraFieldsCondition(t *testing.T) {
+	applicationResource := getApplicationCR()
+	applicationResource.Spec.ExtraFields = map[string]string{"cost_centre": "cc01", "unknown": "value"}
+
+	entity := controllerhelper.NewApplicationEntity(getApplicationJson("live"), nil, getBaseReconciler().Logger())
+	entity.FieldsDefinitions = getApplicationFieldsDefinitions()
+
+	newStatus := NewApplicationStatusReconciler(getBaseReconciler(), applicationResource, entity, "", nil).calculateStatus()
+	if !newStatus.Conditions.IsTrueFor(capabilitiesv1beta1.ApplicationInvalidExtraFieldsConditionType) {
+		t.Fatalf("InvalidExtraFields condition not true: %v", newStatus.Conditions)
+	}
+	condition := newStatus.Conditions.GetCondition(capabilitiesv1beta1.ApplicationInvalidExtraFieldsConditionType)
+	if !strings.Contains(condition.Message, "unknown") || strings.Contains(condition.Message, "cost_centre") {
+		t.Errorf("InvalidExtraFields condition message = %s", condition.Message)
+	}
+	// Ready condition is not affected
+	if !newStatus.Conditions.IsTrueFor(capabilitiesv1beta1.ApplicationReadyConditionType) {
+		t.Errorf("Ready condition not true: %v", newStatus.Conditions)
+	}
+
+	// fields definitions not read, condition is not set
+	newStatus = NewApplicationStatusReconciler(getBaseReconciler(), applicationResource, controllerhelper.NewApplicationEntity(&threescaleapi.Application{}, nil, getBaseReconciler().Logger()), "", nil).calculateStatus()
+	if newStatus.Conditions.GetCondition(capabilitiesv1beta1.ApplicationInvalidExtraFieldsConditionType) != nil {
+		t.Errorf("InvalidExtraFields condition set without fields definitions: %v", newStatus.Conditions)
+	}
+}
@@ -32,6 +32,7 @@ type applicationPlanReconciler struct {
 	productEntity       *controllerhelper.ProductEntity
 	backendRemoteIndex  *controllerhelper.BackendAPIRemoteIndex
 	planEntity          *controllerhelper.ApplicationPlanEntity
+	productFeatures     *controllerhelper.FeatureList
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
 	logger              logr.Logger
 }
@@ -43,6 +44,7 @@ func newApplicationPlanReconciler(b *reconcilers.BaseReconciler,
 	productEntity *controllerhelper.ProductEntity,
 	backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex,
 	planEntity *controllerhelper.ApplicationPlanEntity,
+	productFeatures *controllerhelper.FeatureList,
 	logger logr.Logger,
 ) *applicationPlanReconciler {
 	return &applicationPlanReconciler{
@@ -53,16 +55,18 @@ func newApplicationPlanReconciler(b *reconcilers.BaseReconciler,
 		productEntity:       productEntity,
 		backendRemoteIndex:  backendRemoteIndex,
 		planEntity:          planEntity,
+		productFeatures:     productFeatures,
 		logger:              logger.WithValues("Plan", systemName),
 	}
 }
 
-// Reconcile ensures plan attrs, limits and pricingRules are reconciled
+// Reconcile ensures plan attrs, limits, pricingRules and features are reconciled
 func (a *applicationPlanReconciler) Reconcile() error {
 	taskRunner := helper.NewTaskRunner(nil, a.logger)
 	taskRunner.AddTask("SyncPlan", a.syncPlan)
 	taskRunner.AddTask("SyncLimits", a.syncLimits)
 	taskRunner.AddTask("SyncPricingRules", a.syncPricingRules)
+	taskRunner.AddTask("SyncFeatures", a.syncFeatures)
 
 	err := taskRunner.Run()
 	if err != nil {
@@ -232,6 +236,52 @@ func (a *applicationPlanReconciler) syncPricingRules(_ interface{}) error {
 	return nil
 }
 
+func (a *applicationPlanReconciler) syncFeatures(_ interface{}) error {
+	// Plan features are only managed when product features are managed
+	if a.productFeatures == nil {
+		return nil
+	}
+
+	desiredIDs := map[int64]string{}
+	for _, featureSystemName := range a.resource.Features {
+		featureID, ok := findFeatureID(a.productFeatures, featureSystemName, controllerhelper.FeatureScopeApplicationPlan)
+		if !ok {
+			return fmt.Errorf("error sync plan [%s] features: feature [%s] not found", a.systemName, featureSystemName)
+		}
+		desiredIDs[featureID] = featureSystemName
+	}
+
+	existingList, err := a.planEntity.Features()
+	if err != nil {
+		return fmt.Errorf("error sync plan [%s] features: %w", a.systemName, err)
+	}
+
+	existingIDs := map[int64]string{}
+	for _, existing := range existingList.Features {
+		existingIDs[existing.Element.ID] = existing.Element.SystemName
+	}
+
+	for featureID := range existingIDs {
+		if _, ok := desiredIDs[featureID]; !ok {
+			err := a.planEntity.DeleteFeature(featureID)
+			if err != nil {
+				return fmt.Errorf("error sync plan [%s] features: %w", a.systemName, err)
+			}
+		}
+	}
+
+	for featureID := range desiredIDs {
+		if _, ok := existingIDs[featureID]; !ok {
+			err := a.planEntity.CreateFeature(featureID)
+			if err != nil {
+				return fmt.Errorf("error sync plan [%s] features: %w", a.systemName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (a *applicationPlanReconciler) computeUnDesiredLimits(
 	existingList []threescaleapi.ApplicationPlanLimit,
 	desiredList []capabilitiesv1beta1.LimitSpec,
@@ -20,6 +20,15 @@ func (t *ProductThreescaleReconciler) syncApplicationPlans(_ interface{}) error
 		return fmt.Errorf("error sync product [%s] plans: %w", t.resource.Spec.SystemName, err)
 	}
 
+	// Plan features are only managed when product features are managed
+	var productFeatures *controllerhelper.FeatureList
+	if len(t.resource.Spec.Features) > 0 {
+		productFeatures, err = t.productFeatures()
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] plans: %w", t.resource.Spec.SystemName, err)
+		}
+	}
+
 	existingKeys := make([]string, 0, len(existingList.Plans))
 	existingMap := map[string]threescaleapi.ApplicationPlanItem{}
 	for _, existing := range existingList.Plans {
@@ -50,10 +59,10 @@ func (t *ProductThreescaleReconciler) syncApplicationPlans(_ interface{}) error
 	t.logger.V(1).Info("syncApplicationPlans", "matchedKeys", matchedKeys)
 	for _, systemName := range matchedKeys {
 		// interface to remote entity
-		planEntity := controllerhelper.NewApplicationPlanEntity(t.productEntity.ID(), existingMap[systemName], t.threescaleAPIClient, t.logger)
+		planEntity := controllerhelper.NewApplicationPlanEntity(t.productEntity.ID(), existingMap[systemName], t.threescaleAPIClient, t.adminAPIClient, t.logger)
 		// desired spec
 		planSpec := t.resource.Spec.ApplicationPlans[systemName]
-		reconciler := newApplicationPlanReconciler(t.BaseReconciler, systemName, planSpec, t.threescaleAPIClient, t.productEntity, t.backendRemoteIndex, planEntity, t.logger)
+		reconciler := newApplicationPlanReconciler(t.BaseReconciler, systemName, planSpec, t.threescaleAPIClient, t.productEntity, t.backendRemoteIndex, planEntity, productFeatures, t.logger)
 		err := reconciler.Reconcile()
 		if err != nil {
 			return fmt.Errorf("error sync product [%s] plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
@@ -79,9 +88,9 @@ func (t *ProductThreescaleReconciler) syncApplicationPlans(_ interface{}) error
 			return fmt.Errorf("error sync product [%s] plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
 		}
 		// interface to remote entity
-		planEntity := controllerhelper.NewApplicationPlanEntity(t.productEntity.ID(), obj.Element, t.threescaleAPIClient, t.logger)
+		planEntity := controllerhelper.NewApplicationPlanEntity(t.productEntity.ID(), obj.Element, t.threescaleAPIClient, t.adminAPIClient, t.logger)
 
-		reconciler := newApplicationPlanReconciler(t.BaseReconciler, systemName, planSpec, t.threescaleAPIClient, t.productEntity, t.backendRemoteIndex, planEntity, t.logger)
+		reconciler := newApplicationPlanReconciler(t.BaseReconciler, systemName, planSpec, t.threescaleAPIClient, t.productEntity, t.backendRemoteIndex, planEntity, productFeatures, t.logger)
 		err = reconciler.Reconcile()
 		if err != nil {
 			return fmt.Errorf("error sync product [%s] plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+func (t *ApplicationThreescaleReconciler) syncReferrerFilters(_ any) error {
+	// Referrer filters created from the 3scale UI are kept
+	if len(t.applicationResource.Spec.ReferrerFilters) == 0 && len(t.applicationResource.Status.ManagedReferrerFilters) == 0 {
+		return nil
+	}
+
+	existingList, err := t.adminAPIClient.ListApplicationReferrerFilters(t.accountID, t.applicationEntity.ID())
+	if err != nil {
+		return fmt.Errorf("error sync application [%s] referrer filters: %w", t.applicationResource.Spec.Name, err)
+	}
+
+	existingKeys := make([]string, 0, len(existingList.ReferrerFilters))
+	existingMap := map[string]int64{}
+	for _, existing := range existingList.ReferrerFilters {
+		existingKeys = append(existingKeys, existing.Element.Value)
+		existingMap[existing.Element.Value] = existing.Element.ID
+	}
+
+	desiredKeys := t.applicationResource.Spec.ReferrerFilters
+
+	//
+	// Deleted existing and not desired
+	//
+
+	notDesiredExistingKeys := helper.ArrayStringNotDesired(existingKeys, desiredKeys, t.applicationResource.Status.ManagedReferrerFilters)
+	t.logger.V(1).Info("syncReferrerFilters", "notDesiredExistingKeys", notDesiredExistingKeys)
+	for _, value := range notDesiredExistingKeys {
+		// key is expected to exist
+		// notDesiredExistingKeys is a subset of the existingMap key set
+		err := t.adminAPIClient.DeleteApplicationReferrerFilter(t.accountID, t.applicationEntity.ID(), existingMap[value])
+		if err != nil {
+			return fmt.Errorf("error sync application [%s] referrer filters: %w", t.applicationResource.Spec.Name, err)
+		}
+	}
+
+	//
+	// Create not existing and desired
+	//
+
+	desiredNewKeys := helper.ArrayStringDifference(desiredKeys, existingKeys)
+	t.logger.V(1).Info("syncReferrerFilters", "desiredNewKeys", desiredNewKeys)
+	for _, value := range desiredNewKeys {
+		_, err := t.adminAPIClient.CreateApplicationReferrerFilter(t.accountID, t.applicationEntity.ID(), value)
+		if err != nil {
+			return fmt.Errorf("error sync application [%s] referrer filters: %w", t.applicationResource.Spec.Name, err)
+		}
+	}
+
+	return nil
+}
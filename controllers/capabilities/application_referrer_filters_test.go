@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/go-logr/logr"
+)
+
+func getReferrerFiltersTestClient(created, deleted *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		filtersPath := "/admin/api/accounts/3/applications/3/referrer_filters"
+		response := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}
+
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == filtersPath+".json":
+			response.Body = io.NopCloser(bytes.NewBuffer(responseBody(&controllerhelper.ReferrerFilterList{
+				ReferrerFilters: []controllerhelper.ReferrerFilter{
+					{Element: controllerhelper.ReferrerFilterItem{ID: 1, Value: "keep.example.com"}},
+					{Element: controllerhelper.ReferrerFilterItem{ID: 2, Value: "old.example.com"}},
+				},
+			})))
+		case req.Method == http.MethodPost && req.URL.Path == filtersPath+".json":
+			_ = req.ParseForm()
+			*created = append(*created, req.PostForm.Get("referrer_filter"))
+			response.StatusCode = http.StatusCreated
+			response.Body = io.NopCloser(bytes.NewBuffer(responseBody(&controllerhelper.ReferrerFilter{})))
+		case req.Method == http.MethodDelete:
+			*deleted = append(*deleted, req.URL.Path)
+		default:
+			response.StatusCode = http.StatusNotFound
+		}
+
+		return response
+	})
+}
+
+func TestApplicationThreescaleReconciler_syncReferrerFilters(t *testing.T) {
+	var created, deleted []string
+	httpClient := getReferrerFiltersTestClient(&created, &deleted)
+
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	applicationResource := getApplicationCR()
+	applicationResource.Spec.ReferrerFilters = []string{"keep.example.com", "new.example.com"}
+
+	t1 := &ApplicationThreescaleReconciler{
+		BaseReconciler:      getBaseReconciler(),
+		applicationResource: applicationResource,
+		applicationEntity:   controllerhelper.NewApplicationEntity(getApplicationJson("live"), nil, logr.Discard()),
+		accountID:           3,
+		adminAPIClient:      controllerhelper.NewAdminAPIClient(adminURL, "test", httpClient),
+		logger:              logr.Discard(),
+	}
+
+	err := t1.syncReferrerFilters(nil)
+	if err != nil {
+		t.Fatalf("syncReferrerFilters() error = %v", err)
+	}
+
+	if len(deleted) != 1 || deleted[0] != "/admin/api/accounts/3/applications/3/referrer_filters/2.json" {
+		t.Errorf("deleted referrer filters = %v", deleted)
+	}
+	if len(created) != 1 || created[0] != "new.example.com" {
+		t.Errorf("created referrer filters = %v", created)
+	}
+}
+
+func TestApplicationThreescaleReconciler_syncReferrerFiltersAllRemoved(t *testing.T) {
+	var created, deleted []string
+	httpClient := getReferrerFiltersTestClient(&created, &deleted)
+
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	applicationResource := getApplicationCR()
+	// keep.example.com was created from the 3scale UI
+	applicationResource.Status.ManagedReferrerFilters = []string{"old.example.com"}
+
+	t1 := &ApplicationThreescaleReconciler{
+		BaseReconciler:      getBaseReconciler(),
+		applicationResource: applicationResource,
+		applicationEntity:   controllerhelper.NewApplicationEntity(getApplicationJson("live"), nil, logr.Discard()),
+		accountID:           3,
+		adminAPIClient:      controllerhelper.NewAdminAPIClient(adminURL, "test", httpClient),
+		logger:              logr.Discard(),
+	}
+
+	err := t1.syncReferrerFilters(nil)
+	if err != nil {
+		t.Fatalf("syncReferrerFilters() error = %v", err)
+	}
+
+	if len(deleted) != 1 || deleted[0] != "/admin/api/accounts/3/applications/3/referrer_filters/2.json" {
+		t.Errorf("deleted referrer filters = %v", deleted)
+	}
+	if len(created) != 0 {
+		t.Errorf("created referrer filters = %v", created)
+	}
+}
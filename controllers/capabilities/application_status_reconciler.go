@@ -6,6 +6,7 @@ import (
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -74,11 +75,14 @@ func (s *ApplicationStatusReconciler) Reconcile() (reconcile.Result, error) {
 
 func (s *ApplicationStatusReconciler) calculateStatus() *capabilitiesv1beta1.ApplicationStatus {
 	newStatus := &capabilitiesv1beta1.ApplicationStatus{
-		ID:                  s.applicationResource.Status.ID,
-		State:               s.applicationResource.Status.State,
-		ProviderAccountHost: s.applicationResource.Status.ProviderAccountHost,
-		ObservedGeneration:  s.applicationResource.Status.ObservedGeneration,
-		Conditions:          s.applicationResource.Status.Conditions.Copy(),
+		ID:                      s.applicationResource.Status.ID,
+		State:                   s.applicationResource.Status.State,
+		ProviderAccountHost:     s.applicationResource.Status.ProviderAccountHost,
+		ApplicationPlan:         s.applicationResource.Status.ApplicationPlan,
+		PreviousApplicationPlan: s.applicationResource.Status.PreviousApplicationPlan,
+		ManagedReferrerFilters:  s.managedReferrerFilters(),
+		ObservedGeneration:      s.applicationResource.Status.ObservedGeneration,
+		Conditions:              s.applicationResource.Status.Conditions.Copy(),
 	}
 
 	if s.entity != nil {
@@ -90,15 +94,33 @@ func (s *ApplicationStatusReconciler) calculateStatus() *capabilitiesv1beta1.App
 		newStatus.State = s.entity.ApplicationState()
 	}
 
+	// The previous plan is only recorded when the plan changes
+	if s.entity != nil && s.entity.PlanSystemName() != "" {
+		newStatus.ApplicationPlan = s.entity.PlanSystemName()
+		if s.applicationResource.Status.ApplicationPlan != "" && s.applicationResource.Status.ApplicationPlan != newStatus.ApplicationPlan {
+			newStatus.PreviousApplicationPlan = s.applicationResource.Status.ApplicationPlan
+		}
+	}
+
 	if s.providerAccountHost != "" {
 		newStatus.ProviderAccountHost = s.providerAccountHost
 	}
 
 	newStatus.Conditions.SetCondition(s.ReadyCondition())
 
+	// Fields definitions are only read when extra fields are synchronized
+	if len(s.applicationResource.Spec.ExtraFields) == 0 || (s.entity != nil && s.entity.FieldsDefinitions != nil) {
+		newStatus.Conditions.SetCondition(s.invalidExtraFieldsCondition())
+	}
+
 	return newStatus
 }
 
+// managedReferrerFilters returns the referrer filters managed by the application
+func (s *ApplicationStatusReconciler) managedReferrerFilters() []string {
+	return helper.ArrayStringManaged(s.applicationResource.Spec.ReferrerFilters, s.applicationResource.Status.ManagedReferrerFilters, s.syncError == nil)
+}
+
 func (s *ApplicationStatusReconciler) ReadyCondition() common.Condition {
 	condition := common.Condition{
 		Type:   capabilitiesv1beta1.ApplicationReadyConditionType,
@@ -116,3 +138,22 @@ func (s *ApplicationStatusReconciler) ReadyCondition() common.Condition {
 
 	return condition
 }
+
+func (s *ApplicationStatusReconciler) invalidExtraFieldsCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.ApplicationInvalidExtraFieldsConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if len(s.applicationResource.Spec.ExtraFields) == 0 {
+		return condition
+	}
+
+	fieldErrors := applicationExtraFieldsValidator.Validate(s.applicationResource.Spec.ExtraFields, s.entity.FieldsDefinitions)
+	if len(fieldErrors) > 0 {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = fieldErrors.ToAggregate().Error()
+	}
+
+	return condition
+}
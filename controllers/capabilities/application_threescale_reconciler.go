@@ -11,6 +11,7 @@ import (
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type ApplicationThreescaleReconciler struct {
@@ -21,10 +22,11 @@ type ApplicationThreescaleReconciler struct {
 	accountID           int64
 	productID           int64
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	adminAPIClient      *controllerhelper.AdminAPIClient
 	logger              logr.Logger
 }
 
-func NewApplicationReconciler(b *reconcilers.BaseReconciler, applicationResource *capabilitiesv1beta1.Application, authParams map[string]string, accountID int64, productID int64, threescaleAPIClient *threescaleapi.ThreeScaleClient) *ApplicationThreescaleReconciler {
+func NewApplicationReconciler(b *reconcilers.BaseReconciler, applicationResource *capabilitiesv1beta1.Application, authParams map[string]string, accountID int64, productID int64, threescaleAPIClient *threescaleapi.ThreeScaleClient, adminAPIClient *controllerhelper.AdminAPIClient) *ApplicationThreescaleReconciler {
 	return &ApplicationThreescaleReconciler{
 		BaseReconciler:      b,
 		applicationResource: applicationResource,
@@ -32,6 +34,7 @@ func NewApplicationReconciler(b *reconcilers.BaseReconciler, applicationResource
 		accountID:           accountID,
 		productID:           productID,
 		threescaleAPIClient: threescaleAPIClient,
+		adminAPIClient:      adminAPIClient,
 		logger:              b.Logger().WithValues("3scale Reconciler", applicationResource.Name),
 	}
 }
@@ -39,6 +42,8 @@ func NewApplicationReconciler(b *reconcilers.BaseReconciler, applicationResource
 func (t *ApplicationThreescaleReconciler) Reconcile() (*controllerhelper.ApplicationEntity, error) {
 	taskRunner := helper.NewTaskRunner(nil, t.logger)
 	taskRunner.AddTask("SyncApplication", t.syncApplication)
+	taskRunner.AddTask("SyncReferrerFilters", t.syncReferrerFilters)
+	taskRunner.AddTask("SyncExtraFields", t.syncExtraFields)
 
 	err := taskRunner.Run()
 	if err != nil {
@@ -84,12 +89,7 @@ func (t *ApplicationThreescaleReconciler) findApplication(accountID int64) (*thr
 	return nil, nil
 }
 
-func (t *ApplicationThreescaleReconciler) findPlan(productID int64) (*threescaleapi.ApplicationPlan, error) {
-	planList, err := t.threescaleAPIClient.ListApplicationPlansByProduct(productID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve application plans for product [%s]: %w", t.applicationResource.Spec.ProductCR.Name, err)
-	}
-
+func (t *ApplicationThreescaleReconciler) findPlan(planList *threescaleapi.ApplicationPlanJSONList) (*threescaleapi.ApplicationPlan, error) {
 	for _, plan := range planList.Plans {
 		if plan.Element.SystemName == t.applicationResource.Spec.ApplicationPlanName {
 			return &plan, nil
@@ -100,7 +100,12 @@ func (t *ApplicationThreescaleReconciler) findPlan(productID int64) (*threescale
 }
 
 func (t *ApplicationThreescaleReconciler) syncApplication(_ any) error {
-	plan, err := t.findPlan(t.productID)
+	planList, err := t.threescaleAPIClient.ListApplicationPlansByProduct(t.productID)
+	if err != nil {
+		return fmt.Errorf("error sync application [%s]: failed to retrieve application plans for product [%s]: %w", t.applicationResource.Spec.Name, t.applicationResource.Spec.ProductCR.Name, err)
+	}
+
+	plan, err := t.findPlan(planList)
 	if err != nil {
 		return fmt.Errorf("error sync application [%s]: %w", t.applicationResource.Spec.Name, err)
 	}
@@ -158,6 +163,7 @@ func (t *ApplicationThreescaleReconciler) syncApplication(_ any) error {
 	}
 
 	applicationEntity := controllerhelper.NewApplicationEntity(application, t.threescaleAPIClient, t.logger)
+	applicationEntity.ApplicationPlanJSONList = planList
 	t.applicationEntity = applicationEntity
 
 	params := threescaleapi.Params{}
@@ -171,9 +177,9 @@ func (t *ApplicationThreescaleReconciler) syncApplication(_ any) error {
 	}
 
 	if t.applicationEntity.PlanID() != plan.Element.ID {
-		_, err := t.threescaleAPIClient.ChangeApplicationPlan(t.accountID, applicationEntity.ID(), plan.Element.ID)
+		err := t.changePlan(planList, plan)
 		if err != nil {
-			return fmt.Errorf("error sync application [%s;%d]: %w", t.applicationResource.Spec.Name, applicationEntity.ID(), err)
+			return err
 		}
 	}
 
@@ -200,3 +206,33 @@ func (t *ApplicationThreescaleReconciler) syncApplication(_ any) error {
 
 	return nil
 }
+
+// changePlan moves the application to the desired plan in place.
+// The application keeps its credentials.
+func (t *ApplicationThreescaleReconciler) changePlan(planList *threescaleapi.ApplicationPlanJSONList, plan *threescaleapi.ApplicationPlan) error {
+	// The plan change is applied directly: the approval is bypassed
+	if plan.Element.ApprovalRequired && !t.applicationResource.Spec.ForceApprovalRequiredPlanChange {
+		fieldErrors := field.ErrorList{
+			field.Invalid(field.NewPath("spec").Child("applicationPlanName"), t.applicationResource.Spec.ApplicationPlanName,
+				"application plan requires approval. Set spec.forceApprovalRequiredPlanChange to change the plan bypassing the approval"),
+		}
+		return &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	t.logger.Info("change application plan", "from", t.applicationEntity.PlanSystemName(), "to", plan.Element.SystemName)
+
+	updated, err := t.threescaleAPIClient.ChangeApplicationPlan(t.accountID, t.applicationEntity.ID(), plan.Element.ID)
+	if err != nil {
+		return fmt.Errorf("error sync application [%s;%d]: %w", t.applicationResource.Spec.Name, t.applicationEntity.ID(), err)
+	}
+
+	if updated != nil && updated.ID != 0 {
+		t.applicationEntity = controllerhelper.NewApplicationEntity(updated, t.threescaleAPIClient, t.logger)
+		t.applicationEntity.ApplicationPlanJSONList = planList
+	}
+
+	return nil
+}
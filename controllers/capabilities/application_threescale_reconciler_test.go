@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
@@ -195,3 +196,90 @@ func TestApplicationThreescaleReconciler_syncApplication(t1 *testing.T) {
 		})
 	}
 }
+
+func TestApplicationThreescaleReconciler_changePlan(t1 *testing.T) {
+	approvalRequiredPlanList := func() *threescaleapi.ApplicationPlanJSONList {
+		planList := getApplicationPlanListByProductJson()
+		planList.Plans[1].Element.ApprovalRequired = true
+		return planList
+	}
+
+	tests := []struct {
+		name                        string
+		planList                    *threescaleapi.ApplicationPlanJSONList
+		forceApprovalRequired       bool
+		wantErr                     bool
+		wantApplicationPlan         string
+		wantPreviousApplicationPlan string
+	}{
+		{
+			name:                        "plan changed in place",
+			planList:                    getApplicationPlanListByProductJson(),
+			wantApplicationPlan:         "test2",
+			wantPreviousApplicationPlan: "test",
+		},
+		{
+			name:     "plan requiring approval rejected",
+			planList: approvalRequiredPlanList(),
+			wantErr:  true,
+		},
+		{
+			name:                        "plan requiring approval forced",
+			planList:                    approvalRequiredPlanList(),
+			forceApprovalRequired:       true,
+			wantApplicationPlan:         "test2",
+			wantPreviousApplicationPlan: "test",
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			// application on plan "test" of the product
+			application := getApplicationJson("live")
+			application.ServiceID = *getApplicationProductCR().Status.ID
+
+			httpHandler := mocks.NewApplicationAPIHandler(
+				mocks.WithService(3, tt.planList),
+				mocks.WithAccount(3, &threescaleapi.ApplicationList{Applications: []threescaleapi.ApplicationElem{
+					{Application: *application},
+				}}),
+			)
+			srv := httptest.NewServer(httpHandler)
+			defer srv.Close()
+
+			ap, _ := threescaleapi.NewAdminPortalFromStr(srv.URL)
+			threescaleAPIClient := threescaleapi.NewThreeScale(ap, "test", srv.Client())
+
+			applicationResource := getApplicationCR()
+			applicationResource.Spec.ApplicationPlanName = "test2"
+			applicationResource.Spec.ForceApprovalRequiredPlanChange = tt.forceApprovalRequired
+			applicationResource.Status.ApplicationPlan = "test"
+
+			t := &ApplicationThreescaleReconciler{
+				BaseReconciler:      getBaseReconciler(),
+				applicationResource: applicationResource,
+				accountID:           *getApplicationDeveloperAccount().Status.ID,
+				productID:           *getApplicationProductCR().Status.ID,
+				threescaleAPIClient: threescaleAPIClient,
+				logger:              logr.Discard(),
+			}
+			entity, err := t.Reconcile()
+			if (err != nil) != tt.wantErr {
+				t1.Fatalf("syncApplication() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), "application plan requires approval") {
+					t1.Errorf("syncApplication() error = %v, want approval required error", err)
+				}
+				return
+			}
+
+			newStatus := NewApplicationStatusReconciler(getBaseReconciler(), applicationResource, entity, "", nil).calculateStatus()
+			if newStatus.ApplicationPlan != tt.wantApplicationPlan {
+				t1.Errorf("status applicationPlan = %s, want %s", newStatus.ApplicationPlan, tt.wantApplicationPlan)
+			}
+			if newStatus.PreviousApplicationPlan != tt.wantPreviousApplicationPlan {
+				t1.Errorf("status previousApplicationPlan = %s, want %s", newStatus.PreviousApplicationPlan, tt.wantPreviousApplicationPlan)
+			}
+		})
+	}
+}
@@ -214,11 +214,49 @@ func (r *ApplicationAuthReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			}
 		}
 	}
+
+	if applicationAuth.Spec.KeyRotation != nil && applicationAuth.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ApplicationAuthReadyConditionType) {
+		return r.reconcileKeyRotation(applicationAuth, developerAccount, application, threescaleAPIClient, reqLogger)
+	}
+
 	// final return
 	reqLogger.Info("Successfully reconciled")
 	return ctrl.Result{}, nil
 }
 
+func (r *ApplicationAuthReconciler) reconcileKeyRotation(
+	applicationAuth *capabilitiesv1beta1.ApplicationAuth,
+	developerAccount *capabilitiesv1beta1.DeveloperAccount,
+	application *capabilitiesv1beta1.Application,
+	threescaleClient *threescaleapi.ThreeScaleClient,
+	reqLogger logr.Logger,
+) (ctrl.Result, error) {
+	if developerAccount.Status.ID == nil || application.Status.ID == nil {
+		reqLogger.Info("Application not synchronized yet. Requeueing key rotation.")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	rotationReconciler := NewApplicationAuthKeyRotationReconciler(r.BaseReconciler, applicationAuth, *developerAccount.Status.ID, *application.Status.ID, threescaleClient)
+	keyRotationStatus, requeueAfter, rotationErr := rotationReconciler.Reconcile()
+
+	statusReconciler := NewApplicationAuthKeyRotationStatusReconciler(r.BaseReconciler, applicationAuth, keyRotationStatus, rotationErr)
+	statusResult, statusErr := statusReconciler.Reconcile()
+	if statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
+	if statusResult.Requeue {
+		reqLogger.Info("Reconciling status not finished. Requeueing.")
+		return statusResult, nil
+	}
+
+	if rotationErr != nil {
+		return helper.ReconcileErrorHandler(rotationErr, reqLogger), nil
+	}
+
+	reqLogger.Info("Successfully reconciled", "nextKeyRotationStep", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
 func (r *ApplicationAuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.ApplicationAuth{}).
@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type ApplicationAuthKeyRotationReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.ApplicationAuth
+	accountID           int64
+	applicationID       int64
+	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	now                 func() time.Time
+	logger              logr.Logger
+}
+
+func NewApplicationAuthKeyRotationReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ApplicationAuth, accountID, applicationID int64, threescaleAPIClient *threescaleapi.ThreeScaleClient) *ApplicationAuthKeyRotationReconciler {
+	return &ApplicationAuthKeyRotationReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		accountID:           accountID,
+		applicationID:       applicationID,
+		threescaleAPIClient: threescaleAPIClient,
+		now:                 time.Now,
+		logger:              b.Logger().WithValues("KeyRotation Reconciler", resource.Name),
+	}
+}
+
+// Reconcile deletes the replaced application key when the grace period ends and rotates the application key when due.
+// Rotations are recorded as pending in the status before the new key is created, so an interrupted rotation
+// is either completed or its new key deleted by the next reconciliation.
+// Returns the new rotation status and the time to wait until the next rotation step, zero when nothing is scheduled
+func (r *ApplicationAuthKeyRotationReconciler) Reconcile() (*capabilitiesv1beta1.ApplicationKeyRotationStatus, time.Duration, error) {
+	r.logger.V(1).Info("START")
+
+	now := r.now()
+	spec := r.resource.Spec.KeyRotation
+	rotationTrigger := r.resource.GetAnnotations()[capabilitiesv1beta1.ApplicationAuthRotateKeyAnnotation]
+
+	status := &capabilitiesv1beta1.ApplicationKeyRotationStatus{}
+	if r.resource.Status.KeyRotation != nil {
+		status = r.resource.Status.KeyRotation.DeepCopy()
+	}
+
+	if spec.Interval == nil {
+		status.NextRotationTime = nil
+	} else if status.NextRotationTime == nil {
+		status.NextRotationTime = &metav1.Time{Time: now.Add(spec.Interval.Duration)}
+	}
+
+	if status.Pending != nil {
+		err := r.resolvePendingRotation(status, now)
+		if err != nil {
+			return status, 0, err
+		}
+	}
+
+	if status.RetiringKeyID != "" && status.RetiringKeyDeletionTime != nil && !now.Before(status.RetiringKeyDeletionTime.Time) {
+		err := r.deleteKey(status.RetiringKeyID)
+		if err != nil {
+			return status, 0, err
+		}
+		status.RetiringKeyID = ""
+		status.RetiringKeyDeletionTime = nil
+	}
+
+	// A new rotation waits until the key replaced by the previous one is deleted
+	if status.RetiringKeyID == "" && r.rotationDue(status, rotationTrigger, now) {
+		err := r.rotate(status, rotationTrigger, now)
+		if err != nil {
+			return status, 0, err
+		}
+	}
+
+	keys, err := r.threescaleAPIClient.ApplicationKeys(r.accountID, r.applicationID)
+	if err != nil {
+		return status, 0, fmt.Errorf("error reading application [%d] keys: %w", r.applicationID, err)
+	}
+
+	status.ActiveKeyIDs = make([]string, 0, len(keys))
+	for _, key := range keys {
+		status.ActiveKeyIDs = append(status.ActiveKeyIDs, applicationKeyID(key.Value))
+	}
+
+	return status, keyRotationRequeueAfter(status, now), nil
+}
+
+func (r *ApplicationAuthKeyRotationReconciler) rotationDue(status *capabilitiesv1beta1.ApplicationKeyRotationStatus, trigger string, now time.Time) bool {
+	if trigger != "" && trigger != status.LastRotationTrigger {
+		return true
+	}
+
+	return status.NextRotationTime != nil && !now.Before(status.NextRotationTime.Time)
+}
+
+// rotate adds a new application key and updates the auth secret.
+// The replaced key stays valid until the grace period ends
+func (r *ApplicationAuthKeyRotationReconciler) rotate(status *capabilitiesv1beta1.ApplicationKeyRotationStatus, trigger string, now time.Time) error {
+	authSecret, err := r.authSecret()
+	if err != nil {
+		return err
+	}
+
+	currentKey := string(authSecret.Data[ApplicationKey])
+	if currentKey == "" {
+		return fmt.Errorf("key rotation requires the %s field in the auth secret [%s]", ApplicationKey, authSecret.Name)
+	}
+
+	// only a key present in 3scale is retired
+	keys, err := r.threescaleAPIClient.ApplicationKeys(r.accountID, r.applicationID)
+	if err != nil {
+		return fmt.Errorf("error reading application [%d] keys: %w", r.applicationID, err)
+	}
+	retiringKeyID := ""
+	for _, key := range keys {
+		if key.Value == currentKey {
+			retiringKeyID = applicationKeyID(currentKey)
+		}
+	}
+
+	newKey, err := generateApplicationKey()
+	if err != nil {
+		return err
+	}
+
+	status.Pending = &capabilitiesv1beta1.ApplicationKeyRotationPendingStatus{
+		KeyID:           applicationKeyID(newKey),
+		RetiringKeyID:   retiringKeyID,
+		RotationTrigger: trigger,
+	}
+	err = r.updateStatus(status)
+	if err != nil {
+		return fmt.Errorf("error recording pending key rotation: %w", err)
+	}
+
+	r.logger.Info("rotating application key", "retiringKeyID", retiringKeyID, "newKeyID", status.Pending.KeyID)
+
+	_, err = r.threescaleAPIClient.CreateApplicationKey(r.accountID, r.applicationID, newKey)
+	if err != nil {
+		return fmt.Errorf("error creating application [%d] key: %w", r.applicationID, err)
+	}
+
+	authSecret.Data[ApplicationKey] = []byte(newKey)
+	err = r.Client().Update(r.Context(), authSecret)
+	if err != nil {
+		// the pending rotation deletes the key on the next reconciliation otherwise
+		if deleteErr := r.threescaleAPIClient.DeleteApplicationKey(r.accountID, r.applicationID, newKey); deleteErr != nil {
+			r.logger.Error(deleteErr, "error deleting unused application key", "keyID", status.Pending.KeyID)
+		}
+		return fmt.Errorf("error updating auth secret: %w", err)
+	}
+
+	completeKeyRotation(status, r.resource.Spec.KeyRotation, now)
+	return nil
+}
+
+// resolvePendingRotation completes a rotation interrupted after the new key was recorded as pending.
+// When the new key is not in the auth secret, it is deleted
+func (r *ApplicationAuthKeyRotationReconciler) resolvePendingRotation(status *capabilitiesv1beta1.ApplicationKeyRotationStatus, now time.Time) error {
+	authSecret, err := r.authSecret()
+	if err != nil {
+		return err
+	}
+
+	if applicationKeyID(string(authSecret.Data[ApplicationKey])) == status.Pending.KeyID {
+		completeKeyRotation(status, r.resource.Spec.KeyRotation, now)
+		return nil
+	}
+
+	r.logger.Info("deleting application key of an interrupted rotation", "keyID", status.Pending.KeyID)
+	err = r.deleteKey(status.Pending.KeyID)
+	if err != nil {
+		return err
+	}
+
+	status.Pending = nil
+	return nil
+}
+
+// completeKeyRotation records the pending rotation as done
+func completeKeyRotation(status *capabilitiesv1beta1.ApplicationKeyRotationStatus, spec *capabilitiesv1beta1.ApplicationKeyRotationSpec, now time.Time) {
+	status.RetiringKeyID = status.Pending.RetiringKeyID
+	status.RetiringKeyDeletionTime = nil
+	if status.RetiringKeyID != "" {
+		status.RetiringKeyDeletionTime = &metav1.Time{Time: now.Add(spec.GracePeriodDuration())}
+	}
+	status.LastRotationTime = &metav1.Time{Time: now}
+	status.LastRotationTrigger = status.Pending.RotationTrigger
+	if spec.Interval != nil {
+		status.NextRotationTime = &metav1.Time{Time: now.Add(spec.Interval.Duration)}
+	}
+	status.Pending = nil
+}
+
+func (r *ApplicationAuthKeyRotationReconciler) authSecret() (*corev1.Secret, error) {
+	authSecret := &corev1.Secret{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: r.resource.Spec.AuthSecretRef.Name, Namespace: r.resource.Namespace}, authSecret)
+	if err != nil {
+		return nil, fmt.Errorf("error reading auth secret: %w", err)
+	}
+
+	return authSecret, nil
+}
+
+// updateStatus persists the key rotation status of the resource
+func (r *ApplicationAuthKeyRotationReconciler) updateStatus(status *capabilitiesv1beta1.ApplicationKeyRotationStatus) error {
+	r.resource.Status.KeyRotation = status.DeepCopy()
+	return r.Client().Status().Update(r.Context(), r.resource)
+}
+
+// deleteKey deletes the application key with the given identifier. Keys already deleted are ignored
+func (r *ApplicationAuthKeyRotationReconciler) deleteKey(keyID string) error {
+	keys, err := r.threescaleAPIClient.ApplicationKeys(r.accountID, r.applicationID)
+	if err != nil {
+		return fmt.Errorf("error reading application [%d] keys: %w", r.applicationID, err)
+	}
+
+	for _, key := range keys {
+		if applicationKeyID(key.Value) == keyID {
+			r.logger.Info("deleting retired application key", "keyID", keyID)
+			err := r.threescaleAPIClient.DeleteApplicationKey(r.accountID, r.applicationID, key.Value)
+			if err != nil {
+				return fmt.Errorf("error deleting application [%d] key: %w", r.applicationID, err)
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// keyRotationRequeueAfter returns the time until the next scheduled rotation step
+func keyRotationRequeueAfter(status *capabilitiesv1beta1.ApplicationKeyRotationStatus, now time.Time) time.Duration {
+	var next time.Duration
+
+	for _, t := range []*metav1.Time{status.NextRotationTime, status.RetiringKeyDeletionTime} {
+		if t == nil {
+			continue
+		}
+
+		// due steps are retried right away
+		wait := max(t.Sub(now), time.Second)
+		if next == 0 || wait < next {
+			next = wait
+		}
+	}
+
+	return next
+}
+
+// applicationKeyID returns the identifier of an application key: a fingerprint of the key value
+func applicationKeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func generateApplicationKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating application key: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// mockHttpApplicationKeysClient serves the application keys API of application 3 in account 3 from the keys slice
+func mockHttpApplicationKeysClient(keys *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		keysPath := "/admin/api/accounts/3/applications/3/keys"
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == keysPath+".json":
+			list := &threescaleapi.ApplicationKeysElem{}
+			for _, key := range *keys {
+				list.Keys = append(list.Keys, threescaleapi.ApplicationKeyWrapper{Key: threescaleapi.ApplicationKey{Value: key}})
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBuffer(responseBody(list))),
+			}
+		case req.Method == http.MethodPost && req.URL.Path == keysPath+".json":
+			_ = req.ParseForm()
+			*keys = append(*keys, req.FormValue("key"))
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBuffer(responseBody(&threescaleapi.ApplicationElem{Application: threescaleapi.Application{ID: 3}}))),
+			}
+		case req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, keysPath+"/"):
+			deleted := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, keysPath+"/"), ".json")
+			remaining := []string{}
+			for _, key := range *keys {
+				if key != deleted {
+					remaining = append(remaining, key)
+				}
+			}
+			*keys = remaining
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBuffer(nil)),
+			}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}
+	})
+}
+
+func getApplicationAuthKeyRotation() *capabilitiesv1beta1.ApplicationAuth {
+	CR := getApplicationAuth()
+	CR.Spec.KeyRotation = &capabilitiesv1beta1.ApplicationKeyRotationSpec{
+		Interval:    &metav1.Duration{Duration: 720 * time.Hour},
+		GracePeriod: &metav1.Duration{Duration: time.Hour},
+	}
+	return CR
+}
+
+func TestApplicationAuthKeyRotationReconciler_Reconcile(t *testing.T) {
+	ap, _ := threescaleapi.NewAdminPortalFromStr("https://3scale-admin.test.3scale.net")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("schedule first rotation", func(t *testing.T) {
+		keys := []string{"testkey"}
+		resource := getApplicationAuthKeyRotation()
+		r := NewApplicationAuthKeyRotationReconciler(getBaseReconciler(getAuthSecretObj()), resource, 3, 3, threescaleapi.NewThreeScale(ap, "test", mockHttpApplicationKeysClient(&keys)))
+		r.now = func() time.Time { return now }
+
+		status, requeueAfter, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(keys) != 1 {
+			t.Errorf("keys = %v, want no rotation", keys)
+		}
+		if !status.NextRotationTime.Time.Equal(now.Add(720 * time.Hour)) {
+			t.Errorf("nextRotationTime = %v", status.NextRotationTime)
+		}
+		if requeueAfter != 720*time.Hour {
+			t.Errorf("requeueAfter = %v, want %v", requeueAfter, 720*time.Hour)
+		}
+	})
+
+	t.Run("rotate on trigger annotation", func(t *testing.T) {
+		keys := []string{"testkey"}
+		resource := getApplicationAuthKeyRotation()
+		resource.Annotations = map[string]string{capabilitiesv1beta1.ApplicationAuthRotateKeyAnnotation: "1"}
+		baseReconciler := getBaseReconciler(getAuthSecretObj(), resource)
+		r := NewApplicationAuthKeyRotationReconciler(baseReconciler, resource, 3, 3, threescaleapi.NewThreeScale(ap, "test", mockHttpApplicationKeysClient(&keys)))
+		r.now = func() time.Time { return now }
+
+		status, requeueAfter, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(keys) != 2 || keys[0] != "testkey" {
+			t.Fatalf("keys = %v, want old and new key", keys)
+		}
+
+		secret := &corev1.Secret{}
+		err = baseReconciler.Client().Get(baseReconciler.Context(), types.NamespacedName{Name: "test", Namespace: "test"}, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(secret.Data[ApplicationKey]) != keys[1] {
+			t.Errorf("secret %s = %s, want new key %s", ApplicationKey, secret.Data[ApplicationKey], keys[1])
+		}
+
+		if status.RetiringKeyID != applicationKeyID("testkey") {
+			t.Errorf("retiringKeyID = %s, want %s", status.RetiringKeyID, applicationKeyID("testkey"))
+		}
+		if len(status.ActiveKeyIDs) != 2 {
+			t.Errorf("activeKeyIDs = %v, want 2 keys", status.ActiveKeyIDs)
+		}
+		if status.LastRotationTrigger != "1" {
+			t.Errorf("lastRotationTrigger = %s, want 1", status.LastRotationTrigger)
+		}
+		if requeueAfter != time.Hour {
+			t.Errorf("requeueAfter = %v, want grace period %v", requeueAfter, time.Hour)
+		}
+	})
+
+	t.Run("complete rotation interrupted after the auth secret update", func(t *testing.T) {
+		keys := []string{"oldkey", "testkey"}
+		resource := getApplicationAuthKeyRotation()
+		resource.Status.KeyRotation = &capabilitiesv1beta1.ApplicationKeyRotationStatus{
+			NextRotationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			Pending: &capabilitiesv1beta1.ApplicationKeyRotationPendingStatus{
+				KeyID:           applicationKeyID("testkey"),
+				RetiringKeyID:   applicationKeyID("oldkey"),
+				RotationTrigger: "1",
+			},
+		}
+		r := NewApplicationAuthKeyRotationReconciler(getBaseReconciler(getAuthSecretObj(), resource), resource, 3, 3, threescaleapi.NewThreeScale(ap, "test", mockHttpApplicationKeysClient(&keys)))
+		r.now = func() time.Time { return now }
+
+		status, _, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(keys) != 2 {
+			t.Errorf("keys = %v, want no new rotation", keys)
+		}
+		if status.Pending != nil || status.RetiringKeyID != applicationKeyID("oldkey") || status.LastRotationTrigger != "1" {
+			t.Errorf("pending rotation not completed: %+v", status)
+		}
+	})
+
+	t.Run("delete key of rotation interrupted before the auth secret update", func(t *testing.T) {
+		keys := []string{"testkey", "newkey"}
+		resource := getApplicationAuthKeyRotation()
+		resource.Status.KeyRotation = &capabilitiesv1beta1.ApplicationKeyRotationStatus{
+			NextRotationTime: &metav1.Time{Time: now.Add(time.Hour)},
+			Pending: &capabilitiesv1beta1.ApplicationKeyRotationPendingStatus{
+				KeyID:         applicationKeyID("newkey"),
+				RetiringKeyID: applicationKeyID("testkey"),
+			},
+		}
+		r := NewApplicationAuthKeyRotationReconciler(getBaseReconciler(getAuthSecretObj(), resource), resource, 3, 3, threescaleapi.NewThreeScale(ap, "test", mockHttpApplicationKeysClient(&keys)))
+		r.now = func() time.Time { return now }
+
+		status, _, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(keys) != 1 || keys[0] != "testkey" {
+			t.Errorf("keys = %v, want the new key deleted", keys)
+		}
+		if status.Pending != nil || status.RetiringKeyID != "" || status.LastRotationTime != nil {
+			t.Errorf("pending rotation not discarded: %+v", status)
+		}
+	})
+
+	t.Run("current key not in 3scale is not retired", func(t *testing.T) {
+		keys := []string{"otherkey"}
+		resource := getApplicationAuthKeyRotation()
+		resource.Annotations = map[string]string{capabilitiesv1beta1.ApplicationAuthRotateKeyAnnotation: "1"}
+		r := NewApplicationAuthKeyRotationReconciler(getBaseReconciler(getAuthSecretObj(), resource), resource, 3, 3, threescaleapi.NewThreeScale(ap, "test", mockHttpApplicationKeysClient(&keys)))
+		r.now = func() time.Time { return now }
+
+		status, _, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(keys) != 2 || status.RetiringKeyID != "" || status.RetiringKeyDeletionTime != nil {
+			t.Errorf("keys = %v, status = %+v, want new key and nothing retired", keys, status)
+		}
+	})
+
+	t.Run("delete old key when grace period ends", func(t *testing.T) {
+		keys := []string{"testkey", "newkey"}
+		resource := getApplicationAuthKeyRotation()
+		resource.Status.KeyRotation = &capabilitiesv1beta1.ApplicationKeyRotationStatus{
+			RetiringKeyID:           applicationKeyID("testkey"),
+			RetiringKeyDeletionTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			NextRotationTime:        &metav1.Time{Time: now.Add(time.Hour)},
+		}
+		r := NewApplicationAuthKeyRotationReconciler(getBaseReconciler(getAuthSecretObj()), resource, 3, 3, threescaleapi.NewThreeScale(ap, "test", mockHttpApplicationKeysClient(&keys)))
+		r.now = func() time.Time { return now }
+
+		status, _, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(keys) != 1 || keys[0] != "newkey" {
+			t.Errorf("keys = %v, want only the new key", keys)
+		}
+		if status.RetiringKeyID != "" || status.RetiringKeyDeletionTime != nil {
+			t.Errorf("retiring key not cleared: %v", status)
+		}
+		if len(status.ActiveKeyIDs) != 1 || status.ActiveKeyIDs[0] != applicationKeyID("newkey") {
+			t.Errorf("activeKeyIDs = %v", status.ActiveKeyIDs)
+		}
+	})
+
+	t.Run("rotation waits for the grace period of the previous rotation", func(t *testing.T) {
+		keys := []string{"testkey", "newkey"}
+		resource := getApplicationAuthKeyRotation()
+		resource.Status.KeyRotation = &capabilitiesv1beta1.ApplicationKeyRotationStatus{
+			RetiringKeyID:           applicationKeyID("testkey"),
+			RetiringKeyDeletionTime: &metav1.Time{Time: now.Add(time.Minute)},
+			NextRotationTime:        &metav1.Time{Time: now.Add(-time.Minute)},
+		}
+		r := NewApplicationAuthKeyRotationReconciler(getBaseReconciler(getAuthSecretObj()), resource, 3, 3, threescaleapi.NewThreeScale(ap, "test", mockHttpApplicationKeysClient(&keys)))
+		r.now = func() time.Time { return now }
+
+		_, requeueAfter, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if len(keys) != 2 {
+			t.Errorf("keys = %v, want no rotation", keys)
+		}
+		if requeueAfter != time.Second {
+			t.Errorf("requeueAfter = %v, want %v", requeueAfter, time.Second)
+		}
+	})
+}
@@ -15,9 +15,11 @@ import (
 
 type ApplicationAuthStatusReconciler struct {
 	*reconcilers.BaseReconciler
-	resource       *capabilitiesv1beta1.ApplicationAuth
-	reconcileError error
-	logger         logr.Logger
+	resource         *capabilitiesv1beta1.ApplicationAuth
+	reconcileError   error
+	keyRotation      *capabilitiesv1beta1.ApplicationKeyRotationStatus
+	keyRotationError error
+	logger           logr.Logger
 }
 
 func NewApplicationAuthStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ApplicationAuth, reconcileError error) *ApplicationAuthStatusReconciler {
@@ -29,6 +31,15 @@ func NewApplicationAuthStatusReconciler(b *reconcilers.BaseReconciler, resource
 	}
 }
 
+// NewApplicationAuthKeyRotationStatusReconciler returns a status reconciler for the key rotation of an already pushed ApplicationAuth.
+// Key rotation errors are reported in their own condition, the Ready condition is kept
+func NewApplicationAuthKeyRotationStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ApplicationAuth, keyRotation *capabilitiesv1beta1.ApplicationKeyRotationStatus, keyRotationError error) *ApplicationAuthStatusReconciler {
+	statusReconciler := NewApplicationAuthStatusReconciler(b, resource, nil)
+	statusReconciler.keyRotation = keyRotation
+	statusReconciler.keyRotationError = keyRotationError
+	return statusReconciler
+}
+
 func (s *ApplicationAuthStatusReconciler) Reconcile() (reconcile.Result, error) {
 	s.logger.V(1).Info("START")
 
@@ -63,6 +74,15 @@ func (s *ApplicationAuthStatusReconciler) calculateStatus() *capabilitiesv1beta1
 	newStatus.Conditions.SetCondition(s.readyCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
 
+	newStatus.KeyRotation = s.resource.Status.KeyRotation.DeepCopy()
+	if s.keyRotation != nil {
+		newStatus.KeyRotation = s.keyRotation
+	}
+
+	if s.resource.Spec.KeyRotation != nil {
+		newStatus.Conditions.SetCondition(s.keyRotationFailedCondition())
+	}
+
 	return newStatus
 }
 
@@ -99,3 +119,17 @@ func (s *ApplicationAuthStatusReconciler) failedCondition() common.Condition {
 
 	return condition
 }
+
+func (s *ApplicationAuthStatusReconciler) keyRotationFailedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.ApplicationAuthKeyRotationFailedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.keyRotationError != nil {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.keyRotationError.Error()
+	}
+
+	return condition
+}
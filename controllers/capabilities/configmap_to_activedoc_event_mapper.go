@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/go-logr/logr"
+)
+
+// ConfigMapToActiveDocEventMapper is an EventHandler that maps an OAS source ConfigMap to the ActiveDoc CRs reading it
+type ConfigMapToActiveDocEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (c *ConfigMapToActiveDocEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	activeDocList := &capabilitiesv1beta1.ActiveDocList{}
+
+	err := c.K8sClient.List(ctx, activeDocList)
+	if err != nil {
+		c.Logger.Error(err, "failed to list ActiveDoc resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for idx := range activeDocList.Items {
+		ref := activeDocList.Items[idx].Spec.ActiveDocOpenAPIRef.ConfigMapRef
+		if ref == nil || ref.Name != obj.GetName() {
+			continue
+		}
+
+		// the namespace defaults to the namespace of the resource
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = activeDocList.Items[idx].GetNamespace()
+		}
+		if namespace != obj.GetNamespace() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      activeDocList.Items[idx].GetName(),
+			Namespace: activeDocList.Items[idx].GetNamespace(),
+		}})
+	}
+
+	c.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(requests) > 0)
+
+	return requests
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/go-logr/logr"
+)
+
+// ConfigMapToOpenAPIEventMapper is an EventHandler that maps an OAS source ConfigMap to the OpenAPI CRs reading it
+type ConfigMapToOpenAPIEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (c *ConfigMapToOpenAPIEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	openAPIList := &capabilitiesv1beta1.OpenAPIList{}
+
+	err := c.K8sClient.List(ctx, openAPIList)
+	if err != nil {
+		c.Logger.Error(err, "failed to list OpenAPI resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for idx := range openAPIList.Items {
+		ref := openAPIList.Items[idx].Spec.OpenAPIRef.ConfigMapRef
+		if ref == nil || ref.Name != obj.GetName() {
+			continue
+		}
+
+		// the namespace defaults to the namespace of the resource
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = openAPIList.Items[idx].GetNamespace()
+		}
+		if namespace != obj.GetNamespace() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      openAPIList.Items[idx].GetName(),
+			Namespace: openAPIList.Items[idx].GetNamespace(),
+		}})
+	}
+
+	c.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(requests) > 0)
+
+	return requests
+}
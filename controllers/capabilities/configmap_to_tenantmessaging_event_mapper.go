@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/go-logr/logr"
+)
+
+// ConfigMapToTenantMessagingEventMapper is an EventHandler that maps an email template body ConfigMap to the TenantMessaging CRs reading it
+type ConfigMapToTenantMessagingEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (c *ConfigMapToTenantMessagingEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	messagingList := &capabilitiesv1beta1.TenantMessagingList{}
+
+	// body ConfigMaps are read from the namespace of the resource
+	err := c.K8sClient.List(ctx, messagingList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		c.Logger.Error(err, "failed to list TenantMessaging resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for idx := range messagingList.Items {
+		for _, emailTemplate := range messagingList.Items[idx].Spec.EmailTemplates {
+			if emailTemplate.BodyRef == nil || emailTemplate.BodyRef.Name != obj.GetName() {
+				continue
+			}
+
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      messagingList.Items[idx].GetName(),
+				Namespace: messagingList.Items[idx].GetNamespace(),
+			}})
+			break
+		}
+	}
+
+	c.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(requests) > 0)
+
+	return requests
+}
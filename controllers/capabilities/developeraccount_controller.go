@@ -209,27 +209,33 @@ func (r *DeveloperAccountReconciler) reconcileMetadata(devAccountCR *capabilitie
 func (r *DeveloperAccountReconciler) reconcileSpec(accountCR *capabilitiesv1beta1.DeveloperAccount, logger logr.Logger) (*DeveloperAccountStatusReconciler, error) {
 	err := r.validateSpec(accountCR)
 	if err != nil {
-		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, "", nil, err)
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, "", nil, nil, err)
 		return statusReconciler, err
 	}
 
 	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), accountCR.Namespace, accountCR.Spec.ProviderAccountRef, logger)
 	if err != nil {
-		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, "", nil, err)
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, "", nil, nil, err)
 		return statusReconciler, err
 	}
 
 	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(accountCR.GetAnnotations())
 	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount, insecureSkipVerify)
 	if err != nil {
-		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, err)
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, nil, err)
 		return statusReconciler, err
 	}
 
-	reconciler := NewDeveloperAccountThreescaleReconciler(r.BaseReconciler, accountCR, threescaleAPIClient, providerAccount.AdminURLStr, logger)
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewDeveloperAccountThreescaleReconciler(r.BaseReconciler, accountCR, threescaleAPIClient, adminAPIClient, providerAccount.AdminURLStr, logger)
 	accountObj, err := reconciler.Reconcile()
 
-	statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, accountObj, err)
+	statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, accountObj, reconciler.FieldsDefinitions(), err)
 	return statusReconciler, err
 }
 
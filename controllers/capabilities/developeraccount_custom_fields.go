@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"fmt"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// developerAccountCustomFieldsValidator validates the custom fields against the account fields definitions of the tenant
+var developerAccountCustomFieldsValidator = &controllerhelper.FieldsValidator{
+	Target:  controllerhelper.FieldsDefinitionTargetAccount,
+	FldPath: field.NewPath("spec").Child("customFields"),
+	// account fields managed by other spec fields
+	BuiltinFields: map[string]string{
+		"org_name":                 "orgName",
+		"monthly_billing_enabled":  "monthlyBillingEnabled",
+		"monthly_charging_enabled": "monthlyChargingEnabled",
+		"vat_code":                 "vatCode",
+		"billing_address":          "billingAddress",
+	},
+}
+
+// customFieldParams returns the valid custom fields of the spec, keyed by field name
+func (s *DeveloperAccountThreescaleReconciler) customFieldParams() (threescaleapi.Params, error) {
+	// Custom fields are only managed when declared in the spec.
+	if len(s.resource.Spec.CustomFields) == 0 {
+		return threescaleapi.Params{}, nil
+	}
+
+	definitions, err := s.adminAPIClient.ListFieldsDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("error reading account fields definitions: %w", err)
+	}
+	// status reports invalid custom fields from the definitions
+	s.fieldsDefinitions = definitions
+
+	return developerAccountCustomFieldsValidator.ValidFields(s.resource.Spec.CustomFields, definitions), nil
+}
+
+func (s *DeveloperAccountThreescaleReconciler) syncCustomFields(accountID int64) error {
+	desired, err := s.customFieldParams()
+	if err != nil {
+		return err
+	}
+
+	if len(desired) == 0 {
+		return nil
+	}
+
+	existing, err := s.adminAPIClient.AccountFields(accountID)
+	if err != nil {
+		return fmt.Errorf("error sync developer account [%d] custom fields: %w", accountID, err)
+	}
+
+	// Only the declared fields are updated.
+	params := threescaleapi.Params{}
+	for name, value := range desired {
+		if existing[name] != value {
+			params[name] = value
+		}
+	}
+
+	s.logger.V(1).Info("syncCustomFields", "params", params)
+	if len(params) > 0 {
+		err := s.adminAPIClient.UpdateAccountFields(accountID, params)
+		if err != nil {
+			return fmt.Errorf("error sync developer account [%d] custom fields: %w", accountID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func getDeveloperAccountFieldsDefinitions() *controllerhelper.FieldsDefinitionList {
+	return &controllerhelper.FieldsDefinitionList{
+		FieldsDefinitions: []controllerhelper.FieldsDefinition{
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Account", Name: "org_name"}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Account", Name: "telephone_number"}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Account", Name: "partner_id"}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Account", Name: "tier", Choices: []string{"gold", "silver"}}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Account", Name: "internal_id", ReadOnly: true}},
+			{Element: controllerhelper.FieldsDefinitionItem{Target: "Cinstance", Name: "cost_centre"}},
+		},
+	}
+}
+
+func getDeveloperAccountCR() *capabilitiesv1beta1.DeveloperAccount {
+	return &capabilitiesv1beta1.DeveloperAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "partner", Namespace: "test"},
+		Spec: capabilitiesv1beta1.DeveloperAccountSpec{
+			OrgName: "Partner",
+		},
+	}
+}
+
+func TestDeveloperAccountStatusReconciler_invalidCustomFieldsCondition(t *testing.T) {
+	accountCR := getDeveloperAccountCR()
+	accountCR.Spec.CustomFields = map[string]string{"partner_id": "p-1", "unknown": "value"}
+	remoteAccount := &threescaleapi.DeveloperAccount{Element: threescaleapi.DeveloperAccountItem{ID: ptr.To(int64(3))}}
+
+	newStatus := NewDeveloperAccountStatusReconciler(getBaseReconciler(), accountCR, "", remoteAccount, getDeveloperAccountFieldsDefinitions(), nil).calculateStatus()
+	if !newStatus.Conditions.IsTrueFor(capabilitiesv1beta1.DeveloperAccountInvalidCustomFieldsConditionType) {
+		t.Fatalf("InvalidCustomFields condition not true: %v", newStatus.Conditions)
+	}
+	condition := newStatus.Conditions.GetCondition(capabilitiesv1beta1.DeveloperAccountInvalidCustomFieldsConditionType)
+	if !strings.Contains(condition.Message, "unknown") || strings.Contains(condition.Message, "partner_id") {
+		t.Errorf("InvalidCustomFields condition message = %s", condition.Message)
+	}
+	// Ready condition is not affected
+	if !newStatus.Conditions.IsTrueFor(capabilitiesv1beta1.DeveloperAccountReadyConditionType) {
+		t.Errorf("Ready condition not true: %v", newStatus.Conditions)
+	}
+
+	// fields definitions not read, condition is not set
+	newStatus = NewDeveloperAccountStatusReconciler(getBaseReconciler(), accountCR, "", remoteAccount, nil, nil).calculateStatus()
+	if newStatus.Conditions.GetCondition(capabilitiesv1beta1.DeveloperAccountInvalidCustomFieldsConditionType) != nil {
+		t.Errorf("InvalidCustomFields condition set without fields definitions: %v", newStatus.Conditions)
+	}
+}
+
+func TestDeveloperAccountThreescaleReconciler_syncDeveloperAccount(t *testing.T) {
+	var accountUpdate map[string]interface{}
+	var fieldsUpdate url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/admin/api/fields_definitions.json":
+			definitions := map[string]interface{}{"fields_definitions": getDeveloperAccountFieldsDefinitions().FieldsDefinitions}
+			_ = json.NewEncoder(w).Encode(definitions)
+		case req.Method == http.MethodGet && req.URL.Path == "/admin/api/accounts/3.json":
+			_, _ = io.WriteString(w, `{"account":{"id":3,"org_name":"Partner","telephone_number":"555","extra_fields":{"partner_id":"old"}}}`)
+		case req.Method == http.MethodPut && req.URL.Path == "/admin/api/accounts/3.json" && req.Header.Get("Content-Type") == "application/json":
+			body, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(body, &accountUpdate)
+			_, _ = io.WriteString(w, `{"account":{"id":3,"org_name":"Partner"}}`)
+		case req.Method == http.MethodPut && req.URL.Path == "/admin/api/accounts/3.json":
+			_ = req.ParseForm()
+			fieldsUpdate = req.PostForm
+			_, _ = io.WriteString(w, `{"account":{"id":3}}`)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ap, _ := threescaleapi.NewAdminPortalFromStr(srv.URL)
+	adminURL, _ := url.Parse(srv.URL)
+
+	accountCR := getDeveloperAccountCR()
+	accountCR.Spec.VatCode = "ES123"
+	accountCR.Spec.BillingAddress = &capabilitiesv1beta1.DeveloperAccountBillingAddressSpec{
+		Company:  "Partner Inc",
+		Address1: "Main Street 1",
+		City:     "Barcelona",
+		Country:  "Spain",
+	}
+	accountCR.Spec.CustomFields = map[string]string{"telephone_number": "555", "partner_id": "new", "unknown": "value"}
+
+	reconciler := NewDeveloperAccountThreescaleReconciler(getBaseReconciler(), accountCR,
+		threescaleapi.NewThreeScale(ap, "test", srv.Client()),
+		controllerhelper.NewAdminAPIClient(adminURL, "test", srv.Client()),
+		srv.URL, getBaseReconciler().Logger())
+
+	remoteAccount := &threescaleapi.DeveloperAccount{Element: threescaleapi.DeveloperAccountItem{
+		ID:          ptr.To(int64(3)),
+		OrgName:     ptr.To("Partner"),
+		Annotations: map[string]string{"managed_by": "operator"},
+	}}
+	_, err := reconciler.syncDeveloperAccount(remoteAccount)
+	if err != nil {
+		t.Fatalf("syncDeveloperAccount() error = %v", err)
+	}
+
+	if accountUpdate["vat_code"] != "ES123" {
+		t.Errorf("vat_code not updated: %v", accountUpdate)
+	}
+	billingAddress, _ := accountUpdate["billing_address"].(map[string]interface{})
+	if billingAddress["company"] != "Partner Inc" || billingAddress["country"] != "Spain" {
+		t.Errorf("billing_address not updated: %v", accountUpdate)
+	}
+
+	// only changed and valid custom fields are updated
+	want := url.Values{"partner_id": []string{"new"}}
+	if fieldsUpdate.Encode() != want.Encode() {
+		t.Errorf("custom fields update = %v, want %v", fieldsUpdate, want)
+	}
+
+	if reconciler.FieldsDefinitions() == nil {
+		t.Errorf("fields definitions not kept for the status")
+	}
+}
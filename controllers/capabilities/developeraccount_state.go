@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// developerAccountStateEvents are the state events leading to the desired state.
+// Some states need more than one transition, e.g. suspended accounts are resumed before being rejected.
+// Map: desired state -> current state -> state event
+var developerAccountStateEvents = map[string]map[string]string{
+	capabilitiesv1beta1.DeveloperAccountStateApproved: {
+		capabilitiesv1beta1.DeveloperAccountStatePending:   controllerhelper.AccountStateEventApprove,
+		capabilitiesv1beta1.DeveloperAccountStateRejected:  controllerhelper.AccountStateEventApprove,
+		capabilitiesv1beta1.DeveloperAccountStateSuspended: controllerhelper.AccountStateEventResume,
+	},
+	capabilitiesv1beta1.DeveloperAccountStateRejected: {
+		capabilitiesv1beta1.DeveloperAccountStatePending:   controllerhelper.AccountStateEventReject,
+		capabilitiesv1beta1.DeveloperAccountStateApproved:  controllerhelper.AccountStateEventReject,
+		capabilitiesv1beta1.DeveloperAccountStateSuspended: controllerhelper.AccountStateEventResume,
+	},
+	capabilitiesv1beta1.DeveloperAccountStatePending: {
+		capabilitiesv1beta1.DeveloperAccountStateApproved:  controllerhelper.AccountStateEventMakePending,
+		capabilitiesv1beta1.DeveloperAccountStateRejected:  controllerhelper.AccountStateEventMakePending,
+		capabilitiesv1beta1.DeveloperAccountStateSuspended: controllerhelper.AccountStateEventResume,
+	},
+	capabilitiesv1beta1.DeveloperAccountStateSuspended: {
+		capabilitiesv1beta1.DeveloperAccountStatePending:  controllerhelper.AccountStateEventApprove,
+		capabilitiesv1beta1.DeveloperAccountStateRejected: controllerhelper.AccountStateEventApprove,
+		capabilitiesv1beta1.DeveloperAccountStateApproved: controllerhelper.AccountStateEventSuspend,
+	},
+}
+
+// syncState fires the state events leading to the desired state of the spec.
+// The transitions are recorded in the status. The state is not managed when not set in the spec
+func (s *DeveloperAccountThreescaleReconciler) syncState(devAccount *threescaleapi.DeveloperAccount) (*threescaleapi.DeveloperAccount, error) {
+	if s.resource.Spec.State == nil {
+		return devAccount, nil
+	}
+
+	desiredState := *s.resource.Spec.State
+	// every state is reached in two transitions at most
+	for i := 0; i < 2; i++ {
+		currentState := ptr.Deref(devAccount.Element.State, "")
+		if currentState == desiredState {
+			return devAccount, nil
+		}
+
+		event, ok := developerAccountStateEvents[desiredState][currentState]
+		if !ok {
+			return nil, fmt.Errorf("developer account [%d] cannot transition from state %q to %q", *devAccount.Element.ID, currentState, desiredState)
+		}
+
+		s.logger.Info("Change developer account state", "ID", *devAccount.Element.ID, "from", currentState, "to", desiredState, "event", event)
+		updatedDevAccount, err := s.adminAPIClient.FireAccountStateEvent(*devAccount.Element.ID, event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to %s developer account [%d]: %w", event, *devAccount.Element.ID, err)
+		}
+
+		s.recordStateTransition(currentState, ptr.Deref(updatedDevAccount.Element.State, ""), event)
+		devAccount = updatedDevAccount
+	}
+
+	if currentState := ptr.Deref(devAccount.Element.State, ""); currentState != desiredState {
+		return nil, fmt.Errorf("developer account [%d] in state %q, expected %q", *devAccount.Element.ID, currentState, desiredState)
+	}
+
+	return devAccount, nil
+}
+
+func (s *DeveloperAccountThreescaleReconciler) recordStateTransition(from, to, event string) {
+	transitions := append(s.resource.Status.StateTransitions, capabilitiesv1beta1.DeveloperAccountStateTransition{
+		From:           from,
+		To:             to,
+		Event:          event,
+		TransitionTime: metav1.Now(),
+	})
+
+	if len(transitions) > capabilitiesv1beta1.DeveloperAccountMaxStateTransitions {
+		transitions = transitions[len(transitions)-capabilitiesv1beta1.DeveloperAccountMaxStateTransitions:]
+	}
+
+	s.resource.Status.StateTransitions = transitions
+}
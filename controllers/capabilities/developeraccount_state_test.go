@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeveloperAccountThreescaleReconciler_syncState(t *testing.T) {
+	// state of the account after each state event
+	eventStates := map[string]string{
+		"approve":      "approved",
+		"reject":       "rejected",
+		"make_pending": "pending",
+		"suspend":      "suspended",
+		"resume":       "approved",
+	}
+
+	tests := []struct {
+		name         string
+		currentState string
+		desiredState *string
+		wantEvents   []string
+		wantErr      bool
+	}{
+		{"state not managed", "pending", nil, nil, false},
+		{"state in sync", "approved", ptr.To("approved"), nil, false},
+		{"approve pending account", "pending", ptr.To("approved"), []string{"approve"}, false},
+		{"reject pending account", "pending", ptr.To("rejected"), []string{"reject"}, false},
+		{"make approved account pending", "approved", ptr.To("pending"), []string{"make_pending"}, false},
+		{"suspend approved account", "approved", ptr.To("suspended"), []string{"suspend"}, false},
+		{"resume suspended account", "suspended", ptr.To("approved"), []string{"resume"}, false},
+		{"suspend pending account", "pending", ptr.To("suspended"), []string{"approve", "suspend"}, false},
+		{"reject suspended account", "suspended", ptr.To("rejected"), []string{"resume", "reject"}, false},
+		{"unknown current state", "scheduled_for_deletion", ptr.To("approved"), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				event := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/admin/api/accounts/3/"), ".json")
+				state, ok := eventStates[event]
+				if req.Method != http.MethodPut || !ok {
+					t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				events = append(events, event)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"account":{"id":3,"state":%q}}`, state)
+			}))
+			defer srv.Close()
+
+			adminURL, _ := url.Parse(srv.URL)
+			accountCR := getDeveloperAccountCR()
+			accountCR.Spec.State = tt.desiredState
+
+			reconciler := NewDeveloperAccountThreescaleReconciler(getBaseReconciler(), accountCR, nil,
+				controllerhelper.NewAdminAPIClient(adminURL, "test", srv.Client()),
+				srv.URL, getBaseReconciler().Logger())
+
+			devAccount, err := reconciler.syncState(&threescaleapi.DeveloperAccount{Element: threescaleapi.DeveloperAccountItem{
+				ID:    ptr.To(int64(3)),
+				State: ptr.To(tt.currentState),
+			}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("syncState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(events, tt.wantEvents) {
+				t.Errorf("syncState() events = %v, want %v", events, tt.wantEvents)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.desiredState != nil && *devAccount.Element.State != *tt.desiredState {
+				t.Errorf("syncState() state = %s, want %s", *devAccount.Element.State, *tt.desiredState)
+			}
+
+			// transitions are recorded in the status
+			transitionEvents := []string{}
+			from := tt.currentState
+			for _, transition := range accountCR.Status.StateTransitions {
+				if transition.From != from || transition.To != eventStates[transition.Event] || transition.TransitionTime.IsZero() {
+					t.Errorf("unexpected state transition %v", transition)
+				}
+				from = transition.To
+				transitionEvents = append(transitionEvents, transition.Event)
+			}
+			if len(tt.wantEvents) > 0 && !reflect.DeepEqual(transitionEvents, tt.wantEvents) {
+				t.Errorf("recorded transitions = %v, want %v", transitionEvents, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestDeveloperAccountThreescaleReconciler_recordStateTransition(t *testing.T) {
+	accountCR := getDeveloperAccountCR()
+	reconciler := NewDeveloperAccountThreescaleReconciler(getBaseReconciler(), accountCR, nil, nil, "", getBaseReconciler().Logger())
+
+	for i := 0; i < capabilitiesv1beta1.DeveloperAccountMaxStateTransitions+2; i++ {
+		reconciler.recordStateTransition("pending", "approved", fmt.Sprintf("event%d", i))
+	}
+
+	transitions := accountCR.Status.StateTransitions
+	if len(transitions) != capabilitiesv1beta1.DeveloperAccountMaxStateTransitions {
+		t.Fatalf("recorded transitions = %d, want %d", len(transitions), capabilitiesv1beta1.DeveloperAccountMaxStateTransitions)
+	}
+	// the oldest transitions are dropped
+	if transitions[0].Event != "event2" || transitions[len(transitions)-1].Event != fmt.Sprintf("event%d", capabilitiesv1beta1.DeveloperAccountMaxStateTransitions+1) {
+		t.Errorf("unexpected recorded transitions %v", transitions)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
@@ -20,16 +21,18 @@ type DeveloperAccountStatusReconciler struct {
 	resource               *capabilitiesv1beta1.DeveloperAccount
 	providerAccountHost    string
 	remoteDeveloperAccount *threescaleapi.DeveloperAccount
+	fieldsDefinitions      *controllerhelper.FieldsDefinitionList
 	reconcileError         error
 	logger                 logr.Logger
 }
 
-func NewDeveloperAccountStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, providerAccountHost string, remoteDeveloperAccount *threescaleapi.DeveloperAccount, reconcileError error) *DeveloperAccountStatusReconciler {
+func NewDeveloperAccountStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, providerAccountHost string, remoteDeveloperAccount *threescaleapi.DeveloperAccount, fieldsDefinitions *controllerhelper.FieldsDefinitionList, reconcileError error) *DeveloperAccountStatusReconciler {
 	return &DeveloperAccountStatusReconciler{
 		BaseReconciler:         b,
 		resource:               resource,
 		providerAccountHost:    providerAccountHost,
 		remoteDeveloperAccount: remoteDeveloperAccount,
+		fieldsDefinitions:      fieldsDefinitions,
 		reconcileError:         reconcileError,
 		logger:                 b.Logger().WithValues("Status Reconciler", resource.Name),
 	}
@@ -78,6 +81,7 @@ func (s *DeveloperAccountStatusReconciler) calculateStatus() *capabilitiesv1beta
 		ID:                  s.resource.Status.ID,
 		AccountState:        s.resource.Status.AccountState,
 		CreditCardStored:    s.resource.Status.CreditCardStored,
+		StateTransitions:    s.resource.Status.StateTransitions,
 		ProviderAccountHost: s.resource.Status.ProviderAccountHost,
 		Conditions:          s.resource.Status.Conditions.Copy(),
 		ObservedGeneration:  s.resource.Status.ObservedGeneration,
@@ -98,9 +102,33 @@ func (s *DeveloperAccountStatusReconciler) calculateStatus() *capabilitiesv1beta
 	newStatus.Conditions.SetCondition(s.waitingCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
 
+	// Fields definitions are only read when custom fields are synchronized
+	if len(s.resource.Spec.CustomFields) == 0 || s.fieldsDefinitions != nil {
+		newStatus.Conditions.SetCondition(s.invalidCustomFieldsCondition())
+	}
+
 	return newStatus
 }
 
+func (s *DeveloperAccountStatusReconciler) invalidCustomFieldsCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperAccountInvalidCustomFieldsConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if len(s.resource.Spec.CustomFields) == 0 {
+		return condition
+	}
+
+	fieldErrors := developerAccountCustomFieldsValidator.Validate(s.resource.Spec.CustomFields, s.fieldsDefinitions)
+	if len(fieldErrors) > 0 {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = fieldErrors.ToAggregate().Error()
+	}
+
+	return condition
+}
+
 func (s *DeveloperAccountStatusReconciler) readyCondition() common.Condition {
 	condition := common.Condition{
 		Type:   capabilitiesv1beta1.DeveloperAccountReadyConditionType,
@@ -15,6 +15,7 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -22,20 +23,29 @@ type DeveloperAccountThreescaleReconciler struct {
 	*reconcilers.BaseReconciler
 	resource            *capabilitiesv1beta1.DeveloperAccount
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	adminAPIClient      *controllerhelper.AdminAPIClient
 	providerAccountHost string
 	logger              logr.Logger
+	fieldsDefinitions   *controllerhelper.FieldsDefinitionList
 }
 
-func NewDeveloperAccountThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient, providerAccountHost string, logger logr.Logger) *DeveloperAccountThreescaleReconciler {
+func NewDeveloperAccountThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient, adminAPIClient *controllerhelper.AdminAPIClient, providerAccountHost string, logger logr.Logger) *DeveloperAccountThreescaleReconciler {
 	return &DeveloperAccountThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
+		adminAPIClient:      adminAPIClient,
 		providerAccountHost: providerAccountHost,
 		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
 	}
 }
 
+// FieldsDefinitions returns the fields definitions read to synchronize the custom fields.
+// Nil when the custom fields have not been synchronized
+func (s *DeveloperAccountThreescaleReconciler) FieldsDefinitions() *controllerhelper.FieldsDefinitionList {
+	return s.fieldsDefinitions
+}
+
 func (s *DeveloperAccountThreescaleReconciler) Reconcile() (*threescaleapi.DeveloperAccount, error) {
 	s.logger.V(1).Info("START")
 
@@ -78,9 +88,10 @@ func (s *DeveloperAccountThreescaleReconciler) Reconcile() (*threescaleapi.Devel
 			return createdDevAccount, createErr
 		}
 
-		// Update the CR status with the account's ID and return the account
+		// Update the CR status with the account's ID.
+		// Fields not accepted by the signup, like the billing address, are synchronized next
 		s.resource.Status.ID = createdDevAccount.Element.ID
-		return createdDevAccount, nil
+		return s.syncDeveloperAccount(createdDevAccount)
 	}
 
 	s.logger.V(1).Info("DeveloperAccount already exists", "ID", *devAccount.Element.ID)
@@ -166,13 +177,18 @@ func (s *DeveloperAccountThreescaleReconciler) createDevAccount() (*threescaleap
 		return nil, nil, err
 	}
 
-	params := threescaleapi.Params{
-		"org_name": s.resource.Spec.OrgName,
-		"username": devAdminUserCR.Spec.Username,
-		"email":    devAdminUserCR.Spec.Email,
-		"password": password,
+	customFieldParams, err := s.customFieldParams()
+	if err != nil {
+		return nil, nil, err
 	}
 
+	// custom fields are sent on signup, as fields may be required
+	params := customFieldParams
+	params["org_name"] = s.resource.Spec.OrgName
+	params["username"] = devAdminUserCR.Spec.Username
+	params["email"] = devAdminUserCR.Spec.Email
+	params["password"] = password
+
 	for k, v := range helper.ManagedByOperatorAnnotation() {
 		params[k] = v
 	}
@@ -185,6 +201,14 @@ func (s *DeveloperAccountThreescaleReconciler) createDevAccount() (*threescaleap
 		params["monthly_charging_enabled"] = strconv.FormatBool(*s.resource.Spec.MonthlyChargingEnabled)
 	}
 
+	if s.resource.Spec.AccountPlan != "" {
+		accountPlanID, err := s.findAccountPlanID(s.resource.Spec.AccountPlan)
+		if err != nil {
+			return nil, nil, err
+		}
+		params["account_plan_id"] = strconv.FormatInt(accountPlanID, 10)
+	}
+
 	devAccountObj, signupErr := s.threescaleAPIClient.Signup(params)
 
 	return devAccountObj, devAdminUserCR, signupErr
@@ -210,6 +234,12 @@ func (s *DeveloperAccountThreescaleReconciler) findDeveloperAdminUserCR() (*capa
 		return developerUser.Spec.Role != nil && *developerUser.Spec.Role == "admin", nil
 	}
 
+	// Filter by password
+	// the account is signed up with the admin user's password, invited users cannot be used
+	passwordFilter := func(developerUser *capabilitiesv1beta1.DeveloperUser) (bool, error) {
+		return developerUser.Spec.PasswordCredentialsRef != nil, nil
+	}
+
 	// Filter by orphan condition
 	// the account create operation also creates the developer user,
 	// so the search result must include only orphan objects
@@ -220,6 +250,7 @@ func (s *DeveloperAccountThreescaleReconciler) findDeveloperAdminUserCR() (*capa
 	devUserList, err := controllerhelper.FindDeveloperUserList(s.logger, s.Client(), queryOpts,
 		parentAccountFilter,
 		adminRoleFilter,
+		passwordFilter,
 		// Filter by providerAccount
 		controllerhelper.DeveloperUserProviderAccountFilter(s.Client(), s.resource.Namespace, s.providerAccountHost, s.logger),
 		orphanFilter,
@@ -269,6 +300,25 @@ func (s *DeveloperAccountThreescaleReconciler) syncDeveloperAccount(devAccount *
 		deltaAccount.Element.MonthlyChargingEnabled = &desiredMonthlyChargingEnabled
 	}
 
+	if s.resource.Spec.VatCode != "" && ptr.Deref(devAccount.Element.VatCode, "") != s.resource.Spec.VatCode {
+		update = true
+		deltaAccount.Element.VatCode = &s.resource.Spec.VatCode
+	}
+
+	if s.resource.Spec.BillingAddress != nil && !billingAddressEquals(devAccount.Element.BillingAddress, s.resource.Spec.BillingAddress) {
+		update = true
+		deltaAccount.Element.BillingAddress = &threescaleapi.BillingAddressSpec{
+			Company:     &s.resource.Spec.BillingAddress.Company,
+			Address1:    &s.resource.Spec.BillingAddress.Address1,
+			Address2:    &s.resource.Spec.BillingAddress.Address2,
+			PhoneNumber: &s.resource.Spec.BillingAddress.PhoneNumber,
+			City:        &s.resource.Spec.BillingAddress.City,
+			State:       &s.resource.Spec.BillingAddress.State,
+			Zip:         &s.resource.Spec.BillingAddress.Zip,
+			Country:     &s.resource.Spec.BillingAddress.Country,
+		}
+	}
+
 	if !helper.ManagedByOperatorAnnotationExists(devAccount.Element.Annotations) {
 		for k, v := range helper.ManagedByOperatorDeveloperAccountAnnotation() {
 			update = true
@@ -290,7 +340,46 @@ func (s *DeveloperAccountThreescaleReconciler) syncDeveloperAccount(devAccount *
 		updatedDevAccount = updateRes
 	}
 
-	return updatedDevAccount, nil
+	err := s.syncCustomFields(*devAccount.Element.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.syncState(updatedDevAccount)
+}
+
+func billingAddressEquals(current *threescaleapi.BillingAddressSpec, desired *capabilitiesv1beta1.DeveloperAccountBillingAddressSpec) bool {
+	if current == nil {
+		return false
+	}
+
+	return ptr.Deref(current.Company, "") == desired.Company &&
+		ptr.Deref(current.Address1, "") == desired.Address1 &&
+		ptr.Deref(current.Address2, "") == desired.Address2 &&
+		ptr.Deref(current.PhoneNumber, "") == desired.PhoneNumber &&
+		ptr.Deref(current.City, "") == desired.City &&
+		ptr.Deref(current.State, "") == desired.State &&
+		ptr.Deref(current.Zip, "") == desired.Zip &&
+		ptr.Deref(current.Country, "") == desired.Country
+}
+
+// findAccountPlanID returns the ID of the account plan with the given system name.
+// The account plan may be created by an AccountPlan custom resource, so the reconciliation waits for it
+func (s *DeveloperAccountThreescaleReconciler) findAccountPlanID(systemName string) (int64, error) {
+	planList, err := s.adminAPIClient.ListAccountPlans()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list account plans: %w", err)
+	}
+
+	for _, plan := range planList.Plans {
+		if plan.Element.SystemName == systemName {
+			return plan.Element.ID, nil
+		}
+	}
+
+	return 0, &helper.WaitError{
+		Err: fmt.Errorf("account plan %s not found", systemName),
+	}
 }
 
 func (s *DeveloperAccountThreescaleReconciler) getAdminUserPassword(adminUserCR *capabilitiesv1beta1.DeveloperUser) (string, error) {
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DeveloperAccountSetReconciler reconciles a DeveloperAccountSet object
+type DeveloperAccountSetReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that DeveloperAccountSetReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &DeveloperAccountSetReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developeraccountsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developeraccountsets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developeraccountsets/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *DeveloperAccountSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Logger().WithValues("developeraccountset", req.NamespacedName)
+	reqLogger.Info("Reconcile DeveloperAccountSet", "Operator version", version.Version)
+
+	// Fetch the instance
+	setCR := &capabilitiesv1beta1.DeveloperAccountSet{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, setCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(setCR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// Ignore deleted resource, the generated custom resources are garbage collected
+	if setCR.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcileSpec(setCR, reqLogger)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile developer account set: %v. Failed to update status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to update developer account set status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(setCR, corev1.EventTypeWarning, "Invalid developer account set", "%v", reconcileErr)
+
+			// the source rows might be fixed, read them again after the refresh interval
+			if len(setCR.Validate()) == 0 {
+				return ctrl.Result{RequeueAfter: setCR.RefreshIntervalDuration()}, nil
+			}
+
+			// On spec validation error, no need to retry as spec is not valid and needs to be changed
+			return ctrl.Result{}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(setCR, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	// sources are polled for changes
+	return ctrl.Result{RequeueAfter: setCR.RefreshIntervalDuration()}, nil
+}
+
+func (r *DeveloperAccountSetReconciler) reconcileSpec(setCR *capabilitiesv1beta1.DeveloperAccountSet, logger logr.Logger) (*DeveloperAccountSetStatusReconciler, error) {
+	err := r.validateSpec(setCR)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountSetStatusReconciler(r.BaseReconciler, setCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	sourceReader := NewDeveloperAccountSetSourceReader(r.Context(), r.Client(), setCR.Namespace)
+	rows, err := sourceReader.Read(&setCR.Spec.Source)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountSetStatusReconciler(r.BaseReconciler, setCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewDeveloperAccountSetRowsReconciler(r.BaseReconciler, setCR, rows, logger)
+	rowsStatus, err := reconciler.Reconcile()
+
+	statusReconciler := NewDeveloperAccountSetStatusReconciler(r.BaseReconciler, setCR, rows.Revision, rowsStatus, err)
+	return statusReconciler, err
+}
+
+func (r *DeveloperAccountSetReconciler) validateSpec(resource *capabilitiesv1beta1.DeveloperAccountSet) error {
+	errors := field.ErrorList{}
+	errors = append(errors, resource.Validate()...)
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: errors,
+	}
+}
+
+func (r *DeveloperAccountSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the readiness of the rows is aggregated from the status of the generated custom resources
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.DeveloperAccountSet{}).
+		Owns(&capabilitiesv1beta1.DeveloperAccount{}).
+		Owns(&capabilitiesv1beta1.DeveloperUser{}).
+		Owns(&capabilitiesv1beta1.Application{}).
+		Complete(r)
+}
@@ -0,0 +1,478 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"text/template"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// developerAccountSetLabelKey labels the custom resources generated by a set with the set name
+	developerAccountSetLabelKey = "capabilities.3scale.net/developer-account-set"
+
+	// developerAccountSetRowLabelKey labels the custom resources generated by a set with the row name
+	developerAccountSetRowLabelKey = "capabilities.3scale.net/developer-account-set-row"
+)
+
+// developerAccountSetTemplates are the parsed templates of the set
+type developerAccountSetTemplates struct {
+	developerAccount *template.Template
+	developerUser    *template.Template
+	application      *template.Template
+}
+
+// developerAccountSetRowsStatus is the aggregate readiness of the rows
+type developerAccountSetRowsStatus struct {
+	Rows                int32
+	ReadyRows           int32
+	NotReadyRows        []string
+	PendingDeletions    int32
+	PendingDeletionRows []string
+}
+
+// developerAccountSetRowResources are the custom resources generated for a row
+type developerAccountSetRowResources struct {
+	developerAccount *capabilitiesv1beta1.DeveloperAccount
+	developerUser    *capabilitiesv1beta1.DeveloperUser
+	application      *capabilitiesv1beta1.Application
+}
+
+// DeveloperAccountSetRowsReconciler generates the DeveloperAccount, DeveloperUser and Application
+// custom resources of every row. Resources of removed rows are deleted
+type DeveloperAccountSetRowsReconciler struct {
+	*reconcilers.BaseReconciler
+	setCR  *capabilitiesv1beta1.DeveloperAccountSet
+	rows   *developerAccountSetRows
+	logger logr.Logger
+}
+
+func NewDeveloperAccountSetRowsReconciler(b *reconcilers.BaseReconciler, setCR *capabilitiesv1beta1.DeveloperAccountSet, rows *developerAccountSetRows, logger logr.Logger) *DeveloperAccountSetRowsReconciler {
+	return &DeveloperAccountSetRowsReconciler{
+		BaseReconciler: b,
+		setCR:          setCR,
+		rows:           rows,
+		logger:         logger,
+	}
+}
+
+func (r *DeveloperAccountSetRowsReconciler) Reconcile() (*developerAccountSetRowsStatus, error) {
+	templates, err := parseDeveloperAccountSetTemplates(&r.setCR.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	// every row is rendered before changing any resource, so invalid rows do not leave the set half applied
+	desired := make([]developerAccountSetRowResources, 0, len(r.rows.Rows))
+	for _, row := range r.rows.Rows {
+		resources, err := r.desiredRowResources(templates, row)
+		if err != nil {
+			return nil, err
+		}
+
+		desired = append(desired, *resources)
+	}
+
+	for idx := range desired {
+		err := r.ReconcileResource(&capabilitiesv1beta1.DeveloperAccount{}, desired[idx].developerAccount, r.developerAccountMutator)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.ReconcileResource(&capabilitiesv1beta1.DeveloperUser{}, desired[idx].developerUser, r.developerUserMutator)
+		if err != nil {
+			return nil, err
+		}
+
+		if desired[idx].application != nil {
+			err = r.ReconcileResource(&capabilitiesv1beta1.Application{}, desired[idx].application, r.applicationMutator)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r.pruneAndComputeStatus(templates.application != nil)
+}
+
+// pruneAndComputeStatus deletes the resources of removed rows and computes the readiness of the rows.
+// Nothing is deleted when pruning is disabled or more than maxDeletions rows are removed
+func (r *DeveloperAccountSetRowsReconciler) pruneAndComputeStatus(withApplication bool) (*developerAccountSetRowsStatus, error) {
+	rowNames := map[string]bool{}
+	for _, row := range r.rows.Rows {
+		rowNames[row.Name()] = true
+	}
+
+	listOpts := []client.ListOption{
+		client.InNamespace(r.setCR.Namespace),
+		client.MatchingLabels{developerAccountSetLabelKey: r.setCR.Name},
+	}
+
+	// rows are ready when every generated resource is ready
+	accountsReady := map[string]bool{}
+	usersReady := map[string]bool{}
+	applicationsReady := map[string]bool{}
+
+	// resources to delete by row name. Only resources generated by this set are deleted
+	removed := map[string][]client.Object{}
+	addRemoved := func(rowName string, obj client.Object) {
+		if r.HasOwnerReference(r.setCR, obj) {
+			removed[rowName] = append(removed[rowName], obj)
+		}
+	}
+
+	accountList := &capabilitiesv1beta1.DeveloperAccountList{}
+	if err := r.Client().List(r.Context(), accountList, listOpts...); err != nil {
+		return nil, err
+	}
+	for idx := range accountList.Items {
+		rowName := accountList.Items[idx].GetLabels()[developerAccountSetRowLabelKey]
+		if !rowNames[rowName] {
+			addRemoved(rowName, &accountList.Items[idx])
+			continue
+		}
+		accountsReady[rowName] = accountList.Items[idx].Status.IsReady()
+	}
+
+	userList := &capabilitiesv1beta1.DeveloperUserList{}
+	if err := r.Client().List(r.Context(), userList, listOpts...); err != nil {
+		return nil, err
+	}
+	for idx := range userList.Items {
+		rowName := userList.Items[idx].GetLabels()[developerAccountSetRowLabelKey]
+		if !rowNames[rowName] {
+			addRemoved(rowName, &userList.Items[idx])
+			continue
+		}
+		usersReady[rowName] = userList.Items[idx].Status.Conditions.IsTrueFor(capabilitiesv1beta1.DeveloperUserReadyConditionType)
+	}
+
+	applicationList := &capabilitiesv1beta1.ApplicationList{}
+	if err := r.Client().List(r.Context(), applicationList, listOpts...); err != nil {
+		return nil, err
+	}
+	for idx := range applicationList.Items {
+		rowName := applicationList.Items[idx].GetLabels()[developerAccountSetRowLabelKey]
+		// applications are also deleted when the application template is removed
+		if !rowNames[rowName] || !withApplication {
+			addRemoved(rowName, &applicationList.Items[idx])
+			continue
+		}
+		applicationsReady[rowName] = applicationList.Items[idx].Status.Conditions.IsTrueFor(capabilitiesv1beta1.ApplicationReadyConditionType)
+	}
+
+	status := &developerAccountSetRowsStatus{Rows: int32(len(r.rows.Rows))}
+	for _, row := range r.rows.Rows {
+		if accountsReady[row.Name()] && usersReady[row.Name()] && (!withApplication || applicationsReady[row.Name()]) {
+			status.ReadyRows++
+			continue
+		}
+
+		if len(status.NotReadyRows) < capabilitiesv1beta1.DeveloperAccountSetMaxNotReadyRows {
+			status.NotReadyRows = append(status.NotReadyRows, row.Name())
+		}
+	}
+
+	removedRows := make([]string, 0, len(removed))
+	for rowName := range removed {
+		removedRows = append(removedRows, rowName)
+	}
+	sort.Strings(removedRows)
+
+	if !r.setCR.IsPruneEnabled() || int32(len(removedRows)) > r.setCR.MaxDeletions() {
+		if len(removedRows) > 0 {
+			r.logger.Info("Rows removed from source not deleted", "rows", len(removedRows), "prune", r.setCR.IsPruneEnabled(), "maxDeletions", r.setCR.MaxDeletions())
+		}
+
+		status.PendingDeletions = int32(len(removedRows))
+		for _, rowName := range removedRows {
+			if len(status.PendingDeletionRows) == capabilitiesv1beta1.DeveloperAccountSetMaxNotReadyRows {
+				break
+			}
+			status.PendingDeletionRows = append(status.PendingDeletionRows, rowName)
+		}
+
+		return status, nil
+	}
+
+	for _, rowName := range removedRows {
+		for _, obj := range removed[rowName] {
+			if err := r.deleteRowResource(obj); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return status, nil
+}
+
+func (r *DeveloperAccountSetRowsReconciler) deleteRowResource(obj client.Object) error {
+	r.logger.Info("Row removed from source, deleting", "kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+	err := r.DeleteResource(obj)
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DeveloperAccountSetRowsReconciler) desiredRowResources(templates *developerAccountSetTemplates, row developerAccountSetRow) (*developerAccountSetRowResources, error) {
+	templateFldPath := field.NewPath("spec").Child("template")
+	objectMeta := r.desiredObjectMeta(row)
+
+	developerAccount := &capabilitiesv1beta1.DeveloperAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.DeveloperAccountKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: *objectMeta.DeepCopy(),
+	}
+	err := renderDeveloperAccountSetTemplate(templates.developerAccount, row, &developerAccount.Spec, templateFldPath.Child("developerAccount"))
+	if err != nil {
+		return nil, err
+	}
+	if r.setCR.Spec.ProviderAccountRef != nil {
+		developerAccount.Spec.ProviderAccountRef = r.setCR.Spec.ProviderAccountRef
+	}
+
+	developerUser := &capabilitiesv1beta1.DeveloperUser{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.DeveloperUserKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: *objectMeta.DeepCopy(),
+	}
+	err = renderDeveloperAccountSetTemplate(templates.developerUser, row, &developerUser.Spec, templateFldPath.Child("developerUser"))
+	if err != nil {
+		return nil, err
+	}
+	developerUser.Spec.DeveloperAccountRef = corev1.LocalObjectReference{Name: objectMeta.Name}
+	// the generated user is the admin user the account is signed up with
+	if developerUser.Spec.Role == nil {
+		developerUser.Spec.Role = ptr.To("admin")
+	}
+	if r.setCR.Spec.ProviderAccountRef != nil {
+		developerUser.Spec.ProviderAccountRef = r.setCR.Spec.ProviderAccountRef
+	}
+
+	resources := &developerAccountSetRowResources{
+		developerAccount: developerAccount,
+		developerUser:    developerUser,
+	}
+
+	if templates.application != nil {
+		application := &capabilitiesv1beta1.Application{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       capabilitiesv1beta1.ApplicationKind,
+				APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+			},
+			ObjectMeta: *objectMeta.DeepCopy(),
+		}
+		err = renderDeveloperAccountSetTemplate(templates.application, row, &application.Spec, templateFldPath.Child("application"))
+		if err != nil {
+			return nil, err
+		}
+		// the provider account of applications is the provider account of the developer account
+		application.Spec.AccountCR = &corev1.LocalObjectReference{Name: objectMeta.Name}
+
+		resources.application = application
+	}
+
+	objs := []client.Object{resources.developerAccount, resources.developerUser}
+	if resources.application != nil {
+		objs = append(objs, resources.application)
+	}
+
+	for _, obj := range objs {
+		if err := r.SetControllerOwnerReference(r.setCR, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// desiredObjectMeta returns the metadata of the resources of the row, named after the set and the row
+func (r *DeveloperAccountSetRowsReconciler) desiredObjectMeta(row developerAccountSetRow) *metav1.ObjectMeta {
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(r.setCR.GetAnnotations())
+
+	return &metav1.ObjectMeta{
+		Name:      fmt.Sprintf("%s-%s", r.setCR.Name, row.Name()),
+		Namespace: r.setCR.Namespace,
+		Labels: map[string]string{
+			developerAccountSetLabelKey:    r.setCR.Name,
+			developerAccountSetRowLabelKey: row.Name(),
+		},
+		Annotations: map[string]string{
+			"insecure_skip_verify": strconv.FormatBool(insecureSkipVerify),
+		},
+	}
+}
+
+func (r *DeveloperAccountSetRowsReconciler) developerAccountMutator(existingObj, desiredObj client.Object) (bool, error) {
+	existing, ok := existingObj.(*capabilitiesv1beta1.DeveloperAccount)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.DeveloperAccount", existingObj)
+	}
+	desired, ok := desiredObj.(*capabilitiesv1beta1.DeveloperAccount)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.DeveloperAccount", desiredObj)
+	}
+
+	updated, err := r.ensureRowResourceMeta(existing, desired)
+	if err != nil {
+		return false, err
+	}
+
+	if !reflect.DeepEqual(existing.Spec, desired.Spec) {
+		diff := cmp.Diff(existing.Spec, desired.Spec)
+		r.logger.Info(fmt.Sprintf("%s spec has changed: %s", helper.ObjectInfo(desired), diff))
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
+
+func (r *DeveloperAccountSetRowsReconciler) developerUserMutator(existingObj, desiredObj client.Object) (bool, error) {
+	existing, ok := existingObj.(*capabilitiesv1beta1.DeveloperUser)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.DeveloperUser", existingObj)
+	}
+	desired, ok := desiredObj.(*capabilitiesv1beta1.DeveloperUser)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.DeveloperUser", desiredObj)
+	}
+
+	updated, err := r.ensureRowResourceMeta(existing, desired)
+	if err != nil {
+		return false, err
+	}
+
+	if !reflect.DeepEqual(existing.Spec, desired.Spec) {
+		diff := cmp.Diff(existing.Spec, desired.Spec)
+		r.logger.Info(fmt.Sprintf("%s spec has changed: %s", helper.ObjectInfo(desired), diff))
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
+
+func (r *DeveloperAccountSetRowsReconciler) applicationMutator(existingObj, desiredObj client.Object) (bool, error) {
+	existing, ok := existingObj.(*capabilitiesv1beta1.Application)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.Application", existingObj)
+	}
+	desired, ok := desiredObj.(*capabilitiesv1beta1.Application)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.Application", desiredObj)
+	}
+
+	updated, err := r.ensureRowResourceMeta(existing, desired)
+	if err != nil {
+		return false, err
+	}
+
+	if !reflect.DeepEqual(existing.Spec, desired.Spec) {
+		diff := cmp.Diff(existing.Spec, desired.Spec)
+		r.logger.Info(fmt.Sprintf("%s spec has changed: %s", helper.ObjectInfo(desired), diff))
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
+
+// ensureRowResourceMeta reconciles the labels, annotations and owner of the resources of the row
+func (r *DeveloperAccountSetRowsReconciler) ensureRowResourceMeta(existing, desired client.Object) (bool, error) {
+	// Metadata labels and annotations
+	updated := helper.EnsureObjectMeta(existing, desired)
+
+	// OwnerRefenrence
+	updatedTmp, err := r.EnsureOwnerReference(r.setCR, existing)
+	if err != nil {
+		return false, err
+	}
+
+	return updated || updatedTmp, nil
+}
+
+func parseDeveloperAccountSetTemplates(spec *capabilitiesv1beta1.DeveloperAccountSetTemplateSpec) (*developerAccountSetTemplates, error) {
+	templateFldPath := field.NewPath("spec").Child("template")
+	templates := &developerAccountSetTemplates{}
+
+	var err error
+	templates.developerAccount, err = parseDeveloperAccountSetTemplate("developerAccount", spec.DeveloperAccount, templateFldPath.Child("developerAccount"))
+	if err != nil {
+		return nil, err
+	}
+
+	templates.developerUser, err = parseDeveloperAccountSetTemplate("developerUser", spec.DeveloperUser, templateFldPath.Child("developerUser"))
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Application != "" {
+		templates.application, err = parseDeveloperAccountSetTemplate("application", spec.Application, templateFldPath.Child("application"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+func parseDeveloperAccountSetTemplate(name, text string, fldPath *field.Path) (*template.Template, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"quote": strconv.Quote}).
+		Parse(text)
+	if err != nil {
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: field.ErrorList{field.Invalid(fldPath, text, err.Error())},
+		}
+	}
+
+	return tmpl, nil
+}
+
+// renderDeveloperAccountSetTemplate renders the template with the row columns and reads the resulting spec.
+// Unknown spec fields are rejected
+func renderDeveloperAccountSetTemplate(tmpl *template.Template, row developerAccountSetRow, spec interface{}, fldPath *field.Path) error {
+	invalidError := func(err error) error {
+		return &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: field.ErrorList{field.Invalid(fldPath, tmpl.Name(), fmt.Sprintf("row %s: %s", row.Name(), err))},
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string(row)); err != nil {
+		return invalidError(err)
+	}
+
+	if err := yaml.UnmarshalStrict(buf.Bytes(), spec); err != nil {
+		return invalidError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func getDeveloperAccountSetCR() *capabilitiesv1beta1.DeveloperAccountSet {
+	return &capabilitiesv1beta1.DeveloperAccountSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.DeveloperAccountSetKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "partners", Namespace: "test", UID: "set-uid"},
+		Spec: capabilitiesv1beta1.DeveloperAccountSetSpec{
+			Source: capabilitiesv1beta1.DeveloperAccountSetSourceSpec{
+				ConfigMap: &capabilitiesv1beta1.DeveloperAccountSetConfigMapSourceSpec{Name: "partners", Key: "partners.csv"},
+			},
+			Template: capabilitiesv1beta1.DeveloperAccountSetTemplateSpec{
+				DeveloperAccount: "orgName: {{ quote .orgName }}\n",
+				DeveloperUser:    "username: {{ quote .name }}\nemail: {{ quote .email }}\ninvitation: {}\n",
+				Application:      "name: {{ quote .orgName }}\ndescription: partner\napplicationPlanName: basic\nproductCR:\n  name: product\n",
+			},
+			ProviderAccountRef: &corev1.LocalObjectReference{Name: "provider"},
+		},
+	}
+}
+
+func developerAccountSetTestRows(names ...string) *developerAccountSetRows {
+	rows := &developerAccountSetRows{Revision: "1"}
+	for _, name := range names {
+		rows.Rows = append(rows.Rows, developerAccountSetRow{
+			"name":    name,
+			"orgName": strings.ToUpper(name),
+			"email":   "admin@" + name + ".example.com",
+		})
+	}
+
+	return rows
+}
+
+func TestDeveloperAccountSetRowsReconciler_Reconcile(t *testing.T) {
+	setCR := getDeveloperAccountSetCR()
+	baseReconciler := getBaseReconciler(setCR)
+	logger := logf.Log.WithName("developer account set test")
+
+	// create the resources of both rows
+	status, err := NewDeveloperAccountSetRowsReconciler(baseReconciler, setCR, developerAccountSetTestRows("acme", "globex"), logger).Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if status.Rows != 2 || status.ReadyRows != 0 || !reflect.DeepEqual(status.NotReadyRows, []string{"acme", "globex"}) {
+		t.Errorf("status = %+v, want 2 rows not ready", status)
+	}
+
+	key := types.NamespacedName{Name: "partners-acme", Namespace: "test"}
+	account := &capabilitiesv1beta1.DeveloperAccount{}
+	if err := baseReconciler.Client().Get(baseReconciler.Context(), key, account); err != nil {
+		t.Fatalf("developer account not generated: %v", err)
+	}
+	if account.Spec.OrgName != "ACME" || account.Spec.ProviderAccountRef.Name != "provider" || !baseReconciler.HasOwnerReference(setCR, account) {
+		t.Errorf("developer account = %+v, want rendered spec owned by the set", account)
+	}
+
+	user := &capabilitiesv1beta1.DeveloperUser{}
+	if err := baseReconciler.Client().Get(baseReconciler.Context(), key, user); err != nil {
+		t.Fatalf("developer user not generated: %v", err)
+	}
+	if user.Spec.Email != "admin@acme.example.com" || user.Spec.DeveloperAccountRef.Name != "partners-acme" || *user.Spec.Role != "admin" || !user.IsInvited() {
+		t.Errorf("developer user = %+v, want invited admin of the generated account", user.Spec)
+	}
+
+	application := &capabilitiesv1beta1.Application{}
+	if err := baseReconciler.Client().Get(baseReconciler.Context(), key, application); err != nil {
+		t.Fatalf("application not generated: %v", err)
+	}
+	if application.Spec.AccountCR.Name != "partners-acme" {
+		t.Errorf("application account = %s, want the generated account", application.Spec.AccountCR.Name)
+	}
+
+	// acme resources become ready
+	readyCondition := func(conditionType common.ConditionType) common.Conditions {
+		return common.Conditions{{Type: conditionType, Status: corev1.ConditionTrue}}
+	}
+	account.Status.Conditions = readyCondition(capabilitiesv1beta1.DeveloperAccountReadyConditionType)
+	user.Status.Conditions = readyCondition(capabilitiesv1beta1.DeveloperUserReadyConditionType)
+	application.Status.Conditions = readyCondition(capabilitiesv1beta1.ApplicationReadyConditionType)
+	// generated kinds have no status subresource in the fake client
+	for _, obj := range []client.Object{account, user, application} {
+		if err := baseReconciler.Client().Update(baseReconciler.Context(), obj); err != nil {
+			t.Fatalf("status update error = %v", err)
+		}
+	}
+
+	// resources not generated by the set are never deleted
+	unowned := &capabilitiesv1beta1.DeveloperAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "initech",
+			Namespace: "test",
+			Labels:    map[string]string{developerAccountSetLabelKey: "partners", developerAccountSetRowLabelKey: "initech"},
+		},
+	}
+	if err := baseReconciler.Client().Create(baseReconciler.Context(), unowned); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// globex row removed
+	status, err = NewDeveloperAccountSetRowsReconciler(baseReconciler, setCR, developerAccountSetTestRows("acme"), logger).Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if status.Rows != 1 || status.ReadyRows != 1 || len(status.NotReadyRows) != 0 {
+		t.Errorf("status = %+v, want 1 row ready", status)
+	}
+
+	accountList := &capabilitiesv1beta1.DeveloperAccountList{}
+	if err := baseReconciler.Client().List(baseReconciler.Context(), accountList); err != nil || len(accountList.Items) != 2 {
+		t.Errorf("developer accounts = %d (%v), want acme and the unowned account", len(accountList.Items), err)
+	}
+	userList := &capabilitiesv1beta1.DeveloperUserList{}
+	if err := baseReconciler.Client().List(baseReconciler.Context(), userList); err != nil || len(userList.Items) != 1 {
+		t.Errorf("developer users = %d (%v), want acme user", len(userList.Items), err)
+	}
+
+	// application template removed
+	setCR.Spec.Template.Application = ""
+	status, err = NewDeveloperAccountSetRowsReconciler(baseReconciler, setCR, developerAccountSetTestRows("acme"), logger).Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if status.ReadyRows != 1 {
+		t.Errorf("ready rows = %d, want 1", status.ReadyRows)
+	}
+	applicationList := &capabilitiesv1beta1.ApplicationList{}
+	if err := baseReconciler.Client().List(baseReconciler.Context(), applicationList); err != nil || len(applicationList.Items) != 0 {
+		t.Errorf("applications = %d (%v), want none", len(applicationList.Items), err)
+	}
+}
+
+func TestDeveloperAccountSetRowsReconciler_InvalidTemplate(t *testing.T) {
+	cases := []struct {
+		name        string
+		template    string
+		expectedErr string
+	}{
+		{
+			name:        "parse error",
+			template:    "orgName: {{ .orgName",
+			expectedErr: "spec.template.developerAccount",
+		},
+		{
+			name:        "missing column",
+			template:    "orgName: {{ quote .company }}\n",
+			expectedErr: "row acme",
+		},
+		{
+			name:        "unknown field",
+			template:    "orgName: {{ quote .orgName }}\norganization: acme\n",
+			expectedErr: "unknown field",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			setCR := getDeveloperAccountSetCR()
+			setCR.Spec.Template.DeveloperAccount = tc.template
+			baseReconciler := getBaseReconciler(setCR)
+			logger := logf.Log.WithName("developer account set test")
+
+			_, err := NewDeveloperAccountSetRowsReconciler(baseReconciler, setCR, developerAccountSetTestRows("acme"), logger).Reconcile()
+			if !helper.IsInvalidSpecError(err) || !strings.Contains(err.Error(), tc.expectedErr) {
+				subT.Fatalf("error = %v, want invalid spec error %s", err, tc.expectedErr)
+			}
+
+			// nothing is created when any row is invalid
+			accountList := &capabilitiesv1beta1.DeveloperAccountList{}
+			if err := baseReconciler.Client().List(baseReconciler.Context(), accountList); err != nil || len(accountList.Items) != 0 {
+				subT.Errorf("developer accounts = %d (%v), want none", len(accountList.Items), err)
+			}
+		})
+	}
+}
+
+func TestDeveloperAccountSetRowsReconciler_PendingDeletions(t *testing.T) {
+	cases := []struct {
+		name                string
+		prune               *bool
+		maxDeletions        *int32
+		expectedAccounts    int
+		expectedPendingRows []string
+	}{
+		{
+			name:             "removed rows deleted",
+			expectedAccounts: 1,
+		},
+		{
+			name:                "prune disabled",
+			prune:               ptr.To(false),
+			expectedAccounts:    3,
+			expectedPendingRows: []string{"globex", "initech"},
+		},
+		{
+			name:                "more rows removed than max deletions",
+			maxDeletions:        ptr.To(int32(1)),
+			expectedAccounts:    3,
+			expectedPendingRows: []string{"globex", "initech"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			setCR := getDeveloperAccountSetCR()
+			setCR.Spec.Prune = tc.prune
+			setCR.Spec.MaxDeletions = tc.maxDeletions
+			baseReconciler := getBaseReconciler(setCR)
+			logger := logf.Log.WithName("developer account set test")
+
+			_, err := NewDeveloperAccountSetRowsReconciler(baseReconciler, setCR, developerAccountSetTestRows("acme", "globex", "initech"), logger).Reconcile()
+			if err != nil {
+				subT.Fatalf("Reconcile() error = %v", err)
+			}
+
+			status, err := NewDeveloperAccountSetRowsReconciler(baseReconciler, setCR, developerAccountSetTestRows("acme"), logger).Reconcile()
+			if err != nil {
+				subT.Fatalf("Reconcile() error = %v", err)
+			}
+			if int(status.PendingDeletions) != len(tc.expectedPendingRows) || !reflect.DeepEqual(status.PendingDeletionRows, tc.expectedPendingRows) {
+				subT.Errorf("pending deletions = %d %v, want %v", status.PendingDeletions, status.PendingDeletionRows, tc.expectedPendingRows)
+			}
+
+			accountList := &capabilitiesv1beta1.DeveloperAccountList{}
+			if err := baseReconciler.Client().List(baseReconciler.Context(), accountList); err != nil || len(accountList.Items) != tc.expectedAccounts {
+				subT.Errorf("developer accounts = %d (%v), want %d", len(accountList.Items), err, tc.expectedAccounts)
+			}
+		})
+	}
+}
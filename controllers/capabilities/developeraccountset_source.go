@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// developerAccountSetNameColumn is the column with the unique name of the row.
+	// The name identifies the custom resources generated for the row
+	developerAccountSetNameColumn = "name"
+
+	// developerAccountSetDefaultNameAttribute is the member entry attribute of the name column by default
+	developerAccountSetDefaultNameAttribute = "uid"
+)
+
+// developerAccountSetRow is a row read from the source, keyed by column name
+type developerAccountSetRow map[string]string
+
+// Name returns the unique name of the row
+func (r developerAccountSetRow) Name() string {
+	return r[developerAccountSetNameColumn]
+}
+
+// developerAccountSetRows are the rows read from the source, sorted by name
+type developerAccountSetRows struct {
+	Rows     []developerAccountSetRow
+	Revision string
+}
+
+// DeveloperAccountSetSourceReader reads the rows from ConfigMap and directory group sources
+type DeveloperAccountSetSourceReader struct {
+	ctx       context.Context
+	client    client.Client
+	namespace string
+}
+
+func NewDeveloperAccountSetSourceReader(ctx context.Context, cl client.Client, namespace string) *DeveloperAccountSetSourceReader {
+	return &DeveloperAccountSetSourceReader{
+		ctx:       ctx,
+		client:    cl,
+		namespace: namespace,
+	}
+}
+
+// Read reads and validates the rows of the source
+func (r *DeveloperAccountSetSourceReader) Read(source *capabilitiesv1beta1.DeveloperAccountSetSourceSpec) (*developerAccountSetRows, error) {
+	fldPath := field.NewPath("spec").Child("source")
+
+	var rows []developerAccountSetRow
+	var revision string
+	var sourceRef interface{}
+	var err error
+
+	switch {
+	case source.ConfigMap != nil:
+		fldPath = fldPath.Child("configMap")
+		sourceRef = source.ConfigMap
+		rows, revision, err = r.readConfigMap(source.ConfigMap, fldPath)
+	case source.LDAPGroup != nil:
+		fldPath = fldPath.Child("ldapGroup")
+		sourceRef = source.LDAPGroup
+		rows, revision, err = r.readLDAPGroup(source.LDAPGroup, fldPath)
+	default:
+		return nil, openAPISourceInvalidError(fldPath, source, "source not set")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty source is rather a broken source than the removal of every row
+	if len(rows) == 0 {
+		return nil, openAPISourceInvalidError(fldPath, sourceRef, "source has no rows")
+	}
+
+	if err := validateDeveloperAccountSetRows(rows); err != nil {
+		return nil, openAPISourceInvalidError(fldPath, sourceRef, err.Error())
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name() < rows[j].Name() })
+	return &developerAccountSetRows{Rows: rows, Revision: revision}, nil
+}
+
+func (r *DeveloperAccountSetSourceReader) readConfigMap(source *capabilitiesv1beta1.DeveloperAccountSetConfigMapSourceSpec, fldPath *field.Path) ([]developerAccountSetRow, string, error) {
+	data, revision, err := r.configMapKey(source.Name, source.Key, fldPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	format := source.Format
+	if format == "" {
+		format = strings.TrimPrefix(path.Ext(source.Key), ".")
+	}
+
+	var rows []developerAccountSetRow
+	switch format {
+	case capabilitiesv1beta1.DeveloperAccountSetCSVFormat:
+		rows, err = parseDeveloperAccountSetCSV(data)
+	case capabilitiesv1beta1.DeveloperAccountSetYAMLFormat, "yml":
+		rows, err = parseDeveloperAccountSetYAML(data)
+	default:
+		return nil, "", openAPISourceInvalidError(fldPath.Child("format"), source.Format, "format not set and not guessed from the key extension")
+	}
+	if err != nil {
+		return nil, "", openAPISourceInvalidError(fldPath, source, err.Error())
+	}
+
+	return rows, revision, nil
+}
+
+func (r *DeveloperAccountSetSourceReader) readLDAPGroup(source *capabilitiesv1beta1.DeveloperAccountSetLDAPGroupSourceSpec, fldPath *field.Path) ([]developerAccountSetRow, string, error) {
+	directoryFldPath := fldPath.Child("directoryRef")
+	data, revision, err := r.configMapKey(source.DirectoryRef.Name, source.DirectoryRef.Key, directoryFldPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	directory, err := controllerhelper.NewLDIFDirectory(data)
+	if err != nil {
+		return nil, "", openAPISourceInvalidError(directoryFldPath, source.DirectoryRef, err.Error())
+	}
+
+	rows, err := readDeveloperAccountSetDirectoryGroup(directory, source)
+	if err != nil {
+		if errors.Is(err, controllerhelper.ErrDirectoryGroupNotFound) || errors.Is(err, controllerhelper.ErrDirectoryMemberNotFound) {
+			return nil, "", openAPISourceInvalidError(fldPath, source, err.Error())
+		}
+
+		return nil, "", fmt.Errorf("error reading directory group %s: %w", source.GroupDN, err)
+	}
+
+	return rows, revision, nil
+}
+
+func (r *DeveloperAccountSetSourceReader) configMapKey(name, key string, fldPath *field.Path) ([]byte, string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: name, Namespace: r.namespace}, configMap); err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, "", openAPISourceInvalidError(fldPath, name, "ConfigMap not found")
+		}
+
+		// unexpected error
+		return nil, "", err
+	}
+
+	if value, ok := configMap.Data[key]; ok {
+		return []byte(value), configMap.ResourceVersion, nil
+	}
+
+	if value, ok := configMap.BinaryData[key]; ok {
+		return value, configMap.ResourceVersion, nil
+	}
+
+	return nil, "", openAPISourceInvalidError(fldPath, key, "ConfigMap key not found")
+}
+
+// readDeveloperAccountSetDirectoryGroup returns one row per member of the group.
+// Every attribute of the member entry is a column, mapped attributes are added as the given columns
+func readDeveloperAccountSetDirectoryGroup(directory controllerhelper.Directory, source *capabilitiesv1beta1.DeveloperAccountSetLDAPGroupSourceSpec) ([]developerAccountSetRow, error) {
+	members, err := directory.GroupMembers(source.GroupDN)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]string{developerAccountSetNameColumn: developerAccountSetDefaultNameAttribute}
+	for column, attribute := range source.Attributes {
+		attributes[column] = attribute
+	}
+
+	rows := make([]developerAccountSetRow, 0, len(members))
+	for idx := range members {
+		row := developerAccountSetRow{}
+		for attribute := range members[idx].Attributes {
+			row[attribute] = members[idx].Attribute(attribute)
+		}
+		for column, attribute := range attributes {
+			row[column] = members[idx].Attribute(attribute)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseDeveloperAccountSetCSV reads CSV rows. The header line names the columns
+func parseDeveloperAccountSetCSV(data []byte) ([]developerAccountSetRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("CSV header line not found")
+		}
+
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	rows := []developerAccountSetRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		row := developerAccountSetRow{}
+		for idx, column := range header {
+			row[strings.TrimSpace(column)] = strings.TrimSpace(record[idx])
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseDeveloperAccountSetYAML reads YAML rows: a list of objects with scalar values
+func parseDeveloperAccountSetYAML(data []byte) ([]developerAccountSetRow, error) {
+	var objects []map[string]interface{}
+	if err := yaml.Unmarshal(data, &objects); err != nil {
+		return nil, fmt.Errorf("invalid YAML, expected a list of objects: %w", err)
+	}
+
+	rows := make([]developerAccountSetRow, 0, len(objects))
+	for idx, object := range objects {
+		row := developerAccountSetRow{}
+		for column, value := range object {
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				return nil, fmt.Errorf("row %d: column %s is not a scalar value", idx, column)
+			case nil:
+				row[column] = ""
+			default:
+				row[column] = fmt.Sprint(value)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// validateDeveloperAccountSetRows checks rows have unique names, valid as part of custom resource names
+func validateDeveloperAccountSetRows(rows []developerAccountSetRow) error {
+	names := map[string]bool{}
+	for idx, row := range rows {
+		name := row.Name()
+		if name == "" {
+			return fmt.Errorf("row %d: %s column not set", idx, developerAccountSetNameColumn)
+		}
+
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return fmt.Errorf("row %d: invalid name %q: %s", idx, name, strings.Join(errs, ", "))
+		}
+
+		if names[name] {
+			return fmt.Errorf("row %d: duplicated name %q", idx, name)
+		}
+		names[name] = true
+	}
+
+	return nil
+}
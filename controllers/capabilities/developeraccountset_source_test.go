@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const developerAccountSetTestLDIF = `dn: cn=partners,ou=groups,dc=example,dc=com
+cn: partners
+member: uid=acme,ou=people,dc=example,dc=com
+member: uid=globex,ou=people,dc=example,dc=com
+
+dn: uid=acme,ou=people,dc=example,dc=com
+uid: acme
+o: Acme
+mail: admin@acme.example.com
+
+dn: uid=globex,ou=people,dc=example,dc=com
+uid: globex
+o: Globex
+mail: admin@globex.example.com
+`
+
+func TestDeveloperAccountSetSourceReader_ReadConfigMap(t *testing.T) {
+	cases := []struct {
+		name          string
+		key           string
+		format        string
+		data          string
+		expectedNames []string
+		expectedErr   string
+	}{
+		{
+			name:          "csv",
+			key:           "partners.csv",
+			data:          "name, orgName, email\n# disabled partner\nglobex, Globex, admin@globex.example.com\nacme, \"Acme, Inc\", admin@acme.example.com\n",
+			expectedNames: []string{"acme", "globex"},
+		},
+		{
+			name:          "yaml",
+			key:           "partners",
+			format:        "yaml",
+			data:          "- name: acme\n  orgName: Acme\n  seats: 10\n- name: globex\n  orgName: Globex\n",
+			expectedNames: []string{"acme", "globex"},
+		},
+		{
+			name:        "format not guessed",
+			key:         "partners",
+			data:        "name\nacme\n",
+			expectedErr: "format not set",
+		},
+		{
+			name:        "csv with missing columns",
+			key:         "partners.csv",
+			data:        "name,orgName\nacme\n",
+			expectedErr: "invalid CSV",
+		},
+		{
+			name:        "yaml nested value",
+			key:         "partners.yaml",
+			data:        "- name: acme\n  contacts: [a, b]\n",
+			expectedErr: "not a scalar value",
+		},
+		{
+			name:        "csv header only",
+			key:         "partners.csv",
+			data:        "name,orgName,email\n",
+			expectedErr: "source has no rows",
+		},
+		{
+			name:        "empty yaml",
+			key:         "partners.yaml",
+			data:        "[]\n",
+			expectedErr: "source has no rows",
+		},
+		{
+			name:        "row without name",
+			key:         "partners.csv",
+			data:        "orgName\nAcme\n",
+			expectedErr: "name column not set",
+		},
+		{
+			name:        "invalid name",
+			key:         "partners.csv",
+			data:        "name\nAcme_Inc\n",
+			expectedErr: "invalid name",
+		},
+		{
+			name:        "duplicated name",
+			key:         "partners.csv",
+			data:        "name\nacme\nacme\n",
+			expectedErr: "duplicated name",
+		},
+		{
+			name:        "key not found",
+			key:         "missing.csv",
+			expectedErr: "ConfigMap key not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "partners", Namespace: "test", ResourceVersion: "42"},
+				Data:       map[string]string{},
+			}
+			if tc.data != "" {
+				configMap.Data[tc.key] = tc.data
+			}
+			baseReconciler := getBaseReconciler(configMap)
+			reader := NewDeveloperAccountSetSourceReader(baseReconciler.Context(), baseReconciler.Client(), "test")
+
+			rows, err := reader.Read(&capabilitiesv1beta1.DeveloperAccountSetSourceSpec{
+				ConfigMap: &capabilitiesv1beta1.DeveloperAccountSetConfigMapSourceSpec{Name: "partners", Key: tc.key, Format: tc.format},
+			})
+			if tc.expectedErr != "" {
+				if !helper.IsInvalidSpecError(err) || !strings.Contains(err.Error(), tc.expectedErr) {
+					subT.Fatalf("error = %v, want invalid spec error %s", err, tc.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				subT.Fatalf("Read() error = %v", err)
+			}
+			if rows.Revision != "42" {
+				subT.Errorf("revision = %s, want the configmap resource version", rows.Revision)
+			}
+			if len(rows.Rows) != len(tc.expectedNames) {
+				subT.Fatalf("rows = %v, want %v", rows.Rows, tc.expectedNames)
+			}
+			for idx, name := range tc.expectedNames {
+				if rows.Rows[idx].Name() != name {
+					subT.Errorf("row %d name = %s, want %s", idx, rows.Rows[idx].Name(), name)
+				}
+			}
+		})
+	}
+}
+
+func TestDeveloperAccountSetSourceReader_ReadLDAPGroup(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "directory", Namespace: "test", ResourceVersion: "7"},
+		Data:       map[string]string{"directory.ldif": developerAccountSetTestLDIF},
+	}
+	baseReconciler := getBaseReconciler(configMap)
+	reader := NewDeveloperAccountSetSourceReader(baseReconciler.Context(), baseReconciler.Client(), "test")
+
+	source := &capabilitiesv1beta1.DeveloperAccountSetSourceSpec{
+		LDAPGroup: &capabilitiesv1beta1.DeveloperAccountSetLDAPGroupSourceSpec{
+			GroupDN: "cn=partners,ou=groups,dc=example,dc=com",
+			DirectoryRef: corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "directory"},
+				Key:                  "directory.ldif",
+			},
+			Attributes: map[string]string{"orgName": "o", "email": "mail"},
+		},
+	}
+
+	rows, err := reader.Read(source)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if rows.Revision != "7" || len(rows.Rows) != 2 {
+		t.Fatalf("rows = %v, revision %s", rows.Rows, rows.Revision)
+	}
+	if rows.Rows[0].Name() != "acme" || rows.Rows[0]["orgName"] != "Acme" || rows.Rows[0]["email"] != "admin@acme.example.com" {
+		t.Errorf("row = %v, want mapped attributes of acme", rows.Rows[0])
+	}
+	if rows.Rows[1]["o"] != "Globex" {
+		t.Errorf("row = %v, want every attribute as column", rows.Rows[1])
+	}
+
+	source.LDAPGroup.GroupDN = "cn=unknown,ou=groups,dc=example,dc=com"
+	_, err = reader.Read(source)
+	if !helper.IsInvalidSpecError(err) || !strings.Contains(err.Error(), "group not found") {
+		t.Errorf("error = %v, want invalid spec error group not found", err)
+	}
+}
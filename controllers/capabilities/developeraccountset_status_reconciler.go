@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type DeveloperAccountSetStatusReconciler struct {
+	*reconcilers.BaseReconciler
+	resource       *capabilitiesv1beta1.DeveloperAccountSet
+	revision       string
+	rowsStatus     *developerAccountSetRowsStatus
+	reconcileError error
+	logger         logr.Logger
+}
+
+func NewDeveloperAccountSetStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccountSet, revision string, rowsStatus *developerAccountSetRowsStatus, reconcileError error) *DeveloperAccountSetStatusReconciler {
+	return &DeveloperAccountSetStatusReconciler{
+		BaseReconciler: b,
+		resource:       resource,
+		revision:       revision,
+		rowsStatus:     rowsStatus,
+		reconcileError: reconcileError,
+		logger:         b.Logger().WithValues("Status Reconciler", resource.Name),
+	}
+}
+
+func (s *DeveloperAccountSetStatusReconciler) Reconcile() (reconcile.Result, error) {
+	s.logger.V(1).Info("START")
+
+	newStatus := s.calculateStatus()
+
+	equalStatus := s.resource.Status.Equals(newStatus, s.logger)
+	s.logger.V(1).Info("Status", "status is different", !equalStatus)
+	s.logger.V(1).Info("Status", "generation is different", s.resource.Generation != s.resource.Status.ObservedGeneration)
+	if equalStatus && s.resource.Generation == s.resource.Status.ObservedGeneration {
+		// Steady state
+		s.logger.V(1).Info("Status steady state, status was not updated")
+		return reconcile.Result{}, nil
+	}
+
+	// Save the generation number we acted on, otherwise we might wrongfully indicate
+	// that we've seen a spec update when we retry.
+	// TODO: This can clobber an update if we allow multiple agents to write to the
+	// same status.
+	newStatus.ObservedGeneration = s.resource.Generation
+
+	s.logger.V(1).Info("Updating Status", "sequence no:", fmt.Sprintf("sequence No: %v->%v", s.resource.Status.ObservedGeneration, newStatus.ObservedGeneration))
+
+	s.resource.Status = *newStatus
+	updateErr := s.Client().Status().Update(s.Context(), s.resource)
+	if updateErr != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(updateErr) {
+			s.logger.Info("Failed to update status: resource might just be outdated")
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to update status: %w", updateErr)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (s *DeveloperAccountSetStatusReconciler) calculateStatus() *capabilitiesv1beta1.DeveloperAccountSetStatus {
+	newStatus := &capabilitiesv1beta1.DeveloperAccountSetStatus{
+		Revision:            s.resource.Status.Revision,
+		Rows:                s.resource.Status.Rows,
+		ReadyRows:           s.resource.Status.ReadyRows,
+		NotReadyRows:        s.resource.Status.NotReadyRows,
+		PendingDeletions:    s.resource.Status.PendingDeletions,
+		PendingDeletionRows: s.resource.Status.PendingDeletionRows,
+		ObservedGeneration:  s.resource.Status.ObservedGeneration,
+		Conditions:          s.resource.Status.Conditions.Copy(),
+	}
+
+	// rows are only reported after a complete synchronization
+	if s.reconcileError == nil && s.rowsStatus != nil {
+		newStatus.Revision = s.revision
+		newStatus.Rows = s.rowsStatus.Rows
+		newStatus.ReadyRows = s.rowsStatus.ReadyRows
+		newStatus.NotReadyRows = s.rowsStatus.NotReadyRows
+		newStatus.PendingDeletions = s.rowsStatus.PendingDeletions
+		newStatus.PendingDeletionRows = s.rowsStatus.PendingDeletionRows
+	}
+
+	newStatus.Conditions.SetCondition(s.readyCondition(newStatus))
+	newStatus.Conditions.SetCondition(s.invalidCondition())
+	newStatus.Conditions.SetCondition(s.failedCondition())
+
+	return newStatus
+}
+
+func (s *DeveloperAccountSetStatusReconciler) readyCondition(newStatus *capabilitiesv1beta1.DeveloperAccountSetStatus) common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperAccountSetReadyConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.reconcileError != nil {
+		return condition
+	}
+
+	condition.Message = fmt.Sprintf("%d of %d rows ready", newStatus.ReadyRows, newStatus.Rows)
+	if newStatus.PendingDeletions > 0 {
+		condition.Message = fmt.Sprintf("%s, %d rows pending deletion", condition.Message, newStatus.PendingDeletions)
+	}
+
+	// rows kept on purpose, with pruning disabled, do not prevent the set from being ready
+	deletionsBlocked := newStatus.PendingDeletions > 0 && s.resource.IsPruneEnabled()
+	if newStatus.ReadyRows == newStatus.Rows && !deletionsBlocked {
+		condition.Status = corev1.ConditionTrue
+	}
+
+	return condition
+}
+
+func (s *DeveloperAccountSetStatusReconciler) invalidCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperAccountSetInvalidConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if helper.IsInvalidSpecError(s.reconcileError) {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.reconcileError.Error()
+	}
+
+	return condition
+}
+
+func (s *DeveloperAccountSetStatusReconciler) failedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperAccountSetFailedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	// only activate this condition when others are false and still there is an error
+	if s.reconcileError != nil && s.invalidCondition().IsFalse() {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.reconcileError.Error()
+	}
+
+	return condition
+}
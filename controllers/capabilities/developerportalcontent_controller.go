@@ -0,0 +1,170 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DeveloperPortalContentReconciler reconciles a DeveloperPortalContent object
+type DeveloperPortalContentReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that DeveloperPortalContentReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &DeveloperPortalContentReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developerportalcontents,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developerportalcontents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developerportalcontents/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *DeveloperPortalContentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Logger().WithValues("developerportalcontent", req.NamespacedName)
+	reqLogger.Info("Reconcile DeveloperPortalContent", "Operator version", version.Version)
+
+	// Fetch the instance
+	contentCR := &capabilitiesv1beta1.DeveloperPortalContent{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, contentCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// The content synchronized to the developer portal is kept.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(contentCR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// Ignore deleted resource, the content synchronized to the developer portal is kept
+	if contentCR.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcileSpec(contentCR, reqLogger)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile developer portal content: %v. Failed to update status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to update developer portal content status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(contentCR, corev1.EventTypeWarning, "Invalid developer portal content", "%v", reconcileErr)
+
+			// the source content might be fixed, read it again after the refresh interval
+			if len(contentCR.Validate()) == 0 {
+				return ctrl.Result{RequeueAfter: contentCR.RefreshIntervalDuration()}, nil
+			}
+
+			// On spec validation error, no need to retry as spec is not valid and needs to be changed
+			return ctrl.Result{}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(contentCR, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	// sources are polled for changes
+	return ctrl.Result{RequeueAfter: contentCR.RefreshIntervalDuration()}, nil
+}
+
+func (r *DeveloperPortalContentReconciler) reconcileSpec(contentCR *capabilitiesv1beta1.DeveloperPortalContent, logger logr.Logger) (*DeveloperPortalContentStatusReconciler, error) {
+	err := r.validateSpec(contentCR)
+	if err != nil {
+		statusReconciler := NewDeveloperPortalContentStatusReconciler(r.BaseReconciler, contentCR, "", "", nil, 0, err)
+		return statusReconciler, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), contentCR.Namespace, contentCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		statusReconciler := NewDeveloperPortalContentStatusReconciler(r.BaseReconciler, contentCR, "", "", nil, 0, err)
+		return statusReconciler, err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(contentCR.GetAnnotations())
+	sourceReader := NewDeveloperPortalContentSourceReader(r.Context(), r.Client(), contentCR.Namespace, insecureSkipVerify)
+	content, err := sourceReader.Read(&contentCR.Spec.Source)
+	if err != nil {
+		statusReconciler := NewDeveloperPortalContentStatusReconciler(r.BaseReconciler, contentCR, providerAccount.AdminURLStr, "", nil, 0, err)
+		return statusReconciler, err
+	}
+
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewDeveloperPortalContentStatusReconciler(r.BaseReconciler, contentCR, providerAccount.AdminURLStr, "", nil, 0, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewDeveloperPortalContentThreescaleReconciler(r.BaseReconciler, contentCR, content, adminAPIClient, providerAccount.AdminURLStr, logger)
+	items, err := reconciler.Reconcile()
+
+	statusReconciler := NewDeveloperPortalContentStatusReconciler(r.BaseReconciler, contentCR, providerAccount.AdminURLStr, content.Revision, items, reconciler.PendingDeletions(), err)
+	return statusReconciler, err
+}
+
+func (r *DeveloperPortalContentReconciler) validateSpec(resource *capabilitiesv1beta1.DeveloperPortalContent) error {
+	errors := field.ErrorList{}
+	errors = append(errors, resource.Validate()...)
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: errors,
+	}
+}
+
+func (r *DeveloperPortalContentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.DeveloperPortalContent{}).
+		Complete(r)
+}
@@ -0,0 +1,373 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// developerPortalContentKeySeparator replaces the directory separator in ConfigMap and Secret keys
+	developerPortalContentKeySeparator = "__"
+
+	developerPortalContentSectionsFile = "sections.yaml"
+	developerPortalContentLayoutsDir   = "layouts/"
+	developerPortalContentPartialsDir  = "partials/"
+	developerPortalContentPagesDir     = "pages/"
+	developerPortalContentFilesDir     = "files/"
+
+	developerPortalContentFrontMatterDelimiter = "---\n"
+)
+
+// developerPortalSection is a section of sections.yaml
+type developerPortalSection struct {
+	SystemName string `json:"system_name"`
+	Title      string `json:"title,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Public     *bool  `json:"public,omitempty"`
+	Parent     string `json:"parent,omitempty"`
+}
+
+// developerPortalTemplate is a layout, partial or page read from the source
+type developerPortalTemplate struct {
+	Kind          string `json:"-"`
+	SystemName    string `json:"system_name,omitempty"`
+	Title         string `json:"title,omitempty"`
+	Path          string `json:"path,omitempty"`
+	Section       string `json:"section,omitempty"`
+	Layout        string `json:"layout,omitempty"`
+	ContentType   string `json:"content_type,omitempty"`
+	Handler       string `json:"handler,omitempty"`
+	LiquidEnabled *bool  `json:"liquid_enabled,omitempty"`
+	Content       string `json:"-"`
+}
+
+// Name identifies the template in the status: the system name of layouts and partials and the path of pages
+func (t *developerPortalTemplate) Name() string {
+	if t.Kind == capabilitiesv1beta1.DeveloperPortalContentPageKind {
+		return t.Path
+	}
+
+	return t.SystemName
+}
+
+// developerPortalFile is a file read from the source
+type developerPortalFile struct {
+	Path    string
+	Content []byte
+	Hash    string
+}
+
+// developerPortalContent is the content read from the source, sorted by name
+type developerPortalContent struct {
+	Sections  []developerPortalSection
+	Layouts   []developerPortalTemplate
+	Partials  []developerPortalTemplate
+	Pages     []developerPortalTemplate
+	Files     []developerPortalFile
+	Revision  string
+	fldPath   *field.Path
+	sourceRef interface{}
+}
+
+// DeveloperPortalContentSourceReader reads the content directory from ConfigMap, Secret and git sources
+type DeveloperPortalContentSourceReader struct {
+	ctx                context.Context
+	client             client.Client
+	namespace          string
+	insecureSkipVerify bool
+}
+
+func NewDeveloperPortalContentSourceReader(ctx context.Context, cl client.Client, namespace string, insecureSkipVerify bool) *DeveloperPortalContentSourceReader {
+	return &DeveloperPortalContentSourceReader{
+		ctx:                ctx,
+		client:             cl,
+		namespace:          namespace,
+		insecureSkipVerify: insecureSkipVerify,
+	}
+}
+
+// Read reads and parses the content directory of the source
+func (r *DeveloperPortalContentSourceReader) Read(source *capabilitiesv1beta1.DeveloperPortalContentSourceSpec) (*developerPortalContent, error) {
+	fldPath := field.NewPath("spec").Child("source")
+
+	var files map[string][]byte
+	var revision string
+	var sourceRef interface{}
+	var err error
+
+	switch {
+	case source.ConfigMapRef != nil:
+		fldPath = fldPath.Child("configMapRef")
+		sourceRef = source.ConfigMapRef
+		files, revision, err = r.readConfigMap(source.ConfigMapRef.Name, fldPath)
+	case source.SecretRef != nil:
+		fldPath = fldPath.Child("secretRef")
+		sourceRef = source.SecretRef
+		files, revision, err = r.readSecret(source.SecretRef.Name, fldPath)
+	case source.Git != nil:
+		fldPath = fldPath.Child("git")
+		sourceRef = source.Git
+		files, revision, err = r.readGit(source.Git, fldPath)
+	default:
+		return nil, openAPISourceInvalidError(fldPath, source, "source not set")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := parseDeveloperPortalContent(files)
+	if err != nil {
+		return nil, openAPISourceInvalidError(fldPath, sourceRef, err.Error())
+	}
+
+	// an empty source is rather a broken source than the removal of every item
+	if content.isEmpty() {
+		return nil, openAPISourceInvalidError(fldPath, sourceRef, "source has no content")
+	}
+
+	content.Revision = revision
+	content.fldPath = fldPath
+	content.sourceRef = sourceRef
+	return content, nil
+}
+
+func (r *DeveloperPortalContentSourceReader) readConfigMap(name string, fldPath *field.Path) (map[string][]byte, string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: name, Namespace: r.namespace}, configMap); err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, "", openAPISourceInvalidError(fldPath, name, "ConfigMap not found")
+		}
+
+		// unexpected error
+		return nil, "", err
+	}
+
+	files := map[string][]byte{}
+	for key, value := range configMap.Data {
+		files[developerPortalContentKeyPath(key)] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		files[developerPortalContentKeyPath(key)] = value
+	}
+
+	return files, configMap.ResourceVersion, nil
+}
+
+func (r *DeveloperPortalContentSourceReader) readSecret(name string, fldPath *field.Path) (map[string][]byte, string, error) {
+	secret, err := r.secret(name, fldPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	files := map[string][]byte{}
+	for key, value := range secret.Data {
+		files[developerPortalContentKeyPath(key)] = value
+	}
+
+	return files, secret.ResourceVersion, nil
+}
+
+func (r *DeveloperPortalContentSourceReader) readGit(source *capabilitiesv1beta1.DeveloperPortalContentGitSourceSpec, fldPath *field.Path) (map[string][]byte, string, error) {
+	var credentials *controllerhelper.GitCredentials
+	if source.CredentialsSecretRef != nil {
+		secret, err := r.secret(source.CredentialsSecretRef.Name, fldPath.Child("credentialsSecretRef"))
+		if err != nil {
+			return nil, "", err
+		}
+
+		credentials = &controllerhelper.GitCredentials{
+			Username: string(secret.Data[GitCredentialsUsernameField]),
+			Password: string(secret.Data[GitCredentialsPasswordField]),
+		}
+	}
+
+	repo := &controllerhelper.GitRepository{URL: source.URL, Credentials: credentials, InsecureSkipVerify: r.insecureSkipVerify}
+	ref, err := repo.ResolveRef(r.ctx, source.Ref)
+	if err != nil {
+		return nil, "", r.gitError(source, fldPath, err)
+	}
+
+	files, revision, err := repo.FetchDirectory(r.ctx, ref, source.Path)
+	if err != nil {
+		return nil, "", r.gitError(source, fldPath, err)
+	}
+
+	return files, revision, nil
+}
+
+func (r *DeveloperPortalContentSourceReader) gitError(source *capabilitiesv1beta1.DeveloperPortalContentGitSourceSpec, fldPath *field.Path, err error) error {
+	if isInvalidGitSourceError(err) {
+		return openAPISourceInvalidError(fldPath, source, err.Error())
+	}
+
+	return fmt.Errorf("error reading developer portal content from git repository %s: %w", source.URL, err)
+}
+
+func (r *DeveloperPortalContentSourceReader) secret(name string, fldPath *field.Path) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: name, Namespace: r.namespace}, secret); err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, openAPISourceInvalidError(fldPath, name, "Secret not found")
+		}
+
+		// unexpected error
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// invalidError returns a spec error of the content read from the source
+func (c *developerPortalContent) invalidError(detail string) error {
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: field.ErrorList{field.Invalid(c.fldPath, c.sourceRef, detail)},
+	}
+}
+
+func (c *developerPortalContent) isEmpty() bool {
+	return len(c.Sections) == 0 && len(c.Layouts) == 0 && len(c.Partials) == 0 && len(c.Pages) == 0 && len(c.Files) == 0
+}
+
+func developerPortalContentKeyPath(key string) string {
+	return strings.ReplaceAll(key, developerPortalContentKeySeparator, "/")
+}
+
+// parseDeveloperPortalContent reads the content directory:
+// sections.yaml, layouts/, partials/, pages/ and files/. Other files are ignored
+func parseDeveloperPortalContent(files map[string][]byte) (*developerPortalContent, error) {
+	content := &developerPortalContent{}
+
+	filePaths := make([]string, 0, len(files))
+	for filePath := range files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	pagePaths := map[string]string{}
+	for _, filePath := range filePaths {
+		data := files[filePath]
+		switch {
+		case filePath == developerPortalContentSectionsFile:
+			if err := yaml.UnmarshalStrict(data, &content.Sections); err != nil {
+				return nil, fmt.Errorf("%s: %w", filePath, err)
+			}
+		case strings.HasPrefix(filePath, developerPortalContentLayoutsDir):
+			template, err := parseDeveloperPortalTemplate(capabilitiesv1beta1.DeveloperPortalContentLayoutKind, filePath, data)
+			if err != nil {
+				return nil, err
+			}
+			content.Layouts = append(content.Layouts, *template)
+		case strings.HasPrefix(filePath, developerPortalContentPartialsDir):
+			template, err := parseDeveloperPortalTemplate(capabilitiesv1beta1.DeveloperPortalContentPartialKind, filePath, data)
+			if err != nil {
+				return nil, err
+			}
+			content.Partials = append(content.Partials, *template)
+		case strings.HasPrefix(filePath, developerPortalContentPagesDir):
+			template, err := parseDeveloperPortalTemplate(capabilitiesv1beta1.DeveloperPortalContentPageKind, filePath, data)
+			if err != nil {
+				return nil, err
+			}
+			if previous, ok := pagePaths[template.Path]; ok {
+				return nil, fmt.Errorf("%s: page path %s already used by %s", filePath, template.Path, previous)
+			}
+			pagePaths[template.Path] = filePath
+			content.Pages = append(content.Pages, *template)
+		case strings.HasPrefix(filePath, developerPortalContentFilesDir):
+			hash := sha256.Sum256(data)
+			content.Files = append(content.Files, developerPortalFile{
+				Path:    "/" + strings.TrimPrefix(filePath, developerPortalContentFilesDir),
+				Content: data,
+				Hash:    hex.EncodeToString(hash[:]),
+			})
+		}
+	}
+
+	for idx, section := range content.Sections {
+		if section.SystemName == "" {
+			return nil, fmt.Errorf("%s: section %d without system_name", developerPortalContentSectionsFile, idx)
+		}
+	}
+
+	return content, nil
+}
+
+// parseDeveloperPortalTemplate reads a template file with an optional YAML front matter.
+// The file path sets the system name of layouts and partials and the path of pages,
+// without the .liquid and .html extensions
+func parseDeveloperPortalTemplate(kind, filePath string, data []byte) (*developerPortalTemplate, error) {
+	template := &developerPortalTemplate{}
+
+	body := data
+	if bytes.HasPrefix(data, []byte(developerPortalContentFrontMatterDelimiter)) {
+		frontMatter, rest, found := bytes.Cut(data[len(developerPortalContentFrontMatterDelimiter):], []byte("\n"+developerPortalContentFrontMatterDelimiter))
+		if !found {
+			return nil, fmt.Errorf("%s: front matter not closed", filePath)
+		}
+		if err := yaml.UnmarshalStrict(frontMatter, template); err != nil {
+			return nil, fmt.Errorf("%s: invalid front matter: %w", filePath, err)
+		}
+		body = rest
+	}
+
+	template.Kind = kind
+	template.Content = string(body)
+
+	_, name, _ := strings.Cut(filePath, "/")
+	name = strings.TrimSuffix(name, ".liquid")
+	extension := path.Ext(name)
+	if extension == ".html" {
+		name = strings.TrimSuffix(name, extension)
+	}
+
+	if kind != capabilitiesv1beta1.DeveloperPortalContentPageKind {
+		if template.SystemName == "" {
+			template.SystemName = name
+		}
+		if template.Title == "" {
+			template.Title = template.SystemName
+		}
+		return template, nil
+	}
+
+	if template.Path == "" {
+		template.Path = "/" + name
+		if template.Path == "/index" {
+			template.Path = "/"
+		}
+		template.Path = strings.TrimSuffix(template.Path, "/index")
+	}
+
+	if template.Title == "" {
+		template.Title = path.Base(name)
+	}
+
+	if template.ContentType == "" {
+		template.ContentType = "text/html"
+		if extension != "" && extension != ".html" {
+			if mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(extension)); err == nil {
+				template.ContentType = mediaType
+			}
+		}
+	}
+
+	return template, nil
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeveloperPortalContentSourceReader_ReadConfigMap(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        map[string]string
+		binaryData  map[string][]byte
+		expectedErr string
+	}{
+		{
+			name: "content directory",
+			data: map[string]string{
+				"pages__docs__index.html": "---\ntitle: Docs\nlayout: main\n---\n<h1>Docs</h1>",
+				"pages__theme.css":        "body {}",
+				"layouts__main.html":      "{% content %}",
+				"README.md":               "ignored",
+			},
+			binaryData: map[string][]byte{"files__images__logo.png": {0x89, 0x50}},
+		},
+		{
+			name:        "front matter not closed",
+			data:        map[string]string{"pages__index.html": "---\ntitle: Home\n"},
+			expectedErr: "front matter not closed",
+		},
+		{
+			name:        "unknown front matter field",
+			data:        map[string]string{"pages__index.html": "---\ntitel: Home\n---\n"},
+			expectedErr: "invalid front matter",
+		},
+		{
+			name: "duplicated page path",
+			data: map[string]string{
+				"pages__about.html": "about",
+				"pages__other.html": "---\npath: /about\n---\nother",
+			},
+			expectedErr: "page path /about already used",
+		},
+		{
+			name:        "empty configmap",
+			expectedErr: "source has no content",
+		},
+		{
+			name:        "no content files",
+			data:        map[string]string{"README.md": "ignored"},
+			expectedErr: "source has no content",
+		},
+		{
+			name:        "section without system name",
+			data:        map[string]string{"sections.yaml": "- title: Docs\n"},
+			expectedErr: "without system_name",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "portal", Namespace: "test", ResourceVersion: "42"},
+				Data:       tc.data,
+				BinaryData: tc.binaryData,
+			}
+			baseReconciler := getBaseReconciler(configMap)
+			reader := NewDeveloperPortalContentSourceReader(baseReconciler.Context(), baseReconciler.Client(), "test", false)
+
+			content, err := reader.Read(&capabilitiesv1beta1.DeveloperPortalContentSourceSpec{
+				ConfigMapRef: &corev1.LocalObjectReference{Name: "portal"},
+			})
+			if tc.expectedErr != "" {
+				if !helper.IsInvalidSpecError(err) || !strings.Contains(err.Error(), tc.expectedErr) {
+					subT.Fatalf("error = %v, want invalid spec error %s", err, tc.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				subT.Fatalf("Read() error = %v", err)
+			}
+			if content.Revision != "42" {
+				subT.Errorf("revision = %s, want the configmap resource version", content.Revision)
+			}
+			if len(content.Layouts) != 1 || content.Layouts[0].SystemName != "main" || content.Layouts[0].Title != "main" {
+				subT.Errorf("unexpected layouts: %+v", content.Layouts)
+			}
+			if len(content.Pages) != 2 {
+				subT.Fatalf("unexpected pages: %+v", content.Pages)
+			}
+			docs, theme := content.Pages[0], content.Pages[1]
+			if docs.Path != "/docs" || docs.Title != "Docs" || docs.Layout != "main" || docs.ContentType != "text/html" || docs.Content != "<h1>Docs</h1>" {
+				subT.Errorf("unexpected docs page: %+v", docs)
+			}
+			if theme.Path != "/theme.css" || theme.ContentType != "text/css" {
+				subT.Errorf("unexpected theme page: %+v", theme)
+			}
+			if len(content.Files) != 1 || content.Files[0].Path != "/images/logo.png" || content.Files[0].Hash == "" {
+				subT.Errorf("unexpected files: %+v", content.Files)
+			}
+		})
+	}
+}
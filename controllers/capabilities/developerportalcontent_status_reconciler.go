@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type DeveloperPortalContentStatusReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.DeveloperPortalContent
+	providerAccountHost string
+	revision            string
+	items               []capabilitiesv1beta1.DeveloperPortalContentItemStatus
+	pendingDeletions    int32
+	reconcileError      error
+	logger              logr.Logger
+}
+
+func NewDeveloperPortalContentStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperPortalContent, providerAccountHost, revision string, items []capabilitiesv1beta1.DeveloperPortalContentItemStatus, pendingDeletions int32, reconcileError error) *DeveloperPortalContentStatusReconciler {
+	return &DeveloperPortalContentStatusReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		providerAccountHost: providerAccountHost,
+		revision:            revision,
+		items:               items,
+		pendingDeletions:    pendingDeletions,
+		reconcileError:      reconcileError,
+		logger:              b.Logger().WithValues("Status Reconciler", resource.Name),
+	}
+}
+
+func (s *DeveloperPortalContentStatusReconciler) Reconcile() (reconcile.Result, error) {
+	s.logger.V(1).Info("START")
+
+	newStatus := s.calculateStatus()
+
+	equalStatus := s.resource.Status.Equals(newStatus, s.logger)
+	s.logger.V(1).Info("Status", "status is different", !equalStatus)
+	s.logger.V(1).Info("Status", "generation is different", s.resource.Generation != s.resource.Status.ObservedGeneration)
+	if equalStatus && s.resource.Generation == s.resource.Status.ObservedGeneration {
+		// Steady state
+		s.logger.V(1).Info("Status steady state, status was not updated")
+		return reconcile.Result{}, nil
+	}
+
+	// Save the generation number we acted on, otherwise we might wrongfully indicate
+	// that we've seen a spec update when we retry.
+	// TODO: This can clobber an update if we allow multiple agents to write to the
+	// same status.
+	newStatus.ObservedGeneration = s.resource.Generation
+
+	s.logger.V(1).Info("Updating Status", "sequence no:", fmt.Sprintf("sequence No: %v->%v", s.resource.Status.ObservedGeneration, newStatus.ObservedGeneration))
+
+	s.resource.Status = *newStatus
+	updateErr := s.Client().Status().Update(s.Context(), s.resource)
+	if updateErr != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(updateErr) {
+			s.logger.Info("Failed to update status: resource might just be outdated")
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to update status: %w", updateErr)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (s *DeveloperPortalContentStatusReconciler) calculateStatus() *capabilitiesv1beta1.DeveloperPortalContentStatus {
+	newStatus := &capabilitiesv1beta1.DeveloperPortalContentStatus{
+		ProviderAccountHost: s.resource.Status.ProviderAccountHost,
+		Revision:            s.resource.Status.Revision,
+		Items:               s.resource.Status.Items,
+		PendingDeletions:    s.resource.Status.PendingDeletions,
+		ObservedGeneration:  s.resource.Status.ObservedGeneration,
+		Conditions:          s.resource.Status.Conditions.Copy(),
+	}
+
+	if s.providerAccountHost != "" {
+		newStatus.ProviderAccountHost = s.providerAccountHost
+	}
+
+	// items are only reported after a complete synchronization,
+	// otherwise items pending to be pruned would be forgotten
+	if s.reconcileError == nil {
+		newStatus.Revision = s.revision
+		newStatus.Items = s.items
+		newStatus.PendingDeletions = s.pendingDeletions
+	}
+
+	newStatus.Conditions.SetCondition(s.readyCondition(newStatus))
+	newStatus.Conditions.SetCondition(s.invalidCondition())
+	newStatus.Conditions.SetCondition(s.failedCondition())
+
+	return newStatus
+}
+
+func (s *DeveloperPortalContentStatusReconciler) readyCondition(newStatus *capabilitiesv1beta1.DeveloperPortalContentStatus) common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperPortalContentReadyConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.reconcileError != nil {
+		return condition
+	}
+
+	// deletions blocked by maxDeletions need the source to be fixed or maxDeletions to be raised
+	if newStatus.PendingDeletions > 0 {
+		condition.Message = fmt.Sprintf("%d items removed from the source pending deletion, more than maxDeletions", newStatus.PendingDeletions)
+		return condition
+	}
+
+	condition.Status = corev1.ConditionTrue
+	return condition
+}
+
+func (s *DeveloperPortalContentStatusReconciler) invalidCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperPortalContentInvalidConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if helper.IsInvalidSpecError(s.reconcileError) {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.reconcileError.Error()
+	}
+
+	return condition
+}
+
+func (s *DeveloperPortalContentStatusReconciler) failedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperPortalContentFailedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	// only activate this condition when others are false and still there is an error
+	if s.reconcileError != nil && s.invalidCondition().IsFalse() {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.reconcileError.Error()
+	}
+
+	return condition
+}
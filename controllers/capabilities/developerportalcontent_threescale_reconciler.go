@@ -0,0 +1,496 @@
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+)
+
+type DeveloperPortalContentThreescaleReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.DeveloperPortalContent
+	content             *developerPortalContent
+	adminAPIClient      *controllerhelper.AdminAPIClient
+	providerAccountHost string
+	logger              logr.Logger
+
+	// synchronized items
+	items []capabilitiesv1beta1.DeveloperPortalContentItemStatus
+	// section IDs by system name
+	sectionIDs map[string]int64
+	// layout IDs by system name
+	layoutIDs map[string]int64
+	// items removed from the source and not pruned
+	pendingDeletions int32
+}
+
+func NewDeveloperPortalContentThreescaleReconciler(b *reconcilers.BaseReconciler,
+	resource *capabilitiesv1beta1.DeveloperPortalContent,
+	content *developerPortalContent,
+	adminAPIClient *controllerhelper.AdminAPIClient,
+	providerAccountHost string,
+	logger logr.Logger,
+) *DeveloperPortalContentThreescaleReconciler {
+	return &DeveloperPortalContentThreescaleReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		content:             content,
+		adminAPIClient:      adminAPIClient,
+		providerAccountHost: providerAccountHost,
+		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
+		sectionIDs:          map[string]int64{},
+		layoutIDs:           map[string]int64{},
+	}
+}
+
+// Reconcile synchronizes sections, layouts, partials, pages and files, in this order,
+// and prunes the items synchronized before and removed from the source.
+// Returns the synchronized items
+func (s *DeveloperPortalContentThreescaleReconciler) Reconcile() ([]capabilitiesv1beta1.DeveloperPortalContentItemStatus, error) {
+	s.logger.V(1).Info("START")
+
+	sections, err := s.adminAPIClient.ListCMSSections()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.syncSections(sections)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.adminAPIClient.ListCMSTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kind := range []string{
+		capabilitiesv1beta1.DeveloperPortalContentLayoutKind,
+		capabilitiesv1beta1.DeveloperPortalContentPartialKind,
+		capabilitiesv1beta1.DeveloperPortalContentPageKind,
+	} {
+		err = s.syncTemplates(kind, templates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := s.adminAPIClient.ListCMSFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.syncFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.resource.Spec.Prune {
+		err = s.prune(templates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.items, nil
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) syncSections(remoteSections []controllerhelper.CMSSection) error {
+	remote := map[string]*controllerhelper.CMSSection{}
+	for idx := range remoteSections {
+		remote[remoteSections[idx].SystemName] = &remoteSections[idx]
+		s.sectionIDs[remoteSections[idx].SystemName] = remoteSections[idx].ID
+	}
+
+	// parents are synchronized before their subsections
+	pending := s.content.Sections
+	for len(pending) > 0 {
+		unresolved := []developerPortalSection{}
+		for _, desired := range pending {
+			parent := desired.Parent
+			if parent == "" {
+				parent = controllerhelper.CMSRootSectionSystemName
+			}
+
+			// wait for parents read from the source to be synchronized
+			parentPending := s.contentSection(parent) != nil && s.item(capabilitiesv1beta1.DeveloperPortalContentSectionKind, parent) == nil
+			parentID, ok := s.sectionIDs[parent]
+			if !ok || parentPending {
+				unresolved = append(unresolved, desired)
+				continue
+			}
+
+			section, err := s.syncSection(desired, parentID, remote[desired.SystemName])
+			if err != nil {
+				return err
+			}
+
+			s.sectionIDs[section.SystemName] = section.ID
+			s.addItem(capabilitiesv1beta1.DeveloperPortalContentSectionKind, desired.SystemName, section.ID, "")
+		}
+
+		if len(unresolved) == len(pending) {
+			return s.content.invalidError(fmt.Sprintf("%s: parent section %s not found", developerPortalContentSectionsFile, unresolved[0].Parent))
+		}
+		pending = unresolved
+	}
+
+	return nil
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) syncSection(desired developerPortalSection, parentID int64, remote *controllerhelper.CMSSection) (*controllerhelper.CMSSection, error) {
+	public := desired.Public == nil || *desired.Public
+	title := desired.Title
+	if title == "" {
+		title = desired.SystemName
+	}
+	partialPath := desired.Path
+	if partialPath == "" {
+		partialPath = "/" + desired.SystemName
+	}
+
+	if remote == nil {
+		s.logger.Info("creating section", "systemName", desired.SystemName)
+		return s.adminAPIClient.CreateCMSSection(threescaleapi.Params{
+			"system_name":  desired.SystemName,
+			"title":        title,
+			"partial_path": partialPath,
+			"public":       strconv.FormatBool(public),
+			"parent_id":    strconv.FormatInt(parentID, 10),
+		})
+	}
+
+	params := threescaleapi.Params{}
+	if remote.Title != title {
+		params["title"] = title
+	}
+	if remote.PartialPath != partialPath {
+		params["partial_path"] = partialPath
+	}
+	if remote.Public != public {
+		params["public"] = strconv.FormatBool(public)
+	}
+	if remote.ParentID != parentID {
+		params["parent_id"] = strconv.FormatInt(parentID, 10)
+	}
+
+	if len(params) == 0 {
+		return remote, nil
+	}
+
+	s.logger.Info("updating section", "systemName", desired.SystemName, "params", params)
+	return s.adminAPIClient.UpdateCMSSection(remote.ID, params)
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) syncTemplates(kind string, remoteTemplates []controllerhelper.CMSTemplate) error {
+	var desiredTemplates []developerPortalTemplate
+	switch kind {
+	case capabilitiesv1beta1.DeveloperPortalContentLayoutKind:
+		desiredTemplates = s.content.Layouts
+	case capabilitiesv1beta1.DeveloperPortalContentPartialKind:
+		desiredTemplates = s.content.Partials
+	default:
+		desiredTemplates = s.content.Pages
+	}
+
+	if kind == capabilitiesv1beta1.DeveloperPortalContentPageKind {
+		for _, remote := range remoteTemplates {
+			if remote.Type == controllerhelper.CMSTemplateTypeLayout {
+				if _, ok := s.layoutIDs[remote.SystemName]; !ok {
+					s.layoutIDs[remote.SystemName] = remote.ID
+				}
+			}
+		}
+	}
+
+	for idx := range desiredTemplates {
+		desired := &desiredTemplates[idx]
+		template, err := s.syncTemplate(desired, findCMSTemplate(remoteTemplates, desired))
+		if err != nil {
+			return err
+		}
+
+		if kind == capabilitiesv1beta1.DeveloperPortalContentLayoutKind {
+			s.layoutIDs[desired.SystemName] = template.ID
+		}
+		s.addItem(kind, desired.Name(), template.ID, "")
+	}
+
+	return nil
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) syncTemplate(desired *developerPortalTemplate, remote *controllerhelper.CMSTemplate) (*controllerhelper.CMSTemplate, error) {
+	liquidEnabled := desired.LiquidEnabled == nil || *desired.LiquidEnabled
+
+	desiredParams := threescaleapi.Params{
+		"liquid_enabled": strconv.FormatBool(liquidEnabled),
+	}
+	if desired.SystemName != "" {
+		desiredParams["system_name"] = desired.SystemName
+	}
+	if desired.Kind != capabilitiesv1beta1.DeveloperPortalContentPartialKind {
+		desiredParams["title"] = desired.Title
+	}
+
+	var sectionID, layoutID int64
+	if desired.Kind == capabilitiesv1beta1.DeveloperPortalContentPageKind {
+		section := desired.Section
+		if section == "" {
+			section = controllerhelper.CMSRootSectionSystemName
+		}
+		var ok bool
+		sectionID, ok = s.sectionIDs[section]
+		if !ok {
+			return nil, s.content.invalidError(fmt.Sprintf("page %s: section %s not found", desired.Path, section))
+		}
+
+		if desired.Layout != "" {
+			layoutID, ok = s.layoutIDs[desired.Layout]
+			if !ok {
+				return nil, s.content.invalidError(fmt.Sprintf("page %s: layout %s not found", desired.Path, desired.Layout))
+			}
+			desiredParams["layout_id"] = strconv.FormatInt(layoutID, 10)
+		}
+
+		desiredParams["path"] = desired.Path
+		desiredParams["section_id"] = strconv.FormatInt(sectionID, 10)
+		desiredParams["content_type"] = desired.ContentType
+		if desired.Handler != "" {
+			desiredParams["handler"] = desired.Handler
+		}
+	}
+
+	template := remote
+	var err error
+	if template == nil {
+		s.logger.Info("creating template", "type", desired.Kind, "name", desired.Name())
+		desiredParams["type"] = desired.Kind
+		desiredParams["draft"] = desired.Content
+		template, err = s.adminAPIClient.CreateCMSTemplate(desiredParams)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		params := threescaleapi.Params{}
+		current := template.Draft
+		if current == "" {
+			current = template.Published
+		}
+		if current != desired.Content {
+			params["draft"] = desired.Content
+		}
+
+		// only the content of builtin pages and partials can be changed
+		if !template.IsBuiltin() {
+			if template.LiquidEnabled != liquidEnabled {
+				params["liquid_enabled"] = desiredParams["liquid_enabled"]
+			}
+			if title, ok := desiredParams["title"]; ok && template.Title != title {
+				params["title"] = title
+			}
+			if desired.Kind == capabilitiesv1beta1.DeveloperPortalContentPageKind {
+				if template.Path != desired.Path {
+					params["path"] = desired.Path
+				}
+				if template.SectionID != sectionID {
+					params["section_id"] = desiredParams["section_id"]
+				}
+				if layoutID != 0 && template.LayoutID != layoutID {
+					params["layout_id"] = desiredParams["layout_id"]
+				}
+				if template.ContentType != desired.ContentType {
+					params["content_type"] = desired.ContentType
+				}
+				if template.Handler != desired.Handler {
+					params["handler"] = desired.Handler
+				}
+			}
+		}
+
+		if len(params) > 0 {
+			s.logger.Info("updating template", "type", desired.Kind, "name", desired.Name(), "fields", slices.Sorted(maps.Keys(params)))
+			template, err = s.adminAPIClient.UpdateCMSTemplate(template.ID, params)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.resource.Spec.Publish && template.Published != desired.Content {
+		s.logger.Info("publishing template", "type", desired.Kind, "name", desired.Name())
+		template, err = s.adminAPIClient.PublishCMSTemplate(template.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return template, nil
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) syncFiles(remoteFiles []controllerhelper.CMSFile) error {
+	remote := map[string]*controllerhelper.CMSFile{}
+	for idx := range remoteFiles {
+		remote[remoteFiles[idx].Path] = &remoteFiles[idx]
+	}
+
+	rootSectionID := strconv.FormatInt(s.sectionIDs[controllerhelper.CMSRootSectionSystemName], 10)
+	for _, desired := range s.content.Files {
+		file := remote[desired.Path]
+		var err error
+		if file == nil {
+			s.logger.Info("uploading file", "path", desired.Path)
+			file, err = s.adminAPIClient.CreateCMSFile(threescaleapi.Params{
+				"path":       desired.Path,
+				"section_id": rootSectionID,
+			}, path.Base(desired.Path), desired.Content)
+			if err != nil {
+				return err
+			}
+		} else if previous := s.resource.Status.Item(capabilitiesv1beta1.DeveloperPortalContentFileKind, desired.Path); previous == nil || previous.Hash != desired.Hash {
+			// the uploaded content is not read back, the hash of the last upload tells whether it changed
+			s.logger.Info("uploading file", "path", desired.Path, "ID", file.ID)
+			file, err = s.adminAPIClient.UpdateCMSFile(file.ID, threescaleapi.Params{}, path.Base(desired.Path), desired.Content)
+			if err != nil {
+				return err
+			}
+		}
+
+		s.addItem(capabilitiesv1beta1.DeveloperPortalContentFileKind, desired.Path, file.ID, desired.Hash)
+	}
+
+	return nil
+}
+
+// prune deletes the items of the status not synchronized anymore.
+// Dependent items are deleted first: files, pages, partials, layouts and, last, sections.
+// Nothing is deleted when more than maxDeletions items were removed from the source:
+// the items are kept in the status and reported pending deletion
+func (s *DeveloperPortalContentThreescaleReconciler) prune(remoteTemplates []controllerhelper.CMSTemplate) error {
+	builtin := map[int64]bool{}
+	for _, template := range remoteTemplates {
+		if template.IsBuiltin() {
+			builtin[template.ID] = true
+		}
+	}
+
+	removedItems := []capabilitiesv1beta1.DeveloperPortalContentItemStatus{}
+	for _, kind := range []string{
+		capabilitiesv1beta1.DeveloperPortalContentFileKind,
+		capabilitiesv1beta1.DeveloperPortalContentPageKind,
+		capabilitiesv1beta1.DeveloperPortalContentPartialKind,
+		capabilitiesv1beta1.DeveloperPortalContentLayoutKind,
+		capabilitiesv1beta1.DeveloperPortalContentSectionKind,
+	} {
+		for _, previous := range s.resource.Status.Items {
+			if previous.Kind != kind || s.item(kind, previous.Name) != nil {
+				continue
+			}
+
+			// builtin templates are never deleted
+			isTemplate := kind != capabilitiesv1beta1.DeveloperPortalContentFileKind && kind != capabilitiesv1beta1.DeveloperPortalContentSectionKind
+			if isTemplate && builtin[previous.ID] {
+				continue
+			}
+
+			removedItems = append(removedItems, previous)
+		}
+	}
+
+	if int32(len(removedItems)) > s.resource.MaxDeletions() {
+		s.logger.Info("items removed from source not pruned", "items", len(removedItems), "maxDeletions", s.resource.MaxDeletions())
+		s.items = append(s.items, removedItems...)
+		s.pendingDeletions = int32(len(removedItems))
+		return nil
+	}
+
+	for _, removed := range removedItems {
+		s.logger.Info("pruning item", "kind", removed.Kind, "name", removed.Name, "ID", removed.ID)
+		var err error
+		switch removed.Kind {
+		case capabilitiesv1beta1.DeveloperPortalContentFileKind:
+			err = s.adminAPIClient.DeleteCMSFile(removed.ID)
+		case capabilitiesv1beta1.DeveloperPortalContentSectionKind:
+			err = s.adminAPIClient.DeleteCMSSection(removed.ID)
+		default:
+			err = s.adminAPIClient.DeleteCMSTemplate(removed.ID)
+		}
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PendingDeletions returns the number of items removed from the source and not pruned
+func (s *DeveloperPortalContentThreescaleReconciler) PendingDeletions() int32 {
+	return s.pendingDeletions
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) addItem(kind, name string, id int64, hash string) {
+	s.items = append(s.items, capabilitiesv1beta1.DeveloperPortalContentItemStatus{Kind: kind, Name: name, ID: id, Hash: hash})
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) item(kind, name string) *capabilitiesv1beta1.DeveloperPortalContentItemStatus {
+	for idx := range s.items {
+		if s.items[idx].Kind == kind && s.items[idx].Name == name {
+			return &s.items[idx]
+		}
+	}
+
+	return nil
+}
+
+func (s *DeveloperPortalContentThreescaleReconciler) contentSection(systemName string) *developerPortalSection {
+	for idx := range s.content.Sections {
+		if s.content.Sections[idx].SystemName == systemName {
+			return &s.content.Sections[idx]
+		}
+	}
+
+	return nil
+}
+
+// findCMSTemplate finds the remote template of the desired one.
+// Layouts and partials are matched by system name, builtin partials included.
+// Pages are matched by system name when set, builtin pages included, and by path otherwise
+func findCMSTemplate(remoteTemplates []controllerhelper.CMSTemplate, desired *developerPortalTemplate) *controllerhelper.CMSTemplate {
+	var types []string
+	switch desired.Kind {
+	case capabilitiesv1beta1.DeveloperPortalContentLayoutKind:
+		types = []string{controllerhelper.CMSTemplateTypeLayout}
+	case capabilitiesv1beta1.DeveloperPortalContentPartialKind:
+		types = []string{controllerhelper.CMSTemplateTypePartial, controllerhelper.CMSTemplateTypeBuiltinPartial}
+	default:
+		types = []string{controllerhelper.CMSTemplateTypePage, controllerhelper.CMSTemplateTypeBuiltinPage}
+	}
+
+	for idx := range remoteTemplates {
+		remote := &remoteTemplates[idx]
+		if !slices.Contains(types, remote.Type) {
+			continue
+		}
+
+		if desired.Kind == capabilitiesv1beta1.DeveloperPortalContentPageKind && desired.SystemName == "" {
+			if remote.Type == controllerhelper.CMSTemplateTypePage && remote.Path == desired.Path {
+				return remote
+			}
+			continue
+		}
+
+		if remote.SystemName == desired.SystemName {
+			return remote
+		}
+	}
+
+	return nil
+}
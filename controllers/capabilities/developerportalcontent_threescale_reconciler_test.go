@@ -0,0 +1,423 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// cmsAPIHandler fakes the CMS endpoints, recording the requests changing content
+type cmsAPIHandler struct {
+	templates map[int64]*controllerhelper.CMSTemplate
+	sections  map[int64]*controllerhelper.CMSSection
+	files     map[int64]*controllerhelper.CMSFile
+	nextID    int64
+	requests  []string
+}
+
+func newCMSAPIHandler() *cmsAPIHandler {
+	return &cmsAPIHandler{
+		templates: map[int64]*controllerhelper.CMSTemplate{
+			1: {ID: 1, Type: controllerhelper.CMSTemplateTypeBuiltinPartial, SystemName: "submenu", Draft: "", Published: "builtin"},
+		},
+		sections: map[int64]*controllerhelper.CMSSection{
+			2: {ID: 2, SystemName: controllerhelper.CMSRootSectionSystemName, Title: "Root", PartialPath: "/", Public: true},
+		},
+		files:  map[int64]*controllerhelper.CMSFile{},
+		nextID: 100,
+	}
+}
+
+func (h *cmsAPIHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	writeJSON := func(code int, obj interface{}) {
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(obj)
+	}
+
+	if req.Method != http.MethodGet {
+		h.requests = append(h.requests, req.Method+" "+req.URL.Path)
+	}
+
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+		_ = req.ParseMultipartForm(1 << 20)
+	} else {
+		_ = req.ParseForm()
+	}
+	form := req.PostForm
+
+	resource, rest, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/admin/api/cms/"), "/")
+	resource = strings.TrimSuffix(resource, ".json")
+	idStr, action, _ := strings.Cut(strings.TrimSuffix(rest, ".json"), "/")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+
+	switch {
+	case req.Method == http.MethodGet && rest == "":
+		metadata := controllerhelper.CMSListMetadata{TotalPages: 1, CurrentPage: 1}
+		switch resource {
+		case "templates":
+			list := controllerhelper.CMSTemplateList{Metadata: metadata}
+			for _, template := range h.templates {
+				list.Templates = append(list.Templates, *template)
+			}
+			writeJSON(http.StatusOK, list)
+		case "sections":
+			list := controllerhelper.CMSSectionList{Metadata: metadata}
+			for _, section := range h.sections {
+				list.Sections = append(list.Sections, *section)
+			}
+			writeJSON(http.StatusOK, list)
+		default:
+			list := controllerhelper.CMSFileList{Metadata: metadata}
+			for _, file := range h.files {
+				list.Files = append(list.Files, *file)
+			}
+			writeJSON(http.StatusOK, list)
+		}
+	case req.Method == http.MethodPost:
+		h.nextID++
+		switch resource {
+		case "templates":
+			template := &controllerhelper.CMSTemplate{ID: h.nextID, Type: form.Get("type")}
+			h.updateTemplate(template, form)
+			h.templates[template.ID] = template
+			writeJSON(http.StatusCreated, template)
+		case "sections":
+			section := &controllerhelper.CMSSection{ID: h.nextID}
+			h.updateSection(section, form)
+			h.sections[section.ID] = section
+			writeJSON(http.StatusCreated, section)
+		default:
+			file := &controllerhelper.CMSFile{ID: h.nextID, Path: form.Get("path")}
+			h.files[file.ID] = file
+			writeJSON(http.StatusCreated, file)
+		}
+	case req.Method == http.MethodPut && resource == "templates" && h.templates[id] != nil:
+		template := h.templates[id]
+		if action == "publish" {
+			template.Published = template.Draft
+			template.Draft = ""
+		} else {
+			h.updateTemplate(template, form)
+		}
+		writeJSON(http.StatusOK, template)
+	case req.Method == http.MethodPut && resource == "sections" && h.sections[id] != nil:
+		h.updateSection(h.sections[id], form)
+		writeJSON(http.StatusOK, h.sections[id])
+	case req.Method == http.MethodPut && resource == "files" && h.files[id] != nil:
+		writeJSON(http.StatusOK, h.files[id])
+	case req.Method == http.MethodDelete && (h.templates[id] != nil || h.sections[id] != nil || h.files[id] != nil):
+		delete(h.templates, id)
+		delete(h.sections, id)
+		delete(h.files, id)
+		writeJSON(http.StatusOK, nil)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (h *cmsAPIHandler) updateTemplate(template *controllerhelper.CMSTemplate, form url.Values) {
+	for key, value := range map[string]*string{
+		"system_name": &template.SystemName, "title": &template.Title, "path": &template.Path,
+		"content_type": &template.ContentType, "handler": &template.Handler, "draft": &template.Draft,
+	} {
+		if form.Has(key) {
+			*value = form.Get(key)
+		}
+	}
+	if form.Has("section_id") {
+		template.SectionID, _ = strconv.ParseInt(form.Get("section_id"), 10, 64)
+	}
+	if form.Has("layout_id") {
+		template.LayoutID, _ = strconv.ParseInt(form.Get("layout_id"), 10, 64)
+	}
+	if form.Has("liquid_enabled") {
+		template.LiquidEnabled = form.Get("liquid_enabled") == "true"
+	}
+	if form.Has("headers[subject]") {
+		template.Headers = &controllerhelper.CMSEmailTemplateHeaders{
+			Subject: form.Get("headers[subject]"), From: form.Get("headers[from]"), ReplyTo: form.Get("headers[reply_to]"),
+			Cc: form.Get("headers[cc]"), Bcc: form.Get("headers[bcc]"),
+		}
+	}
+}
+
+func (h *cmsAPIHandler) updateSection(section *controllerhelper.CMSSection, form url.Values) {
+	for key, value := range map[string]*string{
+		"system_name": &section.SystemName, "title": &section.Title, "partial_path": &section.PartialPath,
+	} {
+		if form.Has(key) {
+			*value = form.Get(key)
+		}
+	}
+	if form.Has("public") {
+		section.Public = form.Get("public") == "true"
+	}
+	if form.Has("parent_id") {
+		section.ParentID, _ = strconv.ParseInt(form.Get("parent_id"), 10, 64)
+	}
+}
+
+func (h *cmsAPIHandler) template(templateType, systemName, templatePath string) *controllerhelper.CMSTemplate {
+	for _, template := range h.templates {
+		if template.Type == templateType && template.SystemName == systemName && template.Path == templatePath {
+			return template
+		}
+	}
+
+	return nil
+}
+
+func getDeveloperPortalContentCR() *capabilitiesv1beta1.DeveloperPortalContent {
+	return &capabilitiesv1beta1.DeveloperPortalContent{
+		ObjectMeta: metav1.ObjectMeta{Name: "portal", Namespace: "test"},
+		Spec: capabilitiesv1beta1.DeveloperPortalContentSpec{
+			Source: capabilitiesv1beta1.DeveloperPortalContentSourceSpec{
+				ConfigMapRef: &corev1.LocalObjectReference{Name: "portal"},
+			},
+		},
+	}
+}
+
+func getDeveloperPortalContentFiles() map[string][]byte {
+	return map[string][]byte{
+		"sections.yaml":           []byte("- system_name: docs\n  title: Documentation\n- system_name: guides\n  parent: docs\n"),
+		"layouts/main.html":       []byte("<html>{% content %}</html>"),
+		"partials/submenu.liquid": []byte("custom submenu"),
+		"partials/footer.html":    []byte("footer"),
+		"pages/index.html":        []byte("---\nlayout: main\ntitle: Home\n---\nwelcome"),
+		"pages/docs/start.html":   []byte("---\nlayout: main\nsection: guides\n---\nstart"),
+		"files/css/site.css":      []byte("body {}"),
+	}
+}
+
+func newTestDeveloperPortalContentThreescaleReconciler(t *testing.T, contentCR *capabilitiesv1beta1.DeveloperPortalContent, files map[string][]byte, handler http.Handler) *DeveloperPortalContentThreescaleReconciler {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	adminURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := parseDeveloperPortalContent(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseReconciler := getOpenAPIBaseReconciler(contentCR)
+	return NewDeveloperPortalContentThreescaleReconciler(baseReconciler, contentCR, content,
+		controllerhelper.NewAdminAPIClient(adminURL, "test", srv.Client()),
+		srv.URL, baseReconciler.Logger())
+}
+
+func TestDeveloperPortalContentThreescaleReconciler_Create(t *testing.T) {
+	contentCR := getDeveloperPortalContentCR()
+	contentCR.Spec.Publish = true
+	handler := newCMSAPIHandler()
+	r := newTestDeveloperPortalContentThreescaleReconciler(t, contentCR, getDeveloperPortalContentFiles(), handler)
+
+	items, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	var names []string
+	for _, item := range items {
+		names = append(names, fmt.Sprintf("%s %s", item.Kind, item.Name))
+	}
+	wantNames := []string{
+		"section docs", "section guides", "layout main", "partial footer", "partial submenu",
+		"page /docs/start", "page /", "file /css/site.css",
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("items = %v, want %v", names, wantNames)
+	}
+
+	docs, guides := r.sectionIDs["docs"], r.sectionIDs["guides"]
+	if handler.sections[guides].ParentID != docs || handler.sections[docs].ParentID != 2 {
+		t.Errorf("unexpected section parents: docs %+v, guides %+v", handler.sections[docs], handler.sections[guides])
+	}
+
+	layout := handler.template(controllerhelper.CMSTemplateTypeLayout, "main", "")
+	if layout == nil || layout.Published != "<html>{% content %}</html>" {
+		t.Fatalf("layout not published: %+v", layout)
+	}
+
+	page := handler.template(controllerhelper.CMSTemplateTypePage, "", "/docs/start")
+	if page == nil || page.Published != "start" || page.LayoutID != layout.ID || page.SectionID != guides || page.Title != "start" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+
+	if submenu := handler.templates[1]; submenu.Published != "custom submenu" {
+		t.Errorf("builtin partial not updated: %+v", submenu)
+	}
+}
+
+func TestDeveloperPortalContentThreescaleReconciler_Draft(t *testing.T) {
+	contentCR := getDeveloperPortalContentCR()
+	handler := newCMSAPIHandler()
+	handler.templates[3] = &controllerhelper.CMSTemplate{
+		ID: 3, Type: controllerhelper.CMSTemplateTypePartial, SystemName: "footer", Title: "footer",
+		LiquidEnabled: true, Published: "old footer",
+	}
+	r := newTestDeveloperPortalContentThreescaleReconciler(t, contentCR, getDeveloperPortalContentFiles(), handler)
+
+	_, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if footer := handler.templates[3]; footer.Draft != "footer" || footer.Published != "old footer" {
+		t.Errorf("footer draft not updated: %+v", footer)
+	}
+
+	for _, request := range handler.requests {
+		if strings.HasSuffix(request, "/publish.json") {
+			t.Errorf("unexpected request %s", request)
+		}
+	}
+}
+
+func TestDeveloperPortalContentThreescaleReconciler_Prune(t *testing.T) {
+	contentCR := getDeveloperPortalContentCR()
+	handler := newCMSAPIHandler()
+	r := newTestDeveloperPortalContentThreescaleReconciler(t, contentCR, getDeveloperPortalContentFiles(), handler)
+
+	items, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	contentCR.Status.Items = items
+	contentCR.Spec.Prune = true
+	files := getDeveloperPortalContentFiles()
+	files["sections.yaml"] = []byte("- system_name: docs\n")
+	delete(files, "pages/docs/start.html")
+	delete(files, "partials/submenu.liquid")
+	delete(files, "files/css/site.css")
+	handler.requests = nil
+	r = newTestDeveloperPortalContentThreescaleReconciler(t, contentCR, files, handler)
+
+	_, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	var deletes []string
+	for _, request := range handler.requests {
+		if strings.HasPrefix(request, http.MethodDelete) {
+			deletes = append(deletes, strings.Fields(request)[1])
+		}
+	}
+	guides := items[1].ID
+	start := contentCR.Status.Item(capabilitiesv1beta1.DeveloperPortalContentPageKind, "/docs/start").ID
+	siteCSS := contentCR.Status.Item(capabilitiesv1beta1.DeveloperPortalContentFileKind, "/css/site.css").ID
+	wantDeletes := []string{
+		fmt.Sprintf("/admin/api/cms/files/%d.json", siteCSS),
+		fmt.Sprintf("/admin/api/cms/templates/%d.json", start),
+		fmt.Sprintf("/admin/api/cms/sections/%d.json", guides),
+	}
+	if !reflect.DeepEqual(deletes, wantDeletes) {
+		t.Errorf("deletes = %v, want %v", deletes, wantDeletes)
+	}
+
+	if handler.templates[1] == nil {
+		t.Error("builtin partial deleted")
+	}
+}
+
+func TestDeveloperPortalContentThreescaleReconciler_PruneMaxDeletions(t *testing.T) {
+	contentCR := getDeveloperPortalContentCR()
+	handler := newCMSAPIHandler()
+	r := newTestDeveloperPortalContentThreescaleReconciler(t, contentCR, getDeveloperPortalContentFiles(), handler)
+
+	items, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	contentCR.Status.Items = items
+	contentCR.Spec.Prune = true
+	contentCR.Spec.MaxDeletions = ptr.To(int32(1))
+	files := getDeveloperPortalContentFiles()
+	delete(files, "pages/docs/start.html")
+	delete(files, "files/css/site.css")
+	handler.requests = nil
+	r = newTestDeveloperPortalContentThreescaleReconciler(t, contentCR, files, handler)
+
+	items, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	for _, request := range handler.requests {
+		if strings.HasPrefix(request, http.MethodDelete) {
+			t.Errorf("unexpected request %s", request)
+		}
+	}
+	if r.PendingDeletions() != 2 {
+		t.Errorf("pending deletions = %d, want 2", r.PendingDeletions())
+	}
+	// items pending deletion are kept in the status to be pruned later
+	if len(items) != len(contentCR.Status.Items) {
+		t.Errorf("items = %v, want %v", items, contentCR.Status.Items)
+	}
+}
+
+func TestDeveloperPortalContentReconciler_EmptySourceNotPruned(t *testing.T) {
+	handler := newCMSAPIHandler()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	contentCR := getDeveloperPortalContentCR()
+	contentCR.Spec.Prune = true
+	contentCR.Spec.ProviderAccountRef = &corev1.LocalObjectReference{Name: "provider-account"}
+	contentCR.Status.Items = []capabilitiesv1beta1.DeveloperPortalContentItemStatus{
+		{Kind: capabilitiesv1beta1.DeveloperPortalContentSectionKind, Name: "docs", ID: 100},
+		{Kind: capabilitiesv1beta1.DeveloperPortalContentPageKind, Name: "/", ID: 101},
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "portal", Namespace: "test"}}
+	providerAccountSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider-account", Namespace: "test"},
+		Data:       map[string][]byte{"adminURL": []byte(srv.URL), "token": []byte("token")},
+	}
+	r := &DeveloperPortalContentReconciler{BaseReconciler: getOpenAPIBaseReconciler(contentCR, configMap, providerAccountSecret)}
+
+	statusReconciler, err := r.reconcileSpec(contentCR, r.Logger())
+	if !helper.IsInvalidSpecError(err) || !strings.Contains(err.Error(), "source has no content") {
+		t.Errorf("reconcileSpec() error = %v, want invalid source error", err)
+	}
+	if len(handler.requests) != 0 {
+		t.Errorf("requests = %v, want none", handler.requests)
+	}
+
+	// the synchronized items are kept to be pruned once the source is fixed
+	if status := statusReconciler.calculateStatus(); !reflect.DeepEqual(status.Items, contentCR.Status.Items) {
+		t.Errorf("status items = %v, want %v", status.Items, contentCR.Status.Items)
+	}
+}
+
+func TestDeveloperPortalContentThreescaleReconciler_UnknownLayout(t *testing.T) {
+	contentCR := getDeveloperPortalContentCR()
+	files := getDeveloperPortalContentFiles()
+	delete(files, "layouts/main.html")
+	r := newTestDeveloperPortalContentThreescaleReconciler(t, contentCR, files, newCMSAPIHandler())
+
+	_, err := r.Reconcile()
+	if err == nil || !strings.Contains(err.Error(), "layout main not found") {
+		t.Errorf("Reconcile() error = %v, want layout not found", err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
@@ -188,6 +189,11 @@ func (r *DeveloperUserReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, reconcileErr
 	}
 
+	if developerUserCR.IsInvitationPending() {
+		// Invitations are accepted out of band, check them periodically
+		return ctrl.Result{RequeueAfter: invitationRequeueAfter(developerUserCR, time.Now())}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -242,7 +248,13 @@ func (r *DeveloperUserReconciler) reconcileSpec(userCR *capabilitiesv1beta1.Deve
 		return statusReconciler, err
 	}
 
-	reconciler := NewDeveloperUserThreescaleReconciler(r.BaseReconciler, userCR, parentAccountCR, threescaleAPIClient, providerAccount.AdminURLStr, logger)
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewDeveloperUserStatusReconciler(r.BaseReconciler, userCR, parentAccountCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewDeveloperUserThreescaleReconciler(r.BaseReconciler, userCR, parentAccountCR, threescaleAPIClient, adminAPIClient, providerAccount.AdminURLStr, logger)
 	userObj, err := reconciler.Reconcile()
 
 	statusReconciler := NewDeveloperUserStatusReconciler(r.BaseReconciler, userCR, parentAccountCR, providerAccount.AdminURLStr, userObj, err)
@@ -312,6 +324,12 @@ func (r *DeveloperUserReconciler) findParentAccount(userCR *capabilitiesv1beta1.
 func (r *DeveloperUserReconciler) removeDeveloperUserFrom3scale(developerUser *capabilitiesv1beta1.DeveloperUser) error {
 	logger := r.Logger().WithValues("developerUser", client.ObjectKey{Name: developerUser.Name, Namespace: developerUser.Namespace})
 
+	// Invitations not accepted are removed, so they cannot be accepted anymore
+	if developerUser.Status.ID == nil && developerUser.Status.AccountID != nil && developerUser.Status.Invitation != nil &&
+		developerUser.Status.Invitation.State != capabilitiesv1beta1.DeveloperUserInvitationStateAccepted {
+		return r.removeInvitationFrom3scale(developerUser, logger)
+	}
+
 	// Attempt to remove developerUser only if developerUser.Status.ID is present
 	if developerUser.Status.ID == nil {
 		logger.Info("could not remove developerUser because ID is missing in status")
@@ -346,6 +364,30 @@ func (r *DeveloperUserReconciler) removeDeveloperUserFrom3scale(developerUser *c
 	return nil
 }
 
+func (r *DeveloperUserReconciler) removeInvitationFrom3scale(developerUser *capabilitiesv1beta1.DeveloperUser, logger logr.Logger) error {
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), developerUser.Namespace, developerUser.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("invitation not deleted from 3scale, provider account not found")
+			return nil
+		}
+		return err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(developerUser.GetAnnotations())
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return err
+	}
+
+	err = adminAPIClient.DeleteInvitation(*developerUser.Status.AccountID, developerUser.Status.Invitation.ID)
+	if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 func (r *DeveloperUserReconciler) retrieveDevelopAccountCR(developerUser *capabilitiesv1beta1.DeveloperUser) (*capabilitiesv1beta1.DeveloperAccount, error) {
 	developerAccount := &capabilitiesv1beta1.DeveloperAccount{}
 
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// developerUserInvitationPollInterval is the interval pending invitations are checked for acceptance
+const developerUserInvitationPollInterval = 5 * time.Minute
+
+// reconcileInvitation invites the user to the parent account and tracks the invitation in the status.
+// 3scale emails the activation link and creates the user when the invitation is accepted.
+// Returns the user created from the accepted invitation, nil while the invitation is pending or expired
+func (s *DeveloperUserThreescaleReconciler) reconcileInvitation() (*threescaleapi.DeveloperUser, error) {
+	accountID := *s.parentAccountCR.Status.ID
+
+	invitation, err := s.findInvitation()
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation == nil {
+		// the invitation may have been sent without being recorded in the status
+		invitation, err = s.findPendingInvitation()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if invitation == nil {
+		invitation, err = s.createInvitation()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if invitation.IsAccepted() {
+		s.setInvitationStatus(invitation, capabilitiesv1beta1.DeveloperUserInvitationStateAccepted)
+		return s.findInvitedDevUser(invitation)
+	}
+
+	if s.invitationExpirationTime(invitation).Before(time.Now()) {
+		if !s.userCR.Spec.Invitation.ResendExpired {
+			s.setInvitationStatus(invitation, capabilitiesv1beta1.DeveloperUserInvitationStateExpired)
+			return nil, nil
+		}
+
+		s.logger.Info("Invitation expired, resending", "email", s.userCR.Spec.Email, "invitationID", invitation.Element.ID)
+		err = s.adminAPIClient.DeleteInvitation(accountID, invitation.Element.ID)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return nil, fmt.Errorf("failed to delete expired invitation [%d]: %w", invitation.Element.ID, err)
+		}
+
+		invitation, err = s.createInvitation()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.setInvitationStatus(invitation, capabilitiesv1beta1.DeveloperUserInvitationStatePending)
+	return nil, nil
+}
+
+// findInvitation reads the invitation tracked in the status.
+// Invitations not found, or sent to a former email address, are not valid anymore
+func (s *DeveloperUserThreescaleReconciler) findInvitation() (*controllerhelper.Invitation, error) {
+	if s.userCR.Status.Invitation == nil {
+		return nil, nil
+	}
+
+	accountID := *s.parentAccountCR.Status.ID
+	invitationID := s.userCR.Status.Invitation.ID
+	invitation, err := s.adminAPIClient.Invitation(accountID, invitationID)
+	if err != nil {
+		if controllerhelper.IsAdminAPINotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read invitation [%d]: %w", invitationID, err)
+	}
+
+	if !invitation.IsAccepted() && invitation.Element.Email != s.userCR.Spec.Email {
+		s.logger.Info("Email changed, deleting invitation", "invitationID", invitationID)
+		err = s.adminAPIClient.DeleteInvitation(accountID, invitationID)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return nil, fmt.Errorf("failed to delete invitation [%d]: %w", invitationID, err)
+		}
+
+		return nil, nil
+	}
+
+	return invitation, nil
+}
+
+// findPendingInvitation returns the pending invitation of the parent account sent to the user email, nil when not found
+func (s *DeveloperUserThreescaleReconciler) findPendingInvitation() (*controllerhelper.Invitation, error) {
+	invitationList, err := s.adminAPIClient.ListInvitations(*s.parentAccountCR.Status.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	for idx := range invitationList.Invitations {
+		invitation := &invitationList.Invitations[idx]
+		if !invitation.IsAccepted() && invitation.Element.Email == s.userCR.Spec.Email {
+			s.logger.Info("Pending invitation found", "email", s.userCR.Spec.Email, "invitationID", invitation.Element.ID)
+			return invitation, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *DeveloperUserThreescaleReconciler) createInvitation() (*controllerhelper.Invitation, error) {
+	invitation, err := s.adminAPIClient.CreateInvitation(*s.parentAccountCR.Status.ID, s.userCR.Spec.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite developer user %s: %w", s.userCR.Spec.Email, err)
+	}
+
+	s.logger.Info("Developer user invited", "email", s.userCR.Spec.Email, "invitationID", invitation.Element.ID)
+	return invitation, nil
+}
+
+// findInvitedDevUser returns the user created by 3scale when the invitation was accepted
+func (s *DeveloperUserThreescaleReconciler) findInvitedDevUser(invitation *controllerhelper.Invitation) (*threescaleapi.DeveloperUser, error) {
+	if invitation.Element.UserID != nil {
+		devUser, err := s.findDevUserByID(*invitation.Element.UserID)
+		if err != nil || devUser != nil {
+			return devUser, err
+		}
+	}
+
+	// invited users choose their username, look up by email
+	devUserList, err := s.threescaleAPIClient.ListDeveloperUsers(*s.parentAccountCR.Status.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range devUserList.Items {
+		if devUserList.Items[idx].Element.Email != nil && *devUserList.Items[idx].Element.Email == invitation.Element.Email {
+			return &devUserList.Items[idx], nil
+		}
+	}
+
+	return nil, fmt.Errorf("user of accepted invitation [%d] not found", invitation.Element.ID)
+}
+
+// invitationExpirationTime returns the time the invitation expires, counting from the time it was sent
+func (s *DeveloperUserThreescaleReconciler) invitationExpirationTime(invitation *controllerhelper.Invitation) time.Time {
+	sentTime := time.Now()
+	if invitation.Element.SentAt != nil {
+		sentTime = *invitation.Element.SentAt
+	} else if invitation.Element.CreatedAt != nil {
+		sentTime = *invitation.Element.CreatedAt
+	}
+
+	return sentTime.Add(s.userCR.InvitationExpiresAfter())
+}
+
+func (s *DeveloperUserThreescaleReconciler) setInvitationStatus(invitation *controllerhelper.Invitation, state string) {
+	invitationStatus := &capabilitiesv1beta1.DeveloperUserInvitationStatus{
+		ID:    invitation.Element.ID,
+		State: state,
+	}
+
+	if invitation.Element.SentAt != nil {
+		invitationStatus.SentTime = &metav1.Time{Time: *invitation.Element.SentAt}
+	}
+
+	if invitation.Element.AcceptedAt != nil {
+		invitationStatus.AcceptedTime = &metav1.Time{Time: *invitation.Element.AcceptedAt}
+	} else {
+		invitationStatus.ExpirationTime = &metav1.Time{Time: s.invitationExpirationTime(invitation)}
+	}
+
+	s.userCR.Status.Invitation = invitationStatus
+}
+
+// invitationRequeueAfter returns when pending invitations are checked again, at most at expiration time
+func invitationRequeueAfter(userCR *capabilitiesv1beta1.DeveloperUser, now time.Time) time.Duration {
+	requeueAfter := developerUserInvitationPollInterval
+	if userCR.Status.Invitation.ExpirationTime != nil {
+		if untilExpiration := userCR.Status.Invitation.ExpirationTime.Sub(now); untilExpiration < requeueAfter {
+			requeueAfter = untilExpiration
+		}
+	}
+
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+
+	return requeueAfter
+}
@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func getInvitedDeveloperUserCR() *capabilitiesv1beta1.DeveloperUser {
+	return &capabilitiesv1beta1.DeveloperUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "test"},
+		Spec: capabilitiesv1beta1.DeveloperUserSpec{
+			Username:   "dev",
+			Email:      "dev@example.com",
+			Invitation: &capabilitiesv1beta1.DeveloperUserInvitationSpec{},
+		},
+	}
+}
+
+func TestDeveloperUserThreescaleReconciler_reconcileInvitation(t *testing.T) {
+	now := time.Now().UTC()
+	sentRecently := now.Add(-time.Hour).Format(time.RFC3339)
+	sentLongAgo := now.Add(-30 * 24 * time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name             string
+		statusInvitation *capabilitiesv1beta1.DeveloperUserInvitationStatus
+		resendExpired    bool
+		// invitation 7 returned by 3scale, empty when not found
+		invitation string
+		// invitations of the account, none when empty
+		invitations    string
+		wantRequests   []string
+		wantState      string
+		wantInvitation int64
+		wantUser       bool
+	}{
+		{
+			name: "new invitation",
+			wantRequests: []string{
+				"GET /admin/api/accounts/3/invitations.json",
+				"POST /admin/api/accounts/3/invitations.json",
+			},
+			wantState:      "pending",
+			wantInvitation: 8,
+		},
+		{
+			name:             "pending invitation",
+			statusInvitation: &capabilitiesv1beta1.DeveloperUserInvitationStatus{ID: 7},
+			invitation:       fmt.Sprintf(`{"invitation":{"id":7,"email":"dev@example.com","sent_at":%q}}`, sentRecently),
+			wantRequests:     []string{"GET /admin/api/accounts/3/invitations/7.json"},
+			wantState:        "pending",
+			wantInvitation:   7,
+		},
+		{
+			name:             "invitation deleted in 3scale",
+			statusInvitation: &capabilitiesv1beta1.DeveloperUserInvitationStatus{ID: 7},
+			wantRequests: []string{
+				"GET /admin/api/accounts/3/invitations/7.json",
+				"GET /admin/api/accounts/3/invitations.json",
+				"POST /admin/api/accounts/3/invitations.json",
+			},
+			wantState:      "pending",
+			wantInvitation: 8,
+		},
+		{
+			name:             "invitation to former email",
+			statusInvitation: &capabilitiesv1beta1.DeveloperUserInvitationStatus{ID: 7},
+			invitation:       fmt.Sprintf(`{"invitation":{"id":7,"email":"old@example.com","sent_at":%q}}`, sentRecently),
+			wantRequests: []string{
+				"GET /admin/api/accounts/3/invitations/7.json",
+				"DELETE /admin/api/accounts/3/invitations/7.json",
+				"GET /admin/api/accounts/3/invitations.json",
+				"POST /admin/api/accounts/3/invitations.json",
+			},
+			wantState:      "pending",
+			wantInvitation: 8,
+		},
+		{
+			name: "invitation sent and not recorded",
+			invitations: fmt.Sprintf(`{"invitations":[{"invitation":{"id":6,"email":"other@example.com","sent_at":%q}},{"invitation":{"id":9,"email":"dev@example.com","sent_at":%q}}]}`,
+				sentRecently, sentRecently),
+			wantRequests:   []string{"GET /admin/api/accounts/3/invitations.json"},
+			wantState:      "pending",
+			wantInvitation: 9,
+		},
+		{
+			name:             "expired invitation",
+			statusInvitation: &capabilitiesv1beta1.DeveloperUserInvitationStatus{ID: 7},
+			invitation:       fmt.Sprintf(`{"invitation":{"id":7,"email":"dev@example.com","sent_at":%q}}`, sentLongAgo),
+			wantRequests:     []string{"GET /admin/api/accounts/3/invitations/7.json"},
+			wantState:        "expired",
+			wantInvitation:   7,
+		},
+		{
+			name:             "expired invitation resent",
+			statusInvitation: &capabilitiesv1beta1.DeveloperUserInvitationStatus{ID: 7},
+			resendExpired:    true,
+			invitation:       fmt.Sprintf(`{"invitation":{"id":7,"email":"dev@example.com","sent_at":%q}}`, sentLongAgo),
+			wantRequests: []string{
+				"GET /admin/api/accounts/3/invitations/7.json",
+				"DELETE /admin/api/accounts/3/invitations/7.json",
+				"POST /admin/api/accounts/3/invitations.json",
+			},
+			wantState:      "pending",
+			wantInvitation: 8,
+		},
+		{
+			name:             "accepted invitation",
+			statusInvitation: &capabilitiesv1beta1.DeveloperUserInvitationStatus{ID: 7},
+			invitation:       fmt.Sprintf(`{"invitation":{"id":7,"email":"dev@example.com","sent_at":%q,"accepted_at":%q,"user_id":5}}`, sentLongAgo, sentRecently),
+			wantRequests: []string{
+				"GET /admin/api/accounts/3/invitations/7.json",
+				"GET /admin/api/accounts/3/users/5.json",
+			},
+			wantState:      "accepted",
+			wantInvitation: 7,
+			wantUser:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				request := fmt.Sprintf("%s %s", req.Method, req.URL.Path)
+				requests = append(requests, request)
+				w.Header().Set("Content-Type", "application/json")
+				switch request {
+				case "GET /admin/api/accounts/3/invitations/7.json":
+					if tt.invitation == "" {
+						w.WriteHeader(http.StatusNotFound)
+						fmt.Fprint(w, `{"status":"Not found"}`)
+						return
+					}
+					fmt.Fprint(w, tt.invitation)
+				case "GET /admin/api/accounts/3/invitations.json":
+					if tt.invitations == "" {
+						fmt.Fprint(w, `{"invitations":[]}`)
+						return
+					}
+					fmt.Fprint(w, tt.invitations)
+				case "DELETE /admin/api/accounts/3/invitations/7.json":
+					fmt.Fprint(w, `{}`)
+				case "POST /admin/api/accounts/3/invitations.json":
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprintf(w, `{"invitation":{"id":8,"email":"dev@example.com","sent_at":%q}}`, now.Format(time.RFC3339))
+				case "GET /admin/api/accounts/3/users/5.json":
+					fmt.Fprint(w, `{"user":{"id":5,"username":"chosen","email":"dev@example.com","state":"active","role":"member"}}`)
+				default:
+					t.Errorf("unexpected request %s", request)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			ap, _ := threescaleapi.NewAdminPortalFromStr(srv.URL)
+			adminURL, _ := url.Parse(srv.URL)
+
+			userCR := getInvitedDeveloperUserCR()
+			userCR.Spec.Invitation.ResendExpired = tt.resendExpired
+			userCR.Status.Invitation = tt.statusInvitation
+			accountCR := getDeveloperAccountCR()
+			accountCR.Status.ID = ptr.To(int64(3))
+
+			reconciler := NewDeveloperUserThreescaleReconciler(getBaseReconciler(), userCR, accountCR,
+				threescaleapi.NewThreeScale(ap, "test", srv.Client()),
+				controllerhelper.NewAdminAPIClient(adminURL, "test", srv.Client()),
+				srv.URL, getBaseReconciler().Logger())
+
+			devUser, err := reconciler.reconcileInvitation()
+			if err != nil {
+				t.Fatalf("reconcileInvitation() error = %v", err)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("reconcileInvitation() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if (devUser != nil) != tt.wantUser {
+				t.Errorf("reconcileInvitation() user = %v, want user %v", devUser, tt.wantUser)
+			}
+
+			invitationStatus := userCR.Status.Invitation
+			if invitationStatus == nil || invitationStatus.State != tt.wantState || invitationStatus.ID != tt.wantInvitation {
+				t.Fatalf("invitation status = %+v, want state %s and ID %d", invitationStatus, tt.wantState, tt.wantInvitation)
+			}
+			if tt.wantState == "accepted" && invitationStatus.AcceptedTime == nil {
+				t.Errorf("accepted time not set: %+v", invitationStatus)
+			}
+			if tt.wantState != "accepted" && invitationStatus.ExpirationTime == nil {
+				t.Errorf("expiration time not set: %+v", invitationStatus)
+			}
+		})
+	}
+}
+
+func TestDeveloperUserStatusReconciler_invitationPending(t *testing.T) {
+	userCR := getInvitedDeveloperUserCR()
+	userCR.Status.Invitation = &capabilitiesv1beta1.DeveloperUserInvitationStatus{ID: 7, State: "pending"}
+
+	newStatus := NewDeveloperUserStatusReconciler(getBaseReconciler(), userCR, nil, "", nil, nil).calculateStatus()
+	if !newStatus.Conditions.IsFalseFor(capabilitiesv1beta1.DeveloperUserReadyConditionType) {
+		t.Errorf("Ready condition not false: %v", newStatus.Conditions)
+	}
+	if newStatus.Invitation == nil || newStatus.Invitation.ID != 7 {
+		t.Errorf("invitation status not kept: %+v", newStatus.Invitation)
+	}
+
+	userCR.Status.Invitation.State = "accepted"
+	remoteUser := &threescaleapi.DeveloperUser{Element: threescaleapi.DeveloperUserItem{ID: ptr.To(int64(5))}}
+	newStatus = NewDeveloperUserStatusReconciler(getBaseReconciler(), userCR, nil, "", remoteUser, nil).calculateStatus()
+	if !newStatus.Conditions.IsTrueFor(capabilitiesv1beta1.DeveloperUserReadyConditionType) {
+		t.Errorf("Ready condition not true: %v", newStatus.Conditions)
+	}
+}
+
+func TestInvitationRequeueAfter(t *testing.T) {
+	now := time.Now()
+	userCR := getInvitedDeveloperUserCR()
+	userCR.Status.Invitation = &capabilitiesv1beta1.DeveloperUserInvitationStatus{
+		ID:             7,
+		State:          "pending",
+		ExpirationTime: &metav1.Time{Time: now.Add(time.Hour)},
+	}
+	if got := invitationRequeueAfter(userCR, now); got != developerUserInvitationPollInterval {
+		t.Errorf("invitationRequeueAfter() = %v, want %v", got, developerUserInvitationPollInterval)
+	}
+
+	userCR.Status.Invitation.ExpirationTime = &metav1.Time{Time: now.Add(time.Minute)}
+	if got := invitationRequeueAfter(userCR, now); got != time.Minute {
+		t.Errorf("invitationRequeueAfter() = %v, want %v", got, time.Minute)
+	}
+}
@@ -88,6 +88,7 @@ func (s *DeveloperUserStatusReconciler) calculateStatus() *capabilitiesv1beta1.D
 		DeveloperUserState:  s.userCR.Status.DeveloperUserState,
 		ProviderAccountHost: s.userCR.Status.ProviderAccountHost,
 		AccountID:           s.userCR.Status.AccountID,
+		Invitation:          s.userCR.Status.Invitation,
 		Conditions:          s.userCR.Status.Conditions.Copy(),
 		ObservedGeneration:  s.userCR.Status.ObservedGeneration,
 	}
@@ -123,6 +124,13 @@ func (s *DeveloperUserStatusReconciler) readyCondition() common.Condition {
 		condition.Status = corev1.ConditionTrue
 	}
 
+	// Invited users are not ready until the invitation is accepted
+	if s.reconcileError == nil && s.remoteDeveloperUser == nil && s.userCR.Status.Invitation != nil &&
+		s.userCR.Status.Invitation.State != capabilitiesv1beta1.DeveloperUserInvitationStateAccepted {
+		condition.Status = corev1.ConditionFalse
+		condition.Message = fmt.Sprintf("invitation %s", s.userCR.Status.Invitation.State)
+	}
+
 	return condition
 }
 
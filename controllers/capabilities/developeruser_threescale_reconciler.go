@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
@@ -23,6 +24,7 @@ type DeveloperUserThreescaleReconciler struct {
 	userCR              *capabilitiesv1beta1.DeveloperUser
 	parentAccountCR     *capabilitiesv1beta1.DeveloperAccount
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	adminAPIClient      *controllerhelper.AdminAPIClient
 	providerAccountHost string
 	logger              logr.Logger
 }
@@ -31,6 +33,7 @@ func NewDeveloperUserThreescaleReconciler(b *reconcilers.BaseReconciler,
 	userCR *capabilitiesv1beta1.DeveloperUser,
 	parentAccountCR *capabilitiesv1beta1.DeveloperAccount,
 	threescaleAPIClient *threescaleapi.ThreeScaleClient,
+	adminAPIClient *controllerhelper.AdminAPIClient,
 	providerAccountHost string,
 	logger logr.Logger,
 ) *DeveloperUserThreescaleReconciler {
@@ -39,6 +42,7 @@ func NewDeveloperUserThreescaleReconciler(b *reconcilers.BaseReconciler,
 		userCR:              userCR,
 		parentAccountCR:     parentAccountCR,
 		threescaleAPIClient: threescaleAPIClient,
+		adminAPIClient:      adminAPIClient,
 		providerAccountHost: providerAccountHost,
 		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
 	}
@@ -57,6 +61,16 @@ func (s *DeveloperUserThreescaleReconciler) Reconcile() (*threescaleapi.Develope
 		return nil, err
 	}
 
+	if devUser == nil && s.userCR.IsInvited() {
+		// The DeveloperUser is created by 3scale when the invitation is accepted
+		devUser, err = s.reconcileInvitation()
+		if err != nil || devUser == nil {
+			return nil, err
+		}
+
+		s.userCR.Status.ID = devUser.Element.ID
+	}
+
 	if devUser == nil {
 		s.logger.V(1).Info("DeveloperUser does not exist", "username", s.userCR.Spec.Username)
 		// The DeveloperUser doesn't exist yet and must be created in 3scale
@@ -88,6 +102,20 @@ func (s *DeveloperUserThreescaleReconciler) checkParentAccount() error {
 		}
 	}
 
+	if s.userCR.Status.AccountID != nil &&
+		!reflect.DeepEqual(s.userCR.Status.AccountID, s.parentAccountCR.Status.ID) &&
+		s.userCR.Status.Invitation != nil {
+		// Invitations to the old developer account are not valid anymore
+		if s.userCR.Status.Invitation.State != capabilitiesv1beta1.DeveloperUserInvitationStateAccepted {
+			err := s.adminAPIClient.DeleteInvitation(*s.userCR.Status.AccountID, s.userCR.Status.Invitation.ID)
+			if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+				return err
+			}
+		}
+
+		s.userCR.Status.Invitation = nil
+	}
+
 	return nil
 }
 
@@ -0,0 +1,477 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// openAPIActiveDocSecretKey is the field of the secret with the ActiveDoc document
+	openAPIActiveDocSecretKey = "openapi.json"
+	// openAPIActiveDocHashAnnotationKey annotates the ActiveDoc with the hash of the document.
+	// The ActiveDoc controller does not watch secrets, the annotation triggers the ActiveDoc reconciliation
+	openAPIActiveDocHashAnnotationKey = "apimanager.apps.3scale.net/activedoc-source-hash"
+)
+
+// OpenAPIActiveDocReconciler reconciles the ActiveDoc generated from the OpenAPI document.
+// The document is stored in a secret owned by the OpenAPI CR and referenced by the ActiveDoc
+type OpenAPIActiveDocReconciler struct {
+	*reconcilers.BaseReconciler
+	openapiCR       *capabilitiesv1beta1.OpenAPI
+	openapiObj      *openapi3.T
+	product         *capabilitiesv1beta1.Product
+	providerAccount *controllerhelper.ProviderAccount
+	logger          logr.Logger
+}
+
+func NewOpenAPIActiveDocReconciler(b *reconcilers.BaseReconciler,
+	openapiCR *capabilitiesv1beta1.OpenAPI,
+	openapiObj *openapi3.T,
+	product *capabilitiesv1beta1.Product,
+	providerAccount *controllerhelper.ProviderAccount,
+	logger logr.Logger,
+) *OpenAPIActiveDocReconciler {
+	return &OpenAPIActiveDocReconciler{
+		BaseReconciler:  b,
+		openapiCR:       openapiCR,
+		openapiObj:      openapiObj,
+		product:         product,
+		providerAccount: providerAccount,
+		logger:          logger,
+	}
+}
+
+func (p *OpenAPIActiveDocReconciler) Logger() logr.Logger {
+	return p.logger
+}
+
+// Reconcile creates or updates the ActiveDoc when enabled, deletes it otherwise.
+// The product must be synced, as the public base URLs of hosted products are assigned by 3scale
+func (p *OpenAPIActiveDocReconciler) Reconcile(productSynced bool) (*capabilitiesv1beta1.ActiveDoc, error) {
+	if p.openapiCR.Spec.ActiveDoc == nil {
+		return nil, p.deleteObsoleteActiveDoc("")
+	}
+
+	if !productSynced {
+		// wait for the product
+		return nil, nil
+	}
+
+	document, err := p.desiredDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := p.desiredSecret(document)
+	if err != nil {
+		return nil, err
+	}
+
+	activeDoc, err := p.desiredActiveDoc(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Logger().V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(activeDoc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		p.Logger().V(1).Info(string(jsonData))
+	}
+
+	err = p.ReconcileResource(&corev1.Secret{}, secret, p.secretMutator)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.ReconcileResource(&capabilitiesv1beta1.ActiveDoc{}, activeDoc, p.activeDocMutator)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.deleteObsoleteActiveDoc(activeDoc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return activeDoc, nil
+}
+
+// desiredDocument returns the OpenAPI document of the ActiveDoc.
+// The servers are the product public base URLs and the security is the product authentication mode
+func (p *OpenAPIActiveDocReconciler) desiredDocument() ([]byte, error) {
+	productionPublicBaseURL, stagingPublicBaseURL, err := p.publicBaseURLs()
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy of the document, the OpenAPI object is shared with the other reconcilers
+	data, err := p.openapiObj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	document, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath, err := helper.BasePathFromOpenAPI(p.openapiObj)
+	if err != nil {
+		return nil, err
+	}
+
+	activeDocServers(document, productionPublicBaseURL, stagingPublicBaseURL, basePath)
+	activeDocSecurity(document, p.product.Spec.Deployment)
+
+	return document.MarshalJSON()
+}
+
+// publicBaseURLs returns the production and staging public base URLs.
+// Self managed products have them in the spec, hosted products read them from 3scale
+func (p *OpenAPIActiveDocReconciler) publicBaseURLs() (string, string, error) {
+	if p.product.Spec.Deployment.ApicastSelfManaged != nil {
+		var production, staging string
+		if p.product.Spec.ProdPublicBaseURL() != nil {
+			production = *p.product.Spec.ProdPublicBaseURL()
+		}
+		if p.product.Spec.StagingPublicBaseURL() != nil {
+			staging = *p.product.Spec.StagingPublicBaseURL()
+		}
+		return production, staging, nil
+	}
+
+	product := &capabilitiesv1beta1.Product{}
+	err := p.Client().Get(p.Context(), client.ObjectKeyFromObject(p.product), product)
+	if err != nil {
+		return "", "", err
+	}
+
+	if product.Status.ID == nil {
+		return "", "", fmt.Errorf("product %s does not have ID", helper.ObjectInfo(product))
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(p.openapiCR.GetAnnotations())
+	threescaleAPIClient, err := controllerhelper.PortaClient(p.providerAccount, insecureSkipVerify)
+	if err != nil {
+		return "", "", err
+	}
+
+	proxy, err := threescaleAPIClient.ProductProxy(*product.Status.ID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read product %s proxy: %w", helper.ObjectInfo(product), err)
+	}
+
+	return proxy.Element.Endpoint, proxy.Element.SandboxEndpoint, nil
+}
+
+func (p *OpenAPIActiveDocReconciler) desiredObjName() string {
+	return fmt.Sprintf("%s-activedoc-%s", helper.K8sNameFromOpenAPITitle(p.openapiObj), string(p.openapiCR.UID))
+}
+
+func (p *OpenAPIActiveDocReconciler) desiredSecret(document []byte) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.desiredObjName(),
+			Namespace: p.openapiCR.Namespace,
+		},
+		StringData: map[string]string{
+			openAPIActiveDocSecretKey: string(document),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+
+	err := p.SetControllerOwnerReference(p.openapiCR, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (p *OpenAPIActiveDocReconciler) desiredActiveDoc(document []byte) (*capabilitiesv1beta1.ActiveDoc, error) {
+	activeDocSpec := p.openapiCR.Spec.ActiveDoc
+
+	name := p.openapiObj.Info.Title
+	if activeDocSpec.Name != nil {
+		name = *activeDocSpec.Name
+	}
+
+	var description *string
+	if p.openapiObj.Info.Description != "" {
+		description = &p.openapiObj.Info.Description
+	}
+	if activeDocSpec.Description != nil {
+		description = activeDocSpec.Description
+	}
+
+	productSystemName := p.product.Spec.SystemName
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(p.openapiCR.GetAnnotations())
+
+	activeDoc := &capabilitiesv1beta1.ActiveDoc{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.ActiveDocKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.desiredObjName(),
+			Namespace: p.openapiCR.Namespace,
+			Annotations: map[string]string{
+				"insecure_skip_verify":            strconv.FormatBool(insecureSkipVerify),
+				openAPIActiveDocHashAnnotationKey: controllerhelper.OpenAPIDocumentHash(document),
+			},
+		},
+		Spec: capabilitiesv1beta1.ActiveDocSpec{
+			ProviderAccountRef: p.openapiCR.Spec.ProviderAccountRef,
+			Name:               name,
+			Description:        description,
+			ActiveDocOpenAPIRef: capabilitiesv1beta1.ActiveDocOpenAPIRefSpec{
+				SecretRef: &corev1.ObjectReference{
+					Name:      p.desiredObjName(),
+					Namespace: p.openapiCR.Namespace,
+				},
+			},
+			ProductSystemName:      &productSystemName,
+			Published:              activeDocSpec.Published,
+			SkipSwaggerValidations: activeDocSpec.SkipSwaggerValidations,
+		},
+	}
+
+	activeDoc.SetDefaults(p.Logger())
+
+	// internal validation
+	validationErrors := activeDoc.Validate()
+	if len(validationErrors) > 0 {
+		return nil, errors.New(validationErrors.ToAggregate().Error())
+	}
+
+	err := p.SetControllerOwnerReference(p.openapiCR, activeDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return activeDoc, nil
+}
+
+// deleteObsoleteActiveDoc deletes the activedocs and secrets owned by the OpenAPI CR other than the desired one,
+// i.e. when the activeDoc field is removed or the document title changes.
+// The activedoc controller deletes the activedoc from 3scale.
+func (p *OpenAPIActiveDocReconciler) deleteObsoleteActiveDoc(desiredName string) error {
+	activeDocList := &capabilitiesv1beta1.ActiveDocList{}
+	err := p.Client().List(p.Context(), activeDocList, client.InNamespace(p.openapiCR.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list activedocs: %w", err)
+	}
+
+	for idx := range activeDocList.Items {
+		activeDoc := &activeDocList.Items[idx]
+		if activeDoc.Name == desiredName || !p.HasOwnerReference(p.openapiCR, activeDoc) || activeDoc.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		p.Logger().Info(fmt.Sprintf("deleting obsolete activedoc %s", helper.ObjectInfo(activeDoc)))
+		err = p.DeleteResource(activeDoc)
+		if err != nil && !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		// The secret has the activedoc name
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: activeDoc.Name, Namespace: activeDoc.Namespace},
+		}
+		err = p.DeleteResource(secret)
+		if err != nil && !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *OpenAPIActiveDocReconciler) secretMutator(existingObj, desiredObj client.Object) (bool, error) {
+	existing, ok := existingObj.(*corev1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", existingObj)
+	}
+	desired, ok := desiredObj.(*corev1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", desiredObj)
+	}
+
+	// OwnerRefenrence
+	updated, err := p.EnsureOwnerReference(p.openapiCR, existing)
+	if err != nil {
+		return false, err
+	}
+
+	updated = reconcilers.SecretStringDataMutator(desired, existing) || updated
+
+	return updated, nil
+}
+
+func (p *OpenAPIActiveDocReconciler) activeDocMutator(existingObj, desiredObj client.Object) (bool, error) {
+	existing, ok := existingObj.(*capabilitiesv1beta1.ActiveDoc)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.ActiveDoc", existingObj)
+	}
+	desired, ok := desiredObj.(*capabilitiesv1beta1.ActiveDoc)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.ActiveDoc", desiredObj)
+	}
+
+	// Metadata labels and annotations
+	updated := helper.EnsureObjectMeta(existing, desired)
+
+	// OwnerRefenrence
+	updatedTmp, err := p.EnsureOwnerReference(p.openapiCR, existing)
+	if err != nil {
+		return false, err
+	}
+	updated = updated || updatedTmp
+
+	if !reflect.DeepEqual(existing.Spec, desired.Spec) {
+		diff := cmp.Diff(existing.Spec, desired.Spec)
+		p.Logger().Info(fmt.Sprintf("%s spec has changed: %s", helper.ObjectInfo(desired), diff))
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
+
+// activeDocServers replaces the servers of the document with the public base URLs.
+// The base path of the document is the public base path of the product
+func activeDocServers(document *openapi3.T, productionPublicBaseURL, stagingPublicBaseURL, basePath string) {
+	servers := openapi3.Servers{}
+	for _, publicBaseURL := range []struct {
+		url         string
+		description string
+	}{
+		{productionPublicBaseURL, "Production"},
+		{stagingPublicBaseURL, "Staging"},
+	} {
+		if publicBaseURL.url == "" {
+			continue
+		}
+
+		servers = append(servers, &openapi3.Server{
+			URL:         strings.TrimSuffix(publicBaseURL.url, "/") + LastSlashRegexp.ReplaceAllString(basePath, ""),
+			Description: publicBaseURL.description,
+		})
+	}
+
+	document.Servers = servers
+}
+
+// activeDocSecurity replaces the security schemes of the document with the ones of the authentication mode.
+// 3scale applies the same authentication to every operation, so operation security requirements are removed
+func activeDocSecurity(document *openapi3.T, deployment *capabilitiesv1beta1.ProductDeploymentSpec) {
+	securitySchemes := openapi3.SecuritySchemes{}
+	securityRequirement := openapi3.SecurityRequirement{}
+
+	credentialsLocation := "query"
+	if deployment.CredentialsLocation() != nil {
+		credentialsLocation = *deployment.CredentialsLocation()
+	}
+
+	// The security requirements were validated by the controller.
+	// The product credentials are read from the security schemes of the document when they are located in cookies
+	authentication, _ := helper.OpenAPIAuthenticationFromOpenAPI(document)
+
+	switch {
+	case authentication != nil && len(authentication.CookieCredentials()) > 0:
+		activeDocDocumentSecurity(authentication, securitySchemes, securityRequirement)
+	case *deployment.AuthenticationMode() == "1":
+		userKey := "user_key"
+		if deployment.AuthUserKey() != nil {
+			userKey = *deployment.AuthUserKey()
+		}
+
+		if credentialsLocation == "authorization" {
+			securitySchemes["user_key"] = activeDocBasicSecurityScheme("The user key is the username")
+		} else {
+			securitySchemes["user_key"] = activeDocAPIKeySecurityScheme(userKey, credentialsLocation)
+		}
+		securityRequirement["user_key"] = []string{}
+	case *deployment.AuthenticationMode() == "2":
+		appID := "app_id"
+		if deployment.AuthAppID() != nil {
+			appID = *deployment.AuthAppID()
+		}
+		appKey := "app_key"
+		if deployment.AuthAppKey() != nil {
+			appKey = *deployment.AuthAppKey()
+		}
+
+		if credentialsLocation == "authorization" {
+			securitySchemes["app_id"] = activeDocBasicSecurityScheme("The application ID is the username and the application key is the password")
+			securityRequirement["app_id"] = []string{}
+		} else {
+			securitySchemes["app_id"] = activeDocAPIKeySecurityScheme(appID, credentialsLocation)
+			securitySchemes["app_key"] = activeDocAPIKeySecurityScheme(appKey, credentialsLocation)
+			securityRequirement["app_id"] = []string{}
+			securityRequirement["app_key"] = []string{}
+		}
+	case *deployment.AuthenticationMode() == "oidc" && authentication != nil:
+		// The product OpenID Connect authentication is read from the oauth2 or openIdConnect security scheme
+		activeDocDocumentSecurity(authentication, securitySchemes, securityRequirement)
+	}
+
+	document.Components.SecuritySchemes = securitySchemes
+	document.Security = openapi3.SecurityRequirements{securityRequirement}
+
+	for _, pathItem := range document.Paths {
+		for _, operation := range pathItem.Operations() {
+			operation.Security = nil
+		}
+	}
+}
+
+// activeDocDocumentSecurity keeps the security schemes of the document the authentication is read from
+func activeDocDocumentSecurity(authentication *helper.OpenAPIAuthentication, securitySchemes openapi3.SecuritySchemes, securityRequirement openapi3.SecurityRequirement) {
+	for _, secReq := range authentication.SecuritySchemes() {
+		securitySchemes[secReq.Name] = secReq.SecuritySchemeRef
+		scopes := secReq.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+		securityRequirement[secReq.Name] = scopes
+	}
+}
+
+func activeDocAPIKeySecurityScheme(name, credentialsLocation string) *openapi3.SecuritySchemeRef {
+	in := "query"
+	if credentialsLocation == "headers" {
+		in = "header"
+	}
+
+	return &openapi3.SecuritySchemeRef{
+		Value: openapi3.NewSecurityScheme().WithType("apiKey").WithName(name).WithIn(in),
+	}
+}
+
+func activeDocBasicSecurityScheme(description string) *openapi3.SecuritySchemeRef {
+	return &openapi3.SecuritySchemeRef{
+		Value: openapi3.NewSecurityScheme().WithType("http").WithScheme("basic").WithDescription(description),
+	}
+}
@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func getActiveDocOpenAPICR() *capabilitiesv1beta1.OpenAPI {
+	openapiCR := getOpenAPICR()
+	openapiCR.UID = types.UID("4b1e0e4c-5f4f-4f7e-9a0e-2f3c8a6f7d21")
+	openapiCR.Spec.ProductionPublicBaseURL = ptr.To("https://petstore.example.com")
+	openapiCR.Spec.StagingPublicBaseURL = ptr.To("https://petstore-staging.example.com/")
+	openapiCR.Spec.ActiveDoc = &capabilitiesv1beta1.OpenAPIActiveDocSpec{
+		Published: ptr.To(true),
+	}
+	return openapiCR
+}
+
+func TestOpenAPIActiveDocReconciler_Reconcile(t *testing.T) {
+	openapiCR := getActiveDocOpenAPICR()
+	openapiObj := getOpenAPIObj(getValidOpenAPISecret())
+	baseReconciler := getOpenAPIBaseReconciler(openapiCR)
+
+	product, err := NewOpenAPIProductReconciler(baseReconciler, openapiCR, openapiObj, nil, getOpenAPITestLogger()).desired()
+	if err != nil {
+		t.Fatalf("desired() error = %v", err)
+	}
+
+	p := NewOpenAPIActiveDocReconciler(baseReconciler, openapiCR, openapiObj, product, nil, getOpenAPITestLogger())
+
+	// waits for the product
+	activeDoc, err := p.Reconcile(false)
+	if err != nil || activeDoc != nil {
+		t.Fatalf("Reconcile() = %v, %v, want no activedoc before the product is synced", activeDoc, err)
+	}
+
+	activeDoc, err = p.Reconcile(true)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	existing := &capabilitiesv1beta1.ActiveDoc{}
+	err = baseReconciler.Client().Get(baseReconciler.Context(), client.ObjectKeyFromObject(activeDoc), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if existing.Spec.Name != "Swagger Petstore" {
+		t.Errorf("name = %s", existing.Spec.Name)
+	}
+	if existing.Spec.ProductSystemName == nil || *existing.Spec.ProductSystemName != product.Spec.SystemName {
+		t.Errorf("product system name = %v, want %s", existing.Spec.ProductSystemName, product.Spec.SystemName)
+	}
+	if existing.Spec.Published == nil || !*existing.Spec.Published {
+		t.Errorf("published = %v", existing.Spec.Published)
+	}
+	if existing.Annotations[openAPIActiveDocHashAnnotationKey] == "" {
+		t.Errorf("missing document hash annotation")
+	}
+
+	secret := &corev1.Secret{}
+	err = baseReconciler.Client().Get(baseReconciler.Context(), types.NamespacedName{
+		Name:      existing.Spec.ActiveDocOpenAPIRef.SecretRef.Name,
+		Namespace: existing.Spec.ActiveDocOpenAPIRef.SecretRef.Namespace,
+	}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document, err := openapi3.NewLoader().LoadFromData([]byte(secret.StringData[openAPIActiveDocSecretKey]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	servers := []string{}
+	for _, server := range document.Servers {
+		servers = append(servers, server.URL)
+	}
+	wantServers := []string{"https://petstore.example.com/v1", "https://petstore-staging.example.com/v1"}
+	if !reflect.DeepEqual(servers, wantServers) {
+		t.Errorf("servers diff: %s", cmp.Diff(wantServers, servers))
+	}
+	if _, ok := document.Components.SecuritySchemes["user_key"]; !ok {
+		t.Errorf("missing user_key security scheme: %v", document.Components.SecuritySchemes)
+	}
+
+	// disabling the activedoc deletes it
+	openapiCR.Spec.ActiveDoc = nil
+	_, err = p.Reconcile(true)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	activeDocList := &capabilitiesv1beta1.ActiveDocList{}
+	err = baseReconciler.Client().List(baseReconciler.Context(), activeDocList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activeDocList.Items) != 0 {
+		t.Errorf("got %d activedocs, want 0", len(activeDocList.Items))
+	}
+}
+
+func TestActiveDocSecurity(t *testing.T) {
+	cases := []struct {
+		name           string
+		authentication *capabilitiesv1beta1.AuthenticationSpec
+		want           map[string]*openapi3.SecurityScheme
+	}{
+		{
+			name: "user key defaults",
+			authentication: &capabilitiesv1beta1.AuthenticationSpec{
+				UserKeyAuthentication: &capabilitiesv1beta1.UserKeyAuthenticationSpec{},
+			},
+			want: map[string]*openapi3.SecurityScheme{
+				"user_key": {Type: "apiKey", Name: "user_key", In: "query"},
+			},
+		},
+		{
+			name: "user key in headers",
+			authentication: &capabilitiesv1beta1.AuthenticationSpec{
+				UserKeyAuthentication: &capabilitiesv1beta1.UserKeyAuthenticationSpec{
+					Key:            ptr.To("X-API-Key"),
+					CredentialsLoc: ptr.To("headers"),
+				},
+			},
+			want: map[string]*openapi3.SecurityScheme{
+				"user_key": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+			},
+		},
+		{
+			name: "app id and app key",
+			authentication: &capabilitiesv1beta1.AuthenticationSpec{
+				AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+					AppID: ptr.To("client"),
+				},
+			},
+			want: map[string]*openapi3.SecurityScheme{
+				"app_id":  {Type: "apiKey", Name: "client", In: "query"},
+				"app_key": {Type: "apiKey", Name: "app_key", In: "query"},
+			},
+		},
+		{
+			name: "app id and app key in authorization header",
+			authentication: &capabilitiesv1beta1.AuthenticationSpec{
+				AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+					CredentialsLoc: ptr.To("authorization"),
+				},
+			},
+			want: map[string]*openapi3.SecurityScheme{
+				"app_id": {Type: "http", Scheme: "basic", Description: "The application ID is the username and the application key is the password"},
+			},
+		},
+		{
+			name: "openid connect keeps the document scheme",
+			authentication: &capabilitiesv1beta1.AuthenticationSpec{
+				OIDC: &capabilitiesv1beta1.OIDCSpec{},
+			},
+			want: map[string]*openapi3.SecurityScheme{
+				"oidc": {Type: "openIdConnect", OpenIdConnectUrl: "https://sso.example.com/.well-known/openid-configuration"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			document := &openapi3.T{
+				Components: openapi3.Components{
+					SecuritySchemes: openapi3.SecuritySchemes{
+						"api_key": {Value: &openapi3.SecurityScheme{Type: "apiKey", Name: "api_key", In: "cookie"}},
+						"oidc":    {Value: &openapi3.SecurityScheme{Type: "openIdConnect", OpenIdConnectUrl: "https://sso.example.com/.well-known/openid-configuration"}},
+					},
+				},
+				Paths: openapi3.Paths{
+					"/pets": &openapi3.PathItem{
+						Get: &openapi3.Operation{Security: &openapi3.SecurityRequirements{{"api_key": []string{}}}},
+					},
+				},
+			}
+			if tc.authentication.OIDC != nil {
+				document.Security = openapi3.SecurityRequirements{{"oidc": []string{}}}
+			}
+
+			deployment := &capabilitiesv1beta1.ProductDeploymentSpec{
+				ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{Authentication: tc.authentication},
+			}
+			activeDocSecurity(document, deployment)
+
+			got := map[string]*openapi3.SecurityScheme{}
+			for name, scheme := range document.Components.SecuritySchemes {
+				got[name] = scheme.Value
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				subT.Errorf("security schemes diff: %s", cmp.Diff(tc.want, got))
+			}
+			if len(document.Security) != 1 || len(document.Security[0]) != len(tc.want) {
+				subT.Errorf("security requirements = %v", document.Security)
+			}
+			if document.Paths["/pets"].Get.Security != nil {
+				subT.Errorf("operation security was not removed")
+			}
+		})
+	}
+}
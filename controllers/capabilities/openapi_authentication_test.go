@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+)
+
+func getSecurityOpenAPIObj(t *testing.T, security string) *openapi3.T {
+	document := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+servers:
+  - url: http://petstore.swagger.io/v1
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: A paged array of pets
+` + security
+
+	openapiObj, err := openapi3.NewLoader().LoadFromData([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return openapiObj
+}
+
+func TestOpenAPIProductReconciler_desiredAuthentication(t *testing.T) {
+	cases := []struct {
+		name     string
+		security string
+		mode     string
+		want     *capabilitiesv1beta1.AuthenticationSpec
+	}{
+		{
+			name: "app id and app key by name",
+			security: `
+components:
+  securitySchemes:
+    appId:
+      type: apiKey
+      name: X-App-Id
+      in: header
+    appKey:
+      type: apiKey
+      name: X-App-Key
+      in: header
+security:
+  - appId: []
+    appKey: []
+`,
+			mode: helper.OpenAPIAuthenticationAppIDAppKey,
+			want: &capabilitiesv1beta1.AuthenticationSpec{
+				AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+					AppID:          ptr.To("X-App-Id"),
+					AppKey:         ptr.To("X-App-Key"),
+					CredentialsLoc: ptr.To("headers"),
+				},
+			},
+		},
+		{
+			name: "app id and app key by extension",
+			security: `
+components:
+  securitySchemes:
+    client:
+      type: apiKey
+      name: client
+      in: query
+      x-3scale-credential: app_id
+    secret:
+      type: apiKey
+      name: secret
+      in: query
+      x-3scale-credential: app_key
+security:
+  - client: []
+    secret: []
+`,
+			mode: helper.OpenAPIAuthenticationAppIDAppKey,
+			want: &capabilitiesv1beta1.AuthenticationSpec{
+				AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+					AppID:          ptr.To("client"),
+					AppKey:         ptr.To("secret"),
+					CredentialsLoc: ptr.To("query"),
+				},
+			},
+		},
+		{
+			name: "http basic",
+			security: `
+components:
+  securitySchemes:
+    basic:
+      type: http
+      scheme: basic
+security:
+  - basic: []
+`,
+			mode: helper.OpenAPIAuthenticationAppIDAppKey,
+			want: &capabilitiesv1beta1.AuthenticationSpec{
+				AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+					CredentialsLoc: ptr.To("authorization"),
+				},
+			},
+		},
+		{
+			name: "user key in cookie after unsupported requirement",
+			security: `
+components:
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+    session:
+      type: apiKey
+      name: session
+      in: cookie
+security:
+  - bearer: []
+  - session: []
+`,
+			mode: helper.OpenAPIAuthenticationUserKey,
+			want: &capabilitiesv1beta1.AuthenticationSpec{
+				UserKeyAuthentication: &capabilitiesv1beta1.UserKeyAuthenticationSpec{
+					Key:            ptr.To("session"),
+					CredentialsLoc: ptr.To("headers"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			openapiObj := getSecurityOpenAPIObj(subT, tc.security)
+
+			authentication, err := helper.OpenAPIAuthenticationFromOpenAPI(openapiObj)
+			if err != nil {
+				subT.Fatalf("OpenAPIAuthenticationFromOpenAPI() error = %v", err)
+			}
+			if authentication.Mode != tc.mode || authentication.Reason == "" {
+				subT.Errorf("authentication mode = %s, reason = %s", authentication.Mode, authentication.Reason)
+			}
+
+			p := NewOpenAPIProductReconciler(getOpenAPIBaseReconciler(), getOpenAPICR(), openapiObj, nil, getOpenAPITestLogger())
+			got := p.desiredAuthentication()
+			if !reflect.DeepEqual(got, tc.want) {
+				subT.Errorf("authentication diff: %s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestOpenAPIProductReconciler_desiredCookieCredentialsPolicy(t *testing.T) {
+	openapiObj := getSecurityOpenAPIObj(t, `
+components:
+  securitySchemes:
+    app_id:
+      type: apiKey
+      name: app_id
+      in: cookie
+    app_key:
+      type: apiKey
+      name: app_key
+      in: header
+security:
+  - app_id: []
+    app_key: []
+`)
+
+	p := NewOpenAPIProductReconciler(getOpenAPIBaseReconciler(), getOpenAPICR(), openapiObj, nil, getOpenAPITestLogger())
+	policies, err := p.desiredPolicies()
+	if err != nil {
+		t.Fatalf("desiredPolicies() error = %v", err)
+	}
+	if len(policies) != 2 || policies[0].Name != "headers" || policies[1].Name != "apicast" {
+		t.Fatalf("unexpected policy chain: %v", policies)
+	}
+
+	got := map[string]interface{}{}
+	err = json.Unmarshal(policies[0].Configuration.Raw, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"request": []interface{}{
+			map[string]interface{}{
+				"op":         "set",
+				"header":     "app_id",
+				"value":      "{{ headers['Cookie'] | prepend: '; ' | split: '; app_id=' | last | split: ';' | first }}",
+				"value_type": "liquid",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("configuration diff: %s", cmp.Diff(want, got))
+	}
+}
+
+func TestOpenAPIAuthenticationFromOpenAPI_unsupported(t *testing.T) {
+	openapiObj := getSecurityOpenAPIObj(t, `
+components:
+  securitySchemes:
+    app_key:
+      type: apiKey
+      name: app_key
+      in: query
+    oidc:
+      type: openIdConnect
+      openIdConnectUrl: https://sso.example.com/.well-known/openid-configuration
+security:
+  - app_key: []
+  - app_key: []
+    oidc: []
+`)
+
+	_, err := helper.OpenAPIAuthenticationFromOpenAPI(openapiObj)
+	if err == nil || !strings.Contains(err.Error(), "security requirement 1 [app_key, oidc] skipped") {
+		t.Errorf("OpenAPIAuthenticationFromOpenAPI() error = %v", err)
+	}
+}
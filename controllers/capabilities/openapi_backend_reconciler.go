@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -62,33 +64,56 @@ func (p *OpenAPIBackendReconciler) Reconcile() ([]*capabilitiesv1beta1.Backend,
 		p.Logger().V(1).Info(string(jsonData))
 	}
 
-	// single backend implementation
-	return nil, p.ReconcileResource(&capabilitiesv1beta1.Backend{}, desired, p.backendMutator)
+	for _, backend := range desired {
+		err = p.ReconcileResource(&capabilitiesv1beta1.Backend{}, backend, p.backendMutator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.deleteObsoleteBackends(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return desired, nil
 }
 
-func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, error) {
-	fieldErrors := field.ErrorList{}
-	specFldPath := field.NewPath("spec")
-	openapiRefFldPath := specFldPath.Child("openapiRef")
+func (p *OpenAPIBackendReconciler) desired() ([]*capabilitiesv1beta1.Backend, error) {
+	backendsExtension, err := helper.NewOasBackendsExtension(p.openapiObj)
+	if err != nil {
+		return nil, err
+	}
 
+	// single backend implementation when the document does not split operations into backends
+	if len(backendsExtension) == 0 {
+		backend, err := p.desiredSingleBackend()
+		if err != nil {
+			return nil, err
+		}
+
+		return []*capabilitiesv1beta1.Backend{backend}, nil
+	}
+
+	backends := make([]*capabilitiesv1beta1.Backend, 0, len(backendsExtension))
+	for idx := range backendsExtension {
+		backend, err := p.desiredExtensionBackend(backendsExtension, &backendsExtension[idx])
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+
+	return backends, nil
+}
+
+func (p *OpenAPIBackendReconciler) desiredSingleBackend() (*capabilitiesv1beta1.Backend, error) {
 	// system name
 	systemName := p.desiredSystemName()
 
 	// obj name
 	objName := p.desiredObjName()
 
-	// DNS Subdomain Names
-	// If the name would be part of some label, validation would be DNS Label Names (validation.IsDNS1123Label)
-	// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/
-	errStrings := validation.IsDNS1123Subdomain(objName)
-	if len(errStrings) > 0 {
-		fieldErrors = append(fieldErrors, field.Invalid(openapiRefFldPath, p.openapiCR.Spec.OpenAPIRef, strings.Join(errStrings, ",")))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
 	// backend name
 	name := fmt.Sprintf("%s Backend", p.openapiObj.Info.Title)
 
@@ -101,8 +126,95 @@ func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, erro
 		return nil, err
 	}
 
+	return p.desiredBackend(objName, capabilitiesv1beta1.BackendSpec{
+		Name:           name,
+		SystemName:     systemName,
+		PrivateBaseURL: privateBaseURL,
+		Description:    description,
+	})
+}
+
+// desiredExtensionBackend returns the backend of one of the x-3scale-backends items.
+// The backend owns the methods and mapping rules of the operations routed to it.
+func (p *OpenAPIBackendReconciler) desiredExtensionBackend(backendsExtension []helper.OasBackendExtension, backendExtension *helper.OasBackendExtension) (*capabilitiesv1beta1.Backend, error) {
+	name := backendExtension.Name
+	if name == "" {
+		name = fmt.Sprintf("%s %s Backend", p.openapiObj.Info.Title, backendExtension.SystemName)
+	}
+
+	description := backendExtension.Description
+	if description == "" {
+		description = fmt.Sprintf("Backend of %s serving %s", p.openapiObj.Info.Title, backendExtension.Path)
+	}
+
+	spec := capabilitiesv1beta1.BackendSpec{
+		Name:           name,
+		SystemName:     backendExtension.SystemName,
+		PrivateBaseURL: backendExtension.PrivateBaseURL,
+		Description:    description,
+		Metrics:        backendExtension.Metrics,
+		Methods:        map[string]capabilitiesv1beta1.MethodSpec{},
+		MappingRules:   []capabilitiesv1beta1.MappingRuleSpec{},
+	}
+
+	for path, pathItem := range p.openapiObj.Paths {
+		routedBackend := helper.OasBackendForPath(backendsExtension, path)
+		if routedBackend == nil || routedBackend.SystemName != backendExtension.SystemName {
+			continue
+		}
+
+		// mapping rule patterns of backends are relative to the backend usage path
+		pattern := openAPIMappingRulePattern(p.openapiCR, helper.OasBackendRelativePath(backendExtension, path))
+
+		for opVerb, operation := range pathItem.Operations() {
+			methodSystemName := helper.MethodSystemNameFromOpenAPIOperation(path, opVerb, operation)
+			spec.Methods[methodSystemName] = openAPIMethod(path, opVerb, operation)
+
+			mappingRule, err := openAPIMappingRule(path, opVerb, operation, pattern)
+			if err != nil {
+				return nil, err
+			}
+			spec.MappingRules = append(spec.MappingRules, mappingRule)
+		}
+	}
+
+	// Paths are iterated in random order
+	sort.Slice(spec.MappingRules, func(i, j int) bool {
+		if spec.MappingRules[i].Pattern != spec.MappingRules[j].Pattern {
+			return spec.MappingRules[i].Pattern < spec.MappingRules[j].Pattern
+		}
+		return spec.MappingRules[i].HTTPMethod < spec.MappingRules[j].HTTPMethod
+	})
+
+	objName := fmt.Sprintf("%s-%s-%s",
+		helper.K8sNameFromOpenAPITitle(p.openapiObj),
+		helper.NonAlphanumRegexp.ReplaceAllString(strings.ToLower(backendExtension.SystemName), ""),
+		string(p.openapiCR.UID))
+
+	return p.desiredBackend(objName, spec)
+}
+
+func (p *OpenAPIBackendReconciler) desiredBackend(objName string, spec capabilitiesv1beta1.BackendSpec) (*capabilitiesv1beta1.Backend, error) {
+	fieldErrors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+	openapiRefFldPath := specFldPath.Child("openapiRef")
+
+	// DNS Subdomain Names
+	// If the name would be part of some label, validation would be DNS Label Names (validation.IsDNS1123Label)
+	// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/
+	errStrings := validation.IsDNS1123Subdomain(objName)
+	if len(errStrings) > 0 {
+		fieldErrors = append(fieldErrors, field.Invalid(openapiRefFldPath, p.openapiCR.Spec.OpenAPIRef, strings.Join(errStrings, ",")))
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
 	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(p.openapiCR.GetAnnotations())
 
+	spec.ProviderAccountRef = p.openapiCR.Spec.ProviderAccountRef
+
 	backend := &capabilitiesv1beta1.Backend{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       capabilitiesv1beta1.BackendKind,
@@ -115,13 +227,7 @@ func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, erro
 				"insecure_skip_verify": strconv.FormatBool(insecureSkipVerify),
 			},
 		},
-		Spec: capabilitiesv1beta1.BackendSpec{
-			Name:               name,
-			SystemName:         systemName,
-			PrivateBaseURL:     privateBaseURL,
-			Description:        description,
-			ProviderAccountRef: p.openapiCR.Spec.ProviderAccountRef,
-		},
+		Spec: spec,
 	}
 
 	backend.SetDefaults(p.Logger())
@@ -132,7 +238,7 @@ func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, erro
 		return nil, errors.New(validationErrors.ToAggregate().Error())
 	}
 
-	err = p.SetControllerOwnerReference(p.openapiCR, backend)
+	err := p.SetControllerOwnerReference(p.openapiCR, backend)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +246,37 @@ func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, erro
 	return backend, nil
 }
 
+// deleteObsoleteBackends deletes the backends owned by the OpenAPI CR that are no longer desired,
+// i.e. when backends are removed from the x-3scale-backends extension.
+// The backend controller removes the backend usages from the product before deleting the backend.
+func (p *OpenAPIBackendReconciler) deleteObsoleteBackends(desired []*capabilitiesv1beta1.Backend) error {
+	desiredNames := map[string]bool{}
+	for _, backend := range desired {
+		desiredNames[backend.Name] = true
+	}
+
+	list := &capabilitiesv1beta1.BackendList{}
+	err := p.Client().List(p.Context(), list, client.InNamespace(p.openapiCR.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list backends: %w", err)
+	}
+
+	for idx := range list.Items {
+		backend := &list.Items[idx]
+		if desiredNames[backend.Name] || !p.HasOwnerReference(p.openapiCR, backend) || backend.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		p.Logger().Info(fmt.Sprintf("deleting obsolete backend %s", helper.ObjectInfo(backend)))
+		err = p.DeleteResource(backend)
+		if err != nil && !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *OpenAPIBackendReconciler) backendMutator(existingObj, desiredObj client.Object) (bool, error) {
 	existing, ok := existingObj.(*capabilitiesv1beta1.Backend)
 	if !ok {
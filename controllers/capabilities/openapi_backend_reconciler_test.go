@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func getMultiBackendOpenAPICR() *capabilitiesv1beta1.OpenAPI {
+	openapiCR := getOpenAPICR()
+	openapiCR.UID = types.UID("d9a11fc9-5ce2-4d9f-a4d1-f0b0b6a0b8f0")
+	return openapiCR
+}
+
+func TestOpenAPIBackendReconciler_desired(t *testing.T) {
+	openapiCR := getMultiBackendOpenAPICR()
+	p := NewOpenAPIBackendReconciler(
+		getOpenAPIBaseReconciler(openapiCR, getMultiBackendOpenAPISecret()),
+		openapiCR,
+		getOpenAPIObj(getMultiBackendOpenAPISecret()),
+		nil,
+		getOpenAPITestLogger(),
+	)
+
+	backends, err := p.desired()
+	if err != nil {
+		t.Fatalf("desired() error = %v", err)
+	}
+	if len(backends) != 2 {
+		t.Fatalf("desired() got %d backends, want 2", len(backends))
+	}
+
+	pets := backends[0]
+	if pets.Name != "swaggerpetstore-pets-"+string(openapiCR.UID) {
+		t.Errorf("name = %s", pets.Name)
+	}
+	if pets.Spec.SystemName != "pets" || pets.Spec.PrivateBaseURL != "http://pets.petstore.svc:8080" {
+		t.Errorf("unexpected pets backend: %v", pets.Spec)
+	}
+	wantPetsRules := []capabilitiesv1beta1.MappingRuleSpec{
+		{HTTPMethod: "GET", Pattern: "/$", MetricMethodRef: "listpets", Increment: 1},
+		{HTTPMethod: "GET", Pattern: "/{petId}$", MetricMethodRef: "petreads", Increment: 1},
+	}
+	if !reflect.DeepEqual(pets.Spec.MappingRules, wantPetsRules) {
+		t.Errorf("pets mapping rules diff: %s", cmp.Diff(wantPetsRules, pets.Spec.MappingRules))
+	}
+	if _, ok := pets.Spec.Metrics["petreads"]; !ok {
+		t.Errorf("pets backend is missing the extension metric: %v", pets.Spec.Metrics)
+	}
+
+	stores := backends[1]
+	if stores.Spec.Name != "Stores" {
+		t.Errorf("stores name = %s", stores.Spec.Name)
+	}
+	wantStoresRules := []capabilitiesv1beta1.MappingRuleSpec{
+		{HTTPMethod: "POST", Pattern: "/{storeId}/orders$", MetricMethodRef: "createorder", Increment: 1},
+	}
+	if !reflect.DeepEqual(stores.Spec.MappingRules, wantStoresRules) {
+		t.Errorf("stores mapping rules diff: %s", cmp.Diff(wantStoresRules, stores.Spec.MappingRules))
+	}
+	if _, ok := stores.Spec.Methods["createorder"]; !ok {
+		t.Errorf("stores backend is missing the operation method: %v", stores.Spec.Methods)
+	}
+}
+
+func TestOpenAPIBackendReconciler_Reconcile_deletesObsoleteBackends(t *testing.T) {
+	openapiCR := getMultiBackendOpenAPICR()
+	baseReconciler := getOpenAPIBaseReconciler(openapiCR, getUnextendedOpenAPISecret())
+
+	// the single backend created before the document was split into backends
+	single := NewOpenAPIBackendReconciler(baseReconciler, openapiCR, getOpenAPIObj(getUnextendedOpenAPISecret()), nil, getOpenAPITestLogger())
+	_, err := single.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	multi := NewOpenAPIBackendReconciler(baseReconciler, openapiCR, getOpenAPIObj(getMultiBackendOpenAPISecret()), nil, getOpenAPITestLogger())
+	desired, err := multi.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	list := &capabilitiesv1beta1.BackendList{}
+	err = baseReconciler.Client().List(baseReconciler.Context(), list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != len(desired) {
+		t.Fatalf("got %d backends, want %d", len(list.Items), len(desired))
+	}
+	for _, backend := range list.Items {
+		if backend.Spec.SystemName == "Swagger_Petstore" {
+			t.Errorf("obsolete backend %s was not deleted", backend.Name)
+		}
+	}
+}
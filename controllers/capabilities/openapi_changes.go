@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/getkin/kin-openapi/openapi3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// openAPIAllowBreakingChangesAnnotation allows applying OpenAPI documents with breaking changes when set to "true"
+	openAPIAllowBreakingChangesAnnotation = "apimanager.apps.3scale.net/allow-breaking-changes"
+	// openAPIAppliedHashAnnotationKey is the hash of the applied OpenAPI document
+	openAPIAppliedHashAnnotationKey = "apimanager.apps.3scale.net/applied-openapi-hash"
+	// openAPIAppliedSummaryConfigMapKey is the field of the configmap with the summary of the applied OpenAPI document
+	openAPIAppliedSummaryConfigMapKey = "openapi-summary.json"
+)
+
+// OpenAPIChangeReport describes the changes of the OpenAPI document from the last applied one
+type OpenAPIChangeReport struct {
+	// AppliedHash is the hash of the last applied document
+	AppliedHash string
+	Changes     []capabilitiesv1beta1.OpenAPIChange
+}
+
+// checkOpenAPIChanges compares the OpenAPI document with the last applied one.
+// Returns nil report when the document is already applied.
+// Breaking changes are rejected unless the allow breaking changes annotation is set.
+func (r *OpenAPIReconciler) checkOpenAPIChanges(openapiCR *capabilitiesv1beta1.OpenAPI, openapiObj *openapi3.T, sourceVersion *OpenAPISourceVersion) (*OpenAPIChangeReport, error) {
+	if sourceVersion == nil {
+		return nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: appliedOpenAPIConfigMapName(openapiCR), Namespace: openapiCR.Namespace}, configMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Nothing applied yet
+			return &OpenAPIChangeReport{}, nil
+		}
+		return nil, err
+	}
+
+	appliedHash := configMap.Annotations[openAPIAppliedHashAnnotationKey]
+	if appliedHash == sourceVersion.Hash {
+		return nil, nil
+	}
+
+	appliedSummary, err := appliedOpenAPISummary(configMap)
+	if err != nil {
+		// The applied document cannot be compared, it will be replaced
+		r.Logger().Info("failed to read the applied OpenAPI document", "configmap", configMap.Name, "error", err.Error())
+		return &OpenAPIChangeReport{AppliedHash: appliedHash}, nil
+	}
+
+	report := &OpenAPIChangeReport{
+		AppliedHash: appliedHash,
+		Changes:     controllerhelper.DiffOpenAPISummaries(appliedSummary, controllerhelper.NewOpenAPISummary(openapiObj)),
+	}
+
+	// Report the changes once per document
+	if sourceVersion.Hash != openapiCR.Status.SourceHash {
+		for _, change := range report.Changes {
+			if change.Breaking {
+				r.EventRecorder().Eventf(openapiCR, corev1.EventTypeWarning, "BreakingOpenAPIChange", "%s %s: %s", change.Type, change.Location, change.Message)
+			} else {
+				r.EventRecorder().Eventf(openapiCR, corev1.EventTypeNormal, "OpenAPIChange", "%s %s: %s", change.Type, change.Location, change.Message)
+			}
+		}
+	}
+
+	if controllerhelper.HasBreakingOpenAPIChanges(report.Changes) && openapiCR.GetAnnotations()[openAPIAllowBreakingChangesAnnotation] != "true" {
+		return report, &helper.SpecFieldError{
+			ErrorType: helper.InvalidError,
+			FieldErrorList: field.ErrorList{
+				field.Forbidden(field.NewPath("spec").Child("openapiRef"),
+					fmt.Sprintf("the OpenAPI document has breaking changes, see status.changes. Set the %s annotation to \"true\" to apply them", openAPIAllowBreakingChangesAnnotation)),
+			},
+		}
+	}
+
+	return report, nil
+}
+
+// saveAppliedOpenAPI stores the summary of the applied OpenAPI document to compare the next revisions with.
+// The summary size depends on the operations, not on the size of the document schemas and descriptions
+func (r *OpenAPIReconciler) saveAppliedOpenAPI(openapiCR *capabilitiesv1beta1.OpenAPI, openapiObj *openapi3.T, sourceVersion *OpenAPISourceVersion, report *OpenAPIChangeReport) error {
+	if report == nil {
+		// already applied
+		return nil
+	}
+
+	summary, err := json.Marshal(controllerhelper.NewOpenAPISummary(openapiObj))
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      appliedOpenAPIConfigMapName(openapiCR),
+			Namespace: openapiCR.Namespace,
+			Annotations: map[string]string{
+				openAPIAppliedHashAnnotationKey: sourceVersion.Hash,
+			},
+		},
+		Data: map[string]string{
+			openAPIAppliedSummaryConfigMapKey: string(summary),
+		},
+	}
+
+	err = r.SetControllerOwnerReference(openapiCR, configMap)
+	if err != nil {
+		return err
+	}
+
+	err = r.ReconcileResource(&corev1.ConfigMap{}, configMap, r.appliedOpenAPIConfigMapMutator(openapiCR))
+	if err != nil {
+		return err
+	}
+
+	report.AppliedHash = sourceVersion.Hash
+
+	return nil
+}
+
+func (r *OpenAPIReconciler) appliedOpenAPIConfigMapMutator(openapiCR *capabilitiesv1beta1.OpenAPI) func(existingObj, desiredObj client.Object) (bool, error) {
+	return func(existingObj, desiredObj client.Object) (bool, error) {
+		existing, ok := existingObj.(*corev1.ConfigMap)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *v1.ConfigMap", existingObj)
+		}
+		desired, ok := desiredObj.(*corev1.ConfigMap)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *v1.ConfigMap", desiredObj)
+		}
+
+		// OwnerReference
+		updated, err := r.EnsureOwnerReference(openapiCR, existing)
+		if err != nil {
+			return false, err
+		}
+
+		helper.MergeMapStringString(&updated, &existing.ObjectMeta.Annotations, desired.Annotations)
+
+		if existing.Data == nil {
+			existing.Data = map[string]string{}
+		}
+		updated = reconcilers.ConfigMapReconcileField(desired, existing, openAPIAppliedSummaryConfigMapKey) || updated
+
+		return updated, nil
+	}
+}
+
+// appliedOpenAPISummary reads the summary of the applied OpenAPI document from the configmap
+func appliedOpenAPISummary(configMap *corev1.ConfigMap) (*controllerhelper.OpenAPISummary, error) {
+	data, ok := configMap.Data[openAPIAppliedSummaryConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("%s key not found", openAPIAppliedSummaryConfigMapKey)
+	}
+
+	summary := &controllerhelper.OpenAPISummary{}
+	if err := json.Unmarshal([]byte(data), summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func appliedOpenAPIConfigMapName(openapiCR *capabilitiesv1beta1.OpenAPI) string {
+	return fmt.Sprintf("%s-applied-openapi-%s", openapiCR.Name, string(openapiCR.UID))
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/getkin/kin-openapi/openapi3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOpenAPIReconciler_checkOpenAPIChanges(t *testing.T) {
+	openapiCR := getOpenAPICR()
+	openapiCR.UID = types.UID("0c6a8f61-3d1b-4a4e-b3a2-5e7d9c1f2b88")
+	r := &OpenAPIReconciler{BaseReconciler: getOpenAPIBaseReconciler(openapiCR)}
+
+	previousObj := getOpenAPIObj(getValidOpenAPISecret())
+	previousVersion := &OpenAPISourceVersion{Hash: "sha256:previous"}
+
+	// nothing applied yet
+	report, err := r.checkOpenAPIChanges(openapiCR, previousObj, previousVersion)
+	if err != nil {
+		t.Fatalf("checkOpenAPIChanges() error = %v", err)
+	}
+	if report == nil || len(report.Changes) != 0 {
+		t.Fatalf("checkOpenAPIChanges() = %v, want empty report", report)
+	}
+
+	err = r.saveAppliedOpenAPI(openapiCR, previousObj, previousVersion, report)
+	if err != nil {
+		t.Fatalf("saveAppliedOpenAPI() error = %v", err)
+	}
+	if report.AppliedHash != previousVersion.Hash {
+		t.Errorf("applied hash = %s, want %s", report.AppliedHash, previousVersion.Hash)
+	}
+
+	// already applied
+	report, err = r.checkOpenAPIChanges(openapiCR, previousObj, previousVersion)
+	if err != nil || report != nil {
+		t.Fatalf("checkOpenAPIChanges() = %v, %v, want no report", report, err)
+	}
+
+	// the operation is removed
+	currentObj := getOpenAPIObj(getValidOpenAPISecret())
+	currentObj.Paths = openapi3.Paths{
+		"/owners": &openapi3.PathItem{Get: &openapi3.Operation{OperationID: "listOwners"}},
+	}
+	currentVersion := &OpenAPISourceVersion{Hash: "sha256:current"}
+
+	report, err = r.checkOpenAPIChanges(openapiCR, currentObj, currentVersion)
+	if !helper.IsInvalidSpecError(err) {
+		t.Fatalf("checkOpenAPIChanges() error = %v, want invalid spec error", err)
+	}
+	if report == nil || report.AppliedHash != previousVersion.Hash || len(report.Changes) != 2 {
+		t.Fatalf("checkOpenAPIChanges() = %v", report)
+	}
+	if report.Changes[0].Type != controllerhelper.OpenAPIChangeOperationRemoved || !report.Changes[0].Breaking {
+		t.Errorf("unexpected change %v", report.Changes[0])
+	}
+
+	// breaking changes are allowed by annotation
+	openapiCR.Annotations = map[string]string{openAPIAllowBreakingChangesAnnotation: "true"}
+	report, err = r.checkOpenAPIChanges(openapiCR, currentObj, currentVersion)
+	if err != nil {
+		t.Fatalf("checkOpenAPIChanges() error = %v", err)
+	}
+	if report == nil || len(report.Changes) != 2 {
+		t.Fatalf("checkOpenAPIChanges() = %v", report)
+	}
+}
+
+func TestOpenAPIReconciler_checkOpenAPIChangesMissingSummary(t *testing.T) {
+	openapiCR := getOpenAPICR()
+	openapiCR.UID = types.UID("0c6a8f61-3d1b-4a4e-b3a2-5e7d9c1f2b88")
+
+	// applied configmap without summary
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        appliedOpenAPIConfigMapName(openapiCR),
+			Namespace:   openapiCR.Namespace,
+			Annotations: map[string]string{openAPIAppliedHashAnnotationKey: "sha256:previous"},
+		},
+	}
+	r := &OpenAPIReconciler{BaseReconciler: getOpenAPIBaseReconciler(openapiCR, configMap)}
+
+	currentObj := getOpenAPIObj(getValidOpenAPISecret())
+	currentVersion := &OpenAPISourceVersion{Hash: "sha256:current"}
+
+	// the applied document cannot be compared, it is replaced
+	report, err := r.checkOpenAPIChanges(openapiCR, currentObj, currentVersion)
+	if err != nil {
+		t.Fatalf("checkOpenAPIChanges() error = %v", err)
+	}
+	if report == nil || report.AppliedHash != "sha256:previous" || len(report.Changes) != 0 {
+		t.Fatalf("checkOpenAPIChanges() = %v", report)
+	}
+
+	err = r.saveAppliedOpenAPI(openapiCR, currentObj, currentVersion, report)
+	if err != nil {
+		t.Fatalf("saveAppliedOpenAPI() error = %v", err)
+	}
+
+	err = r.Client().Get(context.TODO(), client.ObjectKeyFromObject(configMap), configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := configMap.Data[openAPIAppliedSummaryConfigMapKey]; !ok {
+		t.Errorf("applied document summary was not stored")
+	}
+}
+
+func TestOpenAPIStatusReconciler_changeReport(t *testing.T) {
+	openapiCR := getOpenAPICR()
+	openapiCR.Status.AppliedSourceHash = "sha256:previous"
+	openapiCR.Status.Changes = []capabilitiesv1beta1.OpenAPIChange{{Type: controllerhelper.OpenAPIChangeOperationAdded, Location: "GET /pets", Message: "operation added"}}
+	baseReconciler := getOpenAPIBaseReconciler(openapiCR)
+
+	// the previous report is kept when the document was not compared
+	status, err := NewOpenAPIStatusReconciler(baseReconciler, openapiCR, "", nil, nil, nil, nil, true).calculateStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.AppliedSourceHash != "sha256:previous" || len(status.Changes) != 1 {
+		t.Errorf("status changes = %s %v", status.AppliedSourceHash, status.Changes)
+	}
+
+	report := &OpenAPIChangeReport{AppliedHash: "sha256:current"}
+	status, err = NewOpenAPIStatusReconciler(baseReconciler, openapiCR, "", nil, nil, report, nil, true).calculateStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.AppliedSourceHash != "sha256:current" || len(status.Changes) != 0 {
+		t.Errorf("status changes = %s %v", status.AppliedSourceHash, status.Changes)
+	}
+}
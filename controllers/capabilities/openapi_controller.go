@@ -21,7 +21,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
-	"time"
+	"regexp"
+	"strings"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
@@ -42,6 +43,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// pricePerUnitRegexp matches the price per unit pattern of the Product CRD
+var pricePerUnitRegexp = regexp.MustCompile(`^\d+(\.\d{2})?$`)
+
 const (
 	oasSecretLabelSelectorKey   = "apimanager.apps.3scale.net/watched-by"
 	oasSecretLabelSelectorValue = "openapi"
@@ -51,6 +55,7 @@ const (
 // OpenAPIReconciler reconciles a OpenAPI object
 type OpenAPIReconciler struct {
 	*reconcilers.BaseReconciler
+	sourceCache OpenAPISourceCache
 }
 
 // blank assignment to verify that OpenAPIReconciler implements reconcile.Reconciler
@@ -73,6 +78,7 @@ func (r *OpenAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
 			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			r.sourceCache.Delete(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -90,6 +96,7 @@ func (r *OpenAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Ignore deleted OpenAPI, this can happen when foregroundDeletion is enabled
 	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if openapiCR.DeletionTimestamp != nil {
+		r.sourceCache.Delete(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -122,6 +129,10 @@ func (r *OpenAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			// On Validation error, no need to retry as spec is not valid and needs to be changed
 			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
 			r.EventRecorder().Eventf(openapiCR, corev1.EventTypeWarning, "Invalid OpenAPI Spec", "%v", reconcileErr)
+			if openapiCR.Spec.OpenAPIRef.Polled() {
+				// The source may be fixed without changing the resource
+				return ctrl.Result{RequeueAfter: openapiCR.Spec.OpenAPIRef.RefreshIntervalDuration()}, nil
+			}
 			return ctrl.Result{}, nil
 		}
 
@@ -140,6 +151,12 @@ func (r *OpenAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Logger:    r.Logger().WithName("secretToOpenAPIEventMapper"),
 	}
 
+	configMapToOpenAPIEventMapper := &ConfigMapToOpenAPIEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("configMapToOpenAPIEventMapper"),
+	}
+
 	oasSecretLabelSelectorPredicate, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			oasSecretLabelSelectorKey: oasSecretLabelSelectorValue,
@@ -153,7 +170,11 @@ func (r *OpenAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&capabilitiesv1beta1.OpenAPI{}).
 		Owns(&capabilitiesv1beta1.Product{}).
 		Owns(&capabilitiesv1beta1.Backend{}).
+		Owns(&capabilitiesv1beta1.ActiveDoc{}).
+		Owns(&capabilitiesv1beta1.CustomPolicyDefinition{}).
+		Owns(&corev1.ConfigMap{}).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(secretToOpenAPIEventMapper.Map), builder.WatchesOption(builder.WithPredicates(oasSecretLabelSelectorPredicate))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(configMapToOpenAPIEventMapper.Map)).
 		Complete(r)
 }
 
@@ -162,13 +183,13 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 
 	err := r.validateSpec(openapiCR)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", nil, nil, nil, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), openapiCR.Namespace, openapiCR.Spec.ProviderAccountRef, logger)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", nil, nil, nil, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
@@ -176,7 +197,7 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 	// Retrieve ownersReference of tenant CR that owns the Backend CR
 	tenantCR, err := controllerhelper.RetrieveTenantCR(providerAccount, r.Client(), r.Logger(), openapiCR.Namespace)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", nil, nil, nil, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
@@ -184,56 +205,70 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 	if tenantCR != nil {
 		updated, err := r.EnsureOwnerReference(tenantCR, openapiCR)
 		if err != nil {
-			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", nil, nil, nil, err, false)
 			return statusReconciler, ctrl.Result{}, err
 		}
 
 		if updated {
 			err := r.Client().Update(r.Context(), openapiCR)
 			if err != nil {
-				statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+				statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", nil, nil, nil, err, false)
 				return statusReconciler, ctrl.Result{}, err
 			}
-			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, nil, nil, nil, err, false)
 			return statusReconciler, ctrl.Result{Requeue: true}, err
 		}
 	}
 
-	openapiObj, err := r.readOpenAPI(openapiCR)
+	openapiObj, sourceVersion, err := r.readOpenAPI(openapiCR)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, nil, nil, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
-	err = r.validateOpenAPIAs3scaleProduct(openapiCR, openapiObj)
+	authentication, err := r.validateOpenAPIAs3scaleProduct(openapiCR, openapiObj)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, nil, nil, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
+	authenticationStatus := openAPIAuthenticationStatus(authentication)
 
-	err = r.validateOIDCSettingsInCR(openapiCR, openapiObj)
+	err = r.validateOIDCSettingsInCR(openapiCR, authentication)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", sourceVersion, authenticationStatus, nil, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	err = r.validateOASExtensions(openapiObj)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, nil, err, false)
+		return statusReconciler, ctrl.Result{}, err
+	}
+
+	changeReport, err := r.checkOpenAPIChanges(openapiCR, openapiObj, sourceVersion)
+	if err != nil {
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, changeReport, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	backendReconciler := NewOpenAPIBackendReconciler(r.BaseReconciler, openapiCR, openapiObj, providerAccount, logger)
 	_, err = backendReconciler.Reconcile()
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, changeReport, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	productReconciler := NewOpenAPIProductReconciler(r.BaseReconciler, openapiCR, openapiObj, providerAccount, logger)
-	_, err = productReconciler.Reconcile()
+	product, err := productReconciler.Reconcile()
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, changeReport, err, false)
+		return statusReconciler, ctrl.Result{}, err
+	}
+
+	// The product CR is updated, the document becomes the reference for the next changes
+	err = r.saveAppliedOpenAPI(openapiCR, openapiObj, sourceVersion, changeReport)
+	if err != nil {
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, changeReport, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
@@ -243,16 +278,24 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 	// The product controller makes sure the backend usage's items are valid Backend CRs and are sync'ed.
 	productSynced, err := r.checkProductSynced(openapiCR)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, changeReport, err, false)
+		return statusReconciler, ctrl.Result{}, err
+	}
+
+	// The activedoc is linked to the product, it is reconciled once the product is synced
+	activeDocReconciler := NewOpenAPIActiveDocReconciler(r.BaseReconciler, openapiCR, openapiObj, product, providerAccount, logger)
+	_, err = activeDocReconciler.Reconcile(productSynced)
+	if err != nil {
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, changeReport, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
-	statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, productSynced)
+	statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, sourceVersion, authenticationStatus, changeReport, err, productSynced)
 
-	// If the product is successfully synced AND the OpenAPI CR is using URL ref, then requeue after 5 minutes
-	// We have to requeue like this in case there were updates to the URL source because we can't watch the URL directly
-	if productSynced && openapiCR.Spec.OpenAPIRef.SecretRef == nil {
-		return statusReconciler, ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Minute}, err
+	// If the product is successfully synced AND the OpenAPI CR is using a polled source, then requeue after the refresh interval
+	// We have to requeue like this in case there were updates to the source because we can't watch URL, git and OCI sources
+	if productSynced && openapiCR.Spec.OpenAPIRef.Polled() {
+		return statusReconciler, ctrl.Result{Requeue: true, RequeueAfter: openapiCR.Spec.OpenAPIRef.RefreshIntervalDuration()}, err
 	}
 
 	return statusReconciler, ctrl.Result{Requeue: !productSynced}, err
@@ -293,20 +336,32 @@ func (r *OpenAPIReconciler) checkProductSynced(resource *capabilitiesv1beta1.Ope
 	return product.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ProductSyncedConditionType), nil
 }
 
-func (r *OpenAPIReconciler) readOpenAPI(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, error) {
+// readOpenAPI reads the OpenAPI document and the version of polled sources
+func (r *OpenAPIReconciler) readOpenAPI(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, *OpenAPISourceVersion, error) {
+	openapiRefFldPath := field.NewPath("spec").Child("openapiRef")
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(resource.GetAnnotations())
+	sourceReader := NewOpenAPISourceReader(r.Context(), r.Client(), resource.Namespace, insecureSkipVerify, &r.sourceCache, client.ObjectKeyFromObject(resource))
+
 	// OpenAPIRef is oneOf by CRD openapiV3 validation
-	if resource.Spec.OpenAPIRef.SecretRef != nil {
+	switch {
+	case resource.Spec.OpenAPIRef.SecretRef != nil:
 		// Label the OAS source secret and OpenAPI so the secret can be watched by the openapi_controller
 		err := r.labelOpenAPISecretAndCR(resource)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		return r.readOpenAPISecret(resource)
+		return sourceReader.ReadSecret(resource.Spec.OpenAPIRef.SecretRef, openapiRefFldPath.Child("secretRef"))
+	case resource.Spec.OpenAPIRef.ConfigMapRef != nil:
+		return sourceReader.ReadConfigMap(resource.Spec.OpenAPIRef.ConfigMapRef, openapiRefFldPath.Child("configMapRef"))
+	case resource.Spec.OpenAPIRef.Git != nil:
+		return sourceReader.ReadGit(resource.Spec.OpenAPIRef.Git, openapiRefFldPath.Child("git"))
+	case resource.Spec.OpenAPIRef.OCI != nil:
+		return sourceReader.ReadOCI(resource.Spec.OpenAPIRef.OCI, openapiRefFldPath.Child("oci"))
 	}
 
 	// Must be URL
-	return r.readOpenAPIFromURL(resource)
+	return sourceReader.ReadURL(*resource.Spec.OpenAPIRef.URL, openapiRefFldPath.Child("url"))
 }
 
 func (r *OpenAPIReconciler) labelOpenAPISecretAndCR(openAPICR *capabilitiesv1beta1.OpenAPI) error {
@@ -358,100 +413,34 @@ func (r *OpenAPIReconciler) labelOpenAPISecretAndCR(openAPICR *capabilitiesv1bet
 	return nil
 }
 
-func (r *OpenAPIReconciler) readOpenAPISecret(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, error) {
+// validateOpenAPIAs3scaleProduct validates the global security requirements map to a 3scale authentication
+func (r *OpenAPIReconciler) validateOpenAPIAs3scaleProduct(openapiCR *capabilitiesv1beta1.OpenAPI, openapiObj *openapi3.T) (*helper.OpenAPIAuthentication, error) {
 	fieldErrors := field.ErrorList{}
 	specFldPath := field.NewPath("spec")
 	openapiRefFldPath := specFldPath.Child("openapiRef")
-	secretRefFldPath := openapiRefFldPath.Child("secretRef")
-
-	objectKey := types.NamespacedName{Name: resource.Spec.OpenAPIRef.SecretRef.Name, Namespace: resource.Spec.OpenAPIRef.SecretRef.Namespace}
-	openapiSecretObj := &corev1.Secret{}
-
-	// Read secret
-	if err := r.Client().Get(r.Context(), objectKey, openapiSecretObj); err != nil {
-		if errors.IsNotFound(err) {
-			fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, "Secret not found"))
-			return nil, &helper.SpecFieldError{
-				ErrorType:      helper.InvalidError,
-				FieldErrorList: fieldErrors,
-			}
-		}
-
-		// unexpected error
-		return nil, err
-	}
 
-	if len(openapiSecretObj.Data) != 1 {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, "Secret was empty or contains too many fields. Only one is required."))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	// Get key value
-	dataByteArray := func(secret *corev1.Secret) []byte {
-		for _, v := range secret.Data {
-			return v
-		}
-		return nil
-	}(openapiSecretObj)
-
-	openapiObj, err := openapi3.NewLoader().LoadFromData(dataByteArray)
+	authentication, err := helper.OpenAPIAuthenticationFromOpenAPI(openapiObj)
 	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, err.Error()))
+		fieldErrors = append(fieldErrors, field.Invalid(openapiRefFldPath, openapiCR.Spec.OpenAPIRef, fmt.Sprintf("Invalid OAS: %s", err.Error())))
 		return nil, &helper.SpecFieldError{
 			ErrorType:      helper.InvalidError,
 			FieldErrorList: fieldErrors,
 		}
 	}
 
-	err = openapiObj.Validate(r.Context())
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
-	}
-
-	return openapiObj, nil
+	return authentication, nil
 }
 
-func (r *OpenAPIReconciler) validateOpenAPIAs3scaleProduct(openapiCR *capabilitiesv1beta1.OpenAPI, openapiObj *openapi3.T) error {
-	fieldErrors := field.ErrorList{}
-	specFldPath := field.NewPath("spec")
-	openapiRefFldPath := specFldPath.Child("openapiRef")
-
-	// Multiple sec requirements
-	globalSecRequirements := helper.OpenAPIGlobalSecurityRequirements(openapiObj)
-	if len(globalSecRequirements) > 1 {
-		fieldErrors = append(fieldErrors, field.Invalid(openapiRefFldPath, openapiCR.Spec.OpenAPIRef, "Invalid OAS: multiple security requirements"))
-		return &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
-		}
+// openAPIAuthenticationStatus describes the authentication in the status
+func openAPIAuthenticationStatus(authentication *helper.OpenAPIAuthentication) *capabilitiesv1beta1.OpenAPIAuthenticationStatus {
+	status := &capabilitiesv1beta1.OpenAPIAuthenticationStatus{
+		Mode:   authentication.Mode,
+		Reason: authentication.Reason,
 	}
-
-	if len(globalSecRequirements) == 1 {
-		// Validate supported types
-		switch globalSecRequirements[0].Value.Type {
-		case "apiKey":
-			break
-		case "oauth2":
-			break
-		case "openIdConnect":
-			break
-		default:
-			fieldErrors = append(fieldErrors, field.Invalid(openapiRefFldPath, openapiCR.Spec.OpenAPIRef, fmt.Sprintf("Unexpected security schema type: %s", globalSecRequirements[0].Value.Type)))
-			return &helper.SpecFieldError{
-				ErrorType:      helper.InvalidError,
-				FieldErrorList: fieldErrors,
-			}
-		}
+	if names := authentication.SecuritySchemeNames(); len(names) > 0 {
+		status.SecuritySchemes = names
 	}
-
-	return nil
+	return status
 }
 
 func (r *OpenAPIReconciler) validateOASExtensions(openapiObj *openapi3.T) error {
@@ -492,6 +481,20 @@ func (r *OpenAPIReconciler) validateOASExtensions(openapiObj *openapi3.T) error
 		}
 	}
 
+	// Validate operation limits and pricing rules
+	operationErrors, err := validateOASOperationPlanExtensions(openapiObj, rootProductExtension)
+	if err != nil {
+		return err
+	}
+	extensionErrors = append(extensionErrors, operationErrors...)
+
+	// Validate backends
+	backendsExtension, err := helper.NewOasBackendsExtension(openapiObj)
+	if err != nil {
+		return err
+	}
+	extensionErrors = append(extensionErrors, validateOASBackendsExtension(openapiObj, backendsExtension)...)
+
 	if len(extensionErrors) == 0 {
 		return nil
 	}
@@ -502,47 +505,115 @@ func (r *OpenAPIReconciler) validateOASExtensions(openapiObj *openapi3.T) error
 	}
 }
 
-func (r *OpenAPIReconciler) readOpenAPIFromURL(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, error) {
-	fieldErrors := field.ErrorList{}
-	specFldPath := field.NewPath("spec")
-	openapiRefFldPath := specFldPath.Child("openapiRef")
-	urlRefFldPath := openapiRefFldPath.Child("url")
+func validateOASOperationPlanExtensions(openapiObj *openapi3.T, rootProductExtension *helper.OasRootProductExtension) (field.ErrorList, error) {
+	extensionErrors := field.ErrorList{}
+	validPeriods := []string{"eternity", "year", "month", "week", "day", "hour", "minute"}
 
-	openAPIURL, err := url.Parse(*resource.Spec.OpenAPIRef.URL)
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, resource.Spec.OpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
+	planExists := func(planKey string) bool {
+		if rootProductExtension == nil {
+			return false
 		}
+		_, ok := rootProductExtension.ApplicationPlans[planKey]
+		return ok
 	}
 
-	// We need to add a cache-busting query parameter to the URL to ensure we are getting the latest version of the OAS source
-	// The openapi3 library will otherwise cache the previous version of the OAS source by default
-	openAPIURL.RawQuery = fmt.Sprintf("t=%d", time.Now().Unix())
+	for _, op := range sortedOpenAPIOperations(openapiObj) {
+		operationExtension, err := helper.NewOasOperationExtension(op.operation)
+		if err != nil {
+			return nil, err
+		}
+		if operationExtension == nil {
+			continue
+		}
+
+		operationExtensionPath := field.NewPath("paths").Key(op.path).Child(strings.ToLower(op.verb), "x-3scale-operation")
 
-	openapiObj, err := openapi3.NewLoader().LoadFromURI(openAPIURL)
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, resource.Spec.OpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
+		for _, planKey := range sortedKeys(operationExtension.Limits) {
+			limitsPath := operationExtensionPath.Child("limits").Key(planKey)
+			if !planExists(planKey) {
+				extensionErrors = append(extensionErrors, field.NotFound(limitsPath, fmt.Sprintf("application plan %s is not declared in x-3scale-product", planKey)))
+			}
+			for idx, limit := range operationExtension.Limits[planKey] {
+				if !helper.ArrayContains(validPeriods, limit.Period) {
+					extensionErrors = append(extensionErrors, field.NotSupported(limitsPath.Index(idx).Child("period"), limit.Period, validPeriods))
+				}
+			}
+		}
+
+		for _, planKey := range sortedKeys(operationExtension.PricingRules) {
+			pricingRulesPath := operationExtensionPath.Child("pricingRules").Key(planKey)
+			if !planExists(planKey) {
+				extensionErrors = append(extensionErrors, field.NotFound(pricingRulesPath, fmt.Sprintf("application plan %s is not declared in x-3scale-product", planKey)))
+			}
+			for idx, pricingRule := range operationExtension.PricingRules[planKey] {
+				if !pricePerUnitRegexp.MatchString(pricingRule.PricePerUnit) {
+					extensionErrors = append(extensionErrors, field.Invalid(pricingRulesPath.Index(idx).Child("pricePerUnit"), pricingRule.PricePerUnit, "pricePerUnit must be a price with two decimals"))
+				}
+				if pricingRule.From > pricingRule.To {
+					extensionErrors = append(extensionErrors, field.Invalid(pricingRulesPath.Index(idx), pricingRule, "from must not be greater than to"))
+				}
+			}
 		}
 	}
 
-	err = openapiObj.Validate(r.Context())
-	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, resource.Spec.OpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
+	return extensionErrors, nil
+}
+
+func validateOASBackendsExtension(openapiObj *openapi3.T, backendsExtension []helper.OasBackendExtension) field.ErrorList {
+	extensionErrors := field.ErrorList{}
+	backendsExtensionPath := field.NewPath("x-3scale-backends")
+
+	if len(backendsExtension) == 0 {
+		return extensionErrors
+	}
+
+	systemNames := map[string]bool{}
+	paths := map[string]bool{}
+	for idx, backend := range backendsExtension {
+		backendPath := backendsExtensionPath.Index(idx)
+
+		if backend.SystemName == "" {
+			extensionErrors = append(extensionErrors, field.Required(backendPath.Child("systemName"), "backend is missing a systemName"))
+		} else if systemNames[backend.SystemName] {
+			extensionErrors = append(extensionErrors, field.Duplicate(backendPath.Child("systemName"), backend.SystemName))
+		}
+		systemNames[backend.SystemName] = true
+
+		if !strings.HasPrefix(backend.Path, "/") {
+			extensionErrors = append(extensionErrors, field.Invalid(backendPath.Child("path"), backend.Path, "path must begin with a slash"))
+		} else if paths[LastSlashRegexp.ReplaceAllString(backend.Path, "")] {
+			extensionErrors = append(extensionErrors, field.Duplicate(backendPath.Child("path"), backend.Path))
+		}
+		paths[LastSlashRegexp.ReplaceAllString(backend.Path, "")] = true
+
+		privateBaseURL, err := url.Parse(backend.PrivateBaseURL)
+		if backend.PrivateBaseURL == "" {
+			extensionErrors = append(extensionErrors, field.Required(backendPath.Child("privateBaseURL"), fmt.Sprintf("backend %s is missing a privateBaseURL", backend.SystemName)))
+		} else if err != nil || privateBaseURL.Host == "" || !isBackendPrivateBaseURLScheme(privateBaseURL.Scheme) {
+			extensionErrors = append(extensionErrors, field.Invalid(backendPath.Child("privateBaseURL"), backend.PrivateBaseURL, "privateBaseURL must be an http(s) or ws(s) URL"))
+		}
+	}
+
+	// every operation must be routed to one backend, the product does not have a backend of its own
+	for path := range openapiObj.Paths {
+		if helper.OasBackendForPath(backendsExtension, path) == nil {
+			extensionErrors = append(extensionErrors, field.Invalid(backendsExtensionPath, path, "path does not match the path of any backend"))
 		}
 	}
 
-	return openapiObj, nil
+	return extensionErrors
+}
+
+// isBackendPrivateBaseURLScheme matches the private base URL pattern of the Backend CRD
+func isBackendPrivateBaseURLScheme(scheme string) bool {
+	switch scheme {
+	case "http", "https", "ws", "wss":
+		return true
+	}
+	return false
 }
 
-func (r *OpenAPIReconciler) validateOIDCSettingsInCR(openapiCR *capabilitiesv1beta1.OpenAPI, openapiObj *openapi3.T) error {
+func (r *OpenAPIReconciler) validateOIDCSettingsInCR(openapiCR *capabilitiesv1beta1.OpenAPI, authentication *helper.OpenAPIAuthentication) error {
 	logger := r.Logger().WithValues("openapi", openapiCR.Name)
 	fieldErrors := field.ErrorList{}
 	specFldPath := field.NewPath("spec")
@@ -561,15 +632,14 @@ func (r *OpenAPIReconciler) validateOIDCSettingsInCR(openapiCR *capabilitiesv1be
 		}
 	}
 
-	globalSecRequirements := helper.OpenAPIGlobalSecurityRequirements(openapiObj)
-	if len(globalSecRequirements) == 0 && openapiCR.Spec.OIDC != nil {
+	if authentication.SecurityRequirement == -1 && openapiCR.Spec.OIDC != nil {
 		logger.Info("OIDC definitions in CR will be ignored, as no security requirements are found. Default to UserKey authentication")
 		r.EventRecorder().Eventf(openapiCR, corev1.EventTypeWarning, "No security requirements are found in OAS", "%v", "OIDC definitions in CR will be ignored, as no security requirements are found. Default to UserKey authentication")
 	}
 
-	if len(globalSecRequirements) == 1 {
+	if authentication.Mode == helper.OpenAPIAuthenticationOIDC {
 		// when the referenced OpenAPI spec's sec scheme is openIdConnect or oauth2, the spec.oidc must not be nil or empty
-		if globalSecRequirements[0].Value.Type == "openIdConnect" || globalSecRequirements[0].Value.Type == "oauth2" {
+		if authentication.OIDC.Value.Type == "openIdConnect" || authentication.OIDC.Value.Type == "oauth2" {
 			if openapiCR.Spec.OIDC == nil {
 				fieldErrors = append(fieldErrors, field.Invalid(openapiOidcFldPath, openapiCR.Spec.OIDC, "Missing "+
 					"OIDC definitions in CR. The referenced OpenAPI spec's sec scheme is openIdConnect or oauth2, the spec.oidc must not be nil or empty"))
@@ -581,7 +651,7 @@ func (r *OpenAPIReconciler) validateOIDCSettingsInCR(openapiCR *capabilitiesv1be
 		}
 		// when OAS securitySchemes type is oauth2, and openapiCR spec is OIDC, then CR OIDC Authentication Flows parameters will be ignored,
 		// and Product authentication flows will be set to match oauth2 flows in OAS
-		if openapiCR.Spec.OIDC != nil && globalSecRequirements[0].Value.Type == "oauth2" {
+		if openapiCR.Spec.OIDC != nil && authentication.OIDC.Value.Type == "oauth2" {
 			logger.Info("OIDC authentication flows in CR will be ignored and Product OIDC authentication flows will be set to match oauth2 flows in OAS since the SecuritySchemes type in OAS is \"oauth2\" (for OIDC it should be \"openIdConnect\")")
 			r.EventRecorder().Eventf(openapiCR, corev1.EventTypeWarning, "OIDC authentication flows in CR will be ignored and Product OIDC authentication flows will be set to match oauth2 flows in OAS since the SecuritySchemes type in OAS is \"oauth2\" (for OIDC it should be \"openIdConnect\")", "%v", "Product OIDC authentication flows parameters will be set to match oauth2 flows as following (OIDC ~ OAuth2): StandardFlowEnabled ~ AuthorizationCode, ImplicitFlowEnabled ~ Implicit, DirectAccessGrantsEnabled ~ Password, ServiceAccountsEnabled ~ ClientCredentials")
 		}
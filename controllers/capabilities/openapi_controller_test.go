@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -114,6 +115,69 @@ func TestOpenAPIReconciler_validateOASExtensions(t *testing.T) {
 	}
 }
 
+func TestValidateOASOperationPlanExtensions(t *testing.T) {
+	openapiObj := getOpenAPIObj(getOperationPlansOpenAPISecret())
+	rootProductExtension, err := helper.NewOasRootProductExtension(openapiObj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs, err := validateOASOperationPlanExtensions(openapiObj, rootProductExtension)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	operation := openapiObj.Paths["/pets"].Get
+	operation.Extensions["x-3scale-operation"] = json.RawMessage(`{"limits":{"plan02":[{"period":"fortnight","value":1}]},"pricingRules":{"plan01":[{"from":10,"to":1,"pricePerUnit":"0.5"}]}}`)
+	operationExtensionPath := field.NewPath("paths").Key("/pets").Child("get", "x-3scale-operation")
+	pricingRulePath := operationExtensionPath.Child("pricingRules").Key("plan01").Index(0)
+	want := field.ErrorList{
+		field.NotFound(operationExtensionPath.Child("limits").Key("plan02"), "application plan plan02 is not declared in x-3scale-product"),
+		field.NotSupported(operationExtensionPath.Child("limits").Key("plan02").Index(0).Child("period"), "fortnight", []string{"eternity", "year", "month", "week", "day", "hour", "minute"}),
+		field.Invalid(pricingRulePath.Child("pricePerUnit"), "0.5", "pricePerUnit must be a price with two decimals"),
+		field.Invalid(pricingRulePath, helper.OasOperationPricingRuleExtension{From: 10, To: 1, PricePerUnit: "0.5"}, "from must not be greater than to"),
+	}
+
+	got, err := validateOASOperationPlanExtensions(openapiObj, rootProductExtension)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validateOASOperationPlanExtensions() got = %v, want %v", got, want)
+	}
+}
+
+func TestValidateOASBackendsExtension(t *testing.T) {
+	backendsExtensionPath := field.NewPath("x-3scale-backends")
+	openapiObj := getOpenAPIObj(getMultiBackendOpenAPISecret())
+
+	backends, err := helper.NewOasBackendsExtension(openapiObj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := validateOASBackendsExtension(openapiObj, backends); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	invalidBackends := []helper.OasBackendExtension{
+		{SystemName: "pets", Path: "/pets", PrivateBaseURL: "http://pets.petstore.svc"},
+		{SystemName: "pets", Path: "pets/", PrivateBaseURL: "ftp://stores.petstore.svc"},
+	}
+	want := field.ErrorList{
+		field.Duplicate(backendsExtensionPath.Index(1).Child("systemName"), "pets"),
+		field.Invalid(backendsExtensionPath.Index(1).Child("path"), "pets/", "path must begin with a slash"),
+		field.Invalid(backendsExtensionPath.Index(1).Child("privateBaseURL"), "ftp://stores.petstore.svc", "privateBaseURL must be an http(s) or ws(s) URL"),
+		field.Invalid(backendsExtensionPath, "/stores/{storeId}/orders", "path does not match the path of any backend"),
+	}
+	got := validateOASBackendsExtension(openapiObj, invalidBackends)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validateOASBackendsExtension() got = %v, want %v", got, want)
+	}
+}
+
 func getOpenAPIBaseReconciler(objects ...runtime.Object) (baseReconciler *reconcilers.BaseReconciler) {
 	// Register operator types with the runtime scheme.
 	s := scheme.Scheme
@@ -172,7 +236,7 @@ func getOpenAPIObj(openAPISecret *corev1.Secret) *openapi3.T {
 		BaseReconciler: getOpenAPIBaseReconciler(openAPISecret, getOpenAPICR()),
 	}
 
-	openapiObj, err := openAPIReconciler.readOpenAPI(getOpenAPICR())
+	openapiObj, _, err := openAPIReconciler.readOpenAPI(getOpenAPICR())
 	if err != nil {
 		panic(err)
 	}
@@ -324,3 +388,133 @@ x-3scale-product:
 		Type: corev1.SecretTypeOpaque,
 	}
 }
+
+func getMultiBackendOpenAPISecret() *corev1.Secret {
+	secretData := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+servers:
+  - url: http://petstore.swagger.io/v1
+paths:
+  /pets:
+    get:
+      summary: List all pets
+      operationId: listPets
+      responses:
+        '200':
+          description: A paged array of pets
+  /pets/{petId}:
+    get:
+      summary: Info for a specific pet
+      operationId: showPetById
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Expected response to a valid request
+      x-3scale-operation:
+        mappingRule:
+          metricMethodRef: "petreads"
+  /stores/{storeId}/orders:
+    post:
+      summary: Create an order
+      operationId: createOrder
+      parameters:
+        - name: storeId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '201':
+          description: Null response
+x-3scale-backends:
+  - systemName: pets
+    path: /pets
+    privateBaseURL: http://pets.petstore.svc:8080
+    metrics:
+      petreads:
+        friendlyName: "Pet reads"
+        unit: "hit"
+  - systemName: stores
+    name: Stores
+    path: /stores
+    privateBaseURL: http://stores.petstore.svc:8080
+`
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testOpenAPISecret",
+			Namespace: "testNamespace",
+		},
+		Data: map[string][]byte{
+			"oas": []byte(secretData),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+}
+
+func getOperationPlansOpenAPISecret() *corev1.Secret {
+	secretData := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+servers:
+  - url: http://petstore.swagger.io/v1
+paths:
+  /pets:
+    get:
+      summary: List all pets
+      operationId: listPets
+      x-3scale-operation:
+        limits:
+          plan01:
+            - period: "week"
+              value: 10
+            - period: "minute"
+              value: 5
+        pricingRules:
+          plan01:
+            - from: 1
+              to: 10
+              pricePerUnit: "0.50"
+      responses:
+        '200':
+          description: A paged array of pets
+x-3scale-product:
+  applicationPlans:
+    plan01:
+      name: "My Plan 01"
+      limits:
+        - period: "week"
+          value: 100
+          metricMethodRef:
+            systemName: "hits"
+        - period: "week"
+          value: 50
+          metricMethodRef:
+            systemName: "listpets"
+`
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testOpenAPISecret",
+			Namespace: "testNamespace",
+		},
+		Data: map[string][]byte{
+			"oas": []byte(secretData),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+}
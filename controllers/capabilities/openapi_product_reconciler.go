@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -66,7 +68,17 @@ func (p *OpenAPIProductReconciler) Reconcile() (*capabilitiesv1beta1.Product, er
 		p.Logger().V(1).Info(string(jsonData))
 	}
 
-	return nil, p.ReconcileResource(&capabilitiesv1beta1.Product{}, desired, p.productMutator)
+	err = p.reconcileRequestValidationPolicyDefinition()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.ReconcileResource(&capabilitiesv1beta1.Product{}, desired, p.productMutator)
+	if err != nil {
+		return nil, err
+	}
+
+	return desired, nil
 }
 
 func (p *OpenAPIProductReconciler) desired() (*capabilitiesv1beta1.Product, error) {
@@ -124,7 +136,11 @@ func (p *OpenAPIProductReconciler) desired() (*capabilitiesv1beta1.Product, erro
 	product.Spec.Deployment = p.desiredDeployment()
 
 	// Methods
-	product.Spec.Methods = p.desiredMethods()
+	methods, err := p.desiredMethods()
+	if err != nil {
+		return nil, err
+	}
+	product.Spec.Methods = methods
 
 	// Mapping rules
 	mappingRules, err := p.desiredMappingRules()
@@ -161,13 +177,11 @@ func (p *OpenAPIProductReconciler) desired() (*capabilitiesv1beta1.Product, erro
 	}
 
 	// backend usages
-	// current implementation assumes same system name for backend and product
-	backendSystemName := p.desiredSystemName()
-	product.Spec.BackendUsages = map[string]capabilitiesv1beta1.BackendUsageSpec{
-		backendSystemName: {
-			Path: "/",
-		},
+	backendUsages, err := p.desiredBackendUsages()
+	if err != nil {
+		return nil, err
 	}
+	product.Spec.BackendUsages = backendUsages
 
 	product.SetDefaults(p.Logger())
 
@@ -259,24 +273,21 @@ func (p *OpenAPIProductReconciler) desiredDeployment() *capabilitiesv1beta1.Prod
 }
 
 func (p *OpenAPIProductReconciler) desiredAuthentication() *capabilitiesv1beta1.AuthenticationSpec {
-	globalSecRequirements := helper.OpenAPIGlobalSecurityRequirements(p.openapiObj)
-	if len(globalSecRequirements) == 0 {
-		// if no security requirements are found, default to UserKey auth
+	// The security requirements were validated by the controller
+	authentication, err := helper.OpenAPIAuthenticationFromOpenAPI(p.openapiObj)
+	if err != nil {
 		return p.desiredUserKeyAuthentication(nil)
 	}
 
-	// Only the first one is used
-	secRequirementExtended := globalSecRequirements[0]
-
 	var authenticationSpec *capabilitiesv1beta1.AuthenticationSpec
 
-	switch secRequirementExtended.Value.Type {
-	case "apiKey":
-		authenticationSpec = p.desiredUserKeyAuthentication(secRequirementExtended)
-	case "oauth2":
-		authenticationSpec = p.desiredOIDCAuthentication(secRequirementExtended)
-	case "openIdConnect":
-		authenticationSpec = p.desiredOIDCAuthentication(secRequirementExtended)
+	switch authentication.Mode {
+	case helper.OpenAPIAuthenticationUserKey:
+		authenticationSpec = p.desiredUserKeyAuthentication(authentication.UserKey)
+	case helper.OpenAPIAuthenticationAppIDAppKey:
+		authenticationSpec = p.desiredAppKeyAppIDAuthentication(authentication)
+	case helper.OpenAPIAuthenticationOIDC:
+		authenticationSpec = p.desiredOIDCAuthentication(authentication.OIDC)
 	}
 
 	if authenticationSpec == nil {
@@ -301,65 +312,84 @@ func (p *OpenAPIProductReconciler) desiredUserKeyAuthentication(secReq *helper.E
 	return authSpec
 }
 
+func (p *OpenAPIProductReconciler) desiredAppKeyAppIDAuthentication(authentication *helper.OpenAPIAuthentication) *capabilitiesv1beta1.AuthenticationSpec {
+	credentialsLoc := authentication.CredentialsLocation()
+
+	authSpec := &capabilitiesv1beta1.AuthenticationSpec{
+		AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+			CredentialsLoc: &credentialsLoc,
+			Security:       p.desiredPrivateAPISecurity(),
+		},
+	}
+
+	if authentication.AppID != nil {
+		authSpec.AppKeyAppIDAuthentication.AppID = &authentication.AppID.Value.Name
+	}
+	if authentication.AppKey != nil {
+		authSpec.AppKeyAppIDAuthentication.AppKey = &authentication.AppKey.Value.Name
+	}
+
+	return authSpec
+}
+
 func (p *OpenAPIProductReconciler) parseUserKeyCredentialsLoc(inField string) *string {
 	tmpQuery := "query"
 	tmpHeaders := "headers"
 	switch inField {
 	case "query":
 		return &tmpQuery
-	case "header":
+	case "header", "cookie":
+		// cookies are copied to headers by the cookie credentials policy
 		return &tmpHeaders
 	default:
 		return nil
 	}
 }
 
-func (p *OpenAPIProductReconciler) desiredMethods() map[string]capabilitiesv1beta1.MethodSpec {
+func (p *OpenAPIProductReconciler) desiredMethods() (map[string]capabilitiesv1beta1.MethodSpec, error) {
+	backends, err := helper.NewOasBackendsExtension(p.openapiObj)
+	if err != nil {
+		return nil, err
+	}
+
 	methods := make(map[string]capabilitiesv1beta1.MethodSpec)
 	for path, pathItem := range p.openapiObj.Paths {
+		// operations routed to a backend are defined in the backend
+		if helper.OasBackendForPath(backends, path) != nil {
+			continue
+		}
+
 		for opVerb, operation := range pathItem.Operations() {
 			methodSystemName := helper.MethodSystemNameFromOpenAPIOperation(path, opVerb, operation)
-			methods[methodSystemName] = capabilitiesv1beta1.MethodSpec{
-				Name:        helper.MethodNameFromOpenAPIOperation(path, opVerb, operation),
-				Description: operation.Description,
-			}
+			methods[methodSystemName] = openAPIMethod(path, opVerb, operation)
 		}
 	}
-	return methods
+	return methods, nil
 }
 
 func (p *OpenAPIProductReconciler) desiredMappingRules() ([]capabilitiesv1beta1.MappingRuleSpec, error) {
+	backends, err := helper.NewOasBackendsExtension(p.openapiObj)
+	if err != nil {
+		return nil, err
+	}
+
 	mappingRules := make([]capabilitiesv1beta1.MappingRuleSpec, 0)
 	for path, pathItem := range p.openapiObj.Paths {
+		// operations routed to a backend are defined in the backend
+		if helper.OasBackendForPath(backends, path) != nil {
+			continue
+		}
+
 		desiredPattern, err := p.desiredMappingRulesPattern(path)
 		if err != nil {
 			return nil, err
 		}
 
 		for opVerb, operation := range pathItem.Operations() {
-			mappingRule := capabilitiesv1beta1.MappingRuleSpec{
-				HTTPMethod:      strings.ToUpper(opVerb),
-				Pattern:         desiredPattern,
-				MetricMethodRef: helper.MethodSystemNameFromOpenAPIOperation(path, opVerb, operation),
-				Increment:       1,
-			}
-
-			// Extract OAS operation extension
-			operationExtension, err := helper.NewOasOperationExtension(operation)
+			mappingRule, err := openAPIMappingRule(path, opVerb, operation, desiredPattern)
 			if err != nil {
 				return nil, err
 			}
-			if operationExtension != nil {
-				if operationExtension.MappingRule.MetricMethodRef != "" {
-					mappingRule.MetricMethodRef = operationExtension.MappingRule.MetricMethodRef
-				}
-				if operationExtension.MappingRule.Increment != 0 {
-					mappingRule.Increment = operationExtension.MappingRule.Increment
-				}
-				if operationExtension.MappingRule.Last != nil {
-					mappingRule.Last = operationExtension.MappingRule.Last
-				}
-			}
 
 			mappingRules = append(mappingRules, mappingRule)
 		}
@@ -367,6 +397,43 @@ func (p *OpenAPIProductReconciler) desiredMappingRules() ([]capabilitiesv1beta1.
 	return mappingRules, nil
 }
 
+func (p *OpenAPIProductReconciler) desiredBackendUsages() (map[string]capabilitiesv1beta1.BackendUsageSpec, error) {
+	backends, err := helper.NewOasBackendsExtension(p.openapiObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(backends) == 0 {
+		// current implementation assumes same system name for backend and product
+		return map[string]capabilitiesv1beta1.BackendUsageSpec{
+			p.desiredSystemName(): {
+				Path: "/",
+			},
+		}, nil
+	}
+
+	publicBasePath, err := p.desiredPublicBasePath()
+	if err != nil {
+		return nil, err
+	}
+
+	// remove the last slash of the publicBasePath
+	publicBasePathSanitized := LastSlashRegexp.ReplaceAllString(publicBasePath, "")
+
+	backendUsages := make(map[string]capabilitiesv1beta1.BackendUsageSpec, len(backends))
+	for _, backend := range backends {
+		path := fmt.Sprintf("%s%s", publicBasePathSanitized, LastSlashRegexp.ReplaceAllString(backend.Path, ""))
+		if path == "" {
+			path = "/"
+		}
+		backendUsages[backend.SystemName] = capabilitiesv1beta1.BackendUsageSpec{
+			Path: path,
+		}
+	}
+
+	return backendUsages, nil
+}
+
 func (p *OpenAPIProductReconciler) desiredMappingRulesPattern(path string) (string, error) {
 	publicBasePath, err := p.desiredPublicBasePath()
 	if err != nil {
@@ -379,11 +446,51 @@ func (p *OpenAPIProductReconciler) desiredMappingRulesPattern(path string) (stri
 	//  According OAS 3.0: path MUST begin with a slash
 	pattern := fmt.Sprintf("%s%s", publicBasePathSanitized, path)
 
-	if p.openapiCR.Spec.PrefixMatching == nil || !*p.openapiCR.Spec.PrefixMatching {
+	return openAPIMappingRulePattern(p.openapiCR, pattern), nil
+}
+
+// openAPIMappingRulePattern anchors the pattern at the end unless prefix matching is enabled
+func openAPIMappingRulePattern(openapiCR *capabilitiesv1beta1.OpenAPI, pattern string) string {
+	if openapiCR.Spec.PrefixMatching == nil || !*openapiCR.Spec.PrefixMatching {
 		pattern = fmt.Sprintf("%s$", pattern)
 	}
 
-	return pattern, nil
+	return pattern
+}
+
+func openAPIMethod(path, opVerb string, operation *openapi3.Operation) capabilitiesv1beta1.MethodSpec {
+	return capabilitiesv1beta1.MethodSpec{
+		Name:        helper.MethodNameFromOpenAPIOperation(path, opVerb, operation),
+		Description: operation.Description,
+	}
+}
+
+func openAPIMappingRule(path, opVerb string, operation *openapi3.Operation, pattern string) (capabilitiesv1beta1.MappingRuleSpec, error) {
+	mappingRule := capabilitiesv1beta1.MappingRuleSpec{
+		HTTPMethod:      strings.ToUpper(opVerb),
+		Pattern:         pattern,
+		MetricMethodRef: helper.MethodSystemNameFromOpenAPIOperation(path, opVerb, operation),
+		Increment:       1,
+	}
+
+	// Extract OAS operation extension
+	operationExtension, err := helper.NewOasOperationExtension(operation)
+	if err != nil {
+		return mappingRule, err
+	}
+	if operationExtension != nil {
+		if operationExtension.MappingRule.MetricMethodRef != "" {
+			mappingRule.MetricMethodRef = operationExtension.MappingRule.MetricMethodRef
+		}
+		if operationExtension.MappingRule.Increment != 0 {
+			mappingRule.Increment = operationExtension.MappingRule.Increment
+		}
+		if operationExtension.MappingRule.Last != nil {
+			mappingRule.Last = operationExtension.MappingRule.Last
+		}
+	}
+
+	return mappingRule, nil
 }
 
 func (p *OpenAPIProductReconciler) desiredMetrics() (map[string]capabilitiesv1beta1.MetricSpec, error) {
@@ -435,9 +542,127 @@ func (p *OpenAPIProductReconciler) desiredApplicationPlans() (map[string]capabil
 		}
 	}
 
+	err = p.mergeOperationPlanRules(applicationPlans)
+	if err != nil {
+		return nil, err
+	}
+
 	return applicationPlans, nil
 }
 
+// mergeOperationPlanRules adds the limits and pricing rules of the operation extensions
+// to the application plans. They reference the method of the operation.
+// Operation limits replace the plan limits of the same method and period.
+// Operation pricing rules replace the plan pricing rules of the same method.
+func (p *OpenAPIProductReconciler) mergeOperationPlanRules(applicationPlans map[string]capabilitiesv1beta1.ApplicationPlanSpec) error {
+	backends, err := helper.NewOasBackendsExtension(p.openapiObj)
+	if err != nil {
+		return err
+	}
+
+	for _, op := range sortedOpenAPIOperations(p.openapiObj) {
+		operationExtension, err := helper.NewOasOperationExtension(op.operation)
+		if err != nil {
+			return err
+		}
+		if operationExtension == nil {
+			continue
+		}
+
+		metricMethodRef := capabilitiesv1beta1.MetricMethodRefSpec{
+			SystemName: helper.MethodSystemNameFromOpenAPIOperation(op.path, op.verb, op.operation),
+		}
+		// methods of operations routed to a backend are backend methods
+		if backend := helper.OasBackendForPath(backends, op.path); backend != nil {
+			backendSystemName := backend.SystemName
+			metricMethodRef.BackendSystemName = &backendSystemName
+		}
+
+		for _, planKey := range sortedKeys(operationExtension.Limits) {
+			plan, ok := applicationPlans[planKey]
+			if !ok {
+				return operationPlanNotFoundError(op, planKey)
+			}
+
+			for _, limit := range operationExtension.Limits[planKey] {
+				limits := make([]capabilitiesv1beta1.LimitSpec, 0, len(plan.Limits)+1)
+				for _, planLimit := range plan.Limits {
+					if planLimit.Period != limit.Period || planLimit.MetricMethodRef.String() != metricMethodRef.String() {
+						limits = append(limits, planLimit)
+					}
+				}
+				plan.Limits = append(limits, capabilitiesv1beta1.LimitSpec{
+					Period:          limit.Period,
+					Value:           limit.Value,
+					MetricMethodRef: metricMethodRef,
+				})
+			}
+			applicationPlans[planKey] = plan
+		}
+
+		for _, planKey := range sortedKeys(operationExtension.PricingRules) {
+			plan, ok := applicationPlans[planKey]
+			if !ok {
+				return operationPlanNotFoundError(op, planKey)
+			}
+
+			pricingRules := make([]capabilitiesv1beta1.PricingRuleSpec, 0, len(plan.PricingRules)+len(operationExtension.PricingRules[planKey]))
+			for _, planPricingRule := range plan.PricingRules {
+				if planPricingRule.MetricMethodRef.String() != metricMethodRef.String() {
+					pricingRules = append(pricingRules, planPricingRule)
+				}
+			}
+			for _, pricingRule := range operationExtension.PricingRules[planKey] {
+				pricingRules = append(pricingRules, capabilitiesv1beta1.PricingRuleSpec{
+					From:            pricingRule.From,
+					To:              pricingRule.To,
+					PricePerUnit:    pricingRule.PricePerUnit,
+					MetricMethodRef: metricMethodRef,
+				})
+			}
+			plan.PricingRules = pricingRules
+			applicationPlans[planKey] = plan
+		}
+	}
+
+	return nil
+}
+
+func operationPlanNotFoundError(op openAPIOperation, planKey string) error {
+	operationFldPath := field.NewPath("paths").Key(op.path).Child(strings.ToLower(op.verb), "x-3scale-operation")
+	return &helper.SpecFieldError{
+		ErrorType: helper.InvalidError,
+		FieldErrorList: field.ErrorList{
+			field.NotFound(operationFldPath, fmt.Sprintf("application plan %s is not declared in x-3scale-product", planKey)),
+		},
+	}
+}
+
+// openAPIOperation is an operation of the OpenAPI document
+type openAPIOperation struct {
+	path      string
+	verb      string
+	operation *openapi3.Operation
+}
+
+// sortedOpenAPIOperations returns the operations of the OpenAPI document sorted by path and verb
+func sortedOpenAPIOperations(openapiObj *openapi3.T) []openAPIOperation {
+	operations := make([]openAPIOperation, 0)
+	for _, path := range sortedKeys(openapiObj.Paths) {
+		pathOperations := openapiObj.Paths[path].Operations()
+		for _, verb := range sortedKeys(pathOperations) {
+			operations = append(operations, openAPIOperation{path: path, verb: verb, operation: pathOperations[verb]})
+		}
+	}
+	return operations
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := helper.MapKeys(m)
+	sort.Strings(keys)
+	return keys
+}
+
 func (p *OpenAPIProductReconciler) desiredPolicies() ([]capabilitiesv1beta1.PolicyConfig, error) {
 	var policyConfigs []capabilitiesv1beta1.PolicyConfig
 
@@ -461,9 +686,70 @@ func (p *OpenAPIProductReconciler) desiredPolicies() ([]capabilitiesv1beta1.Poli
 		}
 	}
 
+	requestValidationPolicy, err := p.desiredRequestValidationPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if requestValidationPolicy != nil {
+		policyConfigs = insertPolicyBeforeAPIcast(policyConfigs, *requestValidationPolicy)
+	}
+
+	cookieCredentialsPolicy, err := p.desiredCookieCredentialsPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if cookieCredentialsPolicy != nil {
+		policyConfigs = insertPolicyBeforeAPIcast(policyConfigs, *cookieCredentialsPolicy)
+	}
+
 	return policyConfigs, nil
 }
 
+// desiredCookieCredentialsPolicy returns the headers policy copying the credentials located in cookies
+// to the request headers the apicast policy reads the credentials from
+func (p *OpenAPIProductReconciler) desiredCookieCredentialsPolicy() (*capabilitiesv1beta1.PolicyConfig, error) {
+	authentication, err := helper.OpenAPIAuthenticationFromOpenAPI(p.openapiObj)
+	if err != nil {
+		// The security requirements were validated by the controller
+		return nil, nil
+	}
+
+	cookieCredentials := authentication.CookieCredentials()
+	if len(cookieCredentials) == 0 {
+		return nil, nil
+	}
+
+	type headerOperation struct {
+		Op        string `json:"op"`
+		Header    string `json:"header"`
+		Value     string `json:"value"`
+		ValueType string `json:"value_type"`
+	}
+
+	operations := []headerOperation{}
+	for _, secReq := range cookieCredentials {
+		operations = append(operations, headerOperation{
+			Op:     "set",
+			Header: secReq.Value.Name,
+			// the cookie value, or empty when the cookie is not sent
+			Value:     fmt.Sprintf("{{ headers['Cookie'] | prepend: '; ' | split: '; %s=' | last | split: ';' | first }}", secReq.Value.Name),
+			ValueType: "liquid",
+		})
+	}
+
+	configuration, err := json.Marshal(map[string]interface{}{"request": operations})
+	if err != nil {
+		return nil, err
+	}
+
+	return &capabilitiesv1beta1.PolicyConfig{
+		Name:          headersPolicyName,
+		Version:       builtinPolicyVersion,
+		Enabled:       true,
+		Configuration: runtime.RawExtension{Raw: configuration},
+	}, nil
+}
+
 func (p *OpenAPIProductReconciler) desiredPublicBasePath() (string, error) {
 	// TODO Override public base path optional param
 
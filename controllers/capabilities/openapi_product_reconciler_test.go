@@ -299,6 +299,47 @@ func TestOpenAPIProductReconciler_desiredApplicationPlans(t *testing.T) {
 			want:    make(map[string]capabilitiesv1beta1.ApplicationPlanSpec), // Expect empty map
 			wantErr: false,
 		},
+		{
+			name: "operation limits and pricing rules",
+			fields: fields{
+				BaseReconciler: getOpenAPIBaseReconciler(getOpenAPICR(), getOperationPlansOpenAPISecret()),
+				openapiCR:      getOpenAPICR(),
+				openapiObj:     getOpenAPIObj(getOperationPlansOpenAPISecret()),
+				logger:         getOpenAPITestLogger(),
+			},
+			want: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"plan01": {
+					Name: &planName,
+					PricingRules: []capabilitiesv1beta1.PricingRuleSpec{
+						{
+							From:            1,
+							To:              10,
+							MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "listpets"},
+							PricePerUnit:    "0.50",
+						},
+					},
+					Limits: []capabilitiesv1beta1.LimitSpec{
+						{
+							Period:          "week",
+							Value:           100,
+							MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "hits"},
+						},
+						{
+							// replaces the plan limit of the same method and period
+							Period:          "week",
+							Value:           10,
+							MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "listpets"},
+						},
+						{
+							Period:          "minute",
+							Value:           5,
+							MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "listpets"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -320,3 +361,66 @@ func TestOpenAPIProductReconciler_desiredApplicationPlans(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAPIProductReconciler_desiredBackendUsages(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   map[string]capabilitiesv1beta1.BackendUsageSpec
+	}{
+		{
+			name:   "single backend",
+			secret: getUnextendedOpenAPISecret(),
+			want: map[string]capabilitiesv1beta1.BackendUsageSpec{
+				"Swagger_Petstore": {Path: "/"},
+			},
+		},
+		{
+			name:   "multiple backends",
+			secret: getMultiBackendOpenAPISecret(),
+			want: map[string]capabilitiesv1beta1.BackendUsageSpec{
+				"pets":   {Path: "/v1/pets"},
+				"stores": {Path: "/v1/stores"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &OpenAPIProductReconciler{
+				BaseReconciler: getOpenAPIBaseReconciler(getOpenAPICR(), tt.secret),
+				openapiCR:      getOpenAPICR(),
+				openapiObj:     getOpenAPIObj(tt.secret),
+				logger:         getOpenAPITestLogger(),
+			}
+			got, err := p.desiredBackendUsages()
+			if err != nil {
+				t.Fatalf("desiredBackendUsages() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("desiredBackendUsages() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPIProductReconciler_routedOperations(t *testing.T) {
+	p := &OpenAPIProductReconciler{
+		BaseReconciler: getOpenAPIBaseReconciler(getOpenAPICR(), getMultiBackendOpenAPISecret()),
+		openapiCR:      getOpenAPICR(),
+		openapiObj:     getOpenAPIObj(getMultiBackendOpenAPISecret()),
+		logger:         getOpenAPITestLogger(),
+	}
+
+	// operations routed to backends are defined in the backends
+	methods, err := p.desiredMethods()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mappingRules, err := p.desiredMappingRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(methods) != 0 || len(mappingRules) != 0 {
+		t.Errorf("product methods = %v, mapping rules = %v", methods, mappingRules)
+	}
+}
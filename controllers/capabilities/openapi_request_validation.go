@@ -0,0 +1,429 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/go-cmp/cmp"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Builtin policies do not need a custom policy definition
+	builtinPolicyVersion = "builtin"
+
+	apicastPolicyName = "apicast"
+	headersPolicyName = "headers"
+)
+
+// requestValidationPolicyConfiguration is the configuration of the request validation policy.
+// Schemas are OpenAPI 3.0 schema objects with the references resolved
+type requestValidationPolicyConfiguration struct {
+	Operations []requestValidationOperation `json:"operations"`
+}
+
+type requestValidationOperation struct {
+	HTTPMethod  string                        `json:"http_method"`
+	Pattern     string                        `json:"pattern"`
+	Parameters  []requestValidationParameter  `json:"parameters,omitempty"`
+	RequestBody *requestValidationRequestBody `json:"request_body,omitempty"`
+}
+
+type requestValidationParameter struct {
+	Name     string      `json:"name"`
+	In       string      `json:"in"`
+	Required bool        `json:"required"`
+	Schema   interface{} `json:"schema,omitempty"`
+}
+
+type requestValidationRequestBody struct {
+	Required bool `json:"required"`
+	// Map: media type -> schema
+	Content map[string]interface{} `json:"content"`
+}
+
+// requestValidationPolicySchema is the JSON schema of the request validation policy configuration
+const requestValidationPolicySchema = `{
+  "type": "object",
+  "properties": {
+    "operations": {
+      "description": "Operations of the OpenAPI document",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "http_method": {"type": "string"},
+          "pattern": {"description": "Request path template", "type": "string"},
+          "parameters": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "name": {"type": "string"},
+                "in": {"type": "string", "enum": ["path", "query", "header", "cookie"]},
+                "required": {"type": "boolean"},
+                "schema": {"description": "OpenAPI 3.0 schema object", "type": "object"}
+              },
+              "required": ["name", "in"]
+            }
+          },
+          "request_body": {
+            "type": "object",
+            "properties": {
+              "required": {"type": "boolean"},
+              "content": {"description": "Map of media types to OpenAPI 3.0 schema objects", "type": "object"}
+            }
+          }
+        },
+        "required": ["http_method", "pattern"]
+      }
+    }
+  }
+}`
+
+// desiredRequestValidationPolicy returns the request validation policy built from the parameters and request bodies
+// of the operations. Operations with the requestValidation operation extension set to false are not validated
+func (p *OpenAPIProductReconciler) desiredRequestValidationPolicy() (*capabilitiesv1beta1.PolicyConfig, error) {
+	requestValidation := p.openapiCR.Spec.RequestValidation
+	if requestValidation == nil {
+		return nil, nil
+	}
+
+	publicBasePath, err := p.desiredPublicBasePath()
+	if err != nil {
+		return nil, err
+	}
+
+	configuration := requestValidationPolicyConfiguration{
+		Operations: []requestValidationOperation{},
+	}
+
+	for _, op := range sortedOpenAPIOperations(p.openapiObj) {
+		operationExtension, err := helper.NewOasOperationExtension(op.operation)
+		if err != nil {
+			return nil, err
+		}
+		if operationExtension != nil && operationExtension.RequestValidation != nil && !*operationExtension.RequestValidation {
+			continue
+		}
+
+		operation, err := openAPIRequestValidationOperation(p.openapiObj.Paths[op.path], op, publicBasePath)
+		if err != nil {
+			return nil, err
+		}
+		if operation != nil {
+			configuration.Operations = append(configuration.Operations, *operation)
+		}
+	}
+
+	rawConfiguration, err := json.Marshal(configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &capabilitiesv1beta1.PolicyConfig{
+		Name:          requestValidation.PolicyName,
+		Version:       requestValidation.PolicyVersion,
+		Enabled:       true,
+		Configuration: runtime.RawExtension{Raw: rawConfiguration},
+	}, nil
+}
+
+// openAPIRequestValidationOperation returns nil when the operation has neither parameters nor request body
+func openAPIRequestValidationOperation(pathItem *openapi3.PathItem, op openAPIOperation, publicBasePath string) (*requestValidationOperation, error) {
+	operation := &requestValidationOperation{
+		HTTPMethod: op.verb,
+		Pattern:    LastSlashRegexp.ReplaceAllString(publicBasePath, "") + op.path,
+	}
+
+	// Operation parameters override path parameters with the same name and location
+	parameters := openapi3.Parameters{}
+	for _, parameterRef := range pathItem.Parameters {
+		if parameterRef.Value != nil && op.operation.Parameters.GetByInAndName(parameterRef.Value.In, parameterRef.Value.Name) == nil {
+			parameters = append(parameters, parameterRef)
+		}
+	}
+	parameters = append(parameters, op.operation.Parameters...)
+
+	for _, parameterRef := range parameters {
+		parameter := parameterRef.Value
+		if parameter == nil {
+			continue
+		}
+
+		schemaRef := parameter.Schema
+		if schemaRef == nil {
+			// parameters with content have one media type
+			for _, mediaType := range parameter.Content {
+				schemaRef = mediaType.Schema
+			}
+		}
+
+		schema, err := inlineOpenAPISchema(schemaRef, map[*openapi3.Schema]bool{})
+		if err != nil {
+			return nil, err
+		}
+
+		operation.Parameters = append(operation.Parameters, requestValidationParameter{
+			Name:     parameter.Name,
+			In:       parameter.In,
+			Required: parameter.Required,
+			Schema:   schema,
+		})
+	}
+
+	if op.operation.RequestBody != nil && op.operation.RequestBody.Value != nil {
+		requestBody := op.operation.RequestBody.Value
+		operation.RequestBody = &requestValidationRequestBody{
+			Required: requestBody.Required,
+			Content:  map[string]interface{}{},
+		}
+		for mediaTypeName, mediaType := range requestBody.Content {
+			schema, err := inlineOpenAPISchema(mediaType.Schema, map[*openapi3.Schema]bool{})
+			if err != nil {
+				return nil, err
+			}
+			if schema == nil {
+				// any content of the media type is valid
+				schema = map[string]interface{}{}
+			}
+			operation.RequestBody.Content[mediaTypeName] = schema
+		}
+	}
+
+	if len(operation.Parameters) == 0 && operation.RequestBody == nil {
+		return nil, nil
+	}
+
+	return operation, nil
+}
+
+// inlineOpenAPISchema returns the schema with the references replaced by the referenced schemas.
+// Recursive schemas accept any value from the recursion on
+func inlineOpenAPISchema(schemaRef *openapi3.SchemaRef, visiting map[*openapi3.Schema]bool) (interface{}, error) {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil, nil
+	}
+
+	schema := schemaRef.Value
+	if visiting[schema] {
+		return map[string]interface{}{}, nil
+	}
+	visiting[schema] = true
+	defer delete(visiting, schema)
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	// Subschemas are serialized as references, replace them
+	if len(schema.Properties) > 0 {
+		properties := map[string]interface{}{}
+		for name, property := range schema.Properties {
+			properties[name], err = inlineOpenAPISchema(property, visiting)
+			if err != nil {
+				return nil, err
+			}
+		}
+		obj["properties"] = properties
+	}
+
+	for keyword, subschema := range map[string]*openapi3.SchemaRef{
+		"items":                schema.Items,
+		"additionalProperties": schema.AdditionalProperties,
+		"not":                  schema.Not,
+	} {
+		if subschema == nil {
+			continue
+		}
+		obj[keyword], err = inlineOpenAPISchema(subschema, visiting)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for keyword, subschemas := range map[string]openapi3.SchemaRefs{
+		"allOf": schema.AllOf,
+		"anyOf": schema.AnyOf,
+		"oneOf": schema.OneOf,
+	} {
+		if len(subschemas) == 0 {
+			continue
+		}
+		inlined := make([]interface{}, 0, len(subschemas))
+		for _, subschema := range subschemas {
+			inlinedSubschema, err := inlineOpenAPISchema(subschema, visiting)
+			if err != nil {
+				return nil, err
+			}
+			inlined = append(inlined, inlinedSubschema)
+		}
+		obj[keyword] = inlined
+	}
+
+	return obj, nil
+}
+
+// insertPolicyBeforeAPIcast inserts the policy before the apicast policy,
+// so requests are processed before they are authorized and proxied.
+// An empty policy chain is the default apicast policy chain
+func insertPolicyBeforeAPIcast(policies []capabilitiesv1beta1.PolicyConfig, policyConfig capabilitiesv1beta1.PolicyConfig) []capabilitiesv1beta1.PolicyConfig {
+	if len(policies) == 0 {
+		return []capabilitiesv1beta1.PolicyConfig{
+			policyConfig,
+			{Name: apicastPolicyName, Version: builtinPolicyVersion, Enabled: true},
+		}
+	}
+
+	idx := 0
+	for i, policy := range policies {
+		if policy.Name == apicastPolicyName {
+			idx = i
+			break
+		}
+	}
+
+	result := make([]capabilitiesv1beta1.PolicyConfig, 0, len(policies)+1)
+	result = append(result, policies[:idx]...)
+	result = append(result, policyConfig)
+	return append(result, policies[idx:]...)
+}
+
+// reconcileRequestValidationPolicyDefinition publishes the request validation policy schema
+// as a CustomPolicyDefinition owned by the OpenAPI CR.
+// The definition is not needed when another CustomPolicyDefinition
+// of the namespace already defines the policy version
+func (p *OpenAPIProductReconciler) reconcileRequestValidationPolicyDefinition() error {
+	desired, err := p.desiredRequestValidationPolicyDefinition()
+	if err != nil {
+		return err
+	}
+
+	list := &capabilitiesv1beta1.CustomPolicyDefinitionList{}
+	err = p.Client().List(p.Context(), list, client.InNamespace(p.openapiCR.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list custom policy definitions: %w", err)
+	}
+
+	for idx := range list.Items {
+		definition := &list.Items[idx]
+		if desired != nil && !p.HasOwnerReference(p.openapiCR, definition) &&
+			definition.Spec.Name == desired.Spec.Name && definition.Spec.Version == desired.Spec.Version {
+			desired = nil
+		}
+	}
+
+	for idx := range list.Items {
+		definition := &list.Items[idx]
+		if (desired != nil && definition.Name == desired.Name) || !p.HasOwnerReference(p.openapiCR, definition) || definition.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		p.Logger().Info(fmt.Sprintf("deleting obsolete custom policy definition %s", helper.ObjectInfo(definition)))
+		err = p.DeleteResource(definition)
+		if err != nil && !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if desired == nil {
+		return nil
+	}
+
+	return p.ReconcileResource(&capabilitiesv1beta1.CustomPolicyDefinition{}, desired, p.customPolicyDefinitionMutator)
+}
+
+func (p *OpenAPIProductReconciler) desiredRequestValidationPolicyDefinition() (*capabilitiesv1beta1.CustomPolicyDefinition, error) {
+	requestValidation := p.openapiCR.Spec.RequestValidation
+	if requestValidation == nil {
+		return nil, nil
+	}
+
+	description := []string{"Validates the requests against the parameters and request bodies of the OpenAPI operations"}
+
+	definition := &capabilitiesv1beta1.CustomPolicyDefinition{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.CustomPolicyDefinitionKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%s-%s",
+				helper.K8sNameFromOpenAPITitle(p.openapiObj),
+				helper.NonAlphanumRegexp.ReplaceAllString(strings.ToLower(requestValidation.PolicyName), ""),
+				string(p.openapiCR.UID)),
+			Namespace: p.openapiCR.Namespace,
+		},
+		Spec: capabilitiesv1beta1.CustomPolicyDefinitionSpec{
+			ProviderAccountRef: p.openapiCR.Spec.ProviderAccountRef,
+			Name:               requestValidation.PolicyName,
+			Version:            requestValidation.PolicyVersion,
+			Schema: capabilitiesv1beta1.CustomPolicySchemaSpec{
+				Name:          "Request validation",
+				Version:       requestValidation.PolicyVersion,
+				Summary:       "Validates the requests against the OpenAPI document",
+				Description:   &description,
+				Schema:        "http://json-schema.org/draft-07/schema#",
+				Configuration: runtime.RawExtension{Raw: []byte(requestValidationPolicySchema)},
+			},
+		},
+	}
+
+	err := p.SetControllerOwnerReference(p.openapiCR, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+func (p *OpenAPIProductReconciler) customPolicyDefinitionMutator(existingObj, desiredObj client.Object) (bool, error) {
+	existing, ok := existingObj.(*capabilitiesv1beta1.CustomPolicyDefinition)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.CustomPolicyDefinition", existingObj)
+	}
+	desired, ok := desiredObj.(*capabilitiesv1beta1.CustomPolicyDefinition)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.CustomPolicyDefinition", desiredObj)
+	}
+
+	// OwnerRefenrence
+	updated, err := p.EnsureOwnerReference(p.openapiCR, existing)
+	if err != nil {
+		return false, err
+	}
+
+	// Compare the unmarshalled configuration schema, resilient to serialization differences
+	existingSpec := existing.Spec.DeepCopy()
+	desiredSpec := desired.Spec.DeepCopy()
+	var existingConfiguration, desiredConfiguration interface{}
+	if err := json.Unmarshal(existingSpec.Schema.Configuration.Raw, &existingConfiguration); err != nil {
+		existingConfiguration = nil
+	}
+	if err := json.Unmarshal(desiredSpec.Schema.Configuration.Raw, &desiredConfiguration); err != nil {
+		return false, err
+	}
+	existingSpec.Schema.Configuration = runtime.RawExtension{}
+	desiredSpec.Schema.Configuration = runtime.RawExtension{}
+
+	if !reflect.DeepEqual(existingSpec, desiredSpec) || !reflect.DeepEqual(existingConfiguration, desiredConfiguration) {
+		diff := cmp.Diff(existing.Spec, desired.Spec)
+		p.Logger().Info(fmt.Sprintf("%s spec has changed: %s", helper.ObjectInfo(desired), diff))
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
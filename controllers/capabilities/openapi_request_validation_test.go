@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func getRequestValidationOpenAPISecret() *corev1.Secret {
+	secretData := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+servers:
+  - url: http://petstore.swagger.io/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+      responses:
+        '200':
+          description: A paged array of pets
+    post:
+      operationId: createPets
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Pet"
+      responses:
+        '201':
+          description: Null response
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: showPetById
+      responses:
+        '200':
+          description: Expected response to a valid request
+    delete:
+      operationId: deletePet
+      x-3scale-operation:
+        requestValidation: false
+      responses:
+        '204':
+          description: Deleted
+  /health:
+    get:
+      operationId: health
+      responses:
+        '200':
+          description: Healthy
+components:
+  schemas:
+    Pet:
+      type: object
+      required:
+        - name
+      properties:
+        name:
+          type: string
+        children:
+          type: array
+          items:
+            $ref: "#/components/schemas/Pet"
+`
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testOpenAPISecret",
+			Namespace: "testNamespace",
+		},
+		Data: map[string][]byte{"oas": []byte(secretData)},
+	}
+}
+
+func getRequestValidationOpenAPICR() *capabilitiesv1beta1.OpenAPI {
+	openapiCR := getOpenAPICR()
+	openapiCR.UID = types.UID("8f0b3c52-2a7d-4c57-8c3e-0b6d5e1f9a44")
+	openapiCR.Spec.RequestValidation = &capabilitiesv1beta1.OpenAPIRequestValidationSpec{
+		PolicyName:    "request_validation",
+		PolicyVersion: "0.1",
+	}
+	return openapiCR
+}
+
+func TestOpenAPIProductReconciler_desiredRequestValidationPolicy(t *testing.T) {
+	openapiCR := getRequestValidationOpenAPICR()
+	p := NewOpenAPIProductReconciler(
+		getOpenAPIBaseReconciler(openapiCR),
+		openapiCR,
+		getOpenAPIObj(getRequestValidationOpenAPISecret()),
+		nil,
+		getOpenAPITestLogger(),
+	)
+
+	policies, err := p.desiredPolicies()
+	if err != nil {
+		t.Fatalf("desiredPolicies() error = %v", err)
+	}
+	if len(policies) != 2 || policies[0].Name != "request_validation" || policies[0].Version != "0.1" || policies[1].Name != "apicast" {
+		t.Fatalf("unexpected policy chain: %v", policies)
+	}
+
+	got := map[string]interface{}{}
+	err = json.Unmarshal(policies[0].Configuration.Raw, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	petSchema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"name"},
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string"},
+			"children": map[string]interface{}{
+				"type": "array",
+				// recursive reference
+				"items": map[string]interface{}{},
+			},
+		},
+	}
+	want := map[string]interface{}{
+		"operations": []interface{}{
+			map[string]interface{}{
+				"http_method": "GET",
+				"pattern":     "/v1/pets",
+				"parameters": []interface{}{
+					map[string]interface{}{"name": "limit", "in": "query", "required": false, "schema": map[string]interface{}{"type": "integer", "maximum": float64(100)}},
+				},
+			},
+			map[string]interface{}{
+				"http_method": "POST",
+				"pattern":     "/v1/pets",
+				"request_body": map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": petSchema},
+				},
+			},
+			map[string]interface{}{
+				"http_method": "GET",
+				"pattern":     "/v1/pets/{petId}",
+				"parameters": []interface{}{
+					map[string]interface{}{"name": "petId", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("configuration diff: %s", cmp.Diff(want, got))
+	}
+}
+
+func TestInsertPolicyBeforeAPIcast(t *testing.T) {
+	requestValidationPolicy := capabilitiesv1beta1.PolicyConfig{Name: "request_validation", Version: "0.1", Enabled: true}
+	cors := capabilitiesv1beta1.PolicyConfig{Name: "cors", Version: "builtin", Enabled: true}
+	apicast := capabilitiesv1beta1.PolicyConfig{Name: "apicast", Version: "builtin", Enabled: true}
+
+	got := insertPolicyBeforeAPIcast([]capabilitiesv1beta1.PolicyConfig{cors, apicast}, requestValidationPolicy)
+	want := []capabilitiesv1beta1.PolicyConfig{cors, requestValidationPolicy, apicast}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("policy chain diff: %s", cmp.Diff(want, got))
+	}
+
+	got = insertPolicyBeforeAPIcast([]capabilitiesv1beta1.PolicyConfig{cors}, requestValidationPolicy)
+	want = []capabilitiesv1beta1.PolicyConfig{requestValidationPolicy, cors}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("policy chain diff: %s", cmp.Diff(want, got))
+	}
+}
+
+func TestOpenAPIProductReconciler_reconcileRequestValidationPolicyDefinition(t *testing.T) {
+	openapiCR := getRequestValidationOpenAPICR()
+	baseReconciler := getOpenAPIBaseReconciler(openapiCR)
+	p := NewOpenAPIProductReconciler(baseReconciler, openapiCR, getOpenAPIObj(getRequestValidationOpenAPISecret()), nil, getOpenAPITestLogger())
+
+	listDefinitions := func() []capabilitiesv1beta1.CustomPolicyDefinition {
+		list := &capabilitiesv1beta1.CustomPolicyDefinitionList{}
+		if err := baseReconciler.Client().List(baseReconciler.Context(), list); err != nil {
+			t.Fatal(err)
+		}
+		return list.Items
+	}
+
+	err := p.reconcileRequestValidationPolicyDefinition()
+	if err != nil {
+		t.Fatalf("reconcileRequestValidationPolicyDefinition() error = %v", err)
+	}
+	definitions := listDefinitions()
+	if len(definitions) != 1 || definitions[0].Spec.Name != "request_validation" || definitions[0].Spec.Version != "0.1" {
+		t.Fatalf("unexpected custom policy definitions: %v", definitions)
+	}
+
+	// the definition is deleted when request validation is disabled
+	openapiCR.Spec.RequestValidation = nil
+	err = p.reconcileRequestValidationPolicyDefinition()
+	if err != nil {
+		t.Fatalf("reconcileRequestValidationPolicyDefinition() error = %v", err)
+	}
+	if definitions := listDefinitions(); len(definitions) != 0 {
+		t.Errorf("got %d custom policy definitions, want 0", len(definitions))
+	}
+}
@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/getkin/kin-openapi/openapi3"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Fields of the git repository credentials secret
+	GitCredentialsUsernameField = "username"
+	GitCredentialsPasswordField = "password"
+)
+
+// OpenAPISourceVersion identifies the read version of an OpenAPI Document
+type OpenAPISourceVersion struct {
+	// Revision of the source: the git commit hash, the OCI manifest digest or the ConfigMap resource version
+	Revision string
+	// Hash of the document content
+	Hash string
+	// FetchTime is the time the document was read
+	FetchTime metav1.Time
+	// DocumentVersion is the swagger or openapi version declared by the document
+	DocumentVersion string
+	// Conversions applied to import the document as OpenAPI 3.0
+	Conversions []string
+	// Document is the document as read from the source, before the conversions. YAML documents are encoded as JSON
+	Document []byte
+}
+
+// OpenAPISourceReader reads OpenAPI Documents from Secret, URL, ConfigMap, git and OCI sources.
+// Swagger 2.0 and OpenAPI 3.1 documents are converted to OpenAPI 3.0.
+// URL, git and OCI documents are kept in the cache under the key of the resource.
+// Git and OCI documents are downloaded again only when the source revision changes
+// and URL documents are requested conditionally on their ETag and Last-Modified headers
+type OpenAPISourceReader struct {
+	ctx                context.Context
+	client             client.Client
+	namespace          string
+	insecureSkipVerify bool
+	cache              *OpenAPISourceCache
+	cacheKey           types.NamespacedName
+	now                func() time.Time
+}
+
+func NewOpenAPISourceReader(ctx context.Context, cl client.Client, namespace string, insecureSkipVerify bool, cache *OpenAPISourceCache, cacheKey types.NamespacedName) *OpenAPISourceReader {
+	return &OpenAPISourceReader{
+		ctx:                ctx,
+		client:             cl,
+		namespace:          namespace,
+		insecureSkipVerify: insecureSkipVerify,
+		cache:              cache,
+		cacheKey:           cacheKey,
+		now:                time.Now,
+	}
+}
+
+// ReadURL fetches the document of the URL.
+// The request is conditional on the previously fetched document, so unchanged documents are not downloaded again
+func (r *OpenAPISourceReader) ReadURL(rawURL string, fldPath *field.Path) (*openapi3.T, *OpenAPISourceVersion, error) {
+	openAPIURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, openAPISourceInvalidError(fldPath, rawURL, err.Error())
+	}
+
+	cacheSource := "url:" + rawURL
+	var previous *controllerhelper.OpenAPIURLDocument
+	if cached := r.cache.get(r.cacheKey, cacheSource); cached != nil {
+		previous = &controllerhelper.OpenAPIURLDocument{
+			Data:         cached.Data,
+			Hash:         controllerhelper.OpenAPIDocumentHash(cached.Data),
+			ETag:         cached.ETag,
+			LastModified: cached.LastModified,
+		}
+	}
+
+	httpClient := controllerhelper.OpenAPISourceHTTPClient(r.insecureSkipVerify)
+	document, err := controllerhelper.FetchOpenAPIURL(r.ctx, httpClient, rawURL, previous)
+	if err != nil {
+		return nil, nil, openAPISourceInvalidError(fldPath, rawURL, err.Error())
+	}
+
+	// Documents without validators cannot be requested conditionally, they are not kept
+	if document != previous && (document.ETag != "" || document.LastModified != "") {
+		r.cache.set(r.cacheKey, &openAPISourceCacheEntry{
+			Source:       cacheSource,
+			ETag:         document.ETag,
+			LastModified: document.LastModified,
+			Data:         document.Data,
+		})
+	}
+
+	openapiObj, documentInfo, err := controllerhelper.LoadOpenAPIDocument(r.ctx, document.Data, openAPIURL)
+	if err != nil {
+		return nil, nil, openAPISourceInvalidError(fldPath, rawURL, err.Error())
+	}
+
+	return openapiObj, r.version("", document.Data, documentInfo), nil
+}
+
+func (r *OpenAPISourceReader) ReadSecret(ref *corev1.ObjectReference, fldPath *field.Path) (*openapi3.T, *OpenAPISourceVersion, error) {
+	secret := &corev1.Secret{}
+	objectKey := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if err := r.client.Get(r.ctx, objectKey, secret); err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, nil, openAPISourceInvalidError(fldPath, ref, "Secret not found")
+		}
+
+		// unexpected error
+		return nil, nil, err
+	}
+
+	if len(secret.Data) != 1 {
+		return nil, nil, openAPISourceInvalidError(fldPath, ref, "Secret was empty or contains too many fields. Only one is required.")
+	}
+
+	var data []byte
+	for _, value := range secret.Data {
+		data = value
+	}
+
+	openapiObj, documentInfo, err := r.load(data, fldPath, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return openapiObj, r.version("", data, documentInfo), nil
+}
+
+func (r *OpenAPISourceReader) ReadConfigMap(ref *capabilitiesv1beta1.OpenAPIConfigMapRefSpec, fldPath *field.Path) (*openapi3.T, *OpenAPISourceVersion, error) {
+	configMap := &corev1.ConfigMap{}
+	// ref.Namespace set in defaults
+	objectKey := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if err := r.client.Get(r.ctx, objectKey, configMap); err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, nil, openAPISourceInvalidError(fldPath, ref, "ConfigMap not found")
+		}
+
+		// unexpected error
+		return nil, nil, err
+	}
+
+	var data string
+	if ref.Key != "" {
+		value, ok := configMap.Data[ref.Key]
+		if !ok {
+			return nil, nil, openAPISourceInvalidError(fldPath, ref, fmt.Sprintf("ConfigMap does not have the key %s", ref.Key))
+		}
+		data = value
+	} else {
+		if len(configMap.Data) != 1 {
+			return nil, nil, openAPISourceInvalidError(fldPath, ref, "ConfigMap was empty or contains too many keys. Set the key.")
+		}
+		for _, value := range configMap.Data {
+			data = value
+		}
+	}
+
+	openapiObj, documentInfo, err := r.load([]byte(data), fldPath, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return openapiObj, r.version(configMap.ResourceVersion, []byte(data), documentInfo), nil
+}
+
+func (r *OpenAPISourceReader) ReadGit(source *capabilitiesv1beta1.OpenAPIGitSourceSpec, fldPath *field.Path) (*openapi3.T, *OpenAPISourceVersion, error) {
+	var credentials *controllerhelper.GitCredentials
+	if source.CredentialsSecretRef != nil {
+		secret, err := r.secret(source.CredentialsSecretRef.Name, fldPath.Child("credentialsSecretRef"), source.CredentialsSecretRef)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		credentials = &controllerhelper.GitCredentials{
+			Username: string(secret.Data[GitCredentialsUsernameField]),
+			Password: string(secret.Data[GitCredentialsPasswordField]),
+		}
+	}
+
+	repo := &controllerhelper.GitRepository{URL: source.URL, Credentials: credentials, InsecureSkipVerify: r.insecureSkipVerify}
+	ref, err := repo.ResolveRef(r.ctx, source.Ref)
+	if err != nil {
+		return nil, nil, r.gitError(source, fldPath, err)
+	}
+
+	cacheSource := fmt.Sprintf("git:%s#%s:%s", source.URL, source.Ref, source.Path)
+	cached := r.cache.get(r.cacheKey, cacheSource)
+	if cached == nil || cached.RemoteRevision != ref.Hash {
+		data, revision, err := repo.FetchFile(r.ctx, ref, source.Path)
+		if err != nil {
+			return nil, nil, r.gitError(source, fldPath, err)
+		}
+
+		cached = &openAPISourceCacheEntry{Source: cacheSource, RemoteRevision: ref.Hash, Revision: revision, Data: data}
+		r.cache.set(r.cacheKey, cached)
+	}
+
+	openapiObj, documentInfo, err := r.load(cached.Data, fldPath, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return openapiObj, r.version(cached.Revision, cached.Data, documentInfo), nil
+}
+
+func (r *OpenAPISourceReader) gitError(source *capabilitiesv1beta1.OpenAPIGitSourceSpec, fldPath *field.Path, err error) error {
+	if isInvalidGitSourceError(err) {
+		return openAPISourceInvalidError(fldPath, source, err.Error())
+	}
+
+	return fmt.Errorf("error reading OpenAPI document from git repository %s: %w", source.URL, err)
+}
+
+func (r *OpenAPISourceReader) ReadOCI(source *capabilitiesv1beta1.OpenAPIOCISourceSpec, fldPath *field.Path) (*openapi3.T, *OpenAPISourceVersion, error) {
+	var dockerConfigJSON []byte
+	if source.PullSecretRef != nil {
+		secret, err := r.secret(source.PullSecretRef.Name, fldPath.Child("pullSecretRef"), source.PullSecretRef)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		dockerConfigJSON = secret.Data[corev1.DockerConfigJsonKey]
+		if len(dockerConfigJSON) == 0 {
+			dockerConfigJSON = secret.Data[corev1.DockerConfigKey]
+		}
+	}
+
+	artifact := &controllerhelper.OCIArtifact{Image: source.Image, DockerConfigJSON: dockerConfigJSON, InsecureSkipVerify: r.insecureSkipVerify}
+	digest, err := artifact.ResolveDigest(r.ctx)
+	if err != nil {
+		return nil, nil, r.ociError(source, fldPath, err)
+	}
+
+	cacheSource := fmt.Sprintf("oci:%s:%s", source.Image, source.Path)
+	cached := r.cache.get(r.cacheKey, cacheSource)
+	if cached == nil || cached.RemoteRevision != digest {
+		data, err := artifact.FetchFile(r.ctx, digest, source.Path)
+		if err != nil {
+			return nil, nil, r.ociError(source, fldPath, err)
+		}
+
+		cached = &openAPISourceCacheEntry{Source: cacheSource, RemoteRevision: digest, Revision: digest, Data: data}
+		r.cache.set(r.cacheKey, cached)
+	}
+
+	openapiObj, documentInfo, err := r.load(cached.Data, fldPath, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return openapiObj, r.version(cached.Revision, cached.Data, documentInfo), nil
+}
+
+func (r *OpenAPISourceReader) ociError(source *capabilitiesv1beta1.OpenAPIOCISourceSpec, fldPath *field.Path, err error) error {
+	if errors.Is(err, controllerhelper.ErrOCIFileNotFound) || errors.Is(err, controllerhelper.ErrOCIFileTooLarge) {
+		return openAPISourceInvalidError(fldPath, source, err.Error())
+	}
+
+	return fmt.Errorf("error reading OpenAPI document from OCI artifact %s: %w", source.Image, err)
+}
+
+func (r *OpenAPISourceReader) version(revision string, data []byte, documentInfo *controllerhelper.OpenAPIDocumentInfo) *OpenAPISourceVersion {
+	return &OpenAPISourceVersion{
+		Revision:        revision,
+		Hash:            controllerhelper.OpenAPIDocumentHash(data),
+		FetchTime:       metav1.NewTime(r.now()),
+		DocumentVersion: documentInfo.Version,
+		Conversions:     documentInfo.Conversions,
+		Document:        documentInfo.Document,
+	}
+}
+
+func (r *OpenAPISourceReader) secret(name string, fldPath *field.Path, value interface{}) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: name, Namespace: r.namespace}, secret); err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, openAPISourceInvalidError(fldPath, value, "Secret not found")
+		}
+
+		// unexpected error
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (r *OpenAPISourceReader) load(data []byte, fldPath *field.Path, value interface{}) (*openapi3.T, *controllerhelper.OpenAPIDocumentInfo, error) {
+	openapiObj, documentInfo, err := controllerhelper.LoadOpenAPIDocument(r.ctx, data, nil)
+	if err != nil {
+		return nil, nil, openAPISourceInvalidError(fldPath, value, err.Error())
+	}
+
+	return openapiObj, documentInfo, nil
+}
+
+// isInvalidGitSourceError returns true when the git source cannot be read until the spec or the repository change
+func isInvalidGitSourceError(err error) bool {
+	return errors.Is(err, controllerhelper.ErrGitRefNotFound) ||
+		errors.Is(err, controllerhelper.ErrGitFileNotFound) ||
+		errors.Is(err, controllerhelper.ErrGitFetchTooLarge)
+}
+
+func openAPISourceInvalidError(fldPath *field.Path, value interface{}, detail string) error {
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: field.ErrorList{field.Invalid(fldPath, value, detail)},
+	}
+}
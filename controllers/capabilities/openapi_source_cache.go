@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"math"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxOpenAPISourceCacheEntries bounds the number of documents kept by a reconciler
+const maxOpenAPISourceCacheEntries = 256
+
+// OpenAPISourceCache keeps the last document read from the remote source of each resource,
+// so the document is not downloaded again while the source revision or the URL validators do not change.
+// The zero value is ready to use. Entries are dropped when the resource is deleted
+// and the least recently used entry is evicted when the cache is full
+type OpenAPISourceCache struct {
+	mutex   sync.Mutex
+	entries map[types.NamespacedName]*openAPISourceCacheEntry
+	clock   uint64
+}
+
+type openAPISourceCacheEntry struct {
+	// Source identifies the source spec the document was read from
+	Source string
+	// RemoteRevision is the revision checked with the source before downloading:
+	// the git reference hash or the OCI manifest digest
+	RemoteRevision string
+	// Revision of the document: the git commit hash or the OCI manifest digest
+	Revision string
+	// ETag and LastModified are the validators of URL documents for conditional requests
+	ETag         string
+	LastModified string
+	Data         []byte
+
+	lastUsed uint64
+}
+
+// get returns the entry of the resource when it was read from the same source
+func (c *OpenAPISourceCache) get(key types.NamespacedName, source string) *openAPISourceCacheEntry {
+	if c == nil {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.Source != source {
+		return nil
+	}
+
+	c.clock++
+	entry.lastUsed = c.clock
+	return entry
+}
+
+func (c *OpenAPISourceCache) set(key types.NamespacedName, entry *openAPISourceCacheEntry) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = map[types.NamespacedName]*openAPISourceCacheEntry{}
+	}
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxOpenAPISourceCacheEntries {
+		oldestKey, oldestUsed := types.NamespacedName{}, uint64(math.MaxUint64)
+		for entryKey, cached := range c.entries {
+			if cached.lastUsed < oldestUsed {
+				oldestKey, oldestUsed = entryKey, cached.lastUsed
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+
+	c.clock++
+	entry.lastUsed = c.clock
+	c.entries[key] = entry
+}
+
+// Delete drops the entry of the resource
+func (c *OpenAPISourceCache) Delete(key types.NamespacedName) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, key)
+}
@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func getOpenAPIConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "openapi",
+			Namespace:       "test",
+			ResourceVersion: "42",
+		},
+		Data: data,
+	}
+}
+
+func TestOpenAPISourceReader_ReadConfigMap(t *testing.T) {
+	openapiDoc := string(getValidOpenAPISecret().Data["oas"])
+	fldPath := field.NewPath("spec").Child("openapiRef").Child("configMapRef")
+
+	cases := []struct {
+		name            string
+		configMap       *corev1.ConfigMap
+		ref             *capabilitiesv1beta1.OpenAPIConfigMapRefSpec
+		expectedErr     string
+		expectedInvalid bool
+	}{
+		{
+			name:      "single key",
+			configMap: getOpenAPIConfigMap(map[string]string{"openapi.yaml": openapiDoc}),
+			ref:       &capabilitiesv1beta1.OpenAPIConfigMapRefSpec{Name: "openapi", Namespace: "test"},
+		},
+		{
+			name:      "selected key",
+			configMap: getOpenAPIConfigMap(map[string]string{"openapi.yaml": openapiDoc, "README": "petstore"}),
+			ref:       &capabilitiesv1beta1.OpenAPIConfigMapRefSpec{Name: "openapi", Namespace: "test", Key: "openapi.yaml"},
+		},
+		{
+			name:            "key required with several keys",
+			configMap:       getOpenAPIConfigMap(map[string]string{"openapi.yaml": openapiDoc, "README": "petstore"}),
+			ref:             &capabilitiesv1beta1.OpenAPIConfigMapRefSpec{Name: "openapi", Namespace: "test"},
+			expectedErr:     "Set the key",
+			expectedInvalid: true,
+		},
+		{
+			name:            "missing key",
+			configMap:       getOpenAPIConfigMap(map[string]string{"openapi.yaml": openapiDoc}),
+			ref:             &capabilitiesv1beta1.OpenAPIConfigMapRefSpec{Name: "openapi", Namespace: "test", Key: "swagger.json"},
+			expectedErr:     "does not have the key swagger.json",
+			expectedInvalid: true,
+		},
+		{
+			name:            "missing configmap",
+			configMap:       getOpenAPIConfigMap(map[string]string{"openapi.yaml": openapiDoc}),
+			ref:             &capabilitiesv1beta1.OpenAPIConfigMapRefSpec{Name: "other", Namespace: "test"},
+			expectedErr:     "ConfigMap not found",
+			expectedInvalid: true,
+		},
+		{
+			name:            "invalid document",
+			configMap:       getOpenAPIConfigMap(map[string]string{"openapi.yaml": "openapi: ["}),
+			ref:             &capabilitiesv1beta1.OpenAPIConfigMapRefSpec{Name: "openapi", Namespace: "test"},
+			expectedInvalid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			baseReconciler := getBaseReconciler(tc.configMap)
+			reader := NewOpenAPISourceReader(baseReconciler.Context(), baseReconciler.Client(), "test", false, nil, k8stypes.NamespacedName{})
+
+			openapiObj, version, err := reader.ReadConfigMap(tc.ref, fldPath)
+			if tc.expectedInvalid {
+				if !helper.IsInvalidSpecError(err) {
+					subT.Fatalf("expected invalid spec error, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tc.expectedErr) {
+					subT.Errorf("error = %v, want %s", err, tc.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				subT.Fatalf("ReadConfigMap() error = %v", err)
+			}
+			if openapiObj.Info.Title != "Swagger Petstore" {
+				subT.Errorf("title = %s", openapiObj.Info.Title)
+			}
+			if version.Revision != "42" {
+				subT.Errorf("revision = %s, want the configmap resource version", version.Revision)
+			}
+			if !strings.HasPrefix(version.Hash, "sha256:") {
+				subT.Errorf("hash = %s", version.Hash)
+			}
+		})
+	}
+}
+
+func TestOpenAPISourceReader_ReadURL(t *testing.T) {
+	openapiDoc := getValidOpenAPISecret().Data["oas"]
+	notModified := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(openapiDoc)
+	}))
+	defer server.Close()
+
+	fldPath := field.NewPath("spec").Child("openapiRef").Child("url")
+	baseReconciler := getBaseReconciler()
+	cache := &OpenAPISourceCache{}
+	cacheKey := k8stypes.NamespacedName{Name: "petstore", Namespace: "test"}
+	reader := NewOpenAPISourceReader(baseReconciler.Context(), baseReconciler.Client(), "test", false, cache, cacheKey)
+
+	readURL := func() {
+		t.Helper()
+		openapiObj, version, err := reader.ReadURL(server.URL+"/openapi.yaml", fldPath)
+		if err != nil {
+			t.Fatalf("ReadURL() error = %v", err)
+		}
+		if openapiObj.Info.Title != "Swagger Petstore" {
+			t.Errorf("title = %s", openapiObj.Info.Title)
+		}
+		if version.Hash != controllerhelper.OpenAPIDocumentHash(openapiDoc) {
+			t.Errorf("hash = %s", version.Hash)
+		}
+	}
+
+	readURL()
+	readURL()
+	if notModified != 1 {
+		t.Errorf("conditional requests = %d, want 1", notModified)
+	}
+
+	// the document is dropped when the resource is deleted
+	cache.Delete(cacheKey)
+	readURL()
+	if notModified != 1 {
+		t.Errorf("conditional requests = %d, want 1", notModified)
+	}
+}
+
+func TestOpenAPISourceReader_ReadSecret(t *testing.T) {
+	swaggerDoc := `{"swagger":"2.0","info":{"title":"Swagger Petstore","version":"1.0.0"},"host":"petstore.swagger.io","paths":{}}`
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "swagger", Namespace: "test"},
+		Data:       map[string][]byte{"swagger.json": []byte(swaggerDoc)},
+	}
+	fldPath := field.NewPath("spec").Child("openapiRef").Child("secretRef")
+	baseReconciler := getBaseReconciler(secret)
+	reader := NewOpenAPISourceReader(baseReconciler.Context(), baseReconciler.Client(), "test", false, nil, k8stypes.NamespacedName{})
+
+	openapiObj, version, err := reader.ReadSecret(&corev1.ObjectReference{Name: "swagger", Namespace: "test"}, fldPath)
+	if err != nil {
+		t.Fatalf("ReadSecret() error = %v", err)
+	}
+	if openapiObj.Info.Title != "Swagger Petstore" {
+		t.Errorf("title = %s", openapiObj.Info.Title)
+	}
+	if version.DocumentVersion != "2.0" {
+		t.Errorf("document version = %s", version.DocumentVersion)
+	}
+	if len(version.Conversions) != 1 || version.Conversions[0] != controllerhelper.OpenAPIConversionSwagger2ToOpenAPI3 {
+		t.Errorf("conversions = %v", version.Conversions)
+	}
+	// the document as read, ActiveDocs upload it without conversions
+	if string(version.Document) != swaggerDoc {
+		t.Errorf("document = %s", version.Document)
+	}
+
+	_, _, err = reader.ReadSecret(&corev1.ObjectReference{Name: "missing", Namespace: "test"}, fldPath)
+	if !helper.IsInvalidSpecError(err) {
+		t.Errorf("expected invalid spec error, got %v", err)
+	}
+}
+
+func TestOpenAPISourceReader_ReadOCI(t *testing.T) {
+	openapiDoc := getValidOpenAPISecret().Data["oas"]
+
+	var blobRequests int32
+	handler := registry.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/blobs/") {
+			atomic.AddInt32(&blobRequests, 1)
+		}
+		handler.ServeHTTP(w, req)
+	}))
+	defer server.Close()
+
+	image := strings.TrimPrefix(server.URL, "http://") + "/team/petstore:v1"
+	push := func(content []byte) {
+		artifact, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+			mutate.Addendum{Layer: static.NewLayer(content, "application/vnd.oai.openapi+yaml")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := remote.Write(ref, artifact); err != nil {
+			t.Fatal(err)
+		}
+	}
+	push(openapiDoc)
+
+	fldPath := field.NewPath("spec").Child("openapiRef").Child("oci")
+	baseReconciler := getBaseReconciler()
+	cache := &OpenAPISourceCache{}
+	cacheKey := k8stypes.NamespacedName{Name: "petstore", Namespace: "test"}
+	source := &capabilitiesv1beta1.OpenAPIOCISourceSpec{Image: image}
+
+	read := func() *OpenAPISourceVersion {
+		t.Helper()
+		reader := NewOpenAPISourceReader(baseReconciler.Context(), baseReconciler.Client(), "test", false, cache, cacheKey)
+		openapiObj, version, err := reader.ReadOCI(source, fldPath)
+		if err != nil {
+			t.Fatalf("ReadOCI() error = %v", err)
+		}
+		if openapiObj.Info.Title != "Swagger Petstore" {
+			t.Errorf("title = %s", openapiObj.Info.Title)
+		}
+		return version
+	}
+
+	first := read()
+	second := read()
+	if first.Revision != second.Revision {
+		t.Errorf("revision = %s, want %s", second.Revision, first.Revision)
+	}
+	// the unchanged artifact is not downloaded again
+	if requests := atomic.LoadInt32(&blobRequests); requests != 1 {
+		t.Errorf("blob requests = %d, want 1", requests)
+	}
+
+	push(append([]byte("# v2\n"), openapiDoc...))
+	third := read()
+	if third.Revision == first.Revision {
+		t.Error("expected the revision of the new artifact")
+	}
+	if requests := atomic.LoadInt32(&blobRequests); requests != 2 {
+		t.Errorf("blob requests = %d, want 2", requests)
+	}
+
+	// the cache entry is dropped with the resource
+	cache.Delete(cacheKey)
+	read()
+	if requests := atomic.LoadInt32(&blobRequests); requests != 3 {
+		t.Errorf("blob requests = %d, want 3", requests)
+	}
+}
@@ -19,16 +19,22 @@ type OpenAPIStatusReconciler struct {
 	*reconcilers.BaseReconciler
 	resource            *capabilitiesv1beta1.OpenAPI
 	providerAccountHost string
+	sourceVersion       *OpenAPISourceVersion
+	authentication      *capabilitiesv1beta1.OpenAPIAuthenticationStatus
+	changeReport        *OpenAPIChangeReport
 	reconcileError      error
 	reconcileReady      bool
 	logger              logr.Logger
 }
 
-func NewOpenAPIStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.OpenAPI, providerAccountHost string, reconcileError error, reconcileReady bool) *OpenAPIStatusReconciler {
+func NewOpenAPIStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.OpenAPI, providerAccountHost string, sourceVersion *OpenAPISourceVersion, authentication *capabilitiesv1beta1.OpenAPIAuthenticationStatus, changeReport *OpenAPIChangeReport, reconcileError error, reconcileReady bool) *OpenAPIStatusReconciler {
 	return &OpenAPIStatusReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		providerAccountHost: providerAccountHost,
+		sourceVersion:       sourceVersion,
+		authentication:      authentication,
+		changeReport:        changeReport,
 		reconcileError:      reconcileError,
 		reconcileReady:      reconcileReady,
 		logger:              b.Logger().WithValues("Status Reconciler", resource.Name),
@@ -91,6 +97,50 @@ func (s *OpenAPIStatusReconciler) calculateStatus() (*capabilitiesv1beta1.OpenAP
 	}
 	newStatus.BackendResourceNames = backendResourceNames
 
+	activeDocResourceName, err := s.getManagedActiveDoc()
+	if err != nil {
+		return nil, err
+	}
+	newStatus.ActiveDocResourceName = activeDocResourceName
+
+	// Keep the last read version when the document was not read
+	newStatus.SourceRevision = s.resource.Status.SourceRevision
+	newStatus.SourceHash = s.resource.Status.SourceHash
+	newStatus.SourceFetchTime = s.resource.Status.SourceFetchTime
+	newStatus.SourceDocumentVersion = s.resource.Status.SourceDocumentVersion
+	newStatus.SourceConversions = s.resource.Status.SourceConversions
+	if s.sourceVersion != nil {
+		newStatus.SourceRevision = s.sourceVersion.Revision
+		newStatus.SourceDocumentVersion = s.sourceVersion.DocumentVersion
+		newStatus.SourceConversions = s.sourceVersion.Conversions
+		// The fetch time changes with the content only, so polling does not update the status
+		if s.sourceVersion.Hash != newStatus.SourceHash || newStatus.SourceFetchTime == nil {
+			if newStatus.SourceHash != "" {
+				s.logger.Info("OpenAPI document changed", "previousHash", newStatus.SourceHash, "hash", s.sourceVersion.Hash)
+			}
+			newStatus.SourceHash = s.sourceVersion.Hash
+			newStatus.SourceFetchTime = &s.sourceVersion.FetchTime
+		}
+	}
+
+	// Keep the last authentication when the document was not validated
+	newStatus.Authentication = s.resource.Status.Authentication
+	if s.authentication != nil {
+		newStatus.Authentication = s.authentication
+	}
+
+	// Keep the last reported changes when the document was not compared
+	newStatus.AppliedSourceHash = s.resource.Status.AppliedSourceHash
+	newStatus.Changes = s.resource.Status.Changes
+	if s.changeReport != nil {
+		newStatus.AppliedSourceHash = s.changeReport.AppliedHash
+		newStatus.Changes = nil
+		// empty list is not stored, avoid status updates when comparing
+		if len(s.changeReport.Changes) > 0 {
+			newStatus.Changes = s.changeReport.Changes
+		}
+	}
+
 	newStatus.ObservedGeneration = s.resource.Status.ObservedGeneration
 
 	newStatus.Conditions = s.resource.Status.Conditions.Copy()
@@ -189,3 +239,26 @@ func (s *OpenAPIStatusReconciler) getManagedBackends() ([]corev1.LocalObjectRefe
 
 	return managedBackends, nil
 }
+
+func (s *OpenAPIStatusReconciler) getManagedActiveDoc() (*corev1.LocalObjectReference, error) {
+	listOps := []client.ListOption{
+		client.InNamespace(s.resource.Namespace),
+	}
+	list := &capabilitiesv1beta1.ActiveDocList{}
+	err := s.Client().List(s.Context(), list, listOps...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activedocs: %w", err)
+	}
+
+	for _, activeDoc := range list.Items {
+		for _, ownerRef := range activeDoc.GetOwnerReferences() {
+			if ownerRef.UID == s.resource.UID {
+				return &corev1.LocalObjectReference{
+					Name: activeDoc.Name,
+				}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -179,6 +179,18 @@ func (r *ProductReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, reconcileErr
 	}
 
+	if exportErr := statusReconciler.openAPIExportError; exportErr != nil {
+		r.EventRecorder().Eventf(product, corev1.EventTypeWarning, "OpenAPIExportError", "%v", exportErr)
+		if helper.IsInvalidSpecError(exportErr) {
+			// The export configmap needs to be changed, no need to retry
+			reqLogger.Info("ERROR", "openapi export spec validation error", exportErr)
+			return ctrl.Result{}, nil
+		}
+
+		reqLogger.Error(exportErr, "Failed to export the product OpenAPI document")
+		return ctrl.Result{}, exportErr
+	}
+
 	reqLogger.Info("END", "error", reconcileErr)
 	return ctrl.Result{}, reconcileErr
 }
@@ -212,15 +224,26 @@ func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Produ
 		return statusReconciler, err
 	}
 
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
+		return statusReconciler, err
+	}
+
 	backendRemoteIndex, err := controllerhelper.NewBackendAPIRemoteIndex(threescaleAPIClient, logger)
 	if err != nil {
 		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
 		return statusReconciler, err
 	}
 
-	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, productResource, threescaleAPIClient, backendRemoteIndex)
+	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, productResource, threescaleAPIClient, adminAPIClient, backendRemoteIndex)
 	productEntity, err := reconciler.Reconcile()
 	statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, productEntity, providerAccount.AdminURLStr, err)
+	if err == nil {
+		// Export failures are reported in their own condition, the product is synchronized
+		statusReconciler.openAPIExportError = r.reconcileOpenAPIExport(productResource, providerAccount)
+	}
+
 	return statusReconciler, err
 }
 
@@ -416,5 +439,6 @@ func (r *ProductReconciler) removeProductFrom3scale(product *capabilitiesv1beta1
 func (r *ProductReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.Product{}).
+		Owns(&corev1.ConfigMap{}).
 		Complete(r)
 }
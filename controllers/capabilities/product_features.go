@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"fmt"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func (t *ProductThreescaleReconciler) syncFeatures(_ interface{}) error {
+	// Features created from the 3scale UI are kept
+	if len(t.resource.Spec.Features) == 0 && len(t.resource.Status.ManagedFeatures) == 0 {
+		return nil
+	}
+
+	desiredKeys := make([]string, 0, len(t.resource.Spec.Features))
+	for systemName := range t.resource.Spec.Features {
+		desiredKeys = append(desiredKeys, systemName)
+	}
+
+	existingList, err := t.productFeatures()
+	if err != nil {
+		return fmt.Errorf("error sync product [%s] features: %w", t.resource.Spec.SystemName, err)
+	}
+
+	existingKeys := make([]string, 0, len(existingList.Features))
+	existingMap := map[string]controllerhelper.FeatureItem{}
+	for _, existing := range existingList.Features {
+		systemName := existing.Element.SystemName
+		existingKeys = append(existingKeys, systemName)
+		existingMap[systemName] = existing.Element
+	}
+
+	//
+	// Deleted existing and not desired
+	//
+
+	notDesiredExistingKeys := helper.ArrayStringNotDesired(existingKeys, desiredKeys, t.resource.Status.ManagedFeatures)
+	t.logger.V(1).Info("syncFeatures", "notDesiredExistingKeys", notDesiredExistingKeys)
+	for _, systemName := range notDesiredExistingKeys {
+		// key is expected to exist
+		// notDesiredExistingKeys is a subset of the existingMap key set
+		err := t.adminAPIClient.DeleteServiceFeature(t.productEntity.ID(), existingMap[systemName].ID)
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] features: %w", t.resource.Spec.SystemName, err)
+		}
+	}
+
+	//
+	// Reconcile existing
+	//
+
+	matchedKeys := helper.ArrayStringIntersection(existingKeys, desiredKeys)
+	t.logger.V(1).Info("syncFeatures", "matchedKeys", matchedKeys)
+	desiredNewKeys := helper.ArrayStringDifference(desiredKeys, existingKeys)
+	for _, systemName := range matchedKeys {
+		existing := existingMap[systemName]
+		spec := t.resource.Spec.Features[systemName]
+
+		// the scope of a feature cannot be updated, the feature is recreated
+		if existing.Scope != spec.FeatureScope() {
+			err := t.adminAPIClient.DeleteServiceFeature(t.productEntity.ID(), existing.ID)
+			if err != nil {
+				return fmt.Errorf("error sync product [%s] features: %w", t.resource.Spec.SystemName, err)
+			}
+			desiredNewKeys = append(desiredNewKeys, systemName)
+			continue
+		}
+
+		params := threescaleapi.Params{}
+		if spec.Name != existing.Name {
+			params["name"] = spec.Name
+		}
+
+		if spec.Description != existing.Description {
+			params["description"] = spec.Description
+		}
+
+		if len(params) > 0 {
+			_, err := t.adminAPIClient.UpdateServiceFeature(t.productEntity.ID(), existing.ID, params)
+			if err != nil {
+				return fmt.Errorf("error sync product [%s] features: %w", t.resource.Spec.SystemName, err)
+			}
+		}
+	}
+
+	//
+	// Create not existing and desired
+	//
+
+	t.logger.V(1).Info("syncFeatures", "desiredNewKeys", desiredNewKeys)
+	for _, systemName := range desiredNewKeys {
+		// key is expected to exist
+		// desiredNewKeys is a subset of the Spec.Features map key set
+		spec := t.resource.Spec.Features[systemName]
+		params := threescaleapi.Params{
+			"name":        spec.Name,
+			"system_name": systemName,
+			"scope":       spec.FeatureScope(),
+		}
+		if len(spec.Description) > 0 {
+			params["description"] = spec.Description
+		}
+		_, err := t.adminAPIClient.CreateServiceFeature(t.productEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] features: %w", t.resource.Spec.SystemName, err)
+		}
+	}
+
+	t.features = nil
+
+	return nil
+}
+
+// productFeatures returns the features of the product.
+// The list is cached until features are synchronized.
+func (t *ProductThreescaleReconciler) productFeatures() (*controllerhelper.FeatureList, error) {
+	if t.features == nil {
+		list, err := t.adminAPIClient.ListServiceFeatures(t.productEntity.ID())
+		if err != nil {
+			return nil, err
+		}
+		t.features = list
+	}
+
+	return t.features, nil
+}
+
+// findFeatureID returns the ID of the feature with the given system name and scope
+func findFeatureID(list *controllerhelper.FeatureList, systemName, scope string) (int64, bool) {
+	for _, feature := range list.Features {
+		if feature.Element.SystemName == systemName && feature.Element.Scope == scope {
+			return feature.Element.ID, true
+		}
+	}
+
+	return 0, false
+}
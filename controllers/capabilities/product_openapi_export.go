@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// productOpenAPIExportLabelKey labels the configmaps with the exported OpenAPI document of a product
+	productOpenAPIExportLabelKey = "capabilities.3scale.net/openapi-export"
+	// productOpenAPIExportConfigMapKey is the field of the configmap with the exported OpenAPI document
+	productOpenAPIExportConfigMapKey = "openapi.json"
+)
+
+// reconcileOpenAPIExport generates the configmap with the product exported as an OpenAPI document.
+// Exports no longer desired are deleted.
+func (r *ProductReconciler) reconcileOpenAPIExport(product *capabilitiesv1beta1.Product, providerAccount *controllerhelper.ProviderAccount) error {
+	desiredName := ""
+	if product.Spec.OpenAPIExport != nil {
+		desiredName = productOpenAPIExportConfigMapName(product)
+
+		err := r.reconcileOpenAPIExportConfigMap(product, providerAccount, desiredName)
+		if err != nil {
+			return err
+		}
+	}
+
+	configMapList := &corev1.ConfigMapList{}
+	err := r.Client().List(r.Context(), configMapList, client.InNamespace(product.Namespace), client.MatchingLabels{productOpenAPIExportLabelKey: "true"})
+	if err != nil {
+		return err
+	}
+
+	for idx := range configMapList.Items {
+		configMap := &configMapList.Items[idx]
+		if configMap.Name == desiredName || !metav1.IsControlledBy(configMap, product) {
+			continue
+		}
+
+		err = r.DeleteResource(configMap)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ProductReconciler) reconcileOpenAPIExportConfigMap(product *capabilitiesv1beta1.Product, providerAccount *controllerhelper.ProviderAccount, name string) error {
+	logger := r.Logger().WithValues("product", product.Name)
+
+	// ConfigMaps not created by the operator are not taken over:
+	// the document would overwrite their data and they would be deleted with the product
+	existing := &corev1.ConfigMap{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: name, Namespace: product.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && !metav1.IsControlledBy(existing, product) {
+		return &helper.SpecFieldError{
+			ErrorType: helper.InvalidError,
+			FieldErrorList: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("openAPIExport").Child("configMapName"), name, "configmap already exists and is not owned by the product"),
+			},
+		}
+	}
+
+	backendList, err := controllerhelper.BackendList(product.Namespace, r.Client(), providerAccount.AdminURLStr, logger)
+	if err != nil {
+		return err
+	}
+
+	issuerEndpoint, err := r.productIssuerEndpoint(product)
+	if err != nil {
+		return err
+	}
+
+	openapiObj, err := controllerhelper.ProductToOpenAPI(product, computeBackendUsageList(backendList, product.Spec.BackendUsages), issuerEndpoint)
+	if err != nil {
+		return err
+	}
+
+	document, err := openapiObj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: product.Namespace,
+			Labels: map[string]string{
+				productOpenAPIExportLabelKey: "true",
+			},
+		},
+		Data: map[string]string{
+			productOpenAPIExportConfigMapKey: string(document),
+		},
+	}
+
+	err = r.SetControllerOwnerReference(product, configMap)
+	if err != nil {
+		return err
+	}
+
+	return r.ReconcileResource(&corev1.ConfigMap{}, configMap, r.openAPIExportConfigMapMutator(product))
+}
+
+// productIssuerEndpoint returns the OIDC issuer endpoint of the product, read from the secret when referenced
+func (r *ProductReconciler) productIssuerEndpoint(product *capabilitiesv1beta1.Product) (string, error) {
+	oidcSpec := product.Spec.OIDCSpec()
+	if oidcSpec == nil {
+		return "", nil
+	}
+
+	// plain value takes precedence over the secret
+	if oidcSpec.IssuerEndpoint != "" || oidcSpec.IssuerEndpointRef == nil {
+		return oidcSpec.IssuerEndpoint, nil
+	}
+
+	secretSource := helper.NewSecretSource(r.Client(), product.Namespace)
+	return secretSource.RequiredFieldValueFromRequiredSecret(oidcSpec.IssuerEndpointRef.Name, "issuerEndpoint")
+}
+
+func (r *ProductReconciler) openAPIExportConfigMapMutator(product *capabilitiesv1beta1.Product) func(existingObj, desiredObj client.Object) (bool, error) {
+	return func(existingObj, desiredObj client.Object) (bool, error) {
+		existing, ok := existingObj.(*corev1.ConfigMap)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *v1.ConfigMap", existingObj)
+		}
+		desired, ok := desiredObj.(*corev1.ConfigMap)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *v1.ConfigMap", desiredObj)
+		}
+
+		// OwnerReference
+		updated, err := r.EnsureOwnerReference(product, existing)
+		if err != nil {
+			return false, err
+		}
+
+		helper.MergeMapStringString(&updated, &existing.ObjectMeta.Labels, desired.Labels)
+
+		if existing.Data == nil {
+			existing.Data = map[string]string{}
+		}
+		updated = reconcilers.ConfigMapReconcileField(desired, existing, productOpenAPIExportConfigMapKey) || updated
+
+		return updated, nil
+	}
+}
+
+func productOpenAPIExportConfigMapName(product *capabilitiesv1beta1.Product) string {
+	if product.Spec.OpenAPIExport != nil && product.Spec.OpenAPIExport.ConfigMapName != nil && *product.Spec.OpenAPIExport.ConfigMapName != "" {
+		return *product.Spec.OpenAPIExport.ConfigMapName
+	}
+
+	return fmt.Sprintf("%s-openapi", product.Name)
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestProductReconciler_reconcileOpenAPIExport(t *testing.T) {
+	product := &capabilitiesv1beta1.Product{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Product",
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore", Namespace: "test", UID: types.UID("4b7e3f0a-8c2d-4e51-9a6f-0d1c2b3a4e5f")},
+		Spec: capabilitiesv1beta1.ProductSpec{
+			Name: "Petstore",
+			MappingRules: []capabilitiesv1beta1.MappingRuleSpec{
+				{HTTPMethod: "GET", Pattern: "/pets$", MetricMethodRef: "hits", Increment: 1},
+			},
+			OpenAPIExport: &capabilitiesv1beta1.ProductOpenAPIExportSpec{},
+		},
+	}
+	r := &ProductReconciler{BaseReconciler: getOpenAPIBaseReconciler(product)}
+	providerAccount := &controllerhelper.ProviderAccount{AdminURLStr: "https://3scale-admin.example.com"}
+
+	err := r.reconcileOpenAPIExport(product, providerAccount)
+	if err != nil {
+		t.Fatalf("reconcileOpenAPIExport() error = %v", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: "petstore-openapi", Namespace: "test"}, configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metav1.IsControlledBy(configMap, product) {
+		t.Errorf("configmap is not owned by the product: %v", configMap.OwnerReferences)
+	}
+	document := map[string]interface{}{}
+	err = json.Unmarshal([]byte(configMap.Data[productOpenAPIExportConfigMapKey]), &document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := document["paths"].(map[string]interface{})["/pets"]; !ok {
+		t.Errorf("exported document paths = %v", document["paths"])
+	}
+
+	// renamed export
+	product.Spec.OpenAPIExport.ConfigMapName = ptr.To("petstore-contract")
+	err = r.reconcileOpenAPIExport(product, providerAccount)
+	if err != nil {
+		t.Fatalf("reconcileOpenAPIExport() error = %v", err)
+	}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: "petstore-contract", Namespace: "test"}, &corev1.ConfigMap{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: "petstore-openapi", Namespace: "test"}, &corev1.ConfigMap{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("previous export not deleted: %v", err)
+	}
+
+	// export disabled
+	product.Spec.OpenAPIExport = nil
+	err = r.reconcileOpenAPIExport(product, providerAccount)
+	if err != nil {
+		t.Fatalf("reconcileOpenAPIExport() error = %v", err)
+	}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: "petstore-contract", Namespace: "test"}, &corev1.ConfigMap{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("export not deleted: %v", err)
+	}
+}
+
+func TestProductReconciler_reconcileOpenAPIExportNotOwnedConfigMap(t *testing.T) {
+	product := &capabilitiesv1beta1.Product{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Product",
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore", Namespace: "test", UID: types.UID("4b7e3f0a-8c2d-4e51-9a6f-0d1c2b3a4e5f")},
+		Spec: capabilitiesv1beta1.ProductSpec{
+			Name: "Petstore",
+			OpenAPIExport: &capabilitiesv1beta1.ProductOpenAPIExportSpec{
+				ConfigMapName: ptr.To("petstore-contract"),
+			},
+		},
+	}
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore-contract", Namespace: "test"},
+		Data:       map[string]string{productOpenAPIExportConfigMapKey: "{}"},
+	}
+	r := &ProductReconciler{BaseReconciler: getOpenAPIBaseReconciler(product, existing)}
+	providerAccount := &controllerhelper.ProviderAccount{AdminURLStr: "https://3scale-admin.example.com"}
+
+	err := r.reconcileOpenAPIExport(product, providerAccount)
+	if !helper.IsInvalidSpecError(err) {
+		t.Fatalf("reconcileOpenAPIExport() error = %v, want invalid spec error", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: "petstore-contract", Namespace: "test"}, configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configMap.OwnerReferences) > 0 || configMap.Data[productOpenAPIExportConfigMapKey] != "{}" {
+		t.Errorf("configmap taken over: %v", configMap)
+	}
+}
@@ -21,6 +21,7 @@ type ProductStatusReconciler struct {
 	entity              *controllerhelper.ProductEntity
 	providerAccountHost string
 	syncError           error
+	openAPIExportError  error
 	logger              logr.Logger
 }
 
@@ -76,6 +77,7 @@ func (s *ProductStatusReconciler) calculateStatus() *capabilitiesv1beta1.Product
 		ID:                  s.resource.Status.ID,
 		ProviderAccountHost: s.resource.Status.ProviderAccountHost,
 		ObservedGeneration:  s.resource.Status.ObservedGeneration,
+		ManagedFeatures:     s.managedFeatures(),
 		Conditions:          s.resource.Status.Conditions.Copy(),
 	}
 	if s.entity != nil {
@@ -92,9 +94,24 @@ func (s *ProductStatusReconciler) calculateStatus() *capabilitiesv1beta1.Product
 	newStatus.Conditions.SetCondition(s.invalidCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
 
+	// The OpenAPI export only runs once the product is synchronized
+	if s.syncError == nil {
+		newStatus.Conditions.SetCondition(s.openAPIExportFailedCondition())
+	}
+
 	return newStatus
 }
 
+// managedFeatures returns the system names of the features managed by the product
+func (s *ProductStatusReconciler) managedFeatures() []string {
+	desired := make([]string, 0, len(s.resource.Spec.Features))
+	for systemName := range s.resource.Spec.Features {
+		desired = append(desired, systemName)
+	}
+
+	return helper.ArrayStringManaged(desired, s.resource.Status.ManagedFeatures, s.syncError == nil)
+}
+
 func (s *ProductStatusReconciler) syncCondition() common.Condition {
 	condition := common.Condition{
 		Type:   capabilitiesv1beta1.ProductSyncedConditionType,
@@ -150,3 +167,17 @@ func (s *ProductStatusReconciler) failedCondition() common.Condition {
 
 	return condition
 }
+
+func (s *ProductStatusReconciler) openAPIExportFailedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.ProductOpenAPIExportFailedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.openAPIExportError != nil {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.openAPIExportError.Error()
+	}
+
+	return condition
+}
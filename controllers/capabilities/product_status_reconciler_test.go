@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+func TestProductStatusReconciler_openAPIExportFailedCondition(t *testing.T) {
+	product := &capabilitiesv1beta1.Product{}
+
+	statusReconciler := NewProductStatusReconciler(getBaseReconciler(), product, nil, "", nil)
+	statusReconciler.openAPIExportError = errors.New("issuer endpoint secret not found")
+	newStatus := statusReconciler.calculateStatus()
+	if !newStatus.Conditions.IsTrueFor(capabilitiesv1beta1.ProductOpenAPIExportFailedConditionType) {
+		t.Errorf("OpenAPIExportFailed condition not true: %v", newStatus.Conditions)
+	}
+	// the synchronization is not affected
+	if !newStatus.Conditions.IsTrueFor(capabilitiesv1beta1.ProductSyncedConditionType) {
+		t.Errorf("Synced condition not true: %v", newStatus.Conditions)
+	}
+
+	// export not run on sync errors, condition is not set
+	newStatus = NewProductStatusReconciler(getBaseReconciler(), product, nil, "", errors.New("sync error")).calculateStatus()
+	if newStatus.Conditions.GetCondition(capabilitiesv1beta1.ProductOpenAPIExportFailedConditionType) != nil {
+		t.Errorf("OpenAPIExportFailed condition set on sync error: %v", newStatus.Conditions)
+	}
+}
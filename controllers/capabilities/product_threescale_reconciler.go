@@ -18,14 +18,17 @@ type ProductThreescaleReconciler struct {
 	productEntity       *controllerhelper.ProductEntity
 	backendRemoteIndex  *controllerhelper.BackendAPIRemoteIndex
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	adminAPIClient      *controllerhelper.AdminAPIClient
+	features            *controllerhelper.FeatureList
 	logger              logr.Logger
 }
 
-func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
+func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, adminAPIClient *controllerhelper.AdminAPIClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
 	return &ProductThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
+		adminAPIClient:      adminAPIClient,
 		backendRemoteIndex:  backendRemoteIndex,
 		logger:              b.Logger().WithValues("3scale Reconciler", resource.Name),
 	}
@@ -49,7 +52,11 @@ func (t *ProductThreescaleReconciler) Reconcile() (*controllerhelper.ProductEnti
 	taskRunner.AddTask("SyncMethods", t.syncMethods)
 	taskRunner.AddTask("SyncMetrics", t.syncMetrics)
 	taskRunner.AddTask("SyncMappingRules", t.syncMappingRules)
+	// Features are enabled in plans.
+	// Plans reference features.
+	taskRunner.AddTask("SyncFeatures", t.syncFeatures)
 	taskRunner.AddTask("SyncApplicationPlans", t.syncApplicationPlans)
+	taskRunner.AddTask("SyncServicePlans", t.syncServicePlans)
 	taskRunner.AddTask("SyncPolicies", t.syncPolicies)
 	taskRunner.AddTask("SyncOIDCConfiguration", t.syncOIDCConfiguration)
 
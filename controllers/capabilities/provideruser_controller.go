@@ -0,0 +1,250 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	providerUserFinalizer = "provideruser.capabilities.3scale.net/finalizer"
+)
+
+// ProviderUserReconciler reconciles a ProviderUser object
+type ProviderUserReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that ProviderUserReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ProviderUserReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=providerusers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=providerusers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=providerusers/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *ProviderUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Logger().WithValues("provideruser", req.NamespacedName)
+	reqLogger.Info("Reconcile ProviderUser", "Operator version", version.Version)
+
+	// Fetch the instance
+	providerUserCR := &capabilitiesv1beta1.ProviderUser{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, providerUserCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(providerUserCR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// ProviderUser has been marked for deletion
+	if providerUserCR.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(providerUserCR, providerUserFinalizer) {
+		err = r.suspendProviderUserIn3scale(providerUserCR)
+		if err != nil {
+			r.EventRecorder().Eventf(providerUserCR, corev1.EventTypeWarning, "Failed to suspend provider user", "%v", err)
+			return ctrl.Result{}, err
+		}
+
+		controllerutil.RemoveFinalizer(providerUserCR, providerUserFinalizer)
+		err = r.UpdateResource(providerUserCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Ignore deleted resource, this can happen when foregroundDeletion is enabled
+	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+	if providerUserCR.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(providerUserCR, providerUserFinalizer) {
+		controllerutil.AddFinalizer(providerUserCR, providerUserFinalizer)
+		err := r.UpdateResource(providerUserCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// No need requeue because the reconcile will trigger automatically since updating the ProviderUser CR
+		return ctrl.Result{}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcileSpec(providerUserCR, reqLogger)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile provider user: %v. Failed to update status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to update provider user status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			// On Validation error, no need to retry as spec is not valid and needs to be changed
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(providerUserCR, corev1.EventTypeWarning, "Invalid provider user spec", "%v", reconcileErr)
+			return ctrl.Result{}, nil
+		}
+
+		if helper.IsOrphanSpecError(reconcileErr) {
+			// On Orphan spec error, retry
+			reqLogger.Info("orphan", "message", reconcileErr)
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(providerUserCR, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ProviderUserReconciler) reconcileSpec(providerUserCR *capabilitiesv1beta1.ProviderUser, logger logr.Logger) (*ProviderUserStatusReconciler, error) {
+	err := r.validateSpec(providerUserCR)
+	if err != nil {
+		statusReconciler := NewProviderUserStatusReconciler(r.BaseReconciler, providerUserCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), providerUserCR.Namespace, providerUserCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		statusReconciler := NewProviderUserStatusReconciler(r.BaseReconciler, providerUserCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(providerUserCR.GetAnnotations())
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewProviderUserStatusReconciler(r.BaseReconciler, providerUserCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewProviderUserStatusReconciler(r.BaseReconciler, providerUserCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewProviderUserThreescaleReconciler(r.BaseReconciler, providerUserCR, adminAPIClient, threescaleAPIClient, providerAccount.AdminURLStr, logger)
+	userObj, err := reconciler.Reconcile()
+
+	statusReconciler := NewProviderUserStatusReconciler(r.BaseReconciler, providerUserCR, providerAccount.AdminURLStr, userObj, err)
+	return statusReconciler, err
+}
+
+func (r *ProviderUserReconciler) validateSpec(resource *capabilitiesv1beta1.ProviderUser) error {
+	errors := field.ErrorList{}
+	errors = append(errors, resource.Validate()...)
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: errors,
+	}
+}
+
+// suspendProviderUserIn3scale suspends the user instead of deleting it,
+// so the user can be reactivated with its permissions and access tokens
+func (r *ProviderUserReconciler) suspendProviderUserIn3scale(providerUserCR *capabilitiesv1beta1.ProviderUser) error {
+	logger := r.Logger().WithValues("provideruser", client.ObjectKey{Name: providerUserCR.Name, Namespace: providerUserCR.Namespace})
+
+	// Attempt to suspend provider user only if providerUserCR.Status.ID is present
+	if providerUserCR.Status.ID == nil {
+		logger.Info("could not suspend provider user because ID is missing in status")
+		return nil
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), providerUserCR.Namespace, providerUserCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("provider user not suspended in 3scale, provider account not found")
+			return nil
+		}
+		return err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(providerUserCR.GetAnnotations())
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return err
+	}
+
+	user, err := adminAPIClient.ReadProviderUser(*providerUserCR.Status.ID)
+	if err != nil {
+		if controllerhelper.IsAdminAPINotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// pending users cannot log in either
+	if user.Element.State != providerUserStateActive {
+		return nil
+	}
+
+	_, err = adminAPIClient.SuspendProviderUser(user.Element.ID)
+	if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ProviderUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.ProviderUser{}).
+		Complete(r)
+}
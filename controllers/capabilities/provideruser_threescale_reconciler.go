@@ -0,0 +1,334 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	providerUserStatePending   = "pending"
+	providerUserStateActive    = "active"
+	providerUserStateSuspended = "suspended"
+)
+
+type ProviderUserThreescaleReconciler struct {
+	*reconcilers.BaseReconciler
+	userCR              *capabilitiesv1beta1.ProviderUser
+	adminAPIClient      *controllerhelper.AdminAPIClient
+	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	providerAccountHost string
+	logger              logr.Logger
+}
+
+func NewProviderUserThreescaleReconciler(b *reconcilers.BaseReconciler,
+	userCR *capabilitiesv1beta1.ProviderUser,
+	adminAPIClient *controllerhelper.AdminAPIClient,
+	threescaleAPIClient *threescaleapi.ThreeScaleClient,
+	providerAccountHost string,
+	logger logr.Logger,
+) *ProviderUserThreescaleReconciler {
+	return &ProviderUserThreescaleReconciler{
+		BaseReconciler:      b,
+		userCR:              userCR,
+		adminAPIClient:      adminAPIClient,
+		threescaleAPIClient: threescaleAPIClient,
+		providerAccountHost: providerAccountHost,
+		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
+	}
+}
+
+func (s *ProviderUserThreescaleReconciler) Reconcile() (*controllerhelper.ProviderUser, error) {
+	s.logger.V(1).Info("START")
+
+	user, err := s.findUser()
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		s.logger.V(1).Info("ProviderUser does not exist", "username", s.userCR.Spec.Username)
+
+		// Record the creation before creating the user,
+		// so the user is adopted when the creation succeeds but the user ID is not recorded
+		if !s.userCR.Status.PendingCreation {
+			s.userCR.Status.PendingCreation = true
+			err = s.Client().Status().Update(s.Context(), s.userCR)
+			if err != nil {
+				return nil, fmt.Errorf("error recording pending provider user creation: %w", err)
+			}
+		}
+
+		user, err = s.createUser()
+		if err != nil {
+			return nil, err
+		}
+
+		// Keep the user's ID, in case the remaining steps fail, to avoid creating it again
+		s.userCR.Status.ID = &user.Element.ID
+	} else {
+		s.logger.V(1).Info("ProviderUser already exists", "ID", user.Element.ID)
+	}
+
+	user, err = s.syncUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.syncMemberPermissions(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *ProviderUserThreescaleReconciler) findUser() (*controllerhelper.ProviderUser, error) {
+	if s.userCR.Status.ID != nil {
+		user, err := s.adminAPIClient.ReadProviderUser(*s.userCR.Status.ID)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			return user, nil
+		}
+	}
+
+	// If not found by ID, try {username, email} set.
+	// Both fields are unique in the provider account scope.
+	userList, err := s.adminAPIClient.ListProviderUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range userList.Users {
+		if userList.Users[idx].Element.Username == s.userCR.Spec.Username &&
+			userList.Users[idx].Element.Email == s.userCR.Spec.Email {
+			// Users not created by the operator are not taken over:
+			// the operator would change their role and permissions and suspend them on deletion
+			if !s.userCR.Status.PendingCreation {
+				return nil, &helper.SpecFieldError{
+					ErrorType: helper.InvalidError,
+					FieldErrorList: field.ErrorList{
+						field.Invalid(field.NewPath("spec").Child("username"), s.userCR.Spec.Username, "provider user already exists in 3scale and was not created by the operator"),
+					},
+				}
+			}
+			return &userList.Users[idx], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *ProviderUserThreescaleReconciler) createUser() (*controllerhelper.ProviderUser, error) {
+	password, err := s.getPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	params := threescaleapi.Params{
+		"username": s.userCR.Spec.Username,
+		"email":    s.userCR.Spec.Email,
+		"password": password,
+	}
+
+	return s.adminAPIClient.CreateProviderUser(params)
+}
+
+func (s *ProviderUserThreescaleReconciler) syncUser(user *controllerhelper.ProviderUser) (*controllerhelper.ProviderUser, error) {
+	params := threescaleapi.Params{}
+	if user.Element.Email != s.userCR.Spec.Email {
+		params["email"] = s.userCR.Spec.Email
+	}
+
+	if user.Element.Username != s.userCR.Spec.Username {
+		params["username"] = s.userCR.Spec.Username
+	}
+
+	updatedUser := user
+	var err error
+
+	if len(params) > 0 {
+		updatedUser, err = s.adminAPIClient.UpdateProviderUser(user.Element.ID, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if updatedUser.Element.State == providerUserStatePending {
+		updatedUser, err = s.adminAPIClient.ActivateProviderUser(user.Element.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if updatedUser.Element.State == providerUserStateSuspended && !s.userCR.Spec.Suspended {
+		updatedUser, err = s.adminAPIClient.UnsuspendProviderUser(user.Element.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if updatedUser.Element.State == providerUserStateActive && s.userCR.Spec.Suspended {
+		updatedUser, err = s.adminAPIClient.SuspendProviderUser(user.Element.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	desiredRole := controllerhelper.ProviderUserRoleMember
+	if s.userCR.IsAdmin() {
+		desiredRole = controllerhelper.ProviderUserRoleAdmin
+	}
+
+	if updatedUser.Element.Role != desiredRole {
+		updatedUser, err = s.adminAPIClient.ChangeProviderUserRole(user.Element.ID, desiredRole)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updatedUser, nil
+}
+
+// syncMemberPermissions reconciles the permissions of member users.
+// Unset member permissions reset the user to the permissions of new member users: no sections and all products
+func (s *ProviderUserThreescaleReconciler) syncMemberPermissions(user *controllerhelper.ProviderUser) error {
+	if s.userCR.IsAdmin() {
+		return nil
+	}
+
+	desiredSections := []string{}
+	if s.userCR.Spec.MemberPermissions != nil {
+		for _, section := range s.userCR.Spec.MemberPermissions.AllowedSections {
+			desiredSections = append(desiredSections, string(section))
+		}
+	}
+	sort.Strings(desiredSections)
+
+	desiredServiceIDs, err := s.desiredServiceIDs()
+	if err != nil {
+		return err
+	}
+
+	current, err := s.adminAPIClient.ReadMemberPermissions(user.Element.ID)
+	if err != nil {
+		return err
+	}
+
+	currentSections := append([]string{}, current.Element.AllowedSections...)
+	sort.Strings(currentSections)
+
+	var currentServiceIDs []int64
+	if current.Element.AllowedServiceIDs != nil {
+		currentServiceIDs = append([]int64{}, current.Element.AllowedServiceIDs...)
+		sort.Slice(currentServiceIDs, func(i, j int) bool { return currentServiceIDs[i] < currentServiceIDs[j] })
+	}
+
+	if reflect.DeepEqual(currentSections, desiredSections) && reflect.DeepEqual(currentServiceIDs, desiredServiceIDs) {
+		return nil
+	}
+
+	s.logger.V(1).Info("updating member permissions", "sections", desiredSections, "services", desiredServiceIDs)
+	_, err = s.adminAPIClient.UpdateMemberPermissions(user.Element.ID, desiredSections, desiredServiceIDs)
+	return err
+}
+
+// desiredServiceIDs returns the sorted IDs of the allowed products, nil when all products are allowed
+func (s *ProviderUserThreescaleReconciler) desiredServiceIDs() ([]int64, error) {
+	if s.userCR.Spec.MemberPermissions == nil || s.userCR.Spec.MemberPermissions.AllowedProducts == nil {
+		return nil, nil
+	}
+
+	allowedProducts := s.userCR.Spec.MemberPermissions.AllowedProducts
+
+	productList, err := s.threescaleAPIClient.ListProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := map[string]int64{}
+	for _, product := range productList.Products {
+		productIDs[product.Element.SystemName] = product.Element.ID
+	}
+
+	fieldErrors := field.ErrorList{}
+	allowedProductsFldPath := field.NewPath("spec").Child("memberPermissions").Child("allowedProducts")
+	serviceIDs := make([]int64, 0, len(allowedProducts))
+	for idx, systemName := range allowedProducts {
+		productID, ok := productIDs[systemName]
+		if !ok {
+			fieldErrors = append(fieldErrors, field.Invalid(allowedProductsFldPath.Index(idx), systemName, "product not found"))
+			continue
+		}
+		serviceIDs = append(serviceIDs, productID)
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.OrphanError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	sort.Slice(serviceIDs, func(i, j int) bool { return serviceIDs[i] < serviceIDs[j] })
+	return serviceIDs, nil
+}
+
+func (s *ProviderUserThreescaleReconciler) getPassword() (string, error) {
+	passwdFieldPath := field.NewPath("spec").Child("passwordCredentialsRef")
+
+	// Get password from secret reference
+	secret := &corev1.Secret{}
+	namespace := s.userCR.Namespace
+	if s.userCR.Spec.PasswordCredentialsRef.Namespace != "" {
+		namespace = s.userCR.Spec.PasswordCredentialsRef.Namespace
+	}
+
+	err := s.Client().Get(s.Context(),
+		types.NamespacedName{
+			Name:      s.userCR.Spec.PasswordCredentialsRef.Name,
+			Namespace: namespace,
+		},
+		secret)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			// Return spec field error if secret was not found
+			return "", &helper.SpecFieldError{
+				ErrorType: helper.InvalidError,
+				FieldErrorList: field.ErrorList{
+					field.Invalid(passwdFieldPath, s.userCR.Spec.PasswordCredentialsRef, "provideruser password reference not found"),
+				},
+			}
+		}
+
+		return "", err
+	}
+
+	passwordByteArray, ok := secret.Data[capabilitiesv1beta1.ProviderUserPasswordSecretField]
+	if !ok {
+		// Return spec field error if secret field was not found
+		return "", &helper.SpecFieldError{
+			ErrorType: helper.InvalidError,
+			FieldErrorList: field.ErrorList{
+				field.Invalid(passwdFieldPath, s.userCR.Spec.PasswordCredentialsRef, "provideruser password secret missing expected field"),
+			},
+		}
+	}
+
+	return bytes.NewBuffer(passwordByteArray).String(), nil
+}
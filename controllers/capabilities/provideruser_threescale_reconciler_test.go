@@ -0,0 +1,299 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// providerUsersAPIHandler fakes the provider users endpoints, recording the requests changing users
+type providerUsersAPIHandler struct {
+	users       map[string]*controllerhelper.ProviderUserItem
+	permissions controllerhelper.MemberPermissionsItem
+	requests    []string
+	permUpdate  url.Values
+}
+
+func (h *providerUsersAPIHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	writeJSON := func(code int, obj interface{}) {
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(obj)
+	}
+
+	if req.Method != http.MethodGet {
+		h.requests = append(h.requests, req.Method+" "+req.URL.Path)
+	}
+
+	switch {
+	case req.URL.Path == "/admin/api/users.json" && req.Method == http.MethodGet:
+		list := controllerhelper.ProviderUserList{}
+		for _, user := range h.users {
+			list.Users = append(list.Users, controllerhelper.ProviderUser{Element: *user})
+		}
+		writeJSON(http.StatusOK, list)
+	case req.URL.Path == "/admin/api/users.json" && req.Method == http.MethodPost:
+		_ = req.ParseForm()
+		user := &controllerhelper.ProviderUserItem{ID: 7, State: "pending", Role: "member",
+			Username: req.PostForm.Get("username"), Email: req.PostForm.Get("email")}
+		h.users["/admin/api/users/7"] = user
+		writeJSON(http.StatusCreated, controllerhelper.ProviderUser{Element: *user})
+	case req.URL.Path == "/admin/api/users/7/permissions.json" && req.Method == http.MethodGet:
+		writeJSON(http.StatusOK, controllerhelper.MemberPermissions{Element: h.permissions})
+	case req.URL.Path == "/admin/api/users/7/permissions.json" && req.Method == http.MethodPut:
+		_ = req.ParseForm()
+		h.permUpdate = req.PostForm
+		writeJSON(http.StatusOK, controllerhelper.MemberPermissions{Element: h.permissions})
+	case req.URL.Path == "/admin/api/services.json":
+		writeJSON(http.StatusOK, threescaleapi.ProductList{Products: []threescaleapi.Product{
+			{Element: threescaleapi.ProductItem{ID: 3, SystemName: "api"}},
+			{Element: threescaleapi.ProductItem{ID: 5, SystemName: "other"}},
+		}})
+	default:
+		user, ok := h.users["/admin/api/users/7"]
+		if !ok || !strings.HasPrefix(req.URL.Path, "/admin/api/users/7") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch strings.TrimPrefix(req.URL.Path, "/admin/api/users/7") {
+		case "/activate.json", "/unsuspend.json":
+			user.State = "active"
+		case "/suspend.json":
+			user.State = "suspended"
+		case "/admin.json":
+			user.Role = "admin"
+		case "/member.json":
+			user.Role = "member"
+		}
+		writeJSON(http.StatusOK, controllerhelper.ProviderUser{Element: *user})
+	}
+}
+
+func getProviderUserCR() *capabilitiesv1beta1.ProviderUser {
+	return &capabilitiesv1beta1.ProviderUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "analyst", Namespace: "test"},
+		Spec: capabilitiesv1beta1.ProviderUserSpec{
+			Username:               "analyst",
+			Email:                  "analyst@example.com",
+			PasswordCredentialsRef: corev1.SecretReference{Name: "analyst-password"},
+		},
+	}
+}
+
+func newTestProviderUserThreescaleReconciler(t *testing.T, userCR *capabilitiesv1beta1.ProviderUser, handler http.Handler) *ProviderUserThreescaleReconciler {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ap, err := threescaleapi.NewAdminPortalFromStr(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "analyst-password", Namespace: "test"},
+		Data:       map[string][]byte{capabilitiesv1beta1.ProviderUserPasswordSecretField: []byte("secret")},
+	}
+
+	baseReconciler := getOpenAPIBaseReconciler(userCR, passwordSecret)
+	return NewProviderUserThreescaleReconciler(baseReconciler, userCR,
+		controllerhelper.NewAdminAPIClient(adminURL, "test", srv.Client()),
+		threescaleapi.NewThreeScale(ap, "test", srv.Client()),
+		srv.URL, baseReconciler.Logger())
+}
+
+func TestProviderUserThreescaleReconciler_Create(t *testing.T) {
+	userCR := getProviderUserCR()
+	userCR.Spec.MemberPermissions = &capabilitiesv1beta1.ProviderUserMemberPermissionsSpec{
+		AllowedSections: []capabilitiesv1beta1.ProviderUserSection{"monitoring"},
+		AllowedProducts: []string{"api"},
+	}
+	handler := &providerUsersAPIHandler{
+		users:       map[string]*controllerhelper.ProviderUserItem{},
+		permissions: controllerhelper.MemberPermissionsItem{UserID: 7, Role: "member", AllowedSections: []string{}},
+	}
+	r := newTestProviderUserThreescaleReconciler(t, userCR, handler)
+
+	user, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if user.Element.ID != 7 || user.Element.State != "active" {
+		t.Errorf("unexpected user: %+v", user.Element)
+	}
+	if userCR.Status.ID == nil || *userCR.Status.ID != 7 {
+		t.Errorf("status ID not set: %v", userCR.Status.ID)
+	}
+	if got := handler.permUpdate["allowed_sections[]"]; len(got) != 1 || got[0] != "monitoring" {
+		t.Errorf("allowed sections = %v, want [monitoring]", got)
+	}
+	if got := handler.permUpdate["allowed_service_ids[]"]; len(got) != 1 || got[0] != "3" {
+		t.Errorf("allowed services = %v, want [3]", got)
+	}
+}
+
+func TestProviderUserThreescaleReconciler_Sync(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         controllerhelper.ProviderUserItem
+		spec         func(*capabilitiesv1beta1.ProviderUserSpec)
+		wantRequests []string
+		wantState    string
+		wantRole     string
+	}{
+		{
+			name:      "in sync",
+			user:      controllerhelper.ProviderUserItem{ID: 7, State: "active", Role: "member", Username: "analyst", Email: "analyst@example.com"},
+			spec:      func(spec *capabilitiesv1beta1.ProviderUserSpec) {},
+			wantState: "active",
+			wantRole:  "member",
+		},
+		{
+			name:         "suspend",
+			user:         controllerhelper.ProviderUserItem{ID: 7, State: "active", Role: "member", Username: "analyst", Email: "analyst@example.com"},
+			spec:         func(spec *capabilitiesv1beta1.ProviderUserSpec) { spec.Suspended = true },
+			wantRequests: []string{"PUT /admin/api/users/7/suspend.json"},
+			wantState:    "suspended",
+			wantRole:     "member",
+		},
+		{
+			name:         "unsuspend and promote to admin",
+			user:         controllerhelper.ProviderUserItem{ID: 7, State: "suspended", Role: "member", Username: "analyst", Email: "analyst@example.com"},
+			spec:         func(spec *capabilitiesv1beta1.ProviderUserSpec) { spec.Role = ptr.To("admin") },
+			wantRequests: []string{"PUT /admin/api/users/7/unsuspend.json", "PUT /admin/api/users/7/admin.json"},
+			wantState:    "active",
+			wantRole:     "admin",
+		},
+		{
+			name:         "update email",
+			user:         controllerhelper.ProviderUserItem{ID: 7, State: "active", Role: "member", Username: "analyst", Email: "old@example.com"},
+			spec:         func(spec *capabilitiesv1beta1.ProviderUserSpec) {},
+			wantRequests: []string{"PUT /admin/api/users/7.json"},
+			wantState:    "active",
+			wantRole:     "member",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			userCR := getProviderUserCR()
+			userCR.Status.ID = ptr.To(int64(7))
+			tt.spec(&userCR.Spec)
+			user := tt.user
+			handler := &providerUsersAPIHandler{users: map[string]*controllerhelper.ProviderUserItem{"/admin/api/users/7": &user}}
+			r := newTestProviderUserThreescaleReconciler(subT, userCR, handler)
+
+			remoteUser, err := r.Reconcile()
+			if err != nil {
+				subT.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if len(handler.requests) != len(tt.wantRequests) {
+				subT.Fatalf("requests = %v, want %v", handler.requests, tt.wantRequests)
+			}
+			for idx := range tt.wantRequests {
+				if handler.requests[idx] != tt.wantRequests[idx] {
+					subT.Errorf("request %d = %s, want %s", idx, handler.requests[idx], tt.wantRequests[idx])
+				}
+			}
+			if remoteUser.Element.State != tt.wantState || remoteUser.Element.Role != tt.wantRole {
+				subT.Errorf("user state = %s, role = %s, want %s, %s", remoteUser.Element.State, remoteUser.Element.Role, tt.wantState, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestProviderUserThreescaleReconciler_UnknownProduct(t *testing.T) {
+	userCR := getProviderUserCR()
+	userCR.Status.ID = ptr.To(int64(7))
+	userCR.Spec.MemberPermissions = &capabilitiesv1beta1.ProviderUserMemberPermissionsSpec{AllowedProducts: []string{"unknown"}}
+	user := controllerhelper.ProviderUserItem{ID: 7, State: "active", Role: "member", Username: "analyst", Email: "analyst@example.com"}
+	handler := &providerUsersAPIHandler{users: map[string]*controllerhelper.ProviderUserItem{"/admin/api/users/7": &user}}
+	r := newTestProviderUserThreescaleReconciler(t, userCR, handler)
+
+	_, err := r.Reconcile()
+	if !helper.IsOrphanSpecError(err) {
+		t.Errorf("Reconcile() error = %v, want orphan spec error", err)
+	}
+}
+
+func TestProviderUserThreescaleReconciler_ExistingUser(t *testing.T) {
+	tests := []struct {
+		name            string
+		pendingCreation bool
+		wantErr         bool
+	}{
+		{name: "not created by the operator", pendingCreation: false, wantErr: true},
+		{name: "creation pending", pendingCreation: true, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			userCR := getProviderUserCR()
+			userCR.Status.PendingCreation = tt.pendingCreation
+			user := controllerhelper.ProviderUserItem{ID: 7, State: "active", Role: "admin", Username: "analyst", Email: "analyst@example.com"}
+			handler := &providerUsersAPIHandler{users: map[string]*controllerhelper.ProviderUserItem{"/admin/api/users/7": &user}}
+			r := newTestProviderUserThreescaleReconciler(subT, userCR, handler)
+
+			remoteUser, err := r.Reconcile()
+			if tt.wantErr {
+				if !helper.IsInvalidSpecError(err) {
+					subT.Errorf("Reconcile() error = %v, want invalid spec error", err)
+				}
+				if len(handler.requests) != 0 {
+					subT.Errorf("requests = %v, want none", handler.requests)
+				}
+				return
+			}
+
+			if err != nil {
+				subT.Fatalf("Reconcile() error = %v", err)
+			}
+			if remoteUser.Element.ID != 7 {
+				subT.Errorf("unexpected user: %+v", remoteUser.Element)
+			}
+		})
+	}
+}
+
+func TestProviderUserThreescaleReconciler_ResetMemberPermissions(t *testing.T) {
+	userCR := getProviderUserCR()
+	userCR.Status.ID = ptr.To(int64(7))
+	user := controllerhelper.ProviderUserItem{ID: 7, State: "active", Role: "member", Username: "analyst", Email: "analyst@example.com"}
+	handler := &providerUsersAPIHandler{
+		users: map[string]*controllerhelper.ProviderUserItem{"/admin/api/users/7": &user},
+		permissions: controllerhelper.MemberPermissionsItem{UserID: 7, Role: "member",
+			AllowedSections: []string{"monitoring"}, AllowedServiceIDs: []int64{3}},
+	}
+	r := newTestProviderUserThreescaleReconciler(t, userCR, handler)
+
+	_, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if handler.permUpdate == nil {
+		t.Fatal("member permissions not updated")
+	}
+	if got := handler.permUpdate.Get("allowed_sections"); got != "[]" {
+		t.Errorf("allowed sections = %v, want none", got)
+	}
+	if got := handler.permUpdate["allowed_service_ids[]"]; len(got) != 1 || got[0] != "" {
+		t.Errorf("allowed services = %v, want all", got)
+	}
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+)
+
+type servicePlanReconciler struct {
+	systemName      string
+	resource        capabilitiesv1beta1.ServicePlanSpec
+	planEntity      *controllerhelper.ServicePlanEntity
+	productFeatures *controllerhelper.FeatureList
+	logger          logr.Logger
+}
+
+func newServicePlanReconciler(systemName string,
+	resource capabilitiesv1beta1.ServicePlanSpec,
+	planEntity *controllerhelper.ServicePlanEntity,
+	productFeatures *controllerhelper.FeatureList,
+	logger logr.Logger,
+) *servicePlanReconciler {
+	return &servicePlanReconciler{
+		systemName:      systemName,
+		resource:        resource,
+		planEntity:      planEntity,
+		productFeatures: productFeatures,
+		logger:          logger.WithValues("ServicePlan", systemName),
+	}
+}
+
+// Reconcile ensures service plan attrs, features and default flag are reconciled
+func (s *servicePlanReconciler) Reconcile() error {
+	taskRunner := helper.NewTaskRunner(nil, s.logger)
+	taskRunner.AddTask("SyncPlan", s.syncPlan)
+	taskRunner.AddTask("SyncFeatures", s.syncFeatures)
+	taskRunner.AddTask("SyncDefault", s.syncDefault)
+
+	err := taskRunner.Run()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *servicePlanReconciler) syncPlan(_ interface{}) error {
+	params := threescaleapi.Params{}
+
+	if s.resource.Name != nil {
+		if s.planEntity.Name() != *s.resource.Name {
+			params["name"] = *s.resource.Name
+		}
+	}
+
+	if s.resource.ApprovalRequired != nil {
+		if s.planEntity.ApprovalRequired() != *s.resource.ApprovalRequired {
+			params["approval_required"] = strconv.FormatBool(*s.resource.ApprovalRequired)
+		}
+	}
+
+	if s.resource.TrialPeriod != nil {
+		if s.planEntity.TrialPeriodDays() != *s.resource.TrialPeriod {
+			params["trial_period_days"] = strconv.Itoa(*s.resource.TrialPeriod)
+		}
+	}
+
+	if s.resource.SetupFee != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*s.resource.SetupFee, 64)
+		if s.planEntity.SetupFee() != desiredValue {
+			params["setup_fee"] = *s.resource.SetupFee
+		}
+	}
+
+	if s.resource.CostMonth != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*s.resource.CostMonth, 64)
+		if s.planEntity.CostPerMonth() != desiredValue {
+			params["cost_per_month"] = *s.resource.CostMonth
+		}
+	}
+
+	planEntityStateIsPublished := s.planEntity.State() == "published" // If the state is not published then we assume it is "hidden"
+	desiredServicePlanIsPublished := s.resource.IsPublished()
+	if planEntityStateIsPublished != desiredServicePlanIsPublished {
+		var stateEventValue string
+		if desiredServicePlanIsPublished {
+			stateEventValue = "publish"
+		} else {
+			stateEventValue = "hide"
+		}
+		params["state_event"] = stateEventValue
+	}
+
+	if len(params) > 0 {
+		err := s.planEntity.Update(params)
+		if err != nil {
+			return fmt.Errorf("error sync service plan [%s;%d]: %w", s.systemName, s.planEntity.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *servicePlanReconciler) syncFeatures(_ interface{}) error {
+	// Plan features are only managed when product features are managed
+	if s.productFeatures == nil {
+		return nil
+	}
+
+	desiredIDs := map[int64]string{}
+	for _, featureSystemName := range s.resource.Features {
+		featureID, ok := findFeatureID(s.productFeatures, featureSystemName, controllerhelper.FeatureScopeServicePlan)
+		if !ok {
+			return fmt.Errorf("error sync service plan [%s;%d] features: feature [%s] not found", s.systemName, s.planEntity.ID(), featureSystemName)
+		}
+		desiredIDs[featureID] = featureSystemName
+	}
+
+	existingList, err := s.planEntity.Features()
+	if err != nil {
+		return fmt.Errorf("error sync service plan [%s;%d] features: %w", s.systemName, s.planEntity.ID(), err)
+	}
+
+	existingIDs := map[int64]string{}
+	for _, existing := range existingList.Features {
+		existingIDs[existing.Element.ID] = existing.Element.SystemName
+	}
+
+	for featureID := range existingIDs {
+		if _, ok := desiredIDs[featureID]; !ok {
+			err := s.planEntity.DeleteFeature(featureID)
+			if err != nil {
+				return fmt.Errorf("error sync service plan [%s;%d] features: %w", s.systemName, s.planEntity.ID(), err)
+			}
+		}
+	}
+
+	for featureID := range desiredIDs {
+		if _, ok := existingIDs[featureID]; !ok {
+			err := s.planEntity.CreateFeature(featureID)
+			if err != nil {
+				return fmt.Errorf("error sync service plan [%s;%d] features: %w", s.systemName, s.planEntity.ID(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *servicePlanReconciler) syncDefault(_ interface{}) error {
+	// The default service plan can only be changed, not unset
+	if !s.resource.IsDefault() || s.planEntity.IsDefault() {
+		return nil
+	}
+
+	err := s.planEntity.SetDefault()
+	if err != nil {
+		return fmt.Errorf("error sync service plan [%s;%d] default: %w", s.systemName, s.planEntity.ID(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"fmt"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func (t *ProductThreescaleReconciler) syncServicePlans(_ interface{}) error {
+	// Service plans are only managed when declared in the spec.
+	// Every product has at least one service plan created by 3scale,
+	// which must not be removed when the spec does not declare any.
+	if len(t.resource.Spec.ServicePlans) == 0 {
+		return nil
+	}
+
+	desiredKeys := make([]string, 0, len(t.resource.Spec.ServicePlans))
+	for systemName := range t.resource.Spec.ServicePlans {
+		desiredKeys = append(desiredKeys, systemName)
+	}
+
+	existingList, err := t.adminAPIClient.ListServicePlans(t.productEntity.ID())
+	if err != nil {
+		return fmt.Errorf("error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+	}
+
+	// Plan features are only managed when product features are managed
+	var productFeatures *controllerhelper.FeatureList
+	if len(t.resource.Spec.Features) > 0 {
+		productFeatures, err = t.productFeatures()
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+		}
+	}
+
+	existingKeys := make([]string, 0, len(existingList.Plans))
+	existingMap := map[string]controllerhelper.PlanItem{}
+	for _, existing := range existingList.Plans {
+		systemName := existing.Element.SystemName
+		existingKeys = append(existingKeys, systemName)
+		existingMap[systemName] = existing.Element
+	}
+
+	//
+	// Deleted existing and not desired
+	//
+
+	notDesiredExistingKeys := helper.ArrayStringDifference(existingKeys, desiredKeys)
+	t.logger.V(1).Info("syncServicePlans", "notDesiredExistingKeys", notDesiredExistingKeys)
+	for _, systemName := range notDesiredExistingKeys {
+		// key is expected to exist
+		// notDesiredExistingKeys is a subset of the existingMap key set
+		err := t.adminAPIClient.DeleteServicePlan(t.productEntity.ID(), existingMap[systemName].ID)
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+		}
+	}
+
+	//
+	// Reconcile existing
+	//
+	matchedKeys := helper.ArrayStringIntersection(existingKeys, desiredKeys)
+	t.logger.V(1).Info("syncServicePlans", "matchedKeys", matchedKeys)
+	for _, systemName := range matchedKeys {
+		// interface to remote entity
+		planEntity := controllerhelper.NewServicePlanEntity(t.productEntity.ID(), existingMap[systemName], t.adminAPIClient, t.logger)
+		// desired spec
+		planSpec := t.resource.Spec.ServicePlans[systemName]
+		reconciler := newServicePlanReconciler(systemName, planSpec, planEntity, productFeatures, t.logger)
+		err := reconciler.Reconcile()
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+	}
+
+	//
+	// Create not existing and desired
+	//
+
+	desiredNewKeys := helper.ArrayStringDifference(desiredKeys, existingKeys)
+	t.logger.V(1).Info("syncServicePlans", "desiredNewKeys", desiredNewKeys)
+	for _, systemName := range desiredNewKeys {
+		// key is expected to exist
+		// desiredNewKeys is a subset of the Spec.ServicePlans map key set
+		planSpec := t.resource.Spec.ServicePlans[systemName]
+
+		// Create Service Plan using system_name.
+		// it cannot be modified later
+		params := threescaleapi.Params{"system_name": systemName, "name": systemName}
+		obj, err := t.adminAPIClient.CreateServicePlan(t.productEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+		// interface to remote entity
+		planEntity := controllerhelper.NewServicePlanEntity(t.productEntity.ID(), obj.Element, t.adminAPIClient, t.logger)
+
+		reconciler := newServicePlanReconciler(systemName, planSpec, planEntity, productFeatures, t.logger)
+		err = reconciler.Reconcile()
+		if err != nil {
+			return fmt.Errorf("error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,367 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tenantAdminAccessTokenScopes are the scopes of the full permission access token of the tenant secret
+var tenantAdminAccessTokenScopes = []capabilitiesv1beta1.TenantAccessTokenScope{"account_management", "stats", "policy_registry"}
+
+type TenantAccessTokensReconciler struct {
+	*reconcilers.BaseReconciler
+	tenantR *capabilitiesv1beta1.Tenant
+	now     func() time.Time
+	logger  logr.Logger
+}
+
+func NewTenantAccessTokensReconciler(b *reconcilers.BaseReconciler, tenantR *capabilitiesv1beta1.Tenant, logger logr.Logger) *TenantAccessTokensReconciler {
+	return &TenantAccessTokensReconciler{
+		BaseReconciler: b,
+		tenantR:        tenantR,
+		now:            time.Now,
+		logger:         logger.WithValues("AccessTokens Reconciler", tenantR.Name),
+	}
+}
+
+// Reconcile generates the scoped access tokens of the tenant secret and rotates the tenant access tokens when due.
+// New tokens are recorded as pending in the status before they are written to the tenant secret,
+// and written to the tenant secret before the replaced ones are revoked.
+// Returns the new access tokens status and the time to wait until the next scheduled rotation, zero when nothing is scheduled
+func (r *TenantAccessTokensReconciler) Reconcile() (*capabilitiesv1beta1.TenantAccessTokensStatus, time.Duration, error) {
+	now := r.now()
+	spec := r.tenantR.Spec.AccessTokens
+	rotationTrigger := r.tenantR.GetAnnotations()[capabilitiesv1beta1.TenantRotateAccessTokensAnnotation]
+
+	status := &capabilitiesv1beta1.TenantAccessTokensStatus{}
+	if r.tenantR.Status.AccessTokens != nil {
+		status = r.tenantR.Status.AccessTokens.DeepCopy()
+	}
+
+	if spec == nil {
+		// scoped tokens generated before are still revoked
+		if len(status.Tokens) <= 1 && status.Pending == nil {
+			return r.tenantR.Status.AccessTokens, 0, nil
+		}
+		spec = &capabilitiesv1beta1.TenantAccessTokensSpec{}
+	}
+
+	tenantSecret := &corev1.Secret{}
+	err := r.Client().Get(r.Context(), r.tenantR.TenantSecretKey(), tenantSecret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return status, 0, &helper.WaitError{Err: fmt.Errorf("tenant secret %s not found", r.tenantR.TenantSecretKey())}
+		}
+		return status, 0, err
+	}
+
+	currentToken := string(tenantSecret.Data[TenantAccessTokenSecretField])
+	adminURL := string(tenantSecret.Data[TenantAdminDomainKeySecretField])
+	if currentToken == "" || adminURL == "" {
+		return status, 0, &helper.WaitError{
+			Err: fmt.Errorf("tenant secret %s missing %s or %s fields", r.tenantR.TenantSecretKey(), TenantAccessTokenSecretField, TenantAdminDomainKeySecretField),
+		}
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(r.tenantR.GetAnnotations())
+	adminClient, err := controllerhelper.AdminClientFromURLString(adminURL, currentToken, insecureSkipVerify)
+	if err != nil {
+		return status, 0, err
+	}
+
+	if status.Pending != nil {
+		err = r.resolvePendingTokens(adminClient, status, spec, tenantSecret, now)
+		if err != nil {
+			return status, 0, err
+		}
+	}
+
+	if spec.RotationInterval == nil {
+		status.NextRotationTime = nil
+	} else if status.NextRotationTime == nil {
+		status.NextRotationTime = &metav1.Time{Time: now.Add(spec.RotationInterval.Duration)}
+	}
+
+	adminTokenStatus := capabilitiesv1beta1.TenantAccessTokenStatus{
+		Name:       TenantAccessTokenSecretField,
+		Permission: controllerhelper.AccessTokenPermissionReadWrite,
+		Scopes:     tenantAdminAccessTokenScopes,
+	}
+	if current := status.Token(TenantAccessTokenSecretField); current != nil {
+		adminTokenStatus = *current
+	}
+
+	rotate := r.rotationDue(status, rotationTrigger, now)
+
+	// secret field -> new token value
+	newValues := map[string]string{}
+	// IDs of the new tokens, revoked when they cannot be written to the secret
+	newIDs := []string{}
+	pendingTokens := []capabilitiesv1beta1.TenantPendingAccessTokenStatus{}
+	// ID, or value when the ID is unknown, of the replaced tokens
+	revoke := []string{}
+	removedFields := []string{}
+	tokens := []capabilitiesv1beta1.TenantAccessTokenStatus{}
+
+	createToken := func(desired capabilitiesv1beta1.TenantAccessTokenStatus) (capabilitiesv1beta1.TenantAccessTokenStatus, error) {
+		scopes := make([]string, 0, len(desired.Scopes))
+		for _, scope := range desired.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+
+		r.logger.Info("creating access token", "field", desired.Name, "permission", desired.Permission, "scopes", scopes)
+		token, err := adminClient.CreateAccessToken(r.tenantR.Status.AdminId, r.accessTokenName(desired.Name), desired.Permission, scopes)
+		if err != nil {
+			return desired, fmt.Errorf("error creating access token %s: %w", desired.Name, err)
+		}
+
+		newValues[desired.Name] = token.Element.Value
+		newIDs = append(newIDs, strconv.FormatInt(token.Element.ID, 10))
+		desired.ID = token.Element.ID
+		pendingTokens = append(pendingTokens, capabilitiesv1beta1.TenantPendingAccessTokenStatus{
+			TenantAccessTokenStatus: desired,
+			ValueHash:               accessTokenValueHash(token.Element.Value),
+		})
+		return desired, nil
+	}
+
+	for _, tokenSpec := range spec.Scoped {
+		desired := capabilitiesv1beta1.TenantAccessTokenStatus{
+			Name:       tokenSpec.Name,
+			Permission: tokenSpec.Permission,
+			Scopes:     tokenSpec.Scopes,
+		}
+		current := status.Token(tokenSpec.Name)
+		currentValue := string(tenantSecret.Data[tokenSpec.Name])
+
+		if !rotate && current != nil && currentValue != "" &&
+			current.Permission == desired.Permission && reflect.DeepEqual(current.Scopes, desired.Scopes) {
+			tokens = append(tokens, *current)
+			continue
+		}
+
+		created, err := createToken(desired)
+		if err != nil {
+			r.revokeTokens(adminClient, newIDs)
+			return status, 0, err
+		}
+		tokens = append(tokens, created)
+		if ref := accessTokenRef(current, currentValue); ref != "" {
+			revoke = append(revoke, ref)
+		}
+	}
+
+	// scoped tokens no longer desired
+	for idx := range status.Tokens {
+		current := &status.Tokens[idx]
+		if current.Name == TenantAccessTokenSecretField || tenantScopedAccessTokenDesired(spec, current.Name) {
+			continue
+		}
+
+		if ref := accessTokenRef(current, string(tenantSecret.Data[current.Name])); ref != "" {
+			revoke = append(revoke, ref)
+		}
+		removedFields = append(removedFields, current.Name)
+	}
+
+	if rotate {
+		created, err := createToken(capabilitiesv1beta1.TenantAccessTokenStatus{
+			Name:       TenantAccessTokenSecretField,
+			Permission: controllerhelper.AccessTokenPermissionReadWrite,
+			Scopes:     tenantAdminAccessTokenScopes,
+		})
+		if err != nil {
+			r.revokeTokens(adminClient, newIDs)
+			return status, 0, err
+		}
+		revoke = append(revoke, accessTokenRef(&adminTokenStatus, currentToken))
+		adminTokenStatus = created
+	}
+
+	if len(newValues) > 0 {
+		// the new tokens are revoked by the next reconciliation when the tenant secret update fails,
+		// and replace the previous ones when the status update after the tenant secret update fails
+		status.Pending = &capabilitiesv1beta1.TenantPendingAccessTokensStatus{
+			Tokens:          pendingTokens,
+			Rotation:        rotate,
+			RotationTrigger: rotationTrigger,
+		}
+		err = r.updateStatus(status)
+		if err != nil {
+			r.revokeTokens(adminClient, newIDs)
+			return status, 0, fmt.Errorf("error recording pending access tokens: %w", err)
+		}
+	}
+
+	if len(newValues) > 0 || len(removedFields) > 0 {
+		if tenantSecret.Data == nil {
+			tenantSecret.Data = map[string][]byte{}
+		}
+		for field, value := range newValues {
+			tenantSecret.Data[field] = []byte(value)
+		}
+		for _, field := range removedFields {
+			delete(tenantSecret.Data, field)
+		}
+
+		err = r.Client().Update(r.Context(), tenantSecret)
+		if err != nil {
+			// tokens not written to the secret would be leaked
+			r.revokeTokens(adminClient, newIDs)
+			return status, 0, fmt.Errorf("error updating tenant secret: %w", err)
+		}
+	}
+
+	status.Pending = nil
+	status.Tokens = append([]capabilitiesv1beta1.TenantAccessTokenStatus{adminTokenStatus}, tokens...)
+	if rotate {
+		status.LastRotationTime = &metav1.Time{Time: now}
+		status.LastRotationTrigger = rotationTrigger
+		if spec.RotationInterval != nil {
+			status.NextRotationTime = &metav1.Time{Time: now.Add(spec.RotationInterval.Duration)}
+		}
+		r.EventRecorder().Eventf(r.tenantR, corev1.EventTypeNormal, "AccessTokensRotated", "Tenant access tokens rotated")
+	}
+
+	// replaced tokens are revoked with the current full permission token
+	if newToken, ok := newValues[TenantAccessTokenSecretField]; ok {
+		adminClient, err = controllerhelper.AdminClientFromURLString(adminURL, newToken, insecureSkipVerify)
+		if err != nil {
+			return status, 0, err
+		}
+	}
+
+	for _, ref := range revoke {
+		r.logger.Info("revoking replaced access token")
+		err := adminClient.DeleteAccessToken(ref)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return status, tenantAccessTokensRequeueAfter(status, now), fmt.Errorf("error revoking replaced access token: %w", err)
+		}
+	}
+
+	return status, tenantAccessTokensRequeueAfter(status, now), nil
+}
+
+// resolvePendingTokens completes a reconciliation interrupted after the pending tokens were recorded.
+// Pending tokens found in the tenant secret replace the previous ones, which are revoked. The others are revoked
+func (r *TenantAccessTokensReconciler) resolvePendingTokens(adminClient *controllerhelper.AdminAPIClient, status *capabilitiesv1beta1.TenantAccessTokensStatus, spec *capabilitiesv1beta1.TenantAccessTokensSpec, tenantSecret *corev1.Secret, now time.Time) error {
+	revoke := []string{}
+	written := false
+	for idx := range status.Pending.Tokens {
+		pending := &status.Pending.Tokens[idx]
+		if accessTokenValueHash(string(tenantSecret.Data[pending.Name])) != pending.ValueHash {
+			// the tenant secret was not updated
+			revoke = append(revoke, strconv.FormatInt(pending.ID, 10))
+			continue
+		}
+
+		written = true
+		current := status.Token(pending.Name)
+		if current == nil {
+			status.Tokens = append(status.Tokens, pending.TenantAccessTokenStatus)
+			continue
+		}
+
+		// the value of a replaced token without ID is not known anymore
+		if current.ID != 0 && current.ID != pending.ID {
+			revoke = append(revoke, strconv.FormatInt(current.ID, 10))
+		}
+		*current = pending.TenantAccessTokenStatus
+	}
+
+	for _, ref := range revoke {
+		r.logger.Info("revoking access token of an interrupted reconciliation")
+		err := adminClient.DeleteAccessToken(ref)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return fmt.Errorf("error revoking access token: %w", err)
+		}
+	}
+
+	if written && status.Pending.Rotation {
+		status.LastRotationTime = &metav1.Time{Time: now}
+		status.LastRotationTrigger = status.Pending.RotationTrigger
+		if spec.RotationInterval != nil {
+			status.NextRotationTime = &metav1.Time{Time: now.Add(spec.RotationInterval.Duration)}
+		}
+	}
+
+	status.Pending = nil
+	return nil
+}
+
+// updateStatus persists the access tokens status of the tenant
+func (r *TenantAccessTokensReconciler) updateStatus(status *capabilitiesv1beta1.TenantAccessTokensStatus) error {
+	r.tenantR.Status.AccessTokens = status.DeepCopy()
+	return r.Client().Status().Update(r.Context(), r.tenantR)
+}
+
+func (r *TenantAccessTokensReconciler) rotationDue(status *capabilitiesv1beta1.TenantAccessTokensStatus, trigger string, now time.Time) bool {
+	if trigger != "" && trigger != status.LastRotationTrigger {
+		return true
+	}
+
+	return status.NextRotationTime != nil && !now.Before(status.NextRotationTime.Time)
+}
+
+// revokeTokens revokes, on a best effort basis, tokens that could not be written to the tenant secret
+func (r *TenantAccessTokensReconciler) revokeTokens(adminClient *controllerhelper.AdminAPIClient, ids []string) {
+	for _, id := range ids {
+		err := adminClient.DeleteAccessToken(id)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			r.logger.Error(err, "error revoking unused access token", "ID", id)
+		}
+	}
+}
+
+// accessTokenName returns the name of a token in 3scale, identifying the tenant secret field it is written to
+func (r *TenantAccessTokensReconciler) accessTokenName(field string) string {
+	return fmt.Sprintf("3scale-operator %s %s", r.tenantR.TenantSecretKey(), field)
+}
+
+// accessTokenValueHash returns the SHA-256 of the token value, hex encoded
+func accessTokenValueHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// accessTokenRef returns the ID of the token, or the value when the ID is unknown
+func accessTokenRef(tokenStatus *capabilitiesv1beta1.TenantAccessTokenStatus, value string) string {
+	if tokenStatus != nil && tokenStatus.ID != 0 {
+		return strconv.FormatInt(tokenStatus.ID, 10)
+	}
+
+	return value
+}
+
+func tenantScopedAccessTokenDesired(spec *capabilitiesv1beta1.TenantAccessTokensSpec, name string) bool {
+	for _, tokenSpec := range spec.Scoped {
+		if tokenSpec.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tenantAccessTokensRequeueAfter returns the time until the next scheduled rotation
+func tenantAccessTokensRequeueAfter(status *capabilitiesv1beta1.TenantAccessTokensStatus, now time.Time) time.Duration {
+	if status.NextRotationTime == nil {
+		return 0
+	}
+
+	// due rotations are retried right away
+	return max(status.NextRotationTime.Sub(now), time.Second)
+}
@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// tenantAccessTokensAPIHandler fakes the access tokens endpoints of the tenant admin user.
+// Revocations record the tenant secret content, to check new tokens were written before
+type tenantAccessTokensAPIHandler struct {
+	baseReconciler *reconcilers.BaseReconciler
+	tenantCR       *capabilitiesv1beta1.Tenant
+	nextID         int64
+	created        []threescaleapi.AccessToken
+	revoked        []string
+	secretOnRevoke []map[string][]byte
+}
+
+func (h *tenantAccessTokensAPIHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.Method == http.MethodPost && req.URL.Path == "/admin/api/users/4/access_tokens.json":
+		_ = req.ParseForm()
+		h.nextID++
+		token := threescaleapi.AccessToken{
+			ID:         h.nextID,
+			Name:       req.PostForm.Get("name"),
+			Permission: req.PostForm.Get("permission"),
+			Scopes:     req.PostForm["scopes[]"],
+			Value:      fmt.Sprintf("token-%d", h.nextID),
+		}
+		h.created = append(h.created, token)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(controllerhelper.AccessToken{Element: token})
+	case req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, "/admin/api/personal/access_tokens/"):
+		h.revoked = append(h.revoked, strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/admin/api/personal/access_tokens/"), ".json"))
+		secret := &corev1.Secret{}
+		_ = h.baseReconciler.Client().Get(context.TODO(), h.tenantCR.TenantSecretKey(), secret)
+		h.secretOnRevoke = append(h.secretOnRevoke, secret.Data)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestTenantAccessTokensReconciler(t *testing.T, tenantCR *capabilitiesv1beta1.Tenant, now time.Time) (*TenantAccessTokensReconciler, *tenantAccessTokensAPIHandler) {
+	handler := &tenantAccessTokensAPIHandler{tenantCR: tenantCR}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	tenantSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-tenant", Namespace: "test"},
+		Data: map[string][]byte{
+			TenantAccessTokenSecretField:    []byte("initial"),
+			TenantAdminDomainKeySecretField: []byte(srv.URL),
+		},
+	}
+
+	baseReconciler := getOpenAPIBaseReconciler(tenantCR, tenantSecret)
+	handler.baseReconciler = baseReconciler
+
+	r := NewTenantAccessTokensReconciler(baseReconciler, tenantCR, baseReconciler.Logger())
+	r.now = func() time.Time { return now }
+	return r, handler
+}
+
+func (h *tenantAccessTokensAPIHandler) secret(t *testing.T) *corev1.Secret {
+	secret := &corev1.Secret{}
+	if err := h.baseReconciler.Client().Get(context.TODO(), h.tenantCR.TenantSecretKey(), secret); err != nil {
+		t.Fatal(err)
+	}
+	return secret
+}
+
+func TestTenantAccessTokensReconciler_ScopedTokens(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tenantCR := getTenantCR()
+	tenantCR.Spec.AccessTokens = &capabilitiesv1beta1.TenantAccessTokensSpec{
+		Scoped: []capabilitiesv1beta1.TenantScopedAccessTokenSpec{
+			{Name: "readOnly", Permission: controllerhelper.AccessTokenPermissionReadOnly, Scopes: []capabilitiesv1beta1.TenantAccessTokenScope{"account_management", "stats"}},
+			{Name: "policyRegistry", Permission: controllerhelper.AccessTokenPermissionReadWrite, Scopes: []capabilitiesv1beta1.TenantAccessTokenScope{"policy_registry"}},
+		},
+	}
+	r, handler := newTestTenantAccessTokensReconciler(t, tenantCR, now)
+
+	status, requeueAfter, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if requeueAfter != 0 {
+		t.Errorf("requeueAfter = %v, want 0", requeueAfter)
+	}
+	if len(handler.created) != 2 || len(handler.revoked) != 0 {
+		t.Fatalf("created %v, revoked %v", handler.created, handler.revoked)
+	}
+	if handler.created[0].Permission != "ro" || strings.Join(handler.created[0].Scopes, ",") != "account_management,stats" {
+		t.Errorf("unexpected read only token: %+v", handler.created[0])
+	}
+
+	secret := handler.secret(t)
+	if string(secret.Data["readOnly"]) != "token-1" || string(secret.Data["policyRegistry"]) != "token-2" || string(secret.Data[TenantAccessTokenSecretField]) != "initial" {
+		t.Errorf("unexpected tenant secret: %v", secret.Data)
+	}
+	if len(status.Tokens) != 3 || status.Token("readOnly").ID != 1 || status.Token(TenantAccessTokenSecretField).ID != 0 {
+		t.Errorf("unexpected status: %+v", status.Tokens)
+	}
+
+	// in sync
+	tenantCR.Status.AccessTokens = status
+	_, _, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(handler.created) != 2 {
+		t.Errorf("unexpected tokens created: %v", handler.created[2:])
+	}
+
+	// permission changed and token removed
+	tenantCR.Spec.AccessTokens.Scoped = tenantCR.Spec.AccessTokens.Scoped[:1]
+	tenantCR.Spec.AccessTokens.Scoped[0].Permission = controllerhelper.AccessTokenPermissionReadWrite
+	status, _, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(handler.created) != 3 || strings.Join(handler.revoked, ",") != "1,2" {
+		t.Fatalf("created %v, revoked %v", handler.created, handler.revoked)
+	}
+	if string(handler.secretOnRevoke[0]["readOnly"]) != "token-3" {
+		t.Errorf("token revoked before the new one was written: %v", handler.secretOnRevoke[0])
+	}
+	if _, ok := handler.secret(t).Data["policyRegistry"]; ok {
+		t.Errorf("removed token kept in the tenant secret")
+	}
+	if len(status.Tokens) != 2 || status.Token("policyRegistry") != nil {
+		t.Errorf("unexpected status: %+v", status.Tokens)
+	}
+}
+
+func TestTenantAccessTokensReconciler_Rotation(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tenantCR := getTenantCR()
+	tenantCR.Spec.AccessTokens = &capabilitiesv1beta1.TenantAccessTokensSpec{
+		Scoped: []capabilitiesv1beta1.TenantScopedAccessTokenSpec{
+			{Name: "readOnly", Permission: controllerhelper.AccessTokenPermissionReadOnly, Scopes: []capabilitiesv1beta1.TenantAccessTokenScope{"account_management"}},
+		},
+		RotationInterval: &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	r, handler := newTestTenantAccessTokensReconciler(t, tenantCR, now)
+
+	status, requeueAfter, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if requeueAfter != 24*time.Hour {
+		t.Errorf("requeueAfter = %v, want 24h", requeueAfter)
+	}
+	if status.LastRotationTime != nil {
+		t.Errorf("unexpected rotation: %v", status.LastRotationTime)
+	}
+
+	// rotation due
+	tenantCR.Status.AccessTokens = status
+	r.now = func() time.Time { return now.Add(25 * time.Hour) }
+	status, requeueAfter, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if requeueAfter != 24*time.Hour {
+		t.Errorf("requeueAfter = %v, want 24h", requeueAfter)
+	}
+	if len(handler.created) != 3 || strings.Join(handler.revoked, ",") != "1,initial" {
+		t.Fatalf("created %v, revoked %v", handler.created, handler.revoked)
+	}
+	for _, secretData := range handler.secretOnRevoke {
+		if string(secretData["readOnly"]) != "token-2" || string(secretData[TenantAccessTokenSecretField]) != "token-3" {
+			t.Errorf("token revoked before the new one was written: %v", secretData)
+		}
+	}
+	if status.LastRotationTime == nil || !status.LastRotationTime.Time.Equal(now.Add(25*time.Hour)) {
+		t.Errorf("unexpected last rotation time: %v", status.LastRotationTime)
+	}
+	if status.Token(TenantAccessTokenSecretField).ID != 3 {
+		t.Errorf("unexpected status: %+v", status.Tokens)
+	}
+
+	// rotation triggered by annotation
+	tenantCR.Status.AccessTokens = status
+	tenantCR.Annotations = map[string]string{capabilitiesv1beta1.TenantRotateAccessTokensAnnotation: "1"}
+	status, _, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(handler.created) != 5 || strings.Join(handler.revoked[2:], ",") != "2,3" {
+		t.Fatalf("created %v, revoked %v", handler.created, handler.revoked)
+	}
+	if status.LastRotationTrigger != "1" {
+		t.Errorf("last rotation trigger = %q, want 1", status.LastRotationTrigger)
+	}
+
+	// same trigger does not rotate again
+	tenantCR.Status.AccessTokens = status
+	_, _, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(handler.created) != 5 {
+		t.Errorf("unexpected tokens created: %v", handler.created[5:])
+	}
+}
+
+func TestTenantAccessTokensReconciler_PendingTokens(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tenantCR := getTenantCR()
+	tenantCR.Annotations = map[string]string{capabilitiesv1beta1.TenantRotateAccessTokensAnnotation: "1"}
+	tenantCR.Spec.AccessTokens = &capabilitiesv1beta1.TenantAccessTokensSpec{}
+	r, handler := newTestTenantAccessTokensReconciler(t, tenantCR, now)
+
+	_, _, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	// the status update after the tenant secret update failed: the persisted status has the pending token
+	persisted := &capabilitiesv1beta1.Tenant{}
+	if err := handler.baseReconciler.Client().Get(context.TODO(), types.NamespacedName{Name: tenantCR.Name, Namespace: tenantCR.Namespace}, persisted); err != nil {
+		t.Fatal(err)
+	}
+	if persisted.Status.AccessTokens == nil || persisted.Status.AccessTokens.Pending == nil || persisted.Status.AccessTokens.Pending.Tokens[0].ID != 1 {
+		t.Fatalf("pending token not recorded before the tenant secret update: %+v", persisted.Status.AccessTokens)
+	}
+	tenantCR.Status.AccessTokens = persisted.Status.AccessTokens
+
+	status, _, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(handler.created) != 1 {
+		t.Errorf("rotated again: %v", handler.created)
+	}
+	if status.Pending != nil || status.Token(TenantAccessTokenSecretField).ID != 1 || status.LastRotationTrigger != "1" {
+		t.Errorf("pending token not resolved: %+v", status)
+	}
+
+	// the tenant secret update failed: the pending token is revoked
+	status.Pending = &capabilitiesv1beta1.TenantPendingAccessTokensStatus{
+		Tokens: []capabilitiesv1beta1.TenantPendingAccessTokenStatus{{
+			TenantAccessTokenStatus: capabilitiesv1beta1.TenantAccessTokenStatus{Name: TenantAccessTokenSecretField, ID: 7},
+			ValueHash:               accessTokenValueHash("token-7"),
+		}},
+		Rotation:        true,
+		RotationTrigger: "2",
+	}
+	tenantCR.Status.AccessTokens = status
+	handler.revoked = nil
+	status, _, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if strings.Join(handler.revoked, ",") != "7" {
+		t.Errorf("revoked %v, want the pending token", handler.revoked)
+	}
+	if status.Pending != nil || status.Token(TenantAccessTokenSecretField).ID != 1 || status.LastRotationTrigger != "1" {
+		t.Errorf("pending token not discarded: %+v", status)
+	}
+	if string(handler.secret(t).Data[TenantAccessTokenSecretField]) != "token-1" {
+		t.Errorf("unexpected tenant secret: %v", handler.secret(t).Data)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -31,7 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -41,9 +42,6 @@ import (
 )
 
 const (
-	// tenant deletion state
-	scheduledForDeletionState = "scheduled_for_deletion"
-
 	// tenant finalizer
 	tenantFinalizer = "tenant.capabilities.3scale.net/finalizer"
 
@@ -78,7 +76,7 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	reqLogger.Info("Reconcile Tenant", "Operator version", version.Version)
 
 	// Fetch the Tenant instance
-	tenantCR := &capabilitiesv1alpha1.Tenant{}
+	tenantCR := &capabilitiesv1beta1.Tenant{}
 	err := r.Client().Get(context.TODO(), req.NamespacedName, tenantCR)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -100,10 +98,13 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		reqLogger.V(1).Info(string(jsonData))
 	}
 
+	// the spec reconcilers update the status in place, changes are detected against the stored status
+	storedStatus := tenantCR.Status.DeepCopy()
+
 	// Setup porta client
 	portaClient, err := r.setupPortaClient(tenantCR, reqLogger)
 	if err != nil {
-		_, statusReconcilerError := r.reconcileStatus(tenantCR, err)
+		_, statusReconcilerError := r.reconcileStatus(tenantCR, storedStatus, err)
 		if statusReconcilerError != nil {
 			return helper.ReconcileErrorHandler(err, reqLogger), statusReconcilerError
 		}
@@ -113,23 +114,22 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	// Reconcile whether or not the tenant should be removed
 	if tenantCR.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(tenantCR, tenantFinalizer) {
-		existingTenant, err := controllerhelper.FetchTenant(tenantCR.Status.TenantId, portaClient)
+		deletionReconciler := NewTenantDeletionReconciler(r.BaseReconciler, tenantCR, portaClient, reqLogger)
+		removeFinalizer, requeueAfter, err := deletionReconciler.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
-		// delete tenantCR if tenant is present in 3scale
-		if existingTenant != nil {
-			// do not attempt to delete tenant that is already scheduled for deletion
-			if existingTenant.Signup.Account.State != scheduledForDeletionState {
-				err := portaClient.DeleteTenant(tenantCR.Status.TenantId)
+		// the tenant deletion is blocked or has been scheduled, report it in the status
+		if !removeFinalizer {
+			if !storedStatus.StatusEqual(&tenantCR.Status, reqLogger) {
+				err = r.UpdateResourceStatus(tenantCR)
 				if err != nil {
-					r.EventRecorder().Eventf(tenantCR, corev1.EventTypeWarning, "Failed to delete tenant", "%v", err)
 					return ctrl.Result{}, err
 				}
-			} else {
-				reqLogger.Info("Removing tenant CR - tenant is already scheduled for deletion", "tenantID", existingTenant.Signup.Account.ID)
 			}
+
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
 		}
 
 		controllerutil.RemoveFinalizer(tenantCR, tenantFinalizer)
@@ -160,10 +160,28 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, nil
 	}
 
+	masterAdminClient, err := r.setupMasterAdminClient(tenantCR, reqLogger)
+	if err != nil {
+		_, statusReconcilerError := r.reconcileStatus(tenantCR, storedStatus, err)
+		if statusReconcilerError != nil {
+			return helper.ReconcileErrorHandler(err, reqLogger), statusReconcilerError
+		}
+
+		return helper.ReconcileErrorHandler(err, reqLogger), nil
+	}
+
 	// Validate and update spec if required
-	internalReconciler := NewTenantThreescaleReconciler(r.BaseReconciler, tenantCR, portaClient, reqLogger)
+	internalReconciler := NewTenantThreescaleReconciler(r.BaseReconciler, tenantCR, portaClient, masterAdminClient, reqLogger)
 	specReconcileErr := internalReconciler.Run()
-	statusIsEqual, statusReconcilerError := r.reconcileStatus(tenantCR, specReconcileErr)
+
+	// Generate scoped access tokens and rotate them when due
+	var requeueAfter time.Duration
+	if specReconcileErr == nil {
+		accessTokensReconciler := NewTenantAccessTokensReconciler(r.BaseReconciler, tenantCR, reqLogger)
+		tenantCR.Status.AccessTokens, requeueAfter, specReconcileErr = accessTokensReconciler.Reconcile()
+	}
+
+	statusIsEqual, statusReconcilerError := r.reconcileStatus(tenantCR, storedStatus, specReconcileErr)
 	if statusReconcilerError != nil {
 		return helper.ReconcileErrorHandler(statusReconcilerError, reqLogger), nil
 	}
@@ -174,15 +192,15 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	// If error did not occur and the status was updated, quit the reoncile loop since another reconcile is incoming
 	if !statusIsEqual {
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	reqLogger.Info("Tenant reconciled successfully")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func (r *TenantReconciler) reconcileStatus(tenantCR *capabilitiesv1alpha1.Tenant, reconcileError error) (bool, error) {
-	statusReconciler := NewTenantStatusReconciler(r.BaseReconciler, tenantCR, reconcileError)
+func (r *TenantReconciler) reconcileStatus(tenantCR *capabilitiesv1beta1.Tenant, storedStatus *capabilitiesv1beta1.TenantStatus, reconcileError error) (bool, error) {
+	statusReconciler := NewTenantStatusReconciler(r.BaseReconciler, tenantCR, storedStatus, reconcileError)
 	statusEqual, err := statusReconciler.Reconcile()
 	if err != nil {
 		return statusEqual, err
@@ -191,7 +209,7 @@ func (r *TenantReconciler) reconcileStatus(tenantCR *capabilitiesv1alpha1.Tenant
 	return statusEqual, nil
 }
 
-func (r *TenantReconciler) reconcileMetadata(tenantCR *capabilitiesv1alpha1.Tenant) bool {
+func (r *TenantReconciler) reconcileMetadata(tenantCR *capabilitiesv1beta1.Tenant) bool {
 	changed := false
 	// If the tenant.Status.TenantID is found and the annotation is not found - create
 	// If the tenant.Status.TenantID is found and the annotation is found but, the value of annotation is different to the status.TenantID - update
@@ -212,7 +230,7 @@ func (r *TenantReconciler) reconcileMetadata(tenantCR *capabilitiesv1alpha1.Tena
 	return changed
 }
 
-func (r *TenantReconciler) fetchMasterCredentials(tenantR *capabilitiesv1alpha1.Tenant) (string, error) {
+func (r *TenantReconciler) fetchMasterCredentials(tenantR *capabilitiesv1beta1.Tenant) (string, error) {
 	masterCredentialsSecret := &corev1.Secret{}
 
 	err := r.Client().Get(context.TODO(), tenantR.MasterSecretKey(), masterCredentialsSecret)
@@ -230,7 +248,7 @@ func (r *TenantReconciler) fetchMasterCredentials(tenantR *capabilitiesv1alpha1.
 	return bytes.NewBuffer(masterAccessTokenByteArray).String(), nil
 }
 
-func (r *TenantReconciler) setupPortaClient(tenantCR *capabilitiesv1alpha1.Tenant, logger logr.Logger) (*threescaleapi.ThreeScaleClient, error) {
+func (r *TenantReconciler) setupPortaClient(tenantCR *capabilitiesv1beta1.Tenant, logger logr.Logger) (*threescaleapi.ThreeScaleClient, error) {
 	masterAccessToken, err := r.fetchMasterCredentials(tenantCR)
 	if err != nil {
 		logger.Error(err, "Error fetching master credentials secret")
@@ -246,8 +264,21 @@ func (r *TenantReconciler) setupPortaClient(tenantCR *capabilitiesv1alpha1.Tenan
 	return portaClient, nil
 }
 
+// setupMasterAdminClient returns the admin API client of the master account,
+// used for the tenant endpoints the porta client does not implement
+func (r *TenantReconciler) setupMasterAdminClient(tenantCR *capabilitiesv1beta1.Tenant, logger logr.Logger) (*controllerhelper.AdminAPIClient, error) {
+	masterAccessToken, err := r.fetchMasterCredentials(tenantCR)
+	if err != nil {
+		logger.Error(err, "Error fetching master credentials secret")
+		return nil, err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(tenantCR.GetAnnotations())
+	return controllerhelper.AdminClientFromURLString(tenantCR.Spec.SystemMasterUrl, masterAccessToken, insecureSkipVerify)
+}
+
 func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1alpha1.Tenant{}).
+		For(&capabilitiesv1beta1.Tenant{}).
 		Complete(r)
 }
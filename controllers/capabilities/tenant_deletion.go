@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantDeletionReconciler applies the deletion policy of a deleted Tenant resource to the tenant account in 3scale
+type TenantDeletionReconciler struct {
+	*reconcilers.BaseReconciler
+	tenantR     *capabilitiesv1beta1.Tenant
+	portaClient *threescaleapi.ThreeScaleClient
+	now         func() time.Time
+	logger      logr.Logger
+}
+
+func NewTenantDeletionReconciler(b *reconcilers.BaseReconciler, tenantR *capabilitiesv1beta1.Tenant, portaClient *threescaleapi.ThreeScaleClient, logger logr.Logger) *TenantDeletionReconciler {
+	return &TenantDeletionReconciler{
+		BaseReconciler: b,
+		tenantR:        tenantR,
+		portaClient:    portaClient,
+		now:            time.Now,
+		logger:         logger.WithValues("Deletion Reconciler", tenantR.Name),
+	}
+}
+
+// Reconcile suspends or deletes the tenant account according to the deletion policy.
+// The tenant is only deleted when the allow-deletion annotation is "true" and the grace period is over,
+// the tenant account is kept suspended meanwhile.
+// Returns true when the finalizer can be removed. Otherwise, the status has the deletion conditions
+// and the resource must be reconciled again after the returned duration, when not zero
+func (r *TenantDeletionReconciler) Reconcile() (bool, time.Duration, error) {
+	tenant, err := controllerhelper.FetchTenant(r.tenantR.Status.TenantId, r.portaClient)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if tenant == nil {
+		r.logger.Info("tenant not found in 3scale")
+		return true, 0, nil
+	}
+
+	tenantID := tenant.Signup.Account.ID
+
+	// do not attempt to delete tenant that is already scheduled for deletion
+	if tenant.Signup.Account.State == capabilitiesv1beta1.TenantStateScheduledForDeletion {
+		r.logger.Info("tenant is already scheduled for deletion", "tenantID", tenantID)
+		return true, 0, nil
+	}
+
+	if r.tenantR.DeletionPolicy() == capabilitiesv1beta1.TenantDeletionPolicySuspend {
+		err = r.suspendTenant(tenant)
+		if err != nil {
+			return false, 0, err
+		}
+
+		r.EventRecorder().Eventf(r.tenantR, corev1.EventTypeNormal, "TenantSuspended", "Tenant %d suspended instead of deleted", tenantID)
+		return true, 0, nil
+	}
+
+	if !r.tenantR.IsDeletionAllowed() {
+		if r.tenantR.Status.DeletionTime != nil {
+			r.EventRecorder().Eventf(r.tenantR, corev1.EventTypeNormal, "TenantDeletionCanceled", "Tenant %d deletion canceled, the tenant is kept suspended", tenantID)
+		}
+
+		message := fmt.Sprintf("tenant %d is not deleted in 3scale, set the %s annotation to \"true\" to delete it", tenantID, capabilitiesv1beta1.TenantAllowDeletionAnnotation)
+		r.tenantR.Status.DeletionTime = nil
+		r.tenantR.Status.Conditions.SetCondition(common.Condition{
+			Type:    capabilitiesv1beta1.TenantDeletionBlockedConditionType,
+			Status:  corev1.ConditionTrue,
+			Reason:  "DeletionNotAllowed",
+			Message: message,
+		})
+		r.tenantR.Status.Conditions.SetCondition(common.Condition{
+			Type:   capabilitiesv1beta1.TenantScheduledForDeletionConditionType,
+			Status: corev1.ConditionFalse,
+		})
+		r.EventRecorder().Eventf(r.tenantR, corev1.EventTypeWarning, "TenantDeletionBlocked", "%s", message)
+
+		// reconciled again when the annotation is set
+		return false, 0, nil
+	}
+
+	now := r.now()
+	if r.tenantR.Status.DeletionTime == nil {
+		deletionTime := metav1.NewTime(now.Add(r.tenantR.DeletionGracePeriod()).Truncate(time.Second))
+		r.tenantR.Status.DeletionTime = &deletionTime
+		r.EventRecorder().Eventf(r.tenantR, corev1.EventTypeNormal, "TenantDeletionScheduled", "Tenant %d will be deleted at %s", tenantID, deletionTime.UTC().Format(time.RFC3339))
+	}
+
+	deletionTime := r.tenantR.Status.DeletionTime
+	r.tenantR.Status.Conditions.SetCondition(common.Condition{
+		Type:   capabilitiesv1beta1.TenantDeletionBlockedConditionType,
+		Status: corev1.ConditionFalse,
+	})
+	r.tenantR.Status.Conditions.SetCondition(common.Condition{
+		Type:    capabilitiesv1beta1.TenantScheduledForDeletionConditionType,
+		Status:  corev1.ConditionTrue,
+		Reason:  "DeletionAllowed",
+		Message: fmt.Sprintf("tenant %d will be deleted in 3scale at %s", tenantID, deletionTime.UTC().Format(time.RFC3339)),
+	})
+
+	if now.Before(deletionTime.Time) {
+		// the tenant cannot be used during the grace period
+		err = r.suspendTenant(tenant)
+		if err != nil {
+			return false, 0, err
+		}
+
+		return false, deletionTime.Sub(now), nil
+	}
+
+	err = r.portaClient.DeleteTenant(tenantID)
+	if err != nil {
+		r.EventRecorder().Eventf(r.tenantR, corev1.EventTypeWarning, "Failed to delete tenant", "%v", err)
+		return false, 0, err
+	}
+
+	r.EventRecorder().Eventf(r.tenantR, corev1.EventTypeNormal, "TenantDeleted", "Tenant %d scheduled for deletion in 3scale", tenantID)
+	return true, 0, nil
+}
+
+// suspendTenant suspends approved tenant accounts and reports the new state in the status
+func (r *TenantDeletionReconciler) suspendTenant(tenant *threescaleapi.Tenant) error {
+	if tenant.Signup.Account.State != capabilitiesv1beta1.TenantStateApproved {
+		return nil
+	}
+
+	updatedTenant, err := r.portaClient.UpdateTenant(tenant.Signup.Account.ID, map[string]string{"state_event": "suspend"})
+	if err != nil {
+		return err
+	}
+
+	r.tenantR.Status.State = updatedTenant.Signup.Account.State
+	return nil
+}
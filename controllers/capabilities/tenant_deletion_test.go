@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newTestTenantDeletionReconciler(t *testing.T, tenantCR *capabilitiesv1beta1.Tenant, handler *tenantMasterAPIHandler, now time.Time) *TenantDeletionReconciler {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ap, err := threescaleapi.NewAdminPortalFromStr(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseReconciler := getOpenAPIBaseReconciler(tenantCR)
+	r := NewTenantDeletionReconciler(baseReconciler, tenantCR, threescaleapi.NewThreeScale(ap, "test", srv.Client()), baseReconciler.Logger())
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func TestTenantDeletionReconciler(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.Add(-time.Minute))
+	future := metav1.NewTime(now.Add(time.Hour))
+
+	tests := []struct {
+		name             string
+		state            string
+		allowDeletion    bool
+		deletion         *capabilitiesv1beta1.TenantDeletionSpec
+		deletionTime     *metav1.Time
+		wantDone         bool
+		wantRequeueAfter time.Duration
+		wantState        string
+		wantDeletionTime *metav1.Time
+		wantBlocked      corev1.ConditionStatus
+		wantScheduled    corev1.ConditionStatus
+	}{
+		{
+			name:          "deletion not allowed",
+			state:         capabilitiesv1beta1.TenantStateApproved,
+			wantState:     capabilitiesv1beta1.TenantStateApproved,
+			wantBlocked:   corev1.ConditionTrue,
+			wantScheduled: corev1.ConditionFalse,
+		},
+		{
+			name:             "deletion allowed",
+			state:            capabilitiesv1beta1.TenantStateApproved,
+			allowDeletion:    true,
+			wantDone:         true,
+			wantState:        capabilitiesv1beta1.TenantStateScheduledForDeletion,
+			wantDeletionTime: ptr.To(metav1.NewTime(now)),
+			wantBlocked:      corev1.ConditionFalse,
+			wantScheduled:    corev1.ConditionTrue,
+		},
+		{
+			name:             "grace period",
+			state:            capabilitiesv1beta1.TenantStateApproved,
+			allowDeletion:    true,
+			deletion:         &capabilitiesv1beta1.TenantDeletionSpec{GracePeriod: &metav1.Duration{Duration: 72 * time.Hour}},
+			wantRequeueAfter: 72 * time.Hour,
+			wantState:        capabilitiesv1beta1.TenantStateSuspended,
+			wantDeletionTime: ptr.To(metav1.NewTime(now.Add(72 * time.Hour))),
+			wantBlocked:      corev1.ConditionFalse,
+			wantScheduled:    corev1.ConditionTrue,
+		},
+		{
+			name:             "grace period not over",
+			state:            capabilitiesv1beta1.TenantStateSuspended,
+			allowDeletion:    true,
+			deletion:         &capabilitiesv1beta1.TenantDeletionSpec{GracePeriod: &metav1.Duration{Duration: 72 * time.Hour}},
+			deletionTime:     &future,
+			wantRequeueAfter: time.Hour,
+			wantState:        capabilitiesv1beta1.TenantStateSuspended,
+			wantDeletionTime: &future,
+			wantBlocked:      corev1.ConditionFalse,
+			wantScheduled:    corev1.ConditionTrue,
+		},
+		{
+			name:             "grace period over",
+			state:            capabilitiesv1beta1.TenantStateSuspended,
+			allowDeletion:    true,
+			deletion:         &capabilitiesv1beta1.TenantDeletionSpec{GracePeriod: &metav1.Duration{Duration: 72 * time.Hour}},
+			deletionTime:     &past,
+			wantDone:         true,
+			wantState:        capabilitiesv1beta1.TenantStateScheduledForDeletion,
+			wantDeletionTime: &past,
+			wantBlocked:      corev1.ConditionFalse,
+			wantScheduled:    corev1.ConditionTrue,
+		},
+		{
+			name:          "deletion canceled",
+			state:         capabilitiesv1beta1.TenantStateSuspended,
+			deletion:      &capabilitiesv1beta1.TenantDeletionSpec{GracePeriod: &metav1.Duration{Duration: 72 * time.Hour}},
+			deletionTime:  &future,
+			wantState:     capabilitiesv1beta1.TenantStateSuspended,
+			wantBlocked:   corev1.ConditionTrue,
+			wantScheduled: corev1.ConditionFalse,
+		},
+		{
+			name:      "suspend policy",
+			state:     capabilitiesv1beta1.TenantStateApproved,
+			deletion:  &capabilitiesv1beta1.TenantDeletionSpec{Policy: ptr.To(capabilitiesv1beta1.TenantDeletionPolicySuspend)},
+			wantDone:  true,
+			wantState: capabilitiesv1beta1.TenantStateSuspended,
+		},
+		{
+			name:      "already scheduled for deletion",
+			state:     capabilitiesv1beta1.TenantStateScheduledForDeletion,
+			wantDone:  true,
+			wantState: capabilitiesv1beta1.TenantStateScheduledForDeletion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			tenantCR := getTenantCR()
+			tenantCR.Spec.Deletion = tt.deletion
+			tenantCR.Status.DeletionTime = tt.deletionTime
+			if tt.allowDeletion {
+				tenantCR.Annotations = map[string]string{capabilitiesv1beta1.TenantAllowDeletionAnnotation: "true"}
+			}
+			handler := &tenantMasterAPIHandler{account: threescaleapi.Account{ID: 3, State: tt.state}}
+			r := newTestTenantDeletionReconciler(subT, tenantCR, handler, now)
+
+			done, requeueAfter, err := r.Reconcile()
+			if err != nil {
+				subT.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if done != tt.wantDone || requeueAfter != tt.wantRequeueAfter {
+				subT.Errorf("Reconcile() = %t, %s, want %t, %s", done, requeueAfter, tt.wantDone, tt.wantRequeueAfter)
+			}
+			if handler.account.State != tt.wantState {
+				subT.Errorf("tenant state = %s, want %s", handler.account.State, tt.wantState)
+			}
+			if !tenantCR.Status.DeletionTime.Equal(tt.wantDeletionTime) {
+				subT.Errorf("deletion time = %v, want %v", tenantCR.Status.DeletionTime, tt.wantDeletionTime)
+			}
+
+			for conditionType, want := range map[common.ConditionType]corev1.ConditionStatus{
+				capabilitiesv1beta1.TenantDeletionBlockedConditionType:      tt.wantBlocked,
+				capabilitiesv1beta1.TenantScheduledForDeletionConditionType: tt.wantScheduled,
+			} {
+				var got corev1.ConditionStatus
+				if condition := tenantCR.Status.Conditions.GetCondition(conditionType); condition != nil {
+					got = condition.Status
+				}
+				if got != want {
+					subT.Errorf("%s condition = %q, want %q", conditionType, got, want)
+				}
+			}
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"strconv"
 
-	apiv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	apispkghelper "github.com/3scale/3scale-operator/pkg/apispkg/helper"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -15,6 +15,7 @@ import (
 	porta_client_pkg "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -22,19 +23,21 @@ import (
 // TenantThreescaleReconciler reconciles a Tenant object
 type TenantThreescaleReconciler struct {
 	*reconcilers.BaseReconciler
-	tenantR     *apiv1alpha1.Tenant
+	tenantR     *capabilitiesv1beta1.Tenant
 	portaClient *porta_client_pkg.ThreeScaleClient
+	adminClient *controllerhelper.AdminAPIClient
 	logger      logr.Logger
 }
 
 // NewTenantThreescaleReconciler constructs InternalReconciler object
-func NewTenantThreescaleReconciler(b *reconcilers.BaseReconciler, tenantR *apiv1alpha1.Tenant,
-	portaClient *porta_client_pkg.ThreeScaleClient, log logr.Logger,
+func NewTenantThreescaleReconciler(b *reconcilers.BaseReconciler, tenantR *capabilitiesv1beta1.Tenant,
+	portaClient *porta_client_pkg.ThreeScaleClient, adminClient *controllerhelper.AdminAPIClient, log logr.Logger,
 ) *TenantThreescaleReconciler {
 	return &TenantThreescaleReconciler{
 		BaseReconciler: b,
 		tenantR:        tenantR,
 		portaClient:    portaClient,
+		adminClient:    adminClient,
 		logger:         log,
 	}
 }
@@ -42,6 +45,7 @@ func NewTenantThreescaleReconciler(b *reconcilers.BaseReconciler, tenantR *apiv1
 // Run tenant reconciliation logic
 // Facts to reconcile:
 // - Have 3scale Tenant Account
+// - Have tenant account attributes, state, domains and account plan in sync with the spec
 // - Have active admin user
 // - Have secret with tenant's access_token
 func (r *TenantThreescaleReconciler) Run() error {
@@ -65,7 +69,7 @@ func (r *TenantThreescaleReconciler) Run() error {
 // This method makes sure that tenant exists, otherwise it will create one
 // On method completion:
 // * tenant will exist
-// * tenant's attributes will be updated if required
+// * tenant's attributes, state, domains and account plan will be updated if required
 func (r *TenantThreescaleReconciler) reconcileTenant() (bool, error) {
 	tenantID, err := r.retrieveTenantID()
 	if err != nil {
@@ -92,7 +96,7 @@ func (r *TenantThreescaleReconciler) reconcileTenant() (bool, error) {
 		}
 
 		// Early update status with the new tenantID
-		newStatus := &apiv1alpha1.TenantStatus{
+		newStatus := &capabilitiesv1beta1.TenantStatus{
 			// reset adminID. It could keep old stale value
 			AdminId:  0,
 			TenantId: tenantDef.Signup.Account.ID,
@@ -106,7 +110,21 @@ func (r *TenantThreescaleReconciler) reconcileTenant() (bool, error) {
 		}
 	}
 
-	err = r.SetUpdateTenantInfo(tenantDef)
+	tenantDef, err = r.SetUpdateTenantInfo(tenantDef)
+	if err != nil {
+		return false, err
+	}
+
+	r.tenantR.Status.State = tenantDef.Signup.Account.State
+	r.tenantR.Status.AdminDomain = tenantDef.Signup.Account.AdminDomain
+	r.tenantR.Status.DeveloperDomain = tenantDef.Signup.Account.Domain
+
+	err = r.reconcileTenantSecretAdminURL(tenantDef)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.reconcileAccountPlan(tenantDef.Signup.Account.ID)
 	if err != nil {
 		return false, err
 	}
@@ -154,7 +172,7 @@ func (r *TenantThreescaleReconciler) reconcileAdminUser() error {
 		return err
 	}
 
-	newStatus := &apiv1alpha1.TenantStatus{
+	newStatus := &capabilitiesv1beta1.TenantStatus{
 		AdminId:  *adminUser.Element.ID,
 		TenantId: tenantID,
 	}
@@ -287,7 +305,7 @@ func (r *TenantThreescaleReconciler) syncAdminUser(tenantID int64, adminUser *po
 }
 
 // Returns whether the status should be updated or not and the error
-func (r *TenantThreescaleReconciler) reconcileStatusIDs(desiredStatus *apiv1alpha1.TenantStatus) bool {
+func (r *TenantThreescaleReconciler) reconcileStatusIDs(desiredStatus *capabilitiesv1beta1.TenantStatus) bool {
 	if desiredStatus.TenantId != r.tenantR.Status.TenantId {
 		r.tenantR.Status.TenantId = desiredStatus.TenantId
 		return true
@@ -322,7 +340,9 @@ func (r *TenantThreescaleReconciler) retrieveTenantID() (int64, error) {
 	return tenantId, nil
 }
 
-func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pkg.Tenant) error {
+// SetUpdateTenantInfo updates the tenant account in the master API when it differs from the spec.
+// Returns the tenant as read after the update
+func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pkg.Tenant) (*porta_client_pkg.Tenant, error) {
 	// set/update Tenant optional Info parameters
 	params := map[string]string{}
 	fieldErrors := field.ErrorList{}
@@ -332,7 +352,7 @@ func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pk
 		if tenant.Signup.Account.FromEmail != *r.tenantR.Spec.FromEmail {
 			if !apispkghelper.IsEmailValid(*r.tenantR.Spec.FromEmail) {
 				fieldErrors = append(fieldErrors, field.Invalid(specFldPath.Child("fromEmail"), r.tenantR.Spec.FromEmail, "invalid FromEmail"))
-				return &helper.SpecFieldError{
+				return nil, &helper.SpecFieldError{
 					ErrorType:      helper.InvalidError,
 					FieldErrorList: fieldErrors,
 				}
@@ -344,7 +364,7 @@ func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pk
 		if tenant.Signup.Account.SupportEmail != *r.tenantR.Spec.SupportEmail {
 			if !apispkghelper.IsEmailValid(*r.tenantR.Spec.SupportEmail) {
 				fieldErrors = append(fieldErrors, field.Invalid(specFldPath.Child("supportEmail"), r.tenantR.Spec.SupportEmail, "invalid SupportEmail"))
-				return &helper.SpecFieldError{
+				return nil, &helper.SpecFieldError{
 					ErrorType:      helper.InvalidError,
 					FieldErrorList: fieldErrors,
 				}
@@ -356,7 +376,7 @@ func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pk
 		if tenant.Signup.Account.FinanceSupportEmail != *r.tenantR.Spec.FinanceSupportEmail {
 			if !apispkghelper.IsEmailValid(*r.tenantR.Spec.FinanceSupportEmail) {
 				fieldErrors = append(fieldErrors, field.Invalid(specFldPath.Child("financeSupportEmail"), r.tenantR.Spec.FinanceSupportEmail, "invalid FinanceSupportEmail"))
-				return &helper.SpecFieldError{
+				return nil, &helper.SpecFieldError{
 					ErrorType:      helper.InvalidError,
 					FieldErrorList: fieldErrors,
 				}
@@ -371,22 +391,135 @@ func (r *TenantThreescaleReconciler) SetUpdateTenantInfo(tenant *porta_client_pk
 		}
 	}
 
+	if tenant.Signup.Account.OrgName != r.tenantR.Spec.OrganizationName {
+		params["org_name"] = r.tenantR.Spec.OrganizationName
+	}
+
+	if r.tenantR.Spec.AdminDomain != nil {
+		if tenant.Signup.Account.AdminDomain != *r.tenantR.Spec.AdminDomain {
+			params["admin_domain"] = *r.tenantR.Spec.AdminDomain
+		}
+	}
+
+	if r.tenantR.Spec.DeveloperDomain != nil {
+		if tenant.Signup.Account.Domain != *r.tenantR.Spec.DeveloperDomain {
+			params["domain"] = *r.tenantR.Spec.DeveloperDomain
+		}
+	}
+
+	// tenants scheduled for deletion are neither suspended nor resumed
+	switch state := tenant.Signup.Account.State; {
+	case r.tenantR.IsSuspended() && state == capabilitiesv1beta1.TenantStateApproved:
+		params["state_event"] = "suspend"
+	case !r.tenantR.IsSuspended() && state == capabilitiesv1beta1.TenantStateSuspended:
+		params["state_event"] = "resume"
+	}
+
 	if !helper.ManagedByOperatorAnnotationExists(tenant.Signup.Account.Annotations) {
 		for k, v := range helper.ManagedByOperatorAnnotation() {
 			params[k] = v
 		}
 	}
 
-	if len(params) > 0 {
-		r.logger.Info("Set/Update Optional parameters for tenant", "OrganizationName", r.tenantR.Spec.OrganizationName, "Username", r.tenantR.Spec.Username)
-		_, err := r.portaClient.UpdateTenant(
-			tenant.Signup.Account.ID,
-			params,
-		)
-		if err != nil {
-			return err
+	if len(params) == 0 {
+		return tenant, nil
+	}
+
+	r.logger.Info("Set/Update parameters for tenant", "OrganizationName", r.tenantR.Spec.OrganizationName, "Username", r.tenantR.Spec.Username, "Params", helper.MapKeys(params))
+	updatedTenant, err := r.portaClient.UpdateTenant(
+		tenant.Signup.Account.ID,
+		params,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if stateEvent, ok := params["state_event"]; ok {
+		r.EventRecorder().Eventf(r.tenantR, v1.EventTypeNormal, "TenantStateChanged", "Tenant %s event applied", stateEvent)
+	}
+
+	return updatedTenant, nil
+}
+
+// reconcileAccountPlan subscribes the tenant to the account plan of the spec.
+// The account plan is not managed when not set in the spec
+func (r *TenantThreescaleReconciler) reconcileAccountPlan(tenantID int64) error {
+	if r.tenantR.Spec.AccountPlan == nil {
+		r.tenantR.Status.AccountPlan = ""
+		return nil
+	}
+
+	currentPlan, err := r.adminClient.ReadAccountPlan(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to read the account plan of tenant %d: %w", tenantID, err)
+	}
+
+	r.tenantR.Status.AccountPlan = currentPlan.Element.SystemName
+
+	if currentPlan.Element.SystemName == *r.tenantR.Spec.AccountPlan {
+		return nil
+	}
+
+	planList, err := r.adminClient.ListAccountPlans()
+	if err != nil {
+		return fmt.Errorf("failed to list master account plans: %w", err)
+	}
+
+	var desiredPlan *controllerhelper.PlanItem
+	for idx := range planList.Plans {
+		if planList.Plans[idx].Element.SystemName == *r.tenantR.Spec.AccountPlan {
+			desiredPlan = &planList.Plans[idx].Element
+			break
+		}
+	}
+
+	if desiredPlan == nil {
+		fieldErrors := field.ErrorList{}
+		specFldPath := field.NewPath("spec")
+		fieldErrors = append(fieldErrors, field.Invalid(specFldPath.Child("accountPlan"), r.tenantR.Spec.AccountPlan, "account plan not found in the master account"))
+		return &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
 		}
 	}
 
+	r.logger.Info("Change tenant account plan", "tenantID", tenantID, "from", currentPlan.Element.SystemName, "to", desiredPlan.SystemName)
+	err = r.adminClient.UpgradeTenantPlan(tenantID, desiredPlan.ID)
+	if err != nil {
+		return fmt.Errorf("failed to change the account plan of tenant %d: %w", tenantID, err)
+	}
+
+	r.tenantR.Status.AccountPlan = desiredPlan.SystemName
+
 	return nil
 }
+
+// reconcileTenantSecretAdminURL keeps the admin URL of the tenant secret in sync with the admin domain.
+// The access token is only available when the tenant is created, so the secret is not created here
+func (r *TenantThreescaleReconciler) reconcileTenantSecretAdminURL(tenantDef *porta_client_pkg.Tenant) error {
+	adminURL, err := controllerhelper.URLFromDomain(tenantDef.Signup.Account.AdminDomain)
+	if err != nil {
+		return err
+	}
+
+	tenantSecret := &v1.Secret{}
+	err = r.Client().Get(r.Context(), r.tenantR.TenantSecretKey(), tenantSecret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if string(tenantSecret.Data[TenantAdminDomainKeySecretField]) == adminURL.String() {
+		return nil
+	}
+
+	if tenantSecret.Data == nil {
+		tenantSecret.Data = map[string][]byte{}
+	}
+	tenantSecret.Data[TenantAdminDomainKeySecretField] = []byte(adminURL.String())
+
+	r.logger.Info("Update tenant secret admin URL", "secret", r.tenantR.TenantSecretKey(), "adminURL", adminURL.String())
+	return r.UpdateResource(tenantSecret)
+}
@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// tenantMasterAPIHandler fakes the master API endpoints of a tenant, recording the update requests
+type tenantMasterAPIHandler struct {
+	account     threescaleapi.Account
+	accountPlan string
+	updates     []url.Values
+	planUpgrade string
+}
+
+func (h *tenantMasterAPIHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	writeJSON := func(obj interface{}) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(obj)
+	}
+
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == "/master/api/providers/3.json":
+		writeJSON(threescaleapi.Tenant{Signup: threescaleapi.Signup{Account: h.account}})
+	case req.Method == http.MethodDelete && req.URL.Path == "/master/api/providers/3.json":
+		h.account.State = capabilitiesv1beta1.TenantStateScheduledForDeletion
+		writeJSON(map[string]string{})
+	case req.Method == http.MethodPut && req.URL.Path == "/master/api/providers/3.json":
+		_ = req.ParseForm()
+		h.updates = append(h.updates, req.PostForm)
+		switch req.PostForm.Get("state_event") {
+		case "suspend":
+			h.account.State = capabilitiesv1beta1.TenantStateSuspended
+		case "resume":
+			h.account.State = capabilitiesv1beta1.TenantStateApproved
+		}
+		if domain := req.PostForm.Get("admin_domain"); domain != "" {
+			h.account.AdminDomain = domain
+		}
+		if domain := req.PostForm.Get("domain"); domain != "" {
+			h.account.Domain = domain
+		}
+		writeJSON(threescaleapi.Tenant{Signup: threescaleapi.Signup{Account: h.account}})
+	case req.Method == http.MethodGet && req.URL.Path == "/admin/api/accounts/3/plan.json":
+		writeJSON(controllerhelper.AccountPlan{Element: controllerhelper.PlanItem{ID: 1, SystemName: h.accountPlan}})
+	case req.Method == http.MethodGet && req.URL.Path == "/admin/api/account_plans.json":
+		writeJSON(controllerhelper.AccountPlanList{Plans: []controllerhelper.AccountPlan{
+			{Element: controllerhelper.PlanItem{ID: 1, SystemName: "basic"}},
+			{Element: controllerhelper.PlanItem{ID: 2, SystemName: "enterprise"}},
+		}})
+	case req.Method == http.MethodPut && req.URL.Path == "/master/api/providers/3/plan_upgrade.json":
+		_ = req.ParseForm()
+		h.planUpgrade = req.PostForm.Get("plan_id")
+		writeJSON(map[string]string{})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func getTenantCR() *capabilitiesv1beta1.Tenant {
+	return &capabilitiesv1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "test"},
+		Spec: capabilitiesv1beta1.TenantSpec{
+			Username:         "admin",
+			Email:            "admin@example.com",
+			OrganizationName: "Example",
+			SystemMasterUrl:  "https://master.example.com",
+			TenantSecretRef:  corev1.SecretReference{Name: "example-tenant", Namespace: "test"},
+		},
+		Status: capabilitiesv1beta1.TenantStatus{TenantId: 3, AdminId: 4},
+	}
+}
+
+func newTestTenantThreescaleReconciler(t *testing.T, tenantCR *capabilitiesv1beta1.Tenant, handler http.Handler) *TenantThreescaleReconciler {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ap, err := threescaleapi.NewAdminPortalFromStr(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseReconciler := getOpenAPIBaseReconciler(tenantCR)
+	return NewTenantThreescaleReconciler(baseReconciler, tenantCR,
+		threescaleapi.NewThreeScale(ap, "test", srv.Client()),
+		controllerhelper.NewAdminAPIClient(adminURL, "test", srv.Client()),
+		baseReconciler.Logger())
+}
+
+func TestTenantThreescaleReconciler_SetUpdateTenantInfo(t *testing.T) {
+	tests := []struct {
+		name            string
+		account         threescaleapi.Account
+		spec            func(*capabilitiesv1beta1.TenantSpec)
+		wantUpdate      bool
+		wantParams      map[string]string
+		wantState       string
+		wantAdminDomain string
+	}{
+		{
+			name: "in sync",
+			account: threescaleapi.Account{ID: 3, State: capabilitiesv1beta1.TenantStateApproved, OrgName: "Example",
+				AdminDomain: "example-admin.3scale.net", Annotations: map[string]string{"managed_by": "operator"}},
+			spec:            func(spec *capabilitiesv1beta1.TenantSpec) {},
+			wantState:       capabilitiesv1beta1.TenantStateApproved,
+			wantAdminDomain: "example-admin.3scale.net",
+		},
+		{
+			name: "suspend",
+			account: threescaleapi.Account{ID: 3, State: capabilitiesv1beta1.TenantStateApproved, OrgName: "Example",
+				Annotations: map[string]string{"managed_by": "operator"}},
+			spec:       func(spec *capabilitiesv1beta1.TenantSpec) { spec.Suspended = ptr.To(true) },
+			wantUpdate: true,
+			wantParams: map[string]string{"state_event": "suspend"},
+			wantState:  capabilitiesv1beta1.TenantStateSuspended,
+		},
+		{
+			name: "resume",
+			account: threescaleapi.Account{ID: 3, State: capabilitiesv1beta1.TenantStateSuspended, OrgName: "Example",
+				Annotations: map[string]string{"managed_by": "operator"}},
+			spec:       func(spec *capabilitiesv1beta1.TenantSpec) { spec.Suspended = ptr.To(false) },
+			wantUpdate: true,
+			wantParams: map[string]string{"state_event": "resume"},
+			wantState:  capabilitiesv1beta1.TenantStateApproved,
+		},
+		{
+			name: "organization name and domains",
+			account: threescaleapi.Account{ID: 3, State: capabilitiesv1beta1.TenantStateApproved, OrgName: "Old",
+				AdminDomain: "old-admin.3scale.net", Domain: "old.3scale.net", Annotations: map[string]string{"managed_by": "operator"}},
+			spec: func(spec *capabilitiesv1beta1.TenantSpec) {
+				spec.AdminDomain = ptr.To("admin.example.com")
+				spec.DeveloperDomain = ptr.To("developer.example.com")
+			},
+			wantUpdate:      true,
+			wantParams:      map[string]string{"org_name": "Example", "admin_domain": "admin.example.com", "domain": "developer.example.com"},
+			wantState:       capabilitiesv1beta1.TenantStateApproved,
+			wantAdminDomain: "admin.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			tenantCR := getTenantCR()
+			tt.spec(&tenantCR.Spec)
+			handler := &tenantMasterAPIHandler{account: tt.account}
+			r := newTestTenantThreescaleReconciler(subT, tenantCR, handler)
+
+			tenantDef, err := r.SetUpdateTenantInfo(&threescaleapi.Tenant{Signup: threescaleapi.Signup{Account: tt.account}})
+			if err != nil {
+				subT.Fatalf("SetUpdateTenantInfo() error = %v", err)
+			}
+
+			if (len(handler.updates) > 0) != tt.wantUpdate {
+				subT.Fatalf("tenant updated = %v, want %v", handler.updates, tt.wantUpdate)
+			}
+			for key, value := range tt.wantParams {
+				if got := handler.updates[0].Get(key); got != value {
+					subT.Errorf("update param %s = %q, want %q", key, got, value)
+				}
+			}
+			if tenantDef.Signup.Account.State != tt.wantState {
+				subT.Errorf("tenant state = %s, want %s", tenantDef.Signup.Account.State, tt.wantState)
+			}
+			if tenantDef.Signup.Account.AdminDomain != tt.wantAdminDomain {
+				subT.Errorf("tenant admin domain = %s, want %s", tenantDef.Signup.Account.AdminDomain, tt.wantAdminDomain)
+			}
+		})
+	}
+}
+
+func TestTenantThreescaleReconciler_reconcileAccountPlan(t *testing.T) {
+	tenantCR := getTenantCR()
+	tenantCR.Spec.AccountPlan = ptr.To("enterprise")
+	handler := &tenantMasterAPIHandler{accountPlan: "basic"}
+	r := newTestTenantThreescaleReconciler(t, tenantCR, handler)
+
+	err := r.reconcileAccountPlan(3)
+	if err != nil {
+		t.Fatalf("reconcileAccountPlan() error = %v", err)
+	}
+	if handler.planUpgrade != "2" {
+		t.Errorf("plan upgrade plan_id = %q, want 2", handler.planUpgrade)
+	}
+	if tenantCR.Status.AccountPlan != "enterprise" {
+		t.Errorf("status account plan = %s, want enterprise", tenantCR.Status.AccountPlan)
+	}
+
+	// unknown account plan
+	tenantCR.Spec.AccountPlan = ptr.To("unknown")
+	err = r.reconcileAccountPlan(3)
+	if !helper.IsInvalidSpecError(err) {
+		t.Errorf("reconcileAccountPlan() error = %v, want invalid spec error", err)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TenantMessagingReconciler reconciles a TenantMessaging object
+type TenantMessagingReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that TenantMessagingReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &TenantMessagingReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=tenantmessagings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=tenantmessagings/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=tenantmessagings/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *TenantMessagingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Logger().WithValues("tenantmessaging", req.NamespacedName)
+	reqLogger.Info("Reconcile TenantMessaging", "Operator version", version.Version)
+
+	// Fetch the instance
+	messagingCR := &capabilitiesv1beta1.TenantMessaging{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, messagingCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// The email templates and notification preferences are kept.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(messagingCR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// Ignore deleted resource, the email templates and notification preferences are kept
+	if messagingCR.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcileSpec(messagingCR, reqLogger)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile tenant messaging: %v. Failed to update status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to update tenant messaging status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(messagingCR, corev1.EventTypeWarning, "Invalid tenant messaging spec", "%v", reconcileErr)
+
+			// On spec validation error, no need to retry as spec is not valid and needs to be changed
+			return ctrl.Result{}, nil
+		}
+
+		if helper.IsWaitError(reconcileErr) {
+			// On wait error, retry
+			reqLogger.Info("retrying", "reason", reconcileErr)
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(messagingCR, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *TenantMessagingReconciler) reconcileSpec(messagingCR *capabilitiesv1beta1.TenantMessaging, logger logr.Logger) (*TenantMessagingStatusReconciler, error) {
+	err := r.validateSpec(messagingCR)
+	if err != nil {
+		statusReconciler := NewTenantMessagingStatusReconciler(r.BaseReconciler, messagingCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), messagingCR.Namespace, messagingCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		statusReconciler := NewTenantMessagingStatusReconciler(r.BaseReconciler, messagingCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(messagingCR.GetAnnotations())
+	adminAPIClient, err := controllerhelper.AdminClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewTenantMessagingStatusReconciler(r.BaseReconciler, messagingCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewTenantMessagingThreescaleReconciler(r.BaseReconciler, messagingCR, adminAPIClient, providerAccount.AdminURLStr, logger)
+	emailTemplates, err := reconciler.Reconcile()
+
+	statusReconciler := NewTenantMessagingStatusReconciler(r.BaseReconciler, messagingCR, providerAccount.AdminURLStr, emailTemplates, err)
+	return statusReconciler, err
+}
+
+func (r *TenantMessagingReconciler) validateSpec(resource *capabilitiesv1beta1.TenantMessaging) error {
+	errors := field.ErrorList{}
+	errors = append(errors, resource.Validate()...)
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: errors,
+	}
+}
+
+func (r *TenantMessagingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	configMapToTenantMessagingEventMapper := &ConfigMapToTenantMessagingEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("configMapToTenantMessagingEventMapper"),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.TenantMessaging{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(configMapToTenantMessagingEventMapper.Map)).
+		Complete(r)
+}
@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type TenantMessagingThreescaleReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.TenantMessaging
+	adminAPIClient      *controllerhelper.AdminAPIClient
+	providerAccountHost string
+	logger              logr.Logger
+}
+
+func NewTenantMessagingThreescaleReconciler(b *reconcilers.BaseReconciler,
+	resource *capabilitiesv1beta1.TenantMessaging,
+	adminAPIClient *controllerhelper.AdminAPIClient,
+	providerAccountHost string,
+	logger logr.Logger,
+) *TenantMessagingThreescaleReconciler {
+	return &TenantMessagingThreescaleReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		adminAPIClient:      adminAPIClient,
+		providerAccountHost: providerAccountHost,
+		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
+	}
+}
+
+// Reconcile synchronizes the email templates and the notification preferences.
+// Returns the synchronized email templates
+func (s *TenantMessagingThreescaleReconciler) Reconcile() ([]capabilitiesv1beta1.TenantMessagingEmailTemplateStatus, error) {
+	s.logger.V(1).Info("START")
+
+	emailTemplates, err := s.syncEmailTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.syncNotificationPreferences()
+	if err != nil {
+		return nil, err
+	}
+
+	return emailTemplates, nil
+}
+
+func (s *TenantMessagingThreescaleReconciler) syncEmailTemplates() ([]capabilitiesv1beta1.TenantMessagingEmailTemplateStatus, error) {
+	templates, err := s.adminAPIClient.ListCMSTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	remote := map[string]*controllerhelper.CMSTemplate{}
+	for idx := range templates {
+		if templates[idx].Type == controllerhelper.CMSTemplateTypeEmailTemplate {
+			remote[templates[idx].SystemName] = &templates[idx]
+		}
+	}
+
+	emailTemplatesFldPath := field.NewPath("spec").Child("emailTemplates")
+	synced := []capabilitiesv1beta1.TenantMessagingEmailTemplateStatus{}
+	for idx := range s.resource.Spec.EmailTemplates {
+		desired := &s.resource.Spec.EmailTemplates[idx]
+		body, err := s.emailTemplateBody(desired, emailTemplatesFldPath.Index(idx))
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := s.syncEmailTemplate(desired, body, remote[desired.SystemName])
+		if err != nil {
+			return nil, err
+		}
+
+		synced = append(synced, capabilitiesv1beta1.TenantMessagingEmailTemplateStatus{SystemName: desired.SystemName, ID: template.ID})
+	}
+
+	// templates removed from the spec are restored to the 3scale default
+	for _, previous := range s.resource.Status.EmailTemplates {
+		if slices.ContainsFunc(synced, func(t capabilitiesv1beta1.TenantMessagingEmailTemplateStatus) bool {
+			return t.SystemName == previous.SystemName
+		}) {
+			continue
+		}
+
+		s.logger.Info("deleting email template", "systemName", previous.SystemName, "ID", previous.ID)
+		err := s.adminAPIClient.DeleteCMSTemplate(previous.ID)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return nil, err
+		}
+	}
+
+	return synced, nil
+}
+
+func (s *TenantMessagingThreescaleReconciler) syncEmailTemplate(desired *capabilitiesv1beta1.TenantMessagingEmailTemplateSpec, body string, remote *controllerhelper.CMSTemplate) (*controllerhelper.CMSTemplate, error) {
+	desiredHeaders := controllerhelper.CMSEmailTemplateHeaders{
+		Subject: desired.Subject,
+		From:    desired.From,
+		ReplyTo: desired.ReplyTo,
+		Cc:      desired.Cc,
+		Bcc:     desired.Bcc,
+	}
+
+	headerParams := threescaleapi.Params{
+		"headers[subject]":  desiredHeaders.Subject,
+		"headers[from]":     desiredHeaders.From,
+		"headers[reply_to]": desiredHeaders.ReplyTo,
+		"headers[cc]":       desiredHeaders.Cc,
+		"headers[bcc]":      desiredHeaders.Bcc,
+	}
+
+	template := remote
+	var err error
+	if template == nil {
+		s.logger.Info("creating email template", "systemName", desired.SystemName)
+		params := threescaleapi.Params{
+			"type":        controllerhelper.CMSTemplateTypeEmailTemplate,
+			"system_name": desired.SystemName,
+			"draft":       body,
+		}
+		for k, v := range headerParams {
+			params[k] = v
+		}
+		template, err = s.adminAPIClient.CreateCMSTemplate(params)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		params := threescaleapi.Params{}
+		current := template.Draft
+		if current == "" {
+			current = template.Published
+		}
+		if current != body {
+			params["draft"] = body
+		}
+		if template.Headers == nil || *template.Headers != desiredHeaders {
+			for k, v := range headerParams {
+				params[k] = v
+			}
+		}
+
+		if len(params) > 0 {
+			s.logger.Info("updating email template", "systemName", desired.SystemName, "ID", template.ID)
+			template, err = s.adminAPIClient.UpdateCMSTemplate(template.ID, params)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// email templates are only sent once published
+	if template.Published != body {
+		s.logger.Info("publishing email template", "systemName", desired.SystemName, "ID", template.ID)
+		template, err = s.adminAPIClient.PublishCMSTemplate(template.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return template, nil
+}
+
+// emailTemplateBody returns the body of the email template, read from the ConfigMap when referenced
+func (s *TenantMessagingThreescaleReconciler) emailTemplateBody(desired *capabilitiesv1beta1.TenantMessagingEmailTemplateSpec, fldPath *field.Path) (string, error) {
+	if desired.BodyRef == nil {
+		return desired.Body, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := s.Client().Get(s.Context(), types.NamespacedName{Name: desired.BodyRef.Name, Namespace: s.resource.Namespace}, configMap)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return "", &helper.WaitError{Err: fmt.Errorf("%s: ConfigMap %s not found", fldPath.Child("bodyRef"), desired.BodyRef.Name)}
+		}
+		return "", err
+	}
+
+	body, ok := configMap.Data[desired.BodyRef.Key]
+	if !ok {
+		return "", &helper.WaitError{Err: fmt.Errorf("%s: ConfigMap %s does not have the key %s", fldPath.Child("bodyRef"), desired.BodyRef.Name, desired.BodyRef.Key)}
+	}
+
+	return body, nil
+}
+
+func (s *TenantMessagingThreescaleReconciler) syncNotificationPreferences() error {
+	spec := s.resource.Spec.NotificationPreferences
+	if spec == nil {
+		return nil
+	}
+
+	userList, err := s.adminAPIClient.ListProviderUsers()
+	if err != nil {
+		return err
+	}
+
+	userIDs := []int64{}
+	if len(spec.Users) == 0 {
+		for _, user := range userList.Users {
+			if user.Element.Role == controllerhelper.ProviderUserRoleAdmin {
+				userIDs = append(userIDs, user.Element.ID)
+			}
+		}
+	}
+
+	for _, username := range spec.Users {
+		idx := slices.IndexFunc(userList.Users, func(user controllerhelper.ProviderUser) bool {
+			return user.Element.Username == username
+		})
+		if idx < 0 {
+			return &helper.WaitError{Err: fmt.Errorf("provider user %s not found", username)}
+		}
+		userIDs = append(userIDs, userList.Users[idx].Element.ID)
+	}
+
+	for _, userID := range userIDs {
+		err := s.syncUserNotificationPreferences(userID, spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TenantMessagingThreescaleReconciler) syncUserNotificationPreferences(userID int64, spec *capabilitiesv1beta1.TenantMessagingNotificationPreferencesSpec) error {
+	current, err := s.adminAPIClient.ReadNotificationPreferences(userID)
+	if err != nil {
+		return err
+	}
+
+	fldPath := field.NewPath("spec").Child("notificationPreferences")
+	fieldErrors := field.ErrorList{}
+	changes := map[string]bool{}
+	for _, desired := range []struct {
+		fieldName     string
+		notifications []string
+		enabled       bool
+	}{
+		{"enabled", spec.Enabled, true},
+		{"disabled", spec.Disabled, false},
+	} {
+		for idx, notification := range desired.notifications {
+			enabled, ok := current.Element.Preferences[notification]
+			if !ok {
+				fieldErrors = append(fieldErrors, field.NotSupported(fldPath.Child(desired.fieldName).Index(idx), notification, slices.Sorted(maps.Keys(current.Element.Preferences))))
+				continue
+			}
+			if enabled != desired.enabled {
+				changes[notification] = desired.enabled
+			}
+		}
+	}
+
+	// Unknown notifications are reported, not ignored
+	if len(fieldErrors) > 0 {
+		return &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	s.logger.Info("updating notification preferences", "userID", userID, "changes", changes)
+	_, err = s.adminAPIClient.UpdateNotificationPreferences(userID, changes)
+	return err
+}
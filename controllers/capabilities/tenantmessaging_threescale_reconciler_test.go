@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// messagingAPIHandler fakes the provider users and notification preferences endpoints,
+// and the CMS endpoints for email templates
+type messagingAPIHandler struct {
+	*cmsAPIHandler
+	users       []controllerhelper.ProviderUser
+	preferences map[string]map[string]bool
+	updates     map[string]url.Values
+}
+
+func newMessagingAPIHandler() *messagingAPIHandler {
+	return &messagingAPIHandler{
+		cmsAPIHandler: newCMSAPIHandler(),
+		users: []controllerhelper.ProviderUser{
+			{Element: controllerhelper.ProviderUserItem{ID: 1, Username: "admin", Role: controllerhelper.ProviderUserRoleAdmin}},
+			{Element: controllerhelper.ProviderUserItem{ID: 7, Username: "analyst", Role: controllerhelper.ProviderUserRoleMember}},
+		},
+		preferences: map[string]map[string]bool{
+			"1": {"account_created": false, "application_created": true},
+			"7": {"account_created": false, "application_created": true},
+		},
+		updates: map[string]url.Values{},
+	}
+}
+
+func (h *messagingAPIHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	writeJSON := func(code int, obj interface{}) {
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(obj)
+	}
+
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/admin/api/cms/"):
+		h.cmsAPIHandler.ServeHTTP(w, req)
+	case req.URL.Path == "/admin/api/users.json":
+		writeJSON(http.StatusOK, controllerhelper.ProviderUserList{Users: h.users})
+	case strings.HasSuffix(req.URL.Path, "/notification_preferences.json"):
+		userID := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/admin/api/users/"), "/notification_preferences.json")
+		preferences, ok := h.preferences[userID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Method == http.MethodPut {
+			_ = req.ParseForm()
+			h.updates[userID] = req.PostForm
+		}
+		writeJSON(http.StatusOK, controllerhelper.NotificationPreferences{Element: controllerhelper.NotificationPreferencesItem{Preferences: preferences}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func getTenantMessagingCR() *capabilitiesv1beta1.TenantMessaging {
+	return &capabilitiesv1beta1.TenantMessaging{
+		ObjectMeta: metav1.ObjectMeta{Name: "messaging", Namespace: "test"},
+		Spec: capabilitiesv1beta1.TenantMessagingSpec{
+			EmailTemplates: []capabilitiesv1beta1.TenantMessagingEmailTemplateSpec{
+				{SystemName: "account_approved", Subject: "Welcome", Body: "Your account is approved"},
+				{SystemName: "application_key_created", BodyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "emails"}, Key: "key_created.liquid",
+				}},
+			},
+		},
+	}
+}
+
+func newTestTenantMessagingThreescaleReconciler(t *testing.T, messagingCR *capabilitiesv1beta1.TenantMessaging, handler http.Handler) *TenantMessagingThreescaleReconciler {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	adminURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emails := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "emails", Namespace: "test"},
+		Data:       map[string]string{"key_created.liquid": "New key {{ application.name }}"},
+	}
+
+	baseReconciler := getOpenAPIBaseReconciler(messagingCR, emails)
+	return NewTenantMessagingThreescaleReconciler(baseReconciler, messagingCR,
+		controllerhelper.NewAdminAPIClient(adminURL, "test", srv.Client()),
+		srv.URL, baseReconciler.Logger())
+}
+
+func TestTenantMessagingThreescaleReconciler_EmailTemplates(t *testing.T) {
+	messagingCR := getTenantMessagingCR()
+	handler := newMessagingAPIHandler()
+	r := newTestTenantMessagingThreescaleReconciler(t, messagingCR, handler)
+
+	emailTemplates, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if len(emailTemplates) != 2 {
+		t.Fatalf("unexpected email templates: %v", emailTemplates)
+	}
+
+	approved := handler.templates[emailTemplates[0].ID]
+	if approved.Type != controllerhelper.CMSTemplateTypeEmailTemplate || approved.Published != "Your account is approved" || approved.Headers.Subject != "Welcome" {
+		t.Errorf("unexpected account_approved template: %+v", approved)
+	}
+	if keyCreated := handler.templates[emailTemplates[1].ID]; keyCreated.Published != "New key {{ application.name }}" {
+		t.Errorf("body not read from the ConfigMap: %+v", keyCreated)
+	}
+
+	// in sync
+	messagingCR.Status.EmailTemplates = emailTemplates
+	handler.requests = nil
+	_, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(handler.requests) != 0 {
+		t.Errorf("unexpected requests: %v", handler.requests)
+	}
+
+	// removed templates are restored to the default
+	messagingCR.Spec.EmailTemplates = messagingCR.Spec.EmailTemplates[1:]
+	emailTemplates, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(emailTemplates) != 1 || handler.templates[approved.ID] != nil {
+		t.Errorf("account_approved template not deleted: %v", handler.requests)
+	}
+}
+
+func TestTenantMessagingThreescaleReconciler_NotificationPreferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        *capabilitiesv1beta1.TenantMessagingNotificationPreferencesSpec
+		wantUpdates map[string]url.Values
+		wantInvalid bool
+		wantWait    bool
+	}{
+		{
+			name: "admin users by default",
+			spec: &capabilitiesv1beta1.TenantMessagingNotificationPreferencesSpec{
+				Enabled: []string{"account_created", "application_created"},
+			},
+			wantUpdates: map[string]url.Values{"1": {"preferences[account_created]": {"true"}}},
+		},
+		{
+			name: "selected users",
+			spec: &capabilitiesv1beta1.TenantMessagingNotificationPreferencesSpec{
+				Users:    []string{"analyst"},
+				Disabled: []string{"application_created"},
+			},
+			wantUpdates: map[string]url.Values{"7": {"preferences[application_created]": {"false"}}},
+		},
+		{
+			name: "unknown notification",
+			spec: &capabilitiesv1beta1.TenantMessagingNotificationPreferencesSpec{
+				Enabled: []string{"unknown"},
+			},
+			wantUpdates: map[string]url.Values{},
+			wantInvalid: true,
+		},
+		{
+			name: "unknown user",
+			spec: &capabilitiesv1beta1.TenantMessagingNotificationPreferencesSpec{
+				Users:   []string{"other"},
+				Enabled: []string{"account_created"},
+			},
+			wantUpdates: map[string]url.Values{},
+			wantWait:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			messagingCR := getTenantMessagingCR()
+			messagingCR.Spec.EmailTemplates = nil
+			messagingCR.Spec.NotificationPreferences = tt.spec
+			handler := newMessagingAPIHandler()
+			r := newTestTenantMessagingThreescaleReconciler(subT, messagingCR, handler)
+
+			_, err := r.Reconcile()
+			if helper.IsInvalidSpecError(err) != tt.wantInvalid || helper.IsWaitError(err) != tt.wantWait {
+				subT.Fatalf("Reconcile() error = %v", err)
+			}
+			if !tt.wantInvalid && !tt.wantWait && err != nil {
+				subT.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(handler.updates, tt.wantUpdates) {
+				subT.Errorf("updates = %v, want %v", handler.updates, tt.wantUpdates)
+			}
+		})
+	}
+}
@@ -269,6 +269,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The conversion webhook serves the deprecated v1alpha1 Tenant version.
+	// Disabled when running the operator locally, out of the cluster
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&capabilitiesv1beta1.Tenant{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Tenant")
+			os.Exit(1)
+		}
+	}
+
 	discoveryClientBackend, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to create discovery client")
@@ -452,6 +461,86 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Application")
 		os.Exit(1)
 	}
+	discoveryAccountPlan, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.AccountPlanReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("AccountPlan"),
+			discoveryAccountPlan,
+			mgr.GetEventRecorderFor("AccountPlan")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AccountPlan")
+		os.Exit(1)
+	}
+	discoveryProviderUser, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.ProviderUserReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("ProviderUser"),
+			discoveryProviderUser,
+			mgr.GetEventRecorderFor("ProviderUser")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProviderUser")
+		os.Exit(1)
+	}
+	discoveryDeveloperPortalContent, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.DeveloperPortalContentReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("DeveloperPortalContent"),
+			discoveryDeveloperPortalContent,
+			mgr.GetEventRecorderFor("DeveloperPortalContent")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DeveloperPortalContent")
+		os.Exit(1)
+	}
+	discoveryTenantMessaging, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.TenantMessagingReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("TenantMessaging"),
+			discoveryTenantMessaging,
+			mgr.GetEventRecorderFor("TenantMessaging")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TenantMessaging")
+		os.Exit(1)
+	}
+	discoveryDeveloperAccountSet, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.DeveloperAccountSetReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("DeveloperAccountSet"),
+			discoveryDeveloperAccountSet,
+			mgr.GetEventRecorderFor("DeveloperAccountSet")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DeveloperAccountSet")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
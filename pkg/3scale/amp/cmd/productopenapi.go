@@ -0,0 +1,174 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+var productOpenAPIIssuerEndpoint string
+
+var productOpenAPIProductName string
+
+var productOpenAPIOutput string
+
+// productOpenAPICmd represents the product-openapi command
+var productOpenAPICmd = &cobra.Command{
+	Use:   getProductOpenAPIUsage(),
+	Short: getProductOpenAPIShortDescription(),
+	Long:  getProductOpenAPILongDescription(),
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProductOpenAPICommand,
+}
+
+func getProductOpenAPIUsage() string {
+	return "product-openapi <manifests-file>"
+}
+
+func getProductOpenAPIShortDescription() string {
+	return "export a Product custom resource as an OpenAPI document"
+}
+
+func getProductOpenAPILongDescription() string {
+	return `export a Product custom resource as an OpenAPI document.
+The manifests file, or "-" for the standard input, contains the Product and, optionally, the Backend custom resources it uses.
+Paths are built from the mapping rules, security from the authentication settings,
+servers from the public base URLs and the x-3scale-product extension from metrics, policies and application plans.`
+}
+
+func runProductOpenAPICommand(cmd *cobra.Command, args []string) error {
+	if productOpenAPIOutput != "json" && productOpenAPIOutput != "yaml" {
+		return fmt.Errorf("unsupported output format %s, use json or yaml", productOpenAPIOutput)
+	}
+
+	reader := os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	products, backends, err := readProductManifests(reader)
+	if err != nil {
+		return err
+	}
+
+	var product *capabilitiesv1beta1.Product
+	for idx := range products {
+		if productOpenAPIProductName == "" || products[idx].Name == productOpenAPIProductName {
+			if product != nil {
+				return errors.New("the manifests contain more than one product, select one with the --product flag")
+			}
+			product = &products[idx]
+		}
+	}
+	if product == nil {
+		return errors.New("product not found in the manifests")
+	}
+
+	issuerEndpoint := productOpenAPIIssuerEndpoint
+	if issuerEndpoint == "" && product.Spec.OIDCSpec() != nil {
+		issuerEndpoint = product.Spec.OIDCSpec().IssuerEndpoint
+	}
+
+	product.SetDefaults(logr.Discard())
+
+	openapiObj, err := controllerhelper.ProductToOpenAPI(product, backends, issuerEndpoint)
+	if err != nil {
+		return err
+	}
+
+	document, err := openapiObj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	if productOpenAPIOutput == "yaml" {
+		document, err = yaml.JSONToYAML(document)
+		if err != nil {
+			return err
+		}
+	} else {
+		document = append(document, '\n')
+	}
+
+	_, err = os.Stdout.Write(document)
+	return err
+}
+
+// readProductManifests reads the products and backends of the manifests stream
+func readProductManifests(reader io.Reader) ([]capabilitiesv1beta1.Product, []capabilitiesv1beta1.Backend, error) {
+	products := []capabilitiesv1beta1.Product{}
+	backends := []capabilitiesv1beta1.Backend{}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		raw := runtime.RawExtension{}
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+
+		typeMeta := runtime.TypeMeta{}
+		if err := json.Unmarshal(raw.Raw, &typeMeta); err != nil {
+			return nil, nil, err
+		}
+
+		switch typeMeta.Kind {
+		case "Product":
+			product := capabilitiesv1beta1.Product{}
+			if err := json.Unmarshal(raw.Raw, &product); err != nil {
+				return nil, nil, err
+			}
+			products = append(products, product)
+		case "Backend":
+			backend := capabilitiesv1beta1.Backend{}
+			if err := json.Unmarshal(raw.Raw, &backend); err != nil {
+				return nil, nil, err
+			}
+			backend.SetDefaults(logr.Discard())
+			backends = append(backends, backend)
+		}
+	}
+
+	return products, backends, nil
+}
+
+func init() {
+	productOpenAPICmd.Flags().StringVar(&productOpenAPIIssuerEndpoint, "issuer-endpoint", "", "OpenID Connect issuer endpoint, required when the product issuer endpoint is read from a secret")
+	productOpenAPICmd.Flags().StringVar(&productOpenAPIProductName, "product", "", "Name of the product to export when the manifests contain more than one")
+	productOpenAPICmd.Flags().StringVarP(&productOpenAPIOutput, "output", "o", "yaml", "Output format: json or yaml")
+	rootCmd.AddCommand(productOpenAPICmd)
+}
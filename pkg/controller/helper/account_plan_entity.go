@@ -0,0 +1,129 @@
+package helper
+
+import (
+	"fmt"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+
+	"github.com/go-logr/logr"
+)
+
+type AccountPlanEntity struct {
+	client   *AdminAPIClient
+	obj      PlanItem
+	features *FeatureList
+	logger   logr.Logger
+}
+
+func NewAccountPlanEntity(obj PlanItem, cl *AdminAPIClient, logger logr.Logger) *AccountPlanEntity {
+	return &AccountPlanEntity{
+		obj:    obj,
+		client: cl,
+		logger: logger.WithValues("AccountPlanEntity", obj.ID),
+	}
+}
+
+func (b *AccountPlanEntity) ID() int64 {
+	return b.obj.ID
+}
+
+func (b *AccountPlanEntity) Name() string {
+	return b.obj.Name
+}
+
+func (b *AccountPlanEntity) SystemName() string {
+	return b.obj.SystemName
+}
+
+func (b *AccountPlanEntity) ApprovalRequired() bool {
+	return b.obj.ApprovalRequired
+}
+
+func (b *AccountPlanEntity) TrialPeriodDays() int {
+	return b.obj.TrialPeriodDays
+}
+
+func (b *AccountPlanEntity) SetupFee() float64 {
+	return b.obj.SetupFee
+}
+
+func (b *AccountPlanEntity) CostPerMonth() float64 {
+	return b.obj.CostPerMonth
+}
+
+func (b *AccountPlanEntity) State() string {
+	return b.obj.State
+}
+
+func (b *AccountPlanEntity) IsDefault() bool {
+	return b.obj.Default
+}
+
+func (b *AccountPlanEntity) Update(params threescaleapi.Params) error {
+	b.logger.V(1).Info("Update", "params", params)
+	updated, err := b.client.UpdateAccountPlan(b.obj.ID, params)
+	if err != nil {
+		return fmt.Errorf("account plan [%s] update: %w", b.obj.SystemName, err)
+	}
+
+	b.obj = updated.Element
+
+	return nil
+}
+
+func (b *AccountPlanEntity) SetDefault() error {
+	b.logger.V(1).Info("SetDefault")
+	updated, err := b.client.SetDefaultAccountPlan(b.obj.ID)
+	if err != nil {
+		return fmt.Errorf("account plan [%s] set default: %w", b.obj.SystemName, err)
+	}
+
+	b.obj = updated.Element
+
+	return nil
+}
+
+func (b *AccountPlanEntity) Features() (*FeatureList, error) {
+	if b.features == nil {
+		features, err := b.getFeatures()
+		if err != nil {
+			return nil, err
+		}
+		b.features = features
+	}
+	return b.features, nil
+}
+
+func (b *AccountPlanEntity) getFeatures() (*FeatureList, error) {
+	b.logger.V(1).Info("getFeatures")
+	list, err := b.client.ListAccountPlanFeatures(b.obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("account plan [%s] get features: %w", b.obj.SystemName, err)
+	}
+
+	return list, nil
+}
+
+func (b *AccountPlanEntity) CreateFeature(featureID int64) error {
+	b.logger.V(1).Info("CreateFeature", "featureID", featureID)
+	err := b.client.CreateAccountPlanFeature(b.obj.ID, featureID)
+	if err != nil {
+		return fmt.Errorf("account plan [%s] create feature: %w", b.obj.SystemName, err)
+	}
+	b.resetFeatures()
+	return nil
+}
+
+func (b *AccountPlanEntity) DeleteFeature(featureID int64) error {
+	b.logger.V(1).Info("DeleteFeature", "featureID", featureID)
+	err := b.client.DeleteAccountPlanFeature(b.obj.ID, featureID)
+	if err != nil {
+		return fmt.Errorf("account plan [%s] delete feature: %w", b.obj.SystemName, err)
+	}
+	b.resetFeatures()
+	return nil
+}
+
+func (b *AccountPlanEntity) resetFeatures() {
+	b.features = nil
+}
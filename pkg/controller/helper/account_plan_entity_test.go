@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+
+	"github.com/go-logr/logr"
+)
+
+func TestAccountPlanEntityBasics(t *testing.T) {
+	planItem := PlanItem{
+		ID:               4567,
+		Name:             "some plan",
+		SystemName:       "some_plan",
+		ApprovalRequired: true,
+		TrialPeriodDays:  3,
+		SetupFee:         5.67,
+		CostPerMonth:     8.67,
+		State:            "published",
+	}
+
+	planEntity := NewAccountPlanEntity(planItem, nil, logr.Discard())
+	equals(t, planEntity.ID(), planItem.ID)
+	equals(t, planEntity.Name(), planItem.Name)
+	equals(t, planEntity.SystemName(), planItem.SystemName)
+	equals(t, planEntity.ApprovalRequired(), planItem.ApprovalRequired)
+	equals(t, planEntity.TrialPeriodDays(), planItem.TrialPeriodDays)
+	equals(t, planEntity.SetupFee(), planItem.SetupFee)
+	equals(t, planEntity.CostPerMonth(), planItem.CostPerMonth)
+	equals(t, planEntity.State(), planItem.State)
+	equals(t, planEntity.IsDefault(), false)
+}
+
+func TestAccountPlanEntityUpdate(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPut, req.Method)
+		equals(t, "/admin/api/account_plans/4567.json", req.URL.Path)
+
+		respObject := AccountPlan{
+			Element: PlanItem{
+				ID:              4567,
+				Name:            "new name",
+				SystemName:      "some_plan",
+				TrialPeriodDays: 5,
+				State:           "published",
+			},
+		}
+
+		responseBodyBytes, err := json.Marshal(respObject)
+		ok(t, err)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBodyBytes)),
+			Header:     make(http.Header),
+		}
+	})
+
+	planEntity := NewAccountPlanEntity(PlanItem{ID: 4567}, client, logr.Discard())
+	err := planEntity.Update(threescaleapi.Params{"name": "new name"})
+	ok(t, err)
+	equals(t, planEntity.Name(), "new name")
+	equals(t, planEntity.TrialPeriodDays(), 5)
+	equals(t, planEntity.State(), "published")
+}
@@ -10,7 +10,9 @@ type ApplicationEntity struct {
 	ApplicationObj  *threescaleapi.Application
 	ApplicationList *threescaleapi.ApplicationList
 	*threescaleapi.ApplicationPlanJSONList
-	logger logr.Logger
+	// FieldsDefinitions fields definitions of the tenant, read when extra fields are managed
+	FieldsDefinitions *FieldsDefinitionList
+	logger            logr.Logger
 }
 
 func NewApplicationEntity(ApplicationObj *threescaleapi.Application, client *threescaleapi.ThreeScaleClient, logger logr.Logger) *ApplicationEntity {
@@ -48,3 +50,18 @@ func (b *ApplicationEntity) ProductId() int64 {
 func (b *ApplicationEntity) ApplicationState() string {
 	return b.ApplicationObj.State
 }
+
+// PlanSystemName returns the system name of the application plan, empty when the plan list is not available
+func (b *ApplicationEntity) PlanSystemName() string {
+	if b.ApplicationPlanJSONList == nil {
+		return ""
+	}
+
+	for _, plan := range b.ApplicationPlanJSONList.Plans {
+		if plan.Element.ID == b.PlanID() {
+			return plan.Element.SystemName
+		}
+	}
+
+	return ""
+}
@@ -11,18 +11,21 @@ import (
 type ApplicationPlanEntity struct {
 	productID    int64
 	client       *threescaleapi.ThreeScaleClient
+	adminClient  *AdminAPIClient
 	obj          threescaleapi.ApplicationPlanItem
 	limits       *threescaleapi.ApplicationPlanLimitList
 	pricingRules *threescaleapi.ApplicationPlanPricingRuleList
+	features     *FeatureList
 	logger       logr.Logger
 }
 
-func NewApplicationPlanEntity(productID int64, obj threescaleapi.ApplicationPlanItem, cl *threescaleapi.ThreeScaleClient, logger logr.Logger) *ApplicationPlanEntity {
+func NewApplicationPlanEntity(productID int64, obj threescaleapi.ApplicationPlanItem, cl *threescaleapi.ThreeScaleClient, adminCl *AdminAPIClient, logger logr.Logger) *ApplicationPlanEntity {
 	return &ApplicationPlanEntity{
-		productID: productID,
-		obj:       obj,
-		client:    cl,
-		logger:    logger.WithValues("ApplicationPlanEntity", obj.ID),
+		productID:   productID,
+		obj:         obj,
+		client:      cl,
+		adminClient: adminCl,
+		logger:      logger.WithValues("ApplicationPlanEntity", obj.ID),
 	}
 }
 
@@ -155,3 +158,48 @@ func (b *ApplicationPlanEntity) CreatePricingRule(metricID int64, params threesc
 func (b *ApplicationPlanEntity) resetPricingRules() {
 	b.pricingRules = nil
 }
+
+func (b *ApplicationPlanEntity) Features() (*FeatureList, error) {
+	if b.features == nil {
+		features, err := b.getFeatures()
+		if err != nil {
+			return nil, err
+		}
+		b.features = features
+	}
+	return b.features, nil
+}
+
+func (b *ApplicationPlanEntity) getFeatures() (*FeatureList, error) {
+	b.logger.V(1).Info("getFeatures")
+	list, err := b.adminClient.ListApplicationPlanFeatures(b.obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("application plan [%s] get features: %w", b.obj.SystemName, err)
+	}
+
+	return list, nil
+}
+
+func (b *ApplicationPlanEntity) CreateFeature(featureID int64) error {
+	b.logger.V(1).Info("CreateFeature", "featureID", featureID)
+	err := b.adminClient.CreateApplicationPlanFeature(b.obj.ID, featureID)
+	if err != nil {
+		return fmt.Errorf("application plan [%s] create feature: %w", b.obj.SystemName, err)
+	}
+	b.resetFeatures()
+	return nil
+}
+
+func (b *ApplicationPlanEntity) DeleteFeature(featureID int64) error {
+	b.logger.V(1).Info("DeleteFeature", "featureID", featureID)
+	err := b.adminClient.DeleteApplicationPlanFeature(b.obj.ID, featureID)
+	if err != nil {
+		return fmt.Errorf("application plan [%s] delete feature: %w", b.obj.SystemName, err)
+	}
+	b.resetFeatures()
+	return nil
+}
+
+func (b *ApplicationPlanEntity) resetFeatures() {
+	b.features = nil
+}
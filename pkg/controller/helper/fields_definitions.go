@@ -0,0 +1,77 @@
+package helper
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// FieldsValidator validates spec fields against the fields definitions of the tenant
+type FieldsValidator struct {
+	// Target is the fields definition target of the fields
+	Target string
+	// FldPath is the path of the spec fields
+	FldPath *field.Path
+	// BuiltinFields are fields managed by other spec fields.
+	// Map: field name -> spec field name
+	BuiltinFields map[string]string
+}
+
+// Validate returns the errors of the invalid fields, sorted by field name
+func (v *FieldsValidator) Validate(fields map[string]string, definitions *FieldsDefinitionList) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if fieldErr := v.validateField(name, fields[name], definitions); fieldErr != nil {
+			fieldErrors = append(fieldErrors, fieldErr)
+		}
+	}
+
+	return fieldErrors
+}
+
+// ValidFields returns the fields that are valid, invalid fields are skipped
+func (v *FieldsValidator) ValidFields(fields map[string]string, definitions *FieldsDefinitionList) map[string]string {
+	valid := map[string]string{}
+	for name, value := range fields {
+		if v.validateField(name, value, definitions) == nil {
+			valid[name] = value
+		}
+	}
+
+	return valid
+}
+
+func (v *FieldsValidator) validateField(name, value string, definitions *FieldsDefinitionList) *field.Error {
+	fldPath := v.FldPath.Key(name)
+
+	if specField, ok := v.BuiltinFields[name]; ok {
+		return field.Invalid(fldPath, value, fmt.Sprintf("%s is managed by spec.%s", name, specField))
+	}
+
+	for _, definition := range definitions.FieldsDefinitions {
+		if definition.Element.Target != v.Target || definition.Element.Name != name {
+			continue
+		}
+
+		if definition.Element.ReadOnly {
+			return field.Forbidden(fldPath, "field is read only")
+		}
+
+		if len(definition.Element.Choices) > 0 && !slices.Contains(definition.Element.Choices, value) {
+			return field.NotSupported(fldPath, value, definition.Element.Choices)
+		}
+
+		return nil
+	}
+
+	return field.NotFound(fldPath, "field is not defined in the fields definitions")
+}
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestFieldsValidator(t *testing.T) {
+	definitions := &FieldsDefinitionList{
+		FieldsDefinitions: []FieldsDefinition{
+			{Element: FieldsDefinitionItem{Target: FieldsDefinitionTargetAccount, Name: "org_name"}},
+			{Element: FieldsDefinitionItem{Target: FieldsDefinitionTargetAccount, Name: "partner_id"}},
+			{Element: FieldsDefinitionItem{Target: FieldsDefinitionTargetAccount, Name: "tier", Choices: []string{"gold", "silver"}}},
+			{Element: FieldsDefinitionItem{Target: FieldsDefinitionTargetAccount, Name: "internal_id", ReadOnly: true}},
+			{Element: FieldsDefinitionItem{Target: FieldsDefinitionTargetApplication, Name: "cost_centre"}},
+		},
+	}
+	validator := &FieldsValidator{
+		Target:        FieldsDefinitionTargetAccount,
+		FldPath:       field.NewPath("spec").Child("customFields"),
+		BuiltinFields: map[string]string{"org_name": "orgName"},
+	}
+
+	tests := []struct {
+		name    string
+		fields  map[string]string
+		wantErr string
+	}{
+		{"valid fields", map[string]string{"partner_id": "p-1", "tier": "gold"}, ""},
+		{"unknown field", map[string]string{"unknown": "value"}, "spec.customFields[unknown]: Not found"},
+		{"field of other target", map[string]string{"cost_centre": "value"}, "spec.customFields[cost_centre]: Not found"},
+		{"builtin field", map[string]string{"org_name": "value"}, "org_name is managed by spec.orgName"},
+		{"read only field", map[string]string{"internal_id": "value"}, "spec.customFields[internal_id]: Forbidden"},
+		{"value not in choices", map[string]string{"tier": "bronze"}, "spec.customFields[tier]: Unsupported value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			fieldErrors := validator.Validate(tt.fields, definitions)
+			validFields := validator.ValidFields(tt.fields, definitions)
+			if tt.wantErr == "" {
+				equals(subT, 0, len(fieldErrors))
+				equals(subT, tt.fields, validFields)
+				return
+			}
+			equals(subT, 1, len(fieldErrors))
+			assert(subT, strings.Contains(fieldErrors.ToAggregate().Error(), tt.wantErr), "unexpected error %v, want %s", fieldErrors, tt.wantErr)
+			equals(subT, map[string]string{}, validFields)
+		})
+	}
+}
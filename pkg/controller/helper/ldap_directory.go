@@ -0,0 +1,199 @@
+package helper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrDirectoryGroupNotFound is returned when the directory does not have the requested group
+var ErrDirectoryGroupNotFound = errors.New("group not found in directory")
+
+// ErrDirectoryMemberNotFound is returned when a member of the group is not an entry of the directory
+var ErrDirectoryMemberNotFound = errors.New("group member not found in directory")
+
+// DirectoryEntry is an entry of an LDAP directory. Attribute names are in lower case
+type DirectoryEntry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of the attribute, empty when not set
+func (e *DirectoryEntry) Attribute(name string) string {
+	values := e.Attributes[strings.ToLower(name)]
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Directory reads the members of LDAP groups
+type Directory interface {
+	// GroupMembers returns the entries of the members of the group, sorted by DN
+	GroupMembers(groupDN string) ([]DirectoryEntry, error)
+}
+
+// LDIFDirectory is a local stand-in of an LDAP server, loaded from an LDIF export of the directory.
+// Group members are read from the member, uniqueMember and memberUid attributes of the group entry
+type LDIFDirectory struct {
+	entries map[string]*DirectoryEntry
+}
+
+// blank assignment to verify that LDIFDirectory implements Directory
+var _ Directory = &LDIFDirectory{}
+
+// NewLDIFDirectory parses the LDIF export of a directory. Only entry records are supported
+func NewLDIFDirectory(data []byte) (*LDIFDirectory, error) {
+	directory := &LDIFDirectory{entries: map[string]*DirectoryEntry{}}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, " ") && len(lines) > 0:
+			// folded line
+			lines[len(lines)-1] += line[1:]
+		case strings.HasPrefix(line, "#"):
+			// comment
+		case strings.TrimSpace(line) == "":
+			if err := directory.addEntry(lines); err != nil {
+				return nil, err
+			}
+			lines = nil
+		default:
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := directory.addEntry(lines); err != nil {
+		return nil, err
+	}
+
+	return directory, nil
+}
+
+func (d *LDIFDirectory) addEntry(lines []string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	entry := &DirectoryEntry{Attributes: map[string][]string{}}
+	for idx, line := range lines {
+		name, value, err := parseLDIFLine(line)
+		if err != nil {
+			return err
+		}
+
+		// the version line may precede the first entry
+		if name == "version" && idx == 0 {
+			if len(lines) == 1 {
+				return nil
+			}
+			continue
+		}
+
+		if name == "dn" {
+			entry.DN = value
+			continue
+		}
+
+		entry.Attributes[name] = append(entry.Attributes[name], value)
+	}
+
+	if entry.DN == "" {
+		return fmt.Errorf("LDIF entry without dn: %q", lines[0])
+	}
+
+	d.entries[normalizeDN(entry.DN)] = entry
+	return nil
+}
+
+// GroupMembers returns the entries of the members of the group, sorted by DN
+func (d *LDIFDirectory) GroupMembers(groupDN string) ([]DirectoryEntry, error) {
+	group, ok := d.entries[normalizeDN(groupDN)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDirectoryGroupNotFound, groupDN)
+	}
+
+	members := map[string]DirectoryEntry{}
+	for _, memberDN := range append(group.Attributes["member"], group.Attributes["uniquemember"]...) {
+		member, ok := d.entries[normalizeDN(memberDN)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrDirectoryMemberNotFound, memberDN)
+		}
+		members[normalizeDN(member.DN)] = *member
+	}
+
+	for _, uid := range group.Attributes["memberuid"] {
+		member := d.entryByUID(uid)
+		if member == nil {
+			return nil, fmt.Errorf("%w: uid %s", ErrDirectoryMemberNotFound, uid)
+		}
+		members[normalizeDN(member.DN)] = *member
+	}
+
+	dns := make([]string, 0, len(members))
+	for dn := range members {
+		dns = append(dns, dn)
+	}
+	sort.Strings(dns)
+
+	entries := make([]DirectoryEntry, 0, len(dns))
+	for _, dn := range dns {
+		entries = append(entries, members[dn])
+	}
+
+	return entries, nil
+}
+
+func (d *LDIFDirectory) entryByUID(uid string) *DirectoryEntry {
+	for _, entry := range d.entries {
+		if entry.Attribute("uid") == uid {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// parseLDIFLine parses "name: value" and base64 encoded "name:: value" lines
+func parseLDIFLine(line string) (string, string, error) {
+	name, value, found := strings.Cut(line, ":")
+	if !found {
+		return "", "", fmt.Errorf("invalid LDIF line: %q", line)
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if strings.HasPrefix(value, ":") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+		if err != nil {
+			return "", "", fmt.Errorf("invalid base64 value of LDIF attribute %s: %w", name, err)
+		}
+
+		return name, string(decoded), nil
+	}
+
+	return name, strings.TrimSpace(value), nil
+}
+
+// normalizeDN makes DNs comparable: attribute names and values are case insensitive
+// and spaces around separators are not significant
+func normalizeDN(dn string) string {
+	rdns := strings.Split(dn, ",")
+	for idx := range rdns {
+		name, value, _ := strings.Cut(rdns[idx], "=")
+		rdns[idx] = strings.TrimSpace(name) + "=" + strings.TrimSpace(value)
+	}
+
+	return strings.ToLower(strings.Join(rdns, ","))
+}
@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+)
+
+const testLDIF = `version: 1
+
+# partners group
+dn: cn=partners,ou=groups,dc=example,dc=com
+objectClass: groupOfNames
+cn: partners
+member: uid=acme,ou=people,dc=example,dc=com
+member: UID=globex, ou=people, dc=example, dc=com
+
+dn: cn=resellers,ou=groups,dc=example,dc=com
+objectClass: posixGroup
+memberUid: initech
+
+dn: cn=broken,ou=groups,dc=example,dc=com
+member: uid=unknown,ou=people,dc=example,dc=com
+
+dn: uid=acme,ou=people,dc=example,dc=com
+uid: acme
+o: Acme
+mail: admin@acme.example.com
+description: Acme Corporation, partner since
+  2019
+
+dn: uid=globex,ou=people,dc=example,dc=com
+uid: globex
+o:: R2xvYmV4IMOccw==
+mail: admin@globex.example.com
+
+dn: uid=initech,ou=people,dc=example,dc=com
+uid: initech
+o: Initech
+`
+
+func TestLDIFDirectoryGroupMembers(t *testing.T) {
+	directory, err := NewLDIFDirectory([]byte(testLDIF))
+	ok(t, err)
+
+	members, err := directory.GroupMembers("CN=partners, ou=groups, dc=example, dc=com")
+	ok(t, err)
+	equals(t, 2, len(members))
+	equals(t, "acme", members[0].Attribute("uid"))
+	equals(t, "Acme Corporation, partner since 2019", members[0].Attribute("description"))
+	equals(t, "Globex Üs", members[1].Attribute("O"))
+
+	members, err = directory.GroupMembers("cn=resellers,ou=groups,dc=example,dc=com")
+	ok(t, err)
+	equals(t, 1, len(members))
+	equals(t, "Initech", members[0].Attribute("o"))
+
+	_, err = directory.GroupMembers("cn=unknown,ou=groups,dc=example,dc=com")
+	equals(t, true, errors.Is(err, ErrDirectoryGroupNotFound))
+
+	_, err = directory.GroupMembers("cn=broken,ou=groups,dc=example,dc=com")
+	equals(t, true, errors.Is(err, ErrDirectoryMemberNotFound))
+}
+
+func TestNewLDIFDirectoryInvalid(t *testing.T) {
+	_, err := NewLDIFDirectory([]byte("uid: acme\n"))
+	if err == nil {
+		t.Fatal("expected error for entry without dn")
+	}
+
+	_, err = NewLDIFDirectory([]byte("dn: uid=acme\ninvalid line\n"))
+	if err == nil {
+		t.Fatal("expected error for invalid line")
+	}
+}
@@ -0,0 +1,404 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Types of changes between OpenAPI documents
+const (
+	OpenAPIChangeOperationAdded          = "OperationAdded"
+	OpenAPIChangeOperationRemoved        = "OperationRemoved"
+	OpenAPIChangeMethodRenamed           = "MethodRenamed"
+	OpenAPIChangeParameterAdded          = "ParameterAdded"
+	OpenAPIChangeRequiredParameterAdded  = "RequiredParameterAdded"
+	OpenAPIChangeParameterRemoved        = "ParameterRemoved"
+	OpenAPIChangeParameterRequired       = "ParameterRequired"
+	OpenAPIChangeParameterTypeChanged    = "ParameterTypeChanged"
+	OpenAPIChangeRequestBodyAdded        = "RequestBodyAdded"
+	OpenAPIChangeRequestBodyRequired     = "RequestBodyRequired"
+	OpenAPIChangeRequestBodyRemoved      = "RequestBodyRemoved"
+	OpenAPIChangeRequestMediaTypeRemoved = "RequestMediaTypeRemoved"
+	OpenAPIChangeBasePathChanged         = "BasePathChanged"
+	OpenAPIChangeSecurityChanged         = "SecurityChanged"
+	OpenAPIChangeApplicationPlanRemoved  = "ApplicationPlanRemoved"
+	OpenAPIChangeApplicationPlanAdded    = "ApplicationPlanAdded"
+	OpenAPIChangeRequestMediaTypeAdded   = "RequestMediaTypeAdded"
+	OpenAPIChangeParameterOptional       = "ParameterOptional"
+)
+
+// pathTemplateParamRegexp matches the path template parameters
+var pathTemplateParamRegexp = regexp.MustCompile(`{[^}]*}`)
+
+// OpenAPISummary is the part of an OpenAPI document compared to detect the changes between revisions.
+// The summary is stored in place of the applied document, whose size is not bounded
+type OpenAPISummary struct {
+	// BasePath is the public base path of the servers
+	BasePath string `json:"basePath"`
+	// Security describes the product authentication read from the global security requirements
+	Security string `json:"security"`
+	// Operations by method and path with the template parameter names removed
+	Operations map[string]OpenAPIOperationSummary `json:"operations"`
+	// ApplicationPlans are the system names of the application plans of the x-3scale-product extension
+	ApplicationPlans []string `json:"applicationPlans,omitempty"`
+}
+
+// OpenAPIOperationSummary is the part of an OpenAPI operation compared to detect changes
+type OpenAPIOperationSummary struct {
+	Path string `json:"path"`
+	Verb string `json:"verb"`
+	// MethodSystemName is the system name of the 3scale method of the operation
+	MethodSystemName string `json:"methodSystemName"`
+	// Parameters by location and name. Path parameters are part of the path
+	Parameters  map[string]OpenAPIParameterSummary `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBodySummary         `json:"requestBody,omitempty"`
+}
+
+// OpenAPIParameterSummary is the part of an OpenAPI parameter compared to detect changes
+type OpenAPIParameterSummary struct {
+	In       string `json:"in"`
+	Name     string `json:"name"`
+	Required bool   `json:"required,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+// OpenAPIRequestBodySummary is the part of an OpenAPI request body compared to detect changes
+type OpenAPIRequestBodySummary struct {
+	Required   bool     `json:"required,omitempty"`
+	MediaTypes []string `json:"mediaTypes,omitempty"`
+}
+
+// NewOpenAPISummary returns the summary of a validated OpenAPI document
+func NewOpenAPISummary(obj *openapi3.T) *OpenAPISummary {
+	// Documents were validated, server URLs can be rendered
+	basePath, _ := helper.BasePathFromOpenAPI(obj)
+
+	summary := &OpenAPISummary{
+		BasePath:   strings.TrimSuffix(basePath, "/"),
+		Security:   openAPISecurityDescription(obj),
+		Operations: map[string]OpenAPIOperationSummary{},
+	}
+
+	for path, pathItem := range obj.Paths {
+		for verb, operation := range pathItem.Operations() {
+			parameters := map[string]OpenAPIParameterSummary{}
+			// Operation parameters override path parameters with the same name and location
+			for _, parameterRefs := range []openapi3.Parameters{pathItem.Parameters, operation.Parameters} {
+				for _, parameterRef := range parameterRefs {
+					parameter := parameterRef.Value
+					if parameter == nil || parameter.In == openapi3.ParameterInPath {
+						continue
+					}
+					parameters[fmt.Sprintf("%s %s", parameter.In, strings.ToLower(parameter.Name))] = OpenAPIParameterSummary{
+						In:       parameter.In,
+						Name:     parameter.Name,
+						Required: parameter.Required,
+						Type:     openAPIParameterType(parameter),
+					}
+				}
+			}
+
+			var requestBody *OpenAPIRequestBodySummary
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				requestBody = &OpenAPIRequestBodySummary{
+					Required:   operation.RequestBody.Value.Required,
+					MediaTypes: sortedStringKeys(operation.RequestBody.Value.Content),
+				}
+			}
+
+			key := fmt.Sprintf("%s %s", verb, pathTemplateParamRegexp.ReplaceAllString(path, "{}"))
+			summary.Operations[key] = OpenAPIOperationSummary{
+				Path:             path,
+				Verb:             verb,
+				MethodSystemName: helper.MethodSystemNameFromOpenAPIOperation(path, verb, operation),
+				Parameters:       parameters,
+				RequestBody:      requestBody,
+			}
+		}
+	}
+
+	// Documents were validated, extensions can be parsed
+	extension, _ := helper.NewOasRootProductExtension(obj)
+	if extension != nil && len(extension.ApplicationPlans) > 0 {
+		summary.ApplicationPlans = sortedStringKeys(extension.ApplicationPlans)
+	}
+
+	return summary
+}
+
+// DiffOpenAPIDocuments returns the changes of the current OpenAPI document from the previous one.
+// See DiffOpenAPISummaries
+func DiffOpenAPIDocuments(previous, current *openapi3.T) []capabilitiesv1beta1.OpenAPIChange {
+	return DiffOpenAPISummaries(NewOpenAPISummary(previous), NewOpenAPISummary(current))
+}
+
+// DiffOpenAPISummaries returns the changes of the current OpenAPI document summary from the previous one.
+// Breaking changes break the requests of the API consumers or the applications subscribed to the product:
+// removed operations, method system name changes, new required parameters or request bodies, parameter type changes,
+// removed request media types, base path and security changes and removed application plans.
+// Operations are matched by method and path, regardless of the path template parameter names.
+func DiffOpenAPISummaries(previous, current *OpenAPISummary) []capabilitiesv1beta1.OpenAPIChange {
+	changes := []capabilitiesv1beta1.OpenAPIChange{}
+
+	changes = append(changes, diffOpenAPIBasePath(previous, current)...)
+	changes = append(changes, diffOpenAPISecurity(previous, current)...)
+
+	for _, key := range sortedStringKeys(previous.Operations) {
+		previousOperation := previous.Operations[key]
+		currentOperation, ok := current.Operations[key]
+		if !ok {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeOperationRemoved,
+				Breaking: true,
+				Location: previousOperation.location(),
+				Message:  "operation removed: requests are no longer routed and the method, mapping rules and plan limits are deleted",
+			})
+			continue
+		}
+
+		changes = append(changes, diffOpenAPIOperation(previousOperation, currentOperation)...)
+	}
+
+	for _, key := range sortedStringKeys(current.Operations) {
+		if _, ok := previous.Operations[key]; !ok {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeOperationAdded,
+				Location: current.Operations[key].location(),
+				Message:  "operation added",
+			})
+		}
+	}
+
+	changes = append(changes, diffOpenAPIApplicationPlans(previous, current)...)
+
+	return changes
+}
+
+// HasBreakingOpenAPIChanges returns true when any of the changes is breaking
+func HasBreakingOpenAPIChanges(changes []capabilitiesv1beta1.OpenAPIChange) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func (o OpenAPIOperationSummary) location() string {
+	return fmt.Sprintf("%s %s", o.Verb, o.Path)
+}
+
+func diffOpenAPIOperation(previous, current OpenAPIOperationSummary) []capabilitiesv1beta1.OpenAPIChange {
+	changes := []capabilitiesv1beta1.OpenAPIChange{}
+	location := current.location()
+
+	// The method is looked up by system name, a new name replaces the method
+	if previous.MethodSystemName != current.MethodSystemName {
+		changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+			Type:     OpenAPIChangeMethodRenamed,
+			Breaking: true,
+			Location: location,
+			Message: fmt.Sprintf("method system name changed from %q to %q: the method is recreated and its plan limits and pricing rules are deleted",
+				previous.MethodSystemName, current.MethodSystemName),
+		})
+	}
+
+	for _, key := range sortedStringKeys(previous.Parameters) {
+		previousParameter := previous.Parameters[key]
+		parameterLocation := fmt.Sprintf("%s %s parameter %s", location, previousParameter.In, previousParameter.Name)
+		currentParameter, ok := current.Parameters[key]
+		if !ok {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeParameterRemoved,
+				Location: parameterLocation,
+				Message:  "parameter removed",
+			})
+			continue
+		}
+
+		if !previousParameter.Required && currentParameter.Required {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeParameterRequired,
+				Breaking: true,
+				Location: parameterLocation,
+				Message:  "parameter is now required",
+			})
+		}
+
+		if previousParameter.Required && !currentParameter.Required {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeParameterOptional,
+				Location: parameterLocation,
+				Message:  "parameter is no longer required",
+			})
+		}
+
+		if previousParameter.Type != currentParameter.Type {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeParameterTypeChanged,
+				Breaking: true,
+				Location: parameterLocation,
+				Message:  fmt.Sprintf("parameter type changed from %q to %q", previousParameter.Type, currentParameter.Type),
+			})
+		}
+	}
+
+	for _, key := range sortedStringKeys(current.Parameters) {
+		if _, ok := previous.Parameters[key]; ok {
+			continue
+		}
+
+		currentParameter := current.Parameters[key]
+		change := capabilitiesv1beta1.OpenAPIChange{
+			Type:     OpenAPIChangeParameterAdded,
+			Location: fmt.Sprintf("%s %s parameter %s", location, currentParameter.In, currentParameter.Name),
+			Message:  "optional parameter added",
+		}
+		if currentParameter.Required {
+			change.Type = OpenAPIChangeRequiredParameterAdded
+			change.Breaking = true
+			change.Message = "required parameter added"
+		}
+		changes = append(changes, change)
+	}
+
+	changes = append(changes, diffOpenAPIRequestBody(location, previous.RequestBody, current.RequestBody)...)
+
+	return changes
+}
+
+func openAPIParameterType(parameter *openapi3.Parameter) string {
+	if parameter.Schema == nil || parameter.Schema.Value == nil {
+		return ""
+	}
+	return parameter.Schema.Value.Type
+}
+
+func diffOpenAPIRequestBody(location string, previous, current *OpenAPIRequestBodySummary) []capabilitiesv1beta1.OpenAPIChange {
+	requestBodyLocation := fmt.Sprintf("%s request body", location)
+
+	switch {
+	case previous == nil && current == nil:
+		return nil
+	case previous == nil:
+		change := capabilitiesv1beta1.OpenAPIChange{
+			Type:     OpenAPIChangeRequestBodyAdded,
+			Location: requestBodyLocation,
+			Message:  "optional request body added",
+		}
+		if current.Required {
+			change.Type = OpenAPIChangeRequestBodyRequired
+			change.Breaking = true
+			change.Message = "required request body added"
+		}
+		return []capabilitiesv1beta1.OpenAPIChange{change}
+	case current == nil:
+		return []capabilitiesv1beta1.OpenAPIChange{{
+			Type:     OpenAPIChangeRequestBodyRemoved,
+			Location: requestBodyLocation,
+			Message:  "request body removed",
+		}}
+	}
+
+	changes := []capabilitiesv1beta1.OpenAPIChange{}
+
+	if !previous.Required && current.Required {
+		changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+			Type:     OpenAPIChangeRequestBodyRequired,
+			Breaking: true,
+			Location: requestBodyLocation,
+			Message:  "request body is now required",
+		})
+	}
+
+	for _, mediaType := range previous.MediaTypes {
+		if !helper.ArrayContains(current.MediaTypes, mediaType) {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeRequestMediaTypeRemoved,
+				Breaking: true,
+				Location: requestBodyLocation,
+				Message:  fmt.Sprintf("media type %s removed", mediaType),
+			})
+		}
+	}
+
+	for _, mediaType := range current.MediaTypes {
+		if !helper.ArrayContains(previous.MediaTypes, mediaType) {
+			changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+				Type:     OpenAPIChangeRequestMediaTypeAdded,
+				Location: requestBodyLocation,
+				Message:  fmt.Sprintf("media type %s added", mediaType),
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffOpenAPIBasePath(previous, current *OpenAPISummary) []capabilitiesv1beta1.OpenAPIChange {
+	if previous.BasePath == current.BasePath {
+		return nil
+	}
+
+	return []capabilitiesv1beta1.OpenAPIChange{{
+		Type:     OpenAPIChangeBasePathChanged,
+		Breaking: true,
+		Location: "servers",
+		Message:  fmt.Sprintf("public base path changed from %q to %q", previous.BasePath, current.BasePath),
+	}}
+}
+
+func diffOpenAPISecurity(previous, current *OpenAPISummary) []capabilitiesv1beta1.OpenAPIChange {
+	if previous.Security == current.Security {
+		return nil
+	}
+
+	return []capabilitiesv1beta1.OpenAPIChange{{
+		Type:     OpenAPIChangeSecurityChanged,
+		Breaking: true,
+		Location: "security",
+		Message:  fmt.Sprintf("authentication changed from %s to %s", previous.Security, current.Security),
+	}}
+}
+
+// openAPISecurityDescription describes the product authentication read from the global security requirements
+func openAPISecurityDescription(obj *openapi3.T) string {
+	authentication, err := helper.OpenAPIAuthenticationFromOpenAPI(obj)
+	if err != nil {
+		return "unsupported security"
+	}
+	return authentication.Description()
+}
+
+func diffOpenAPIApplicationPlans(previous, current *OpenAPISummary) []capabilitiesv1beta1.OpenAPIChange {
+	changes := []capabilitiesv1beta1.OpenAPIChange{}
+	for _, planSystemName := range helper.ArrayStringDifference(previous.ApplicationPlans, current.ApplicationPlans) {
+		changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+			Type:     OpenAPIChangeApplicationPlanRemoved,
+			Breaking: true,
+			Location: fmt.Sprintf("x-3scale-product.applicationPlans.%s", planSystemName),
+			Message:  "application plan removed: the subscribed applications are deleted",
+		})
+	}
+	for _, planSystemName := range helper.ArrayStringDifference(current.ApplicationPlans, previous.ApplicationPlans) {
+		changes = append(changes, capabilitiesv1beta1.OpenAPIChange{
+			Type:     OpenAPIChangeApplicationPlanAdded,
+			Location: fmt.Sprintf("x-3scale-product.applicationPlans.%s", planSystemName),
+			Message:  "application plan added",
+		})
+	}
+
+	return changes
+}
+
+func sortedStringKeys[V any](m map[string]V) []string {
+	keys := helper.MapKeys(m)
+	sort.Strings(keys)
+	return keys
+}
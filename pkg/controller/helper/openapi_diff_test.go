@@ -0,0 +1,226 @@
+package helper
+
+import (
+	"encoding/json"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const testPreviousDiffDocument = `
+openapi: "3.0.0"
+info:
+  title: Swagger Petstore
+  version: 1.0.0
+servers:
+  - url: http://petstore.swagger.io/v1
+x-3scale-product:
+  applicationPlans:
+    basic:
+      name: Basic
+    premium:
+      name: Premium
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: tag
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: A paged array of pets
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+          application/xml:
+            schema:
+              type: object
+      responses:
+        "201":
+          description: Null response
+  /pets/{petId}:
+    get:
+      operationId: showPetById
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Expected response to a valid request
+    delete:
+      responses:
+        "204":
+          description: Deleted
+`
+
+const testCurrentDiffDocument = `
+openapi: "3.0.0"
+info:
+  title: Swagger Petstore
+  version: 1.1.0
+servers:
+  - url: http://petstore.swagger.io/v2
+x-3scale-product:
+  applicationPlans:
+    basic:
+      name: Basic
+    gold:
+      name: Gold
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      name: X-API-Key
+      in: header
+security:
+  - api_key: []
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: string
+        - name: owner
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: A paged array of pets
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        "201":
+          description: Null response
+  /pets/{id}:
+    get:
+      operationId: showPetById
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Expected response to a valid request
+  /owners:
+    get:
+      responses:
+        "200":
+          description: Owners
+`
+
+func TestDiffOpenAPIDocuments(t *testing.T) {
+	loader := openapi3.NewLoader()
+	previous, err := loader.LoadFromData([]byte(testPreviousDiffDocument))
+	ok(t, err)
+	current, err := loader.LoadFromData([]byte(testCurrentDiffDocument))
+	ok(t, err)
+
+	changes := DiffOpenAPIDocuments(previous, current)
+
+	type change struct {
+		Type     string
+		Breaking bool
+		Location string
+	}
+	got := []change{}
+	for _, c := range changes {
+		assert(t, c.Message != "", "change %s %s has no message", c.Type, c.Location)
+		got = append(got, change{c.Type, c.Breaking, c.Location})
+	}
+
+	equals(t, []change{
+		{OpenAPIChangeBasePathChanged, true, "servers"},
+		{OpenAPIChangeSecurityChanged, true, "security"},
+		{OpenAPIChangeOperationRemoved, true, "DELETE /pets/{petId}"},
+		{OpenAPIChangeParameterRequired, true, "GET /pets query parameter limit"},
+		{OpenAPIChangeParameterTypeChanged, true, "GET /pets query parameter limit"},
+		{OpenAPIChangeParameterRemoved, false, "GET /pets query parameter tag"},
+		{OpenAPIChangeParameterAdded, false, "GET /pets query parameter owner"},
+		{OpenAPIChangeRequestBodyRequired, true, "POST /pets request body"},
+		{OpenAPIChangeRequestMediaTypeRemoved, true, "POST /pets request body"},
+		{OpenAPIChangeOperationAdded, false, "GET /owners"},
+		{OpenAPIChangeApplicationPlanRemoved, true, "x-3scale-product.applicationPlans.premium"},
+		{OpenAPIChangeApplicationPlanAdded, false, "x-3scale-product.applicationPlans.gold"},
+	}, got)
+	assert(t, HasBreakingOpenAPIChanges(changes), "expected breaking changes")
+}
+
+func TestDiffOpenAPIDocumentsNonBreaking(t *testing.T) {
+	loader := openapi3.NewLoader()
+	previous, err := loader.LoadFromData([]byte(testPreviousDiffDocument))
+	ok(t, err)
+	current, err := loader.LoadFromData([]byte(testPreviousDiffDocument))
+	ok(t, err)
+
+	equals(t, []capabilitiesv1beta1.OpenAPIChange{}, DiffOpenAPIDocuments(previous, current))
+
+	current.Paths["/owners"] = &openapi3.PathItem{Get: &openapi3.Operation{}}
+	changes := DiffOpenAPIDocuments(previous, current)
+	equals(t, 1, len(changes))
+	equals(t, OpenAPIChangeOperationAdded, changes[0].Type)
+	assert(t, !HasBreakingOpenAPIChanges(changes), "expected no breaking changes")
+}
+
+func TestDiffOpenAPIDocumentsMethodRenamed(t *testing.T) {
+	loader := openapi3.NewLoader()
+	previous, err := loader.LoadFromData([]byte(testPreviousDiffDocument))
+	ok(t, err)
+	current, err := loader.LoadFromData([]byte(testPreviousDiffDocument))
+	ok(t, err)
+
+	// the method system name is derived from the operation ID
+	current.Paths["/pets/{petId}"].Get.OperationID = "getPet"
+	// and from the path when the operation has no ID
+	current.Paths["/pets/{id}"] = current.Paths["/pets/{petId}"]
+	delete(current.Paths, "/pets/{petId}")
+
+	changes := DiffOpenAPIDocuments(previous, current)
+	equals(t, 2, len(changes))
+	equals(t, OpenAPIChangeMethodRenamed, changes[0].Type)
+	equals(t, "DELETE /pets/{id}", changes[0].Location)
+	equals(t, `method system name changed from "deletepetspetid" to "deletepetsid": the method is recreated and its plan limits and pricing rules are deleted`, changes[0].Message)
+	equals(t, OpenAPIChangeMethodRenamed, changes[1].Type)
+	equals(t, "GET /pets/{id}", changes[1].Location)
+	assert(t, HasBreakingOpenAPIChanges(changes), "expected breaking changes")
+}
+
+func TestOpenAPISummaryJSON(t *testing.T) {
+	loader := openapi3.NewLoader()
+	previous, err := loader.LoadFromData([]byte(testPreviousDiffDocument))
+	ok(t, err)
+	current, err := loader.LoadFromData([]byte(testCurrentDiffDocument))
+	ok(t, err)
+
+	// the stored summary compares as the document it was computed from
+	data, err := json.Marshal(NewOpenAPISummary(previous))
+	ok(t, err)
+	stored := &OpenAPISummary{}
+	ok(t, json.Unmarshal(data, stored))
+
+	equals(t, DiffOpenAPIDocuments(previous, current), DiffOpenAPISummaries(stored, NewOpenAPISummary(current)))
+	equals(t, []capabilitiesv1beta1.OpenAPIChange{}, DiffOpenAPISummaries(stored, NewOpenAPISummary(previous)))
+}
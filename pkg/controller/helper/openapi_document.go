@@ -0,0 +1,494 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"sigs.k8s.io/yaml"
+)
+
+// Conversions applied to OpenAPI documents to import them as OpenAPI 3.0
+const (
+	OpenAPIConversionSwagger2ToOpenAPI3       = "Swagger2ToOpenAPI3"
+	OpenAPIConversionOpenAPI31ToOpenAPI30     = "OpenAPI31ToOpenAPI30"
+	OpenAPIConversionWebhooksRemoved          = "WebhooksRemoved"
+	OpenAPIConversionTypeArraysToNullable     = "TypeArraysToNullable"
+	OpenAPIConversionConstToEnum              = "ConstToEnum"
+	OpenAPIConversionSchemaExamplesToExample  = "SchemaExamplesToExample"
+	OpenAPIConversionNumericExclusiveBounds   = "NumericExclusiveBounds"
+	OpenAPIConversionDefsToComponentSchemas   = "DefsToComponentSchemas"
+	OpenAPIConversionUnsupportedFieldsRemoved = "UnsupportedFieldsRemoved"
+)
+
+// openAPIConversionsOrder keeps the reported conversions in a stable order
+var openAPIConversionsOrder = []string{
+	OpenAPIConversionSwagger2ToOpenAPI3,
+	OpenAPIConversionOpenAPI31ToOpenAPI30,
+	OpenAPIConversionWebhooksRemoved,
+	OpenAPIConversionTypeArraysToNullable,
+	OpenAPIConversionConstToEnum,
+	OpenAPIConversionSchemaExamplesToExample,
+	OpenAPIConversionNumericExclusiveBounds,
+	OpenAPIConversionDefsToComponentSchemas,
+	OpenAPIConversionUnsupportedFieldsRemoved,
+}
+
+// JSON Schema 2020-12 keywords without OpenAPI 3.0 equivalent
+var openAPI31UnsupportedSchemaKeywords = []string{
+	"$schema", "$id", "$anchor", "$comment", "$dynamicRef", "$dynamicAnchor", "$vocabulary",
+	"contentMediaType", "contentEncoding", "contentSchema",
+	"unevaluatedProperties", "unevaluatedItems", "dependentRequired", "dependentSchemas",
+	"if", "then", "else", "prefixItems", "propertyNames", "patternProperties",
+	"contains", "minContains", "maxContains",
+}
+
+// componentNameInvalidCharRegexp matches the characters not allowed in component names
+var componentNameInvalidCharRegexp = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// OpenAPIDocumentInfo describes how an OpenAPI document was imported
+type OpenAPIDocumentInfo struct {
+	// Version is the swagger or openapi version declared by the document
+	Version string
+	// Document is the document as read, before any conversion. YAML documents are encoded as JSON
+	Document []byte
+	// Conversions applied to import the document as OpenAPI 3.0
+	Conversions []string
+}
+
+// LoadOpenAPIDocument parses and validates Swagger 2.0, OpenAPI 3.0 and OpenAPI 3.1 documents.
+// Swagger 2.0 documents are converted to OpenAPI 3.0.
+// OpenAPI 3.1 documents are downgraded to OpenAPI 3.0: 3.1 only features are removed
+// and JSON Schema 2020-12 keywords are replaced by their OpenAPI 3.0 equivalent when there is one.
+// Schema $defs are moved to the component schemas.
+// The location, when not nil, is used to resolve relative references.
+func LoadOpenAPIDocument(ctx context.Context, data []byte, location *url.URL) (*openapi3.T, *OpenAPIDocumentInfo, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := struct {
+		Swagger string `json:"swagger"`
+		OpenAPI string `json:"openapi"`
+	}{}
+	if err := json.Unmarshal(jsonData, &header); err != nil {
+		return nil, nil, fmt.Errorf("OpenAPI document is not an object: %w", err)
+	}
+
+	info := &OpenAPIDocumentInfo{Document: jsonData}
+	if json.Valid(data) {
+		// JSON documents are kept as read
+		info.Document = data
+	}
+	conversions := map[string]bool{}
+
+	switch {
+	case strings.HasPrefix(header.Swagger, "2."):
+		info.Version = header.Swagger
+		jsonData, err = convertSwagger2(jsonData)
+		if err != nil {
+			return nil, nil, err
+		}
+		conversions[OpenAPIConversionSwagger2ToOpenAPI3] = true
+	case strings.HasPrefix(header.OpenAPI, "3.1"):
+		info.Version = header.OpenAPI
+		jsonData, err = downgradeOpenAPI31(jsonData, conversions)
+		if err != nil {
+			return nil, nil, err
+		}
+		conversions[OpenAPIConversionOpenAPI31ToOpenAPI30] = true
+	case strings.HasPrefix(header.OpenAPI, "3.0"):
+		info.Version = header.OpenAPI
+	case header.Swagger != "":
+		return nil, nil, fmt.Errorf("unsupported swagger version %s", header.Swagger)
+	case header.OpenAPI != "":
+		return nil, nil, fmt.Errorf("unsupported openapi version %s", header.OpenAPI)
+	default:
+		return nil, nil, fmt.Errorf("OpenAPI document does not declare the swagger or openapi version")
+	}
+
+	loader := openapi3.NewLoader()
+	var openapiObj *openapi3.T
+	if location != nil {
+		openapiObj, err = loader.LoadFromDataWithPath(jsonData, location)
+	} else {
+		openapiObj, err = loader.LoadFromData(jsonData)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = openapiObj.Validate(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, conversion := range openAPIConversionsOrder {
+		if conversions[conversion] {
+			info.Conversions = append(info.Conversions, conversion)
+		}
+	}
+
+	return openapiObj, info, nil
+}
+
+func convertSwagger2(jsonData []byte) ([]byte, error) {
+	doc2 := &openapi2.T{}
+	if err := json.Unmarshal(jsonData, doc2); err != nil {
+		return nil, fmt.Errorf("error parsing swagger 2.0 document: %w", err)
+	}
+
+	doc3, err := openapi2conv.ToV3(doc2)
+	if err != nil {
+		return nil, fmt.Errorf("error converting swagger 2.0 document: %w", err)
+	}
+
+	// swagger 2.0 documents without operations are converted without paths object
+	if doc3.Paths == nil {
+		doc3.Paths = openapi3.Paths{}
+	}
+
+	// serialized to be loaded again, so references are resolved by the loader
+	return json.Marshal(doc3)
+}
+
+func downgradeOpenAPI31(jsonData []byte, conversions map[string]bool) ([]byte, error) {
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, err
+	}
+
+	doc["openapi"] = "3.0.3"
+
+	if _, ok := doc["webhooks"]; ok {
+		delete(doc, "webhooks")
+		conversions[OpenAPIConversionWebhooksRemoved] = true
+	}
+
+	if deleteKeys(doc, "jsonSchemaDialect") {
+		conversions[OpenAPIConversionUnsupportedFieldsRemoved] = true
+	}
+
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		if deleteKeys(info, "summary") {
+			conversions[OpenAPIConversionUnsupportedFieldsRemoved] = true
+		}
+		if license, ok := info["license"].(map[string]interface{}); ok && deleteKeys(license, "identifier") {
+			conversions[OpenAPIConversionUnsupportedFieldsRemoved] = true
+		}
+	}
+
+	// paths are optional in OpenAPI 3.1
+	if _, ok := doc["paths"]; !ok {
+		doc["paths"] = map[string]interface{}{}
+	}
+
+	// $defs are moved before the component schemas are downgraded, so they are downgraded as well
+	if err := moveOpenAPI31Defs(doc, conversions); err != nil {
+		return nil, err
+	}
+
+	if components, ok := doc["components"].(map[string]interface{}); ok {
+		if deleteKeys(components, "pathItems") {
+			conversions[OpenAPIConversionUnsupportedFieldsRemoved] = true
+		}
+		if schemas, ok := components["schemas"].(map[string]interface{}); ok {
+			for _, schema := range schemas {
+				downgradeOpenAPI31Schema(schema, conversions)
+			}
+		}
+	}
+
+	downgradeOpenAPI31Schemas(doc, conversions)
+
+	return json.Marshal(doc)
+}
+
+// moveOpenAPI31Defs moves the schema $defs to the component schemas and rewrites the references to them.
+// References to $defs are JSON pointers from the document root, for instance #/components/schemas/Pet/$defs/Tag
+func moveOpenAPI31Defs(doc map[string]interface{}, conversions map[string]bool) error {
+	movedRefs := map[string]string{}
+	collectOpenAPI31Defs(doc, "#", doc, movedRefs)
+	if len(movedRefs) == 0 {
+		return nil
+	}
+
+	// longest references first, so nested $defs are rewritten before the $defs containing them
+	fromRefs := sortedStringKeys(movedRefs)
+	sort.SliceStable(fromRefs, func(i, j int) bool { return len(fromRefs[i]) > len(fromRefs[j]) })
+
+	var rewriteErr error
+	walkOpenAPI31Refs(doc, func(ref string) string {
+		for _, from := range fromRefs {
+			if ref == from || strings.HasPrefix(ref, from+"/") {
+				return movedRefs[from] + strings.TrimPrefix(ref, from)
+			}
+		}
+		if strings.Contains(ref, "$defs") && rewriteErr == nil {
+			rewriteErr = fmt.Errorf("unsupported reference %q: $defs can only be referenced by JSON pointer from the document root", ref)
+		}
+		return ref
+	})
+	if rewriteErr != nil {
+		return rewriteErr
+	}
+
+	conversions[OpenAPIConversionDefsToComponentSchemas] = true
+	return nil
+}
+
+// collectOpenAPI31Defs moves the $defs found under the node to the component schemas.
+// movedRefs gets the new reference of every moved definition by its original reference
+func collectOpenAPI31Defs(doc map[string]interface{}, pointer string, node interface{}, movedRefs map[string]string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedStringKeys(value) {
+			child := value[key]
+			childPointer := pointer + "/" + escapeJSONPointer(key)
+			if key != "$defs" {
+				if key != "example" && key != "examples" {
+					collectOpenAPI31Defs(doc, childPointer, child, movedRefs)
+				}
+				continue
+			}
+
+			defs, ok := child.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, defName := range sortedStringKeys(defs) {
+				defPointer := childPointer + "/" + escapeJSONPointer(defName)
+				// nested $defs keep the pointer of the definition containing them
+				collectOpenAPI31Defs(doc, defPointer, defs[defName], movedRefs)
+				componentName := addComponentSchema(doc, defName, defs[defName])
+				movedRefs[defPointer] = "#/components/schemas/" + escapeJSONPointer(componentName)
+			}
+			delete(value, "$defs")
+		}
+	case []interface{}:
+		for idx, child := range value {
+			collectOpenAPI31Defs(doc, fmt.Sprintf("%s/%d", pointer, idx), child, movedRefs)
+		}
+	}
+}
+
+// addComponentSchema adds the schema to the component schemas with an unused name derived from the given one
+func addComponentSchema(doc map[string]interface{}, name string, schema interface{}) string {
+	components, ok := doc["components"].(map[string]interface{})
+	if !ok {
+		components = map[string]interface{}{}
+		doc["components"] = components
+	}
+	schemas, ok := components["schemas"].(map[string]interface{})
+	if !ok {
+		schemas = map[string]interface{}{}
+		components["schemas"] = schemas
+	}
+
+	baseName := componentNameInvalidCharRegexp.ReplaceAllString(name, "_")
+	componentName := baseName
+	for idx := 2; ; idx++ {
+		if _, ok := schemas[componentName]; !ok {
+			break
+		}
+		componentName = fmt.Sprintf("%s_%d", baseName, idx)
+	}
+
+	schemas[componentName] = schema
+	return componentName
+}
+
+// walkOpenAPI31Refs replaces every $ref of the node with the value returned by the rewrite function
+func walkOpenAPI31Refs(node interface{}, rewrite func(string) string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				value[key] = rewrite(ref)
+				continue
+			}
+			if key != "example" && key != "examples" {
+				walkOpenAPI31Refs(child, rewrite)
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			walkOpenAPI31Refs(child, rewrite)
+		}
+	}
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// downgradeOpenAPI31Schemas downgrades the schemas of parameters, headers and media types
+func downgradeOpenAPI31Schemas(node interface{}, conversions map[string]bool) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			switch key {
+			case "schema":
+				downgradeOpenAPI31Schema(child, conversions)
+			case "example", "examples":
+				// payloads are not walked
+			case "components":
+				// component schemas are downgraded separately
+				if components, ok := child.(map[string]interface{}); ok {
+					for componentType, componentsOfType := range components {
+						if componentType != "schemas" {
+							downgradeOpenAPI31Schemas(componentsOfType, conversions)
+						}
+					}
+				}
+			default:
+				downgradeOpenAPI31Schemas(child, conversions)
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			downgradeOpenAPI31Schemas(child, conversions)
+		}
+	}
+}
+
+func downgradeOpenAPI31Schema(node interface{}, conversions map[string]bool) {
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if deleteKeys(schema, openAPI31UnsupportedSchemaKeywords...) {
+		conversions[OpenAPIConversionUnsupportedFieldsRemoved] = true
+	}
+
+	// anyOf/oneOf with a null type alternative
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		alternatives, ok := schema[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+		nonNullAlternatives := make([]interface{}, 0, len(alternatives))
+		for _, alternative := range alternatives {
+			if alternativeSchema, ok := alternative.(map[string]interface{}); ok && len(alternativeSchema) == 1 && alternativeSchema["type"] == "null" {
+				schema["nullable"] = true
+				conversions[OpenAPIConversionTypeArraysToNullable] = true
+				continue
+			}
+			nonNullAlternatives = append(nonNullAlternatives, alternative)
+		}
+
+		// empty anyOf and oneOf are not valid in 3.0
+		if len(nonNullAlternatives) == 0 {
+			delete(schema, keyword)
+			continue
+		}
+		schema[keyword] = nonNullAlternatives
+	}
+
+	// type: [string, "null"] -> type: string, nullable: true
+	if types, ok := schema["type"].([]interface{}); ok {
+		nonNullTypes := make([]interface{}, 0, len(types))
+		for _, schemaType := range types {
+			if schemaType == "null" {
+				schema["nullable"] = true
+				continue
+			}
+			nonNullTypes = append(nonNullTypes, schemaType)
+		}
+
+		switch len(nonNullTypes) {
+		case 0:
+			delete(schema, "type")
+		case 1:
+			schema["type"] = nonNullTypes[0]
+		default:
+			typeAlternatives := make([]interface{}, 0, len(nonNullTypes))
+			for _, schemaType := range nonNullTypes {
+				typeAlternatives = append(typeAlternatives, map[string]interface{}{"type": schemaType})
+			}
+			delete(schema, "type")
+
+			// both the types and the existing anyOf must match
+			if existingAnyOf, ok := schema["anyOf"]; ok {
+				allOf, _ := schema["allOf"].([]interface{})
+				schema["allOf"] = append(allOf,
+					map[string]interface{}{"anyOf": typeAlternatives},
+					map[string]interface{}{"anyOf": existingAnyOf},
+				)
+				delete(schema, "anyOf")
+			} else {
+				schema["anyOf"] = typeAlternatives
+			}
+		}
+		conversions[OpenAPIConversionTypeArraysToNullable] = true
+	}
+
+	if value, ok := schema["const"]; ok {
+		delete(schema, "const")
+		schema["enum"] = []interface{}{value}
+		conversions[OpenAPIConversionConstToEnum] = true
+	}
+
+	if examples, ok := schema["examples"].([]interface{}); ok {
+		delete(schema, "examples")
+		if len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+		conversions[OpenAPIConversionSchemaExamplesToExample] = true
+	}
+
+	for bound, exclusiveBound := range map[string]string{"minimum": "exclusiveMinimum", "maximum": "exclusiveMaximum"} {
+		value, ok := schema[exclusiveBound].(float64)
+		if !ok {
+			continue
+		}
+		conversions[OpenAPIConversionNumericExclusiveBounds] = true
+
+		// the stricter bound is kept, the exclusive flag only applies to the exclusive value
+		if current, ok := schema[bound].(float64); ok && ((bound == "minimum" && current > value) || (bound == "maximum" && current < value)) {
+			delete(schema, exclusiveBound)
+			continue
+		}
+		schema[bound] = value
+		schema[exclusiveBound] = true
+	}
+
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, property := range properties {
+			downgradeOpenAPI31Schema(property, conversions)
+		}
+	}
+
+	for _, keyword := range []string{"items", "additionalProperties", "not"} {
+		downgradeOpenAPI31Schema(schema[keyword], conversions)
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if subschemas, ok := schema[keyword].([]interface{}); ok {
+			for _, subschema := range subschemas {
+				downgradeOpenAPI31Schema(subschema, conversions)
+			}
+		}
+	}
+}
+
+// deleteKeys deletes the keys from the object and returns true when any key was found
+func deleteKeys(obj map[string]interface{}, keys ...string) bool {
+	found := false
+	for _, key := range keys {
+		if _, ok := obj[key]; ok {
+			delete(obj, key)
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,280 @@
+package helper
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const testSwagger2Document = `
+swagger: "2.0"
+info:
+  title: Swagger Petstore
+  version: 1.0.0
+host: petstore.swagger.io
+basePath: /v1
+schemes:
+  - https
+securityDefinitions:
+  api_key:
+    type: apiKey
+    name: api_key
+    in: header
+security:
+  - api_key: []
+paths:
+  /pets/{petId}:
+    get:
+      operationId: showPetById
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          type: string
+      responses:
+        "200":
+          description: Expected response to a valid request
+          schema:
+            $ref: "#/definitions/Pet"
+definitions:
+  Pet:
+    type: object
+    properties:
+      name:
+        type: string
+`
+
+const testOpenAPI31Document = `
+openapi: 3.1.0
+info:
+  title: Swagger Petstore
+  summary: Pets
+  version: 1.0.0
+  license:
+    name: MIT
+    identifier: MIT
+jsonSchemaDialect: https://json-schema.org/draft/2020-12/schema
+servers:
+  - url: http://petstore.swagger.io/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: [integer, "null"]
+            exclusiveMinimum: 0
+      responses:
+        "200":
+          description: A paged array of pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+              examples:
+                pets:
+                  value:
+                    - kind: dog
+webhooks:
+  newPet:
+    post:
+      responses:
+        "200":
+          description: Return a 200 status
+components:
+  schemas:
+    Pet:
+      $schema: https://json-schema.org/draft/2020-12/schema
+      type: object
+      properties:
+        kind:
+          const: dog
+        tag:
+          anyOf:
+            - type: string
+            - type: "null"
+          examples:
+            - puppy
+`
+
+func TestLoadOpenAPIDocumentSwagger2(t *testing.T) {
+	openapiObj, info, err := LoadOpenAPIDocument(context.TODO(), []byte(testSwagger2Document), nil)
+	ok(t, err)
+	equals(t, "2.0", info.Version)
+	equals(t, []string{OpenAPIConversionSwagger2ToOpenAPI3}, info.Conversions)
+	equals(t, "https://petstore.swagger.io/v1", openapiObj.Servers[0].URL)
+	equals(t, "apiKey", openapiObj.Components.SecuritySchemes["api_key"].Value.Type)
+
+	operation := openapiObj.Paths["/pets/{petId}"].Get
+	assert(t, operation != nil, "missing converted operation")
+	schema := operation.Responses["200"].Value.Content["application/json"].Schema
+	equals(t, "#/components/schemas/Pet", schema.Ref)
+	assert(t, schema.Value != nil, "schema reference was not resolved")
+}
+
+func TestLoadOpenAPIDocumentOpenAPI31(t *testing.T) {
+	openapiObj, info, err := LoadOpenAPIDocument(context.TODO(), []byte(testOpenAPI31Document), nil)
+	ok(t, err)
+	equals(t, "3.1.0", info.Version)
+	equals(t, []string{
+		OpenAPIConversionOpenAPI31ToOpenAPI30,
+		OpenAPIConversionWebhooksRemoved,
+		OpenAPIConversionTypeArraysToNullable,
+		OpenAPIConversionConstToEnum,
+		OpenAPIConversionSchemaExamplesToExample,
+		OpenAPIConversionNumericExclusiveBounds,
+		OpenAPIConversionUnsupportedFieldsRemoved,
+	}, info.Conversions)
+
+	limit := openapiObj.Paths["/pets"].Get.Parameters[0].Value.Schema.Value
+	equals(t, "integer", limit.Type)
+	assert(t, limit.Nullable, "limit should be nullable")
+	assert(t, limit.ExclusiveMin && *limit.Min == 0, "limit should have an exclusive minimum")
+
+	pet := openapiObj.Components.Schemas["Pet"].Value
+	equals(t, []interface{}{"dog"}, pet.Properties["kind"].Value.Enum)
+	tag := pet.Properties["tag"].Value
+	assert(t, tag.Nullable, "tag should be nullable")
+	equals(t, 1, len(tag.AnyOf))
+	equals(t, "puppy", tag.Example)
+}
+
+func TestLoadOpenAPIDocumentOpenAPI30(t *testing.T) {
+	_, info, err := LoadOpenAPIDocument(context.TODO(), []byte(`{"openapi":"3.0.2","info":{"title":"t","version":"1"},"paths":{}}`), nil)
+	ok(t, err)
+	equals(t, "3.0.2", info.Version)
+	equals(t, 0, len(info.Conversions))
+}
+
+func TestLoadOpenAPIDocumentUnsupportedVersion(t *testing.T) {
+	_, _, err := LoadOpenAPIDocument(context.TODO(), []byte(`swagger: "1.2"`), nil)
+	assert(t, err != nil, "swagger 1.2 should return error")
+
+	_, _, err = LoadOpenAPIDocument(context.TODO(), []byte(`info: {}`), nil)
+	assert(t, err != nil, "document without version should return error")
+}
+
+const testOpenAPI31DefsDocument = `
+openapi: 3.1.0
+info:
+  title: Swagger Petstore
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: A paged array of pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Tag:
+      type: string
+    Pet:
+      type: object
+      properties:
+        tag:
+          $ref: "#/components/schemas/Pet/$defs/Tag"
+        color:
+          $ref: "#/components/schemas/Pet/$defs/Tag/$defs/Color"
+      $defs:
+        Tag:
+          type: object
+          properties:
+            color:
+              $ref: "#/components/schemas/Pet/$defs/Tag/$defs/Color"
+          $defs:
+            Color:
+              type: [string, "null"]
+`
+
+func TestLoadOpenAPIDocumentOpenAPI31Defs(t *testing.T) {
+	openapiObj, info, err := LoadOpenAPIDocument(context.TODO(), []byte(testOpenAPI31DefsDocument), nil)
+	ok(t, err)
+	equals(t, []string{
+		OpenAPIConversionOpenAPI31ToOpenAPI30,
+		OpenAPIConversionTypeArraysToNullable,
+		OpenAPIConversionDefsToComponentSchemas,
+	}, info.Conversions)
+
+	// the existing Tag schema is kept
+	equals(t, "string", openapiObj.Components.Schemas["Tag"].Value.Type)
+
+	pet := openapiObj.Components.Schemas["Pet"].Value
+	equals(t, "#/components/schemas/Tag_2", pet.Properties["tag"].Ref)
+	equals(t, "object", pet.Properties["tag"].Value.Type)
+	equals(t, "#/components/schemas/Color", pet.Properties["color"].Ref)
+
+	color := openapiObj.Components.Schemas["Color"].Value
+	equals(t, "string", color.Type)
+	assert(t, color.Nullable, "color should be nullable")
+	equals(t, "#/components/schemas/Color", openapiObj.Components.Schemas["Tag_2"].Value.Properties["color"].Ref)
+
+	// the document as read is kept
+	assert(t, strings.Contains(string(info.Document), `"openapi":"3.1.0"`), "document should not be converted")
+	assert(t, strings.Contains(string(info.Document), `"$defs"`), "document should not be converted")
+}
+
+func TestLoadOpenAPIDocumentOpenAPI31UnsupportedDefsRef(t *testing.T) {
+	document := strings.Replace(testOpenAPI31DefsDocument, `$ref: "#/components/schemas/Pet/$defs/Tag"`, `$ref: "#/$defs/Tag"`, 1)
+	_, _, err := LoadOpenAPIDocument(context.TODO(), []byte(document), nil)
+	assert(t, err != nil && strings.Contains(err.Error(), `unsupported reference "#/$defs/Tag"`), "expected unsupported reference error, got %v", err)
+}
+
+func TestDowngradeOpenAPI31Schema(t *testing.T) {
+	cases := []struct {
+		name     string
+		schema   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "exclusive minimum stricter than minimum",
+			schema:   map[string]interface{}{"minimum": 3.0, "exclusiveMinimum": 5.0},
+			expected: map[string]interface{}{"minimum": 5.0, "exclusiveMinimum": true},
+		},
+		{
+			name:     "minimum stricter than exclusive minimum",
+			schema:   map[string]interface{}{"minimum": 10.0, "exclusiveMinimum": 5.0},
+			expected: map[string]interface{}{"minimum": 10.0},
+		},
+		{
+			name:     "maximum stricter than exclusive maximum",
+			schema:   map[string]interface{}{"maximum": 10.0, "exclusiveMaximum": 20.0},
+			expected: map[string]interface{}{"maximum": 10.0},
+		},
+		{
+			name: "type array with existing anyOf",
+			schema: map[string]interface{}{
+				"type":  []interface{}{"string", "integer"},
+				"anyOf": []interface{}{map[string]interface{}{"minLength": 1.0}, map[string]interface{}{"minimum": 1.0}},
+			},
+			expected: map[string]interface{}{
+				"allOf": []interface{}{
+					map[string]interface{}{"anyOf": []interface{}{map[string]interface{}{"type": "string"}, map[string]interface{}{"type": "integer"}}},
+					map[string]interface{}{"anyOf": []interface{}{map[string]interface{}{"minLength": 1.0}, map[string]interface{}{"minimum": 1.0}}},
+				},
+			},
+		},
+		{
+			name:     "only null alternatives",
+			schema:   map[string]interface{}{"oneOf": []interface{}{map[string]interface{}{"type": "null"}}},
+			expected: map[string]interface{}{"nullable": true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			downgradeOpenAPI31Schema(tc.schema, map[string]bool{})
+			equals(subT, tc.expected, tc.schema)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	// DefaultGitMaxFetchSize limits the size of the objects downloaded to read the files of a commit
+	DefaultGitMaxFetchSize = 256 << 20
+
+	// gitTimeout limits the time to list the references or fetch a commit of a git repository
+	gitTimeout = 2 * time.Minute
+
+	// gitFetchedReference is the local reference of the fetched commit
+	gitFetchedReference = plumbing.ReferenceName("refs/remotes/origin/fetched")
+)
+
+// ErrGitFileNotFound is returned when the path does not exist in the fetched commit
+var ErrGitFileNotFound = errors.New("file not found in git repository")
+
+// ErrGitRefNotFound is returned when the repository does not have the requested reference
+var ErrGitRefNotFound = errors.New("reference not found in git repository")
+
+// ErrGitFetchTooLarge is returned when the objects of the fetched commit exceed the maximum fetch size
+var ErrGitFetchTooLarge = errors.New("git commit exceeds the maximum fetch size")
+
+// GitCredentials holds the basic auth credentials of a git repository.
+// Token based authentication uses the token as password
+type GitCredentials struct {
+	Username string
+	Password string
+}
+
+// GitRepository is a remote git repository served over HTTP(S)
+type GitRepository struct {
+	URL                string
+	Credentials        *GitCredentials
+	InsecureSkipVerify bool
+	// MaxFetchSize limits the size of the objects of the fetched commit. Defaults to DefaultGitMaxFetchSize
+	MaxFetchSize int64
+}
+
+// GitRef is a reference of a remote git repository resolved to the object it points to
+type GitRef struct {
+	// Name of the reference. Empty when the reference is a commit hash
+	Name string
+	// Hash of the object the reference points to: a commit, or the tag object of annotated tags
+	Hash string
+}
+
+// ResolveRef returns the hash the branch, tag or commit reference points to, listing the remote references
+// without downloading any object. Empty reference resolves to HEAD
+func (g *GitRepository) ResolveRef(ctx context.Context, ref string) (*GitRef, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	if plumbing.IsHash(ref) {
+		return &GitRef{Hash: ref}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{g.URL}})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: g.auth(), InsecureSkipTLS: g.InsecureSkipVerify})
+	if err != nil {
+		return nil, fmt.Errorf("error listing git references: %w", err)
+	}
+
+	refsByName := map[plumbing.ReferenceName]*plumbing.Reference{}
+	for _, remoteRef := range refs {
+		refsByName[remoteRef.Name()] = remoteRef
+	}
+
+	for _, name := range []string{ref, "refs/heads/" + ref, "refs/tags/" + ref} {
+		remoteRef, ok := refsByName[plumbing.ReferenceName(name)]
+		if ok && remoteRef.Type() == plumbing.SymbolicReference {
+			remoteRef, ok = refsByName[remoteRef.Target()]
+		}
+		if ok {
+			return &GitRef{Name: remoteRef.Name().String(), Hash: remoteRef.Hash().String()}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrGitRefNotFound, ref)
+}
+
+// FetchFile reads a file of the commit the reference points to.
+// Returns the file content and the commit hash
+func (g *GitRepository) FetchFile(ctx context.Context, ref *GitRef, filePath string) ([]byte, string, error) {
+	commit, err := g.fetch(ctx, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filePath = strings.Trim(filePath, "/")
+	file, err := commit.File(filePath)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, "", fmt.Errorf("%w: %s", ErrGitFileNotFound, filePath)
+		}
+		return nil, "", err
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return []byte(content), commit.Hash.String(), nil
+}
+
+// FetchDirectory reads the files of a directory of the commit the reference points to, subdirectories included.
+// Empty directory path reads the whole repository.
+// Returns the file contents by path relative to the directory and the commit hash
+func (g *GitRepository) FetchDirectory(ctx context.Context, ref *GitRef, dirPath string) (map[string][]byte, string, error) {
+	commit, err := g.fetch(ctx, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if dir := strings.Trim(dirPath, "/"); dir != "" {
+		tree, err = tree.Tree(dir)
+		if err != nil {
+			if errors.Is(err, object.ErrDirectoryNotFound) {
+				return nil, "", fmt.Errorf("%w: %s", ErrGitFileNotFound, dir)
+			}
+			return nil, "", err
+		}
+	}
+
+	files := map[string][]byte{}
+	err = tree.Files().ForEach(func(file *object.File) error {
+		content, err := file.Contents()
+		if err != nil {
+			return err
+		}
+		files[file.Name] = []byte(content)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return files, commit.Hash.String(), nil
+}
+
+// fetch downloads the commit the reference points to without its history
+func (g *GitRepository) fetch(ctx context.Context, ref *GitRef) (*object.Commit, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	limit := g.MaxFetchSize
+	if limit <= 0 {
+		limit = DefaultGitMaxFetchSize
+	}
+
+	repo, err := git.Init(&gitLimitedStorage{Storage: memory.NewStorage(), limit: limit}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := repo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{g.URL}})
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetching by name does not require the server to allow arbitrary commits in wants
+	source := ref.Name
+	if source == "" {
+		source = ref.Hash
+	}
+
+	err = remote.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs:        []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", source, gitFetchedReference))},
+		Depth:           1,
+		Tags:            git.NoTags,
+		Auth:            g.auth(),
+		InsecureSkipTLS: g.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching git reference %s: %w", source, err)
+	}
+
+	fetched, err := repo.Reference(gitFetchedReference, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchedObject, err := repo.Object(plumbing.AnyObject, fetched.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	switch obj := fetchedObject.(type) {
+	case *object.Commit:
+		return obj, nil
+	case *object.Tag:
+		return obj.Commit()
+	}
+
+	return nil, fmt.Errorf("git reference %s does not point to a commit", source)
+}
+
+func (g *GitRepository) auth() transport.AuthMethod {
+	if g.Credentials == nil {
+		return nil
+	}
+
+	return &githttp.BasicAuth{Username: g.Credentials.Username, Password: g.Credentials.Password}
+}
+
+// gitLimitedStorage is an in memory git storage failing when the stored objects exceed the limit
+type gitLimitedStorage struct {
+	*memory.Storage
+	limit int64
+	size  int64
+}
+
+func (s *gitLimitedStorage) SetEncodedObject(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	s.size += obj.Size()
+	if s.size > s.limit {
+		return plumbing.ZeroHash, fmt.Errorf("%w of %d bytes", ErrGitFetchTooLarge, s.limit)
+	}
+
+	return s.Storage.SetEncodedObject(obj)
+}
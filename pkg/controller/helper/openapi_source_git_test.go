@@ -0,0 +1,188 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testGitBaseContent   = "openapi: 3.0.0\ninfo: old\n"
+	testGitTargetContent = "openapi: 3.0.0\ninfo: new\n"
+)
+
+// testGitServer serves over the git smart HTTP protocol a repository whose main branch has two commits
+// changing docs/openapi.yaml. The first commit is tagged v1 by an annotated tag.
+// Returns the server and the hashes of both commits
+func testGitServer(t *testing.T) (*httptest.Server, string, string) {
+	t.Helper()
+
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	work := filepath.Join(root, "work")
+	runGit := func(dir string, args ...string) string {
+		cmd := exec.Command(gitPath, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		assert(t, err == nil, "git %v: %v: %s", args, err, out)
+		return strings.TrimSpace(string(out))
+	}
+
+	ok(t, os.MkdirAll(filepath.Join(work, "docs"), 0o755))
+	runGit(work, "init", "--initial-branch=main")
+	ok(t, os.WriteFile(filepath.Join(work, "docs", "openapi.yaml"), []byte(testGitBaseContent), 0o644))
+	runGit(work, "add", ".")
+	runGit(work, "commit", "-m", "initial")
+	runGit(work, "tag", "-a", "v1", "-m", "v1")
+	baseHash := runGit(work, "rev-parse", "HEAD")
+
+	ok(t, os.WriteFile(filepath.Join(work, "docs", "openapi.yaml"), []byte(testGitTargetContent), 0o644))
+	runGit(work, "commit", "-am", "update")
+	targetHash := runGit(work, "rev-parse", "HEAD")
+
+	runGit(root, "clone", "--bare", work, "repo.git")
+	runGit(filepath.Join(root, "repo.git"), "config", "uploadpack.allowReachableSHA1InWant", "true")
+
+	backend := &cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Root: "/",
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, _ := req.BasicAuth()
+		if username != "user" || password != "token" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		backend.ServeHTTP(w, req)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, baseHash, targetHash
+}
+
+func TestGitRepositoryResolveRef(t *testing.T) {
+	server, baseHash, targetHash := testGitServer(t)
+	repo := &GitRepository{URL: server.URL + "/repo.git", Credentials: &GitCredentials{Username: "user", Password: "token"}}
+
+	t.Run("default reference", func(t *testing.T) {
+		ref, err := repo.ResolveRef(context.TODO(), "")
+		ok(t, err)
+		equals(t, &GitRef{Name: "refs/heads/main", Hash: targetHash}, ref)
+	})
+
+	t.Run("branch", func(t *testing.T) {
+		ref, err := repo.ResolveRef(context.TODO(), "main")
+		ok(t, err)
+		equals(t, &GitRef{Name: "refs/heads/main", Hash: targetHash}, ref)
+	})
+
+	t.Run("annotated tag", func(t *testing.T) {
+		ref, err := repo.ResolveRef(context.TODO(), "v1")
+		ok(t, err)
+		equals(t, "refs/tags/v1", ref.Name)
+		// the tag object hash, not the tagged commit
+		assert(t, ref.Hash != baseHash, "expected the tag object hash")
+	})
+
+	t.Run("commit hash", func(t *testing.T) {
+		ref, err := repo.ResolveRef(context.TODO(), baseHash)
+		ok(t, err)
+		equals(t, &GitRef{Hash: baseHash}, ref)
+	})
+
+	t.Run("missing reference", func(t *testing.T) {
+		_, err := repo.ResolveRef(context.TODO(), "develop")
+		assert(t, errors.Is(err, ErrGitRefNotFound), "expected reference not found error, got %v", err)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		_, err := (&GitRepository{URL: repo.URL}).ResolveRef(context.TODO(), "main")
+		assert(t, err != nil, "missing credentials should return error")
+	})
+}
+
+func TestGitRepositoryFetchFile(t *testing.T) {
+	server, baseHash, targetHash := testGitServer(t)
+	repo := &GitRepository{URL: server.URL + "/repo.git", Credentials: &GitCredentials{Username: "user", Password: "token"}}
+
+	fetchFile := func(t *testing.T, reference, filePath string) ([]byte, string, error) {
+		t.Helper()
+		ref, err := repo.ResolveRef(context.TODO(), reference)
+		ok(t, err)
+		return repo.FetchFile(context.TODO(), ref, filePath)
+	}
+
+	t.Run("branch", func(t *testing.T) {
+		content, revision, err := fetchFile(t, "main", "/docs/openapi.yaml")
+		ok(t, err)
+		equals(t, testGitTargetContent, string(content))
+		equals(t, targetHash, revision)
+	})
+
+	t.Run("annotated tag", func(t *testing.T) {
+		content, revision, err := fetchFile(t, "v1", "docs/openapi.yaml")
+		ok(t, err)
+		equals(t, testGitBaseContent, string(content))
+		equals(t, baseHash, revision)
+	})
+
+	t.Run("commit hash", func(t *testing.T) {
+		content, revision, err := fetchFile(t, baseHash, "docs/openapi.yaml")
+		ok(t, err)
+		equals(t, testGitBaseContent, string(content))
+		equals(t, baseHash, revision)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, _, err := fetchFile(t, "main", "docs/missing.yaml")
+		assert(t, errors.Is(err, ErrGitFileNotFound), "expected file not found error, got %v", err)
+	})
+}
+
+func TestGitRepositoryFetchDirectory(t *testing.T) {
+	server, _, targetHash := testGitServer(t)
+	repo := &GitRepository{URL: server.URL + "/repo.git", Credentials: &GitCredentials{Username: "user", Password: "token"}}
+
+	ref, err := repo.ResolveRef(context.TODO(), "main")
+	ok(t, err)
+
+	files, revision, err := repo.FetchDirectory(context.TODO(), ref, "")
+	ok(t, err)
+	equals(t, targetHash, revision)
+	equals(t, map[string][]byte{"docs/openapi.yaml": []byte(testGitTargetContent)}, files)
+
+	files, _, err = repo.FetchDirectory(context.TODO(), ref, "/docs/")
+	ok(t, err)
+	equals(t, map[string][]byte{"openapi.yaml": []byte(testGitTargetContent)}, files)
+
+	_, _, err = repo.FetchDirectory(context.TODO(), ref, "missing")
+	assert(t, errors.Is(err, ErrGitFileNotFound), "expected file not found error, got %v", err)
+}
+
+func TestGitRepositoryMaxFetchSize(t *testing.T) {
+	server, _, _ := testGitServer(t)
+	repo := &GitRepository{URL: server.URL + "/repo.git", Credentials: &GitCredentials{Username: "user", Password: "token"}, MaxFetchSize: 16}
+
+	ref, err := repo.ResolveRef(context.TODO(), "main")
+	ok(t, err)
+
+	_, _, err = repo.FetchFile(context.TODO(), ref, "docs/openapi.yaml")
+	assert(t, errors.Is(err, ErrGitFetchTooLarge), "expected fetch too large error, got %v", err)
+}
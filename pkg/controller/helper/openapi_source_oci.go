@@ -0,0 +1,268 @@
+package helper
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	ociImageTitleAnnotation = "org.opencontainers.image.title"
+	dockerHubLegacyAuthKey  = "https://index.docker.io/v1/"
+	dockerHubRegistry       = "docker.io"
+	dockerHubRegistryAPI    = "registry-1.docker.io"
+	maxOCIFileSize          = 64 << 20
+
+	// ociTimeout limits the time to resolve the digest or read a file of an artifact
+	ociTimeout = 2 * time.Minute
+)
+
+// ErrOCIFileNotFound is returned when the artifact does not have the requested file
+var ErrOCIFileNotFound = errors.New("file not found in OCI artifact")
+
+// ErrOCIFileTooLarge is returned when the artifact layer or the file exceeds the maximum size
+var ErrOCIFileTooLarge = errors.New("OCI artifact file exceeds the maximum size")
+
+// OCIArtifact is an artifact of an OCI registry
+type OCIArtifact struct {
+	// Image is the artifact reference: registry/repository[:tag][@digest]. Docker Hub is the default registry
+	Image string
+	// DockerConfigJSON holds the registry credentials in the dockerconfigjson or dockercfg format, it can be empty
+	DockerConfigJSON   []byte
+	InsecureSkipVerify bool
+}
+
+// ResolveDigest returns the manifest digest the artifact reference points to, without downloading the artifact
+func (a *OCIArtifact) ResolveDigest(ctx context.Context) (string, error) {
+	ref, err := name.ParseReference(a.Image)
+	if err != nil {
+		return "", err
+	}
+
+	if digest, ok := ref.(name.Digest); ok {
+		return digest.DigestStr(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ociTimeout)
+	defer cancel()
+
+	options, err := a.remoteOptions(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	descriptor, err := remote.Head(ref, options...)
+	if err != nil {
+		return "", fmt.Errorf("error reading OCI manifest %s: %w", a.Image, err)
+	}
+
+	return descriptor.Digest.String(), nil
+}
+
+// FetchFile reads a file of the artifact manifest with the given digest.
+// The file is the artifact layer titled with the file path, or the only layer when the path is empty.
+// Layers packed as tar archives are searched for the file path
+func (a *OCIArtifact) FetchFile(ctx context.Context, digest, filePath string) ([]byte, error) {
+	ref, err := name.ParseReference(a.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ociTimeout)
+	defer cancel()
+
+	options, err := a.remoteOptions(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestRef := ref.Context().Digest(digest)
+	descriptor, err := remote.Get(manifestRef, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OCI manifest %s: %w", manifestRef, err)
+	}
+
+	manifest, err := v1.ParseManifest(bytes.NewReader(descriptor.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding OCI manifest %s: %w", manifestRef, err)
+	}
+
+	layer, err := ociSelectLayer(manifest, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if layer.Size > maxOCIFileSize {
+		return nil, fmt.Errorf("%w of %d bytes: layer %s has %d bytes", ErrOCIFileTooLarge, maxOCIFileSize, layer.Digest, layer.Size)
+	}
+
+	remoteLayer, err := remote.Layer(ref.Context().Digest(layer.Digest.String()), options...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OCI blob %s: %w", layer.Digest, err)
+	}
+
+	// The compressed content is the blob as stored, its digest is verified while reading
+	reader, err := remoteLayer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("error reading OCI blob %s: %w", layer.Digest, err)
+	}
+	defer reader.Close()
+
+	blob, err := ociReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OCI blob %s: %w", layer.Digest, err)
+	}
+
+	mediaType := string(layer.MediaType)
+	if strings.HasSuffix(mediaType, ".tar") || strings.HasSuffix(mediaType, ".tar+gzip") || strings.HasSuffix(mediaType, ".tar.gzip") {
+		return ociTarFile(blob, strings.HasSuffix(mediaType, "gzip"), filePath)
+	}
+
+	return blob, nil
+}
+
+func (a *OCIArtifact) remoteOptions(ctx context.Context, ref name.Reference) ([]remote.Option, error) {
+	auth := authn.Anonymous
+	if len(a.DockerConfigJSON) > 0 {
+		username, password, err := dockerConfigCredentials(a.DockerConfigJSON, ref.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		if username != "" || password != "" {
+			auth = authn.FromConfig(authn.AuthConfig{Username: username, Password: password})
+		}
+	}
+
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuth(auth),
+		remote.WithTransport(threescaleHTTPClient(a.InsecureSkipVerify).Transport),
+	}, nil
+}
+
+// ociReadAll reads the content up to the maximum file size
+func ociReadAll(reader io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxOCIFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxOCIFileSize {
+		return nil, fmt.Errorf("%w of %d bytes", ErrOCIFileTooLarge, maxOCIFileSize)
+	}
+
+	return data, nil
+}
+
+// ociSelectLayer returns the layer titled with the file path, or the only layer when the path is empty or not titled
+func ociSelectLayer(manifest *v1.Manifest, filePath string) (*v1.Descriptor, error) {
+	for idx := range manifest.Layers {
+		title := manifest.Layers[idx].Annotations[ociImageTitleAnnotation]
+		if filePath != "" && (title == filePath || title == path.Base(filePath)) {
+			return &manifest.Layers[idx], nil
+		}
+	}
+
+	if len(manifest.Layers) == 1 {
+		return &manifest.Layers[0], nil
+	}
+
+	if filePath == "" {
+		return nil, fmt.Errorf("OCI artifact has %d layers, the file path is required", len(manifest.Layers))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrOCIFileNotFound, filePath)
+}
+
+// ociTarFile reads a file from a tar layer
+func ociTarFile(blob []byte, gzipped bool, filePath string) ([]byte, error) {
+	var reader io.Reader = bytes.NewReader(blob)
+	if gzipped {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing OCI layer: %w", err)
+		}
+		reader = gzipReader
+	}
+
+	filePath = strings.Trim(filePath, "/")
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %s", ErrOCIFileNotFound, filePath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading OCI layer: %w", err)
+		}
+
+		if header.Typeflag == tar.TypeReg && strings.Trim(path.Clean(header.Name), "/") == filePath {
+			return ociReadAll(tarReader)
+		}
+	}
+}
+
+// dockerConfigCredentials reads the credentials of the registry from dockerconfigjson or dockercfg content
+func dockerConfigCredentials(data []byte, registry string) (string, string, error) {
+	type authEntry struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	config := struct {
+		Auths map[string]authEntry `json:"auths"`
+	}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", "", fmt.Errorf("error decoding registry credentials: %w", err)
+	}
+
+	// dockercfg format has no auths wrapper
+	if config.Auths == nil {
+		if err := json.Unmarshal(data, &config.Auths); err != nil {
+			return "", "", fmt.Errorf("error decoding registry credentials: %w", err)
+		}
+	}
+
+	keys := []string{registry, "https://" + registry, "http://" + registry}
+	if registry == name.DefaultRegistry || registry == dockerHubRegistry {
+		keys = append(keys, dockerHubRegistry, dockerHubLegacyAuthKey, dockerHubRegistryAPI)
+	}
+
+	for _, key := range keys {
+		entry, ok := config.Auths[key]
+		if !ok {
+			entry, ok = config.Auths[key+"/"]
+		}
+		if !ok {
+			continue
+		}
+
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return "", "", fmt.Errorf("error decoding registry credentials: %w", err)
+			}
+			username, password, _ := strings.Cut(string(decoded), ":")
+			return username, password, nil
+		}
+
+		return entry.Username, entry.Password, nil
+	}
+
+	return "", "", nil
+}
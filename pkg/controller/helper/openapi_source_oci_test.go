@@ -0,0 +1,163 @@
+package helper
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// testOCIRegistry serves a registry requiring basic auth credentials
+func testOCIRegistry(t *testing.T) (string, []byte) {
+	t.Helper()
+
+	handler := registry.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, _ := req.BasicAuth()
+		if username != "user" || password != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handler.ServeHTTP(w, req)
+	}))
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	dockerConfigJSON := []byte(fmt.Sprintf(`{"auths":{"%s":{"auth":"%s"}}}`, host, base64.StdEncoding.EncodeToString([]byte("user:pass"))))
+
+	return host, dockerConfigJSON
+}
+
+// testOCIPush pushes an artifact with the given layers and returns the manifest digest
+func testOCIPush(t *testing.T, image string, layers ...mutate.Addendum) string {
+	t.Helper()
+
+	artifact, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1), layers...)
+	ok(t, err)
+
+	ref, err := name.ParseReference(image)
+	ok(t, err)
+	ok(t, remote.Write(ref, artifact, remote.WithAuth(&authn.Basic{Username: "user", Password: "pass"})))
+
+	digest, err := artifact.Digest()
+	ok(t, err)
+	return digest.String()
+}
+
+func testOCILayer(content []byte, mediaType, title string) mutate.Addendum {
+	return mutate.Addendum{
+		Layer:       static.NewLayer(content, types.MediaType(mediaType)),
+		Annotations: map[string]string{ociImageTitleAnnotation: title},
+	}
+}
+
+func TestOCIArtifactResolveDigest(t *testing.T) {
+	host, dockerConfigJSON := testOCIRegistry(t)
+	digest := testOCIPush(t, host+"/team/petstore:v1", testOCILayer([]byte("openapi: 3.0.0\n"), "application/vnd.oai.openapi+yaml", "openapi.yaml"))
+
+	artifact := &OCIArtifact{Image: host + "/team/petstore:v1", DockerConfigJSON: dockerConfigJSON}
+	resolved, err := artifact.ResolveDigest(context.TODO())
+	ok(t, err)
+	equals(t, digest, resolved)
+
+	// digest references are not resolved with the registry
+	resolved, err = (&OCIArtifact{Image: "quay.io/team/petstore@" + digest}).ResolveDigest(context.TODO())
+	ok(t, err)
+	equals(t, digest, resolved)
+
+	_, err = (&OCIArtifact{Image: host + "/team/petstore:v1"}).ResolveDigest(context.TODO())
+	assert(t, err != nil, "missing credentials should return error")
+}
+
+func TestOCIArtifactFetchFile(t *testing.T) {
+	openapiContent := []byte("openapi: 3.0.0\n")
+	readmeContent := []byte("# petstore\n")
+
+	host, dockerConfigJSON := testOCIRegistry(t)
+	image := host + "/team/petstore:v1"
+	digest := testOCIPush(t, image,
+		testOCILayer(openapiContent, "application/vnd.oai.openapi+yaml", "openapi.yaml"),
+		testOCILayer(readmeContent, "text/markdown", "README.md"),
+	)
+	artifact := &OCIArtifact{Image: image, DockerConfigJSON: dockerConfigJSON}
+
+	t.Run("titled layer", func(t *testing.T) {
+		content, err := artifact.FetchFile(context.TODO(), digest, "openapi.yaml")
+		ok(t, err)
+		equals(t, string(openapiContent), string(content))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := artifact.FetchFile(context.TODO(), digest, "swagger.json")
+		assert(t, errors.Is(err, ErrOCIFileNotFound), "expected file not found error, got %v", err)
+	})
+
+	t.Run("path required with several layers", func(t *testing.T) {
+		_, err := artifact.FetchFile(context.TODO(), digest, "")
+		assert(t, err != nil, "empty path should return error")
+	})
+
+	t.Run("unknown digest", func(t *testing.T) {
+		_, err := artifact.FetchFile(context.TODO(), "sha256:"+strings.Repeat("0", 64), "openapi.yaml")
+		assert(t, err != nil, "unknown digest should return error")
+	})
+
+	t.Run("tar layer", func(t *testing.T) {
+		archive := &bytes.Buffer{}
+		tarWriter := tar.NewWriter(archive)
+		ok(t, tarWriter.WriteHeader(&tar.Header{Name: "docs/openapi.yaml", Mode: 0o644, Size: int64(len(openapiContent)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write(openapiContent)
+		ok(t, err)
+		ok(t, tarWriter.Close())
+
+		tarImage := host + "/team/petstore:tar"
+		tarDigest := testOCIPush(t, tarImage, mutate.Addendum{Layer: static.NewLayer(archive.Bytes(), types.OCIUncompressedLayer)})
+
+		content, err := (&OCIArtifact{Image: tarImage, DockerConfigJSON: dockerConfigJSON}).FetchFile(context.TODO(), tarDigest, "docs/openapi.yaml")
+		ok(t, err)
+		equals(t, string(openapiContent), string(content))
+	})
+}
+
+func TestOCIReadAll(t *testing.T) {
+	_, err := ociReadAll(bytes.NewReader(make([]byte, maxOCIFileSize+1)))
+	assert(t, errors.Is(err, ErrOCIFileTooLarge), "expected file too large error, got %v", err)
+
+	data, err := ociReadAll(bytes.NewReader([]byte("openapi: 3.0.0\n")))
+	ok(t, err)
+	equals(t, "openapi: 3.0.0\n", string(data))
+}
+
+func TestDockerConfigCredentials(t *testing.T) {
+	username, password, err := dockerConfigCredentials([]byte(`{"auths":{"https://quay.io":{"username":"user","password":"pass"}}}`), "quay.io")
+	ok(t, err)
+	equals(t, "user", username)
+	equals(t, "pass", password)
+
+	// dockercfg format
+	username, password, err = dockerConfigCredentials([]byte(`{"https://index.docker.io/v1/":{"auth":"`+base64.StdEncoding.EncodeToString([]byte("hub:secret"))+`"}}`), name.DefaultRegistry)
+	ok(t, err)
+	equals(t, "hub", username)
+	equals(t, "secret", password)
+
+	username, _, err = dockerConfigCredentials([]byte(`{"auths":{}}`), "quay.io")
+	ok(t, err)
+	equals(t, "", username)
+}
@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxOpenAPIDocumentSize limits the size of OpenAPI documents fetched from URLs
+const maxOpenAPIDocumentSize = 64 << 20
+
+// ErrOpenAPIDocumentTooLarge is returned when the document exceeds the maximum size
+var ErrOpenAPIDocumentTooLarge = errors.New("OpenAPI document exceeds the maximum size")
+
+// OpenAPISourceHTTPClient returns the http client used to fetch OpenAPI documents from URLs
+func OpenAPISourceHTTPClient(insecureSkipVerify bool) *http.Client {
+	client := threescaleHTTPClient(insecureSkipVerify)
+	client.Timeout = 2 * time.Minute
+	return client
+}
+
+// OpenAPIURLDocument is an OpenAPI document fetched from an URL
+type OpenAPIURLDocument struct {
+	Data         []byte
+	Hash         string
+	ETag         string
+	LastModified string
+}
+
+// FetchOpenAPIURL fetches the OpenAPI document of the URL.
+// When the previous document is given, the request is conditional on the previous ETag and Last-Modified headers
+// and the previous document is returned when the server answers it was not modified
+func FetchOpenAPIURL(ctx context.Context, httpClient *http.Client, rawURL string, previous *OpenAPIURLDocument) (*OpenAPIURLDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != nil {
+		if previous.ETag != "" {
+			req.Header.Set("If-None-Match", previous.ETag)
+		}
+		if previous.LastModified != "" {
+			req.Header.Set("If-Modified-Since", previous.LastModified)
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && previous != nil {
+		return previous, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching %s: unexpected status %s", rawURL, resp.Status)
+	}
+
+	// one byte over the limit tells truncated documents apart
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOpenAPIDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %w", rawURL, err)
+	}
+
+	if len(data) > maxOpenAPIDocumentSize {
+		return nil, fmt.Errorf("error fetching %s: %w of %d bytes", rawURL, ErrOpenAPIDocumentTooLarge, maxOpenAPIDocumentSize)
+	}
+
+	return &OpenAPIURLDocument{
+		Data:         data,
+		Hash:         OpenAPIDocumentHash(data),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// OpenAPIDocumentHash returns the content hash of an OpenAPI document
+func OpenAPIDocumentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
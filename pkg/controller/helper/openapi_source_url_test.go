@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchOpenAPIURL(t *testing.T) {
+	content := "openapi: 3.0.0\n"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	document, err := FetchOpenAPIURL(context.TODO(), server.Client(), server.URL+"/openapi.yaml", nil)
+	ok(t, err)
+	equals(t, content, string(document.Data))
+	equals(t, OpenAPIDocumentHash([]byte(content)), document.Hash)
+	equals(t, `"v1"`, document.ETag)
+	equals(t, "Mon, 01 Jan 2024 00:00:00 GMT", document.LastModified)
+
+	// Not modified answer returns the previous document
+	notModified, err := FetchOpenAPIURL(context.TODO(), server.Client(), server.URL+"/openapi.yaml", document)
+	ok(t, err)
+	assert(t, notModified == document, "expected the previous document")
+	equals(t, 2, requests)
+
+	// Outdated ETag downloads the document again
+	updated, err := FetchOpenAPIURL(context.TODO(), server.Client(), server.URL+"/openapi.yaml", &OpenAPIURLDocument{ETag: `"v0"`})
+	ok(t, err)
+	equals(t, content, string(updated.Data))
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	_, err = FetchOpenAPIURL(context.TODO(), server.Client(), server.URL+"/openapi.yaml", nil)
+	assert(t, err != nil, "not found response should return error")
+
+	// oversized documents are rejected instead of truncated
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte(" "), maxOpenAPIDocumentSize+1))
+	})
+	_, err = FetchOpenAPIURL(context.TODO(), server.Client(), server.URL+"/openapi.yaml", nil)
+	assert(t, errors.Is(err, ErrOpenAPIDocumentTooLarge), "expected document too large error, got %v", err)
+}
@@ -0,0 +1,301 @@
+package helper
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// ProductOpenAPIVersion is the info version of the OpenAPI documents exported from products
+	ProductOpenAPIVersion = "1.0.0"
+
+	productOpenAPIDefaultUserKey = "user_key"
+	productOpenAPIDefaultAppID   = "app_id"
+	productOpenAPIDefaultAppKey  = "app_key"
+	// 3scale reads the credentials from the query by default
+	productOpenAPIDefaultCredentialsLoc = "query"
+)
+
+var (
+	// mappingRulePathParamRegexp matches the {param} placeholders of the mapping rule patterns
+	mappingRulePathParamRegexp = regexp.MustCompile(`{([^{}/]+)}`)
+)
+
+// ProductToOpenAPI exports the product as an OpenAPI document skeleton.
+// Paths are built from the product mapping rules and the mapping rules of the used backends,
+// security from the authentication settings and servers from the public base URLs.
+// Metrics, policies and application plans are set in the x-3scale-product extension
+// and the used backends in the x-3scale-backends extension.
+// The OIDC issuer endpoint is required when the product authentication is OpenID Connect.
+func ProductToOpenAPI(product *capabilitiesv1beta1.Product, backends []capabilitiesv1beta1.Backend, issuerEndpoint string) (*openapi3.T, error) {
+	obj := &openapi3.T{
+		OpenAPI: "3.0.2",
+		Info: &openapi3.Info{
+			Title:       product.Spec.Name,
+			Description: product.Spec.Description,
+			Version:     ProductOpenAPIVersion,
+		},
+		Paths: openapi3.Paths{},
+	}
+
+	if product.Spec.ProdPublicBaseURL() != nil && *product.Spec.ProdPublicBaseURL() != "" {
+		obj.Servers = append(obj.Servers, &openapi3.Server{URL: *product.Spec.ProdPublicBaseURL(), Description: "Production"})
+	}
+	if product.Spec.StagingPublicBaseURL() != nil && *product.Spec.StagingPublicBaseURL() != "" {
+		obj.Servers = append(obj.Servers, &openapi3.Server{URL: *product.Spec.StagingPublicBaseURL(), Description: "Staging"})
+	}
+
+	err := productOpenAPISecurity(obj, product, issuerEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	operationIDs := productOpenAPIOperationIDs(product, backends)
+
+	addMappingRuleOperations(obj, "", product.Spec.MappingRules, product.Spec.Methods, product.Spec.Metrics, operationIDs)
+
+	backendSystemNames := helper.MapKeys(product.Spec.BackendUsages)
+	sort.Strings(backendSystemNames)
+
+	backendExtensions := make([]helper.OasBackendExtension, 0)
+	for _, backendSystemName := range backendSystemNames {
+		backend := findBackend(backends, backendSystemName)
+		if backend == nil {
+			continue
+		}
+
+		usagePath := product.Spec.BackendUsages[backendSystemName].Path
+		addMappingRuleOperations(obj, strings.TrimSuffix(usagePath, "/"), backend.Spec.MappingRules, backend.Spec.Methods, backend.Spec.Metrics, operationIDs)
+
+		backendExtensions = append(backendExtensions, helper.OasBackendExtension{
+			SystemName:     backendSystemName,
+			Name:           backend.Spec.Name,
+			Description:    backend.Spec.Description,
+			Path:           usagePath,
+			PrivateBaseURL: backend.Spec.PrivateBaseURL,
+			Metrics:        productOpenAPIMetrics(backend.Spec.Metrics),
+		})
+	}
+
+	obj.Extensions = map[string]interface{}{
+		"x-3scale-product": &helper.OasRootProductExtension{
+			Metrics:          productOpenAPIMetrics(product.Spec.Metrics),
+			Policies:         product.Spec.Policies,
+			ApplicationPlans: product.Spec.ApplicationPlans,
+		},
+	}
+	if len(backendExtensions) > 0 {
+		obj.Extensions["x-3scale-backends"] = backendExtensions
+	}
+
+	return obj, nil
+}
+
+// productOpenAPIMetrics returns the metrics without the default hits metric
+func productOpenAPIMetrics(metrics map[string]capabilitiesv1beta1.MetricSpec) map[string]capabilitiesv1beta1.MetricSpec {
+	result := map[string]capabilitiesv1beta1.MetricSpec{}
+	for systemName, metric := range metrics {
+		if systemName == "hits" {
+			continue
+		}
+		result[systemName] = metric
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// productOpenAPIOperationIDs returns the methods referenced by a single mapping rule.
+// Only those can be used as operation IDs, which must be unique in the document.
+func productOpenAPIOperationIDs(product *capabilitiesv1beta1.Product, backends []capabilitiesv1beta1.Backend) map[string]bool {
+	references := map[string]int{}
+	countReferences := func(mappingRules []capabilitiesv1beta1.MappingRuleSpec, methods map[string]capabilitiesv1beta1.MethodSpec) {
+		for _, mappingRule := range mappingRules {
+			if _, ok := methods[mappingRule.MetricMethodRef]; ok {
+				references[mappingRule.MetricMethodRef]++
+			}
+		}
+	}
+
+	countReferences(product.Spec.MappingRules, product.Spec.Methods)
+	for backendSystemName := range product.Spec.BackendUsages {
+		if backend := findBackend(backends, backendSystemName); backend != nil {
+			countReferences(backend.Spec.MappingRules, backend.Spec.Methods)
+		}
+	}
+
+	operationIDs := map[string]bool{}
+	for methodSystemName, count := range references {
+		if count == 1 {
+			operationIDs[methodSystemName] = true
+		}
+	}
+
+	return operationIDs
+}
+
+// addMappingRuleOperations adds one operation per mapping rule path and verb.
+// The first mapping rule of the path and verb is used, as 3scale does when matching requests.
+func addMappingRuleOperations(obj *openapi3.T, pathPrefix string, mappingRules []capabilitiesv1beta1.MappingRuleSpec,
+	methods map[string]capabilitiesv1beta1.MethodSpec, metrics map[string]capabilitiesv1beta1.MetricSpec, operationIDs map[string]bool) {
+	for _, mappingRule := range mappingRules {
+		path := MappingRulePatternToOpenAPIPath(mappingRule.Pattern)
+		if pathPrefix != "" {
+			if path == "/" {
+				// the root pattern of a backend matches the backend usage path
+				path = ""
+			}
+			path = pathPrefix + path
+		}
+		if path == "" {
+			path = "/"
+		}
+
+		pathItem, ok := obj.Paths[path]
+		if !ok {
+			pathItem = &openapi3.PathItem{}
+			for _, match := range mappingRulePathParamRegexp.FindAllStringSubmatch(path, -1) {
+				pathItem.Parameters = append(pathItem.Parameters, &openapi3.ParameterRef{
+					Value: openapi3.NewPathParameter(match[1]).WithSchema(openapi3.NewStringSchema()),
+				})
+			}
+			obj.Paths[path] = pathItem
+		}
+
+		if pathItem.GetOperation(mappingRule.HTTPMethod) != nil {
+			continue
+		}
+
+		operation := &openapi3.Operation{
+			Responses: openapi3.NewResponses(),
+		}
+		if method, ok := methods[mappingRule.MetricMethodRef]; ok {
+			operation.Summary = method.Name
+			operation.Description = method.Description
+			if operationIDs[mappingRule.MetricMethodRef] {
+				operation.OperationID = mappingRule.MetricMethodRef
+			}
+		} else if metric, ok := metrics[mappingRule.MetricMethodRef]; ok {
+			operation.Summary = metric.Name
+		}
+
+		operation.Extensions = map[string]interface{}{
+			"x-3scale-operation": &helper.OasOperationExtension{
+				MappingRule: helper.OasMappingRuleExtension{
+					MetricMethodRef: mappingRule.MetricMethodRef,
+					Increment:       mappingRule.Increment,
+					Last:            mappingRule.Last,
+				},
+			},
+		}
+
+		pathItem.SetOperation(mappingRule.HTTPMethod, operation)
+	}
+}
+
+// MappingRulePatternToOpenAPIPath removes the end anchor and the query string of the mapping rule pattern
+func MappingRulePatternToOpenAPIPath(pattern string) string {
+	path := strings.SplitN(pattern, "?", 2)[0]
+	return strings.TrimSuffix(path, "$")
+}
+
+func productOpenAPISecurity(obj *openapi3.T, product *capabilitiesv1beta1.Product, issuerEndpoint string) error {
+	authenticationMode := product.Spec.AuthenticationMode()
+	if authenticationMode == nil {
+		return nil
+	}
+
+	credentialsLoc := productOpenAPIDefaultCredentialsLoc
+	if product.Spec.CredentialsLocation() != nil {
+		credentialsLoc = *product.Spec.CredentialsLocation()
+	}
+
+	// The key is the security scheme name
+	securitySchemes := map[string]*openapi3.SecurityScheme{}
+	switch *authenticationMode {
+	case "1":
+		userKey := stringOrDefault(product.Spec.AuthUserKey(), productOpenAPIDefaultUserKey)
+		if credentialsLoc == "authorization" {
+			securitySchemes[userKey] = openapi3.NewSecurityScheme().WithType("http").WithScheme("basic")
+		} else {
+			securitySchemes[userKey] = productOpenAPIAPIKeyScheme(userKey, credentialsLoc, helper.OasCredentialUserKey)
+		}
+	case "2":
+		if credentialsLoc == "authorization" {
+			securitySchemes["basic"] = openapi3.NewSecurityScheme().WithType("http").WithScheme("basic")
+		} else {
+			appID := stringOrDefault(product.Spec.AuthAppID(), productOpenAPIDefaultAppID)
+			appKey := stringOrDefault(product.Spec.AuthAppKey(), productOpenAPIDefaultAppKey)
+			securitySchemes[appID] = productOpenAPIAPIKeyScheme(appID, credentialsLoc, helper.OasCredentialAppID)
+			securitySchemes[appKey] = productOpenAPIAPIKeyScheme(appKey, credentialsLoc, helper.OasCredentialAppKey)
+		}
+	case "oidc":
+		if issuerEndpoint == "" {
+			return fmt.Errorf("product %s: OIDC issuer endpoint is required to export the OpenID Connect security scheme", product.Name)
+		}
+
+		issuerURL, err := url.Parse(issuerEndpoint)
+		if err != nil {
+			return fmt.Errorf("product %s: invalid OIDC issuer endpoint: %w", product.Name, err)
+		}
+		// The issuer endpoint includes the client credentials
+		issuerURL.User = nil
+		issuerURL.Path = strings.TrimSuffix(issuerURL.Path, "/") + "/.well-known/openid-configuration"
+
+		securitySchemes["oidc"] = openapi3.NewOIDCSecurityScheme(issuerURL.String())
+	default:
+		return nil
+	}
+
+	obj.Components.SecuritySchemes = openapi3.SecuritySchemes{}
+	securityRequirement := openapi3.NewSecurityRequirement()
+	for name, securityScheme := range securitySchemes {
+		obj.Components.SecuritySchemes[name] = &openapi3.SecuritySchemeRef{Value: securityScheme}
+		securityRequirement.Authenticate(name)
+	}
+	obj.Security = *openapi3.NewSecurityRequirements().With(securityRequirement)
+
+	return nil
+}
+
+func productOpenAPIAPIKeyScheme(name, credentialsLoc, credential string) *openapi3.SecurityScheme {
+	in := "query"
+	if credentialsLoc == "headers" {
+		in = "header"
+	}
+
+	securityScheme := openapi3.NewSecurityScheme().WithType("apiKey").WithName(name).WithIn(in)
+	securityScheme.Extensions = map[string]interface{}{
+		"x-3scale-credential": credential,
+	}
+
+	return securityScheme
+}
+
+func findBackend(backends []capabilitiesv1beta1.Backend, systemName string) *capabilitiesv1beta1.Backend {
+	for idx := range backends {
+		if backends[idx].Spec.SystemName == systemName {
+			return &backends[idx]
+		}
+	}
+
+	return nil
+}
+
+func stringOrDefault(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+
+	return *value
+}
@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"fmt"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+
+	"github.com/go-logr/logr"
+)
+
+type ServicePlanEntity struct {
+	productID int64
+	client    *AdminAPIClient
+	obj       PlanItem
+	features  *FeatureList
+	logger    logr.Logger
+}
+
+func NewServicePlanEntity(productID int64, obj PlanItem, cl *AdminAPIClient, logger logr.Logger) *ServicePlanEntity {
+	return &ServicePlanEntity{
+		productID: productID,
+		obj:       obj,
+		client:    cl,
+		logger:    logger.WithValues("ServicePlanEntity", obj.ID),
+	}
+}
+
+func (b *ServicePlanEntity) ID() int64 {
+	return b.obj.ID
+}
+
+func (b *ServicePlanEntity) Name() string {
+	return b.obj.Name
+}
+
+func (b *ServicePlanEntity) ApprovalRequired() bool {
+	return b.obj.ApprovalRequired
+}
+
+func (b *ServicePlanEntity) TrialPeriodDays() int {
+	return b.obj.TrialPeriodDays
+}
+
+func (b *ServicePlanEntity) SetupFee() float64 {
+	return b.obj.SetupFee
+}
+
+func (b *ServicePlanEntity) CostPerMonth() float64 {
+	return b.obj.CostPerMonth
+}
+
+func (b *ServicePlanEntity) State() string {
+	return b.obj.State
+}
+
+func (b *ServicePlanEntity) IsDefault() bool {
+	return b.obj.Default
+}
+
+func (b *ServicePlanEntity) Update(params threescaleapi.Params) error {
+	b.logger.V(1).Info("Update", "params", params)
+	updated, err := b.client.UpdateServicePlan(b.productID, b.obj.ID, params)
+	if err != nil {
+		return fmt.Errorf("product [%d] service plan [%s] update: %w", b.productID, b.obj.SystemName, err)
+	}
+
+	b.obj = updated.Element
+
+	return nil
+}
+
+func (b *ServicePlanEntity) SetDefault() error {
+	b.logger.V(1).Info("SetDefault")
+	updated, err := b.client.SetDefaultServicePlan(b.productID, b.obj.ID)
+	if err != nil {
+		return fmt.Errorf("product [%d] service plan [%s] set default: %w", b.productID, b.obj.SystemName, err)
+	}
+
+	b.obj = updated.Element
+
+	return nil
+}
+
+func (b *ServicePlanEntity) Features() (*FeatureList, error) {
+	if b.features == nil {
+		features, err := b.getFeatures()
+		if err != nil {
+			return nil, err
+		}
+		b.features = features
+	}
+	return b.features, nil
+}
+
+func (b *ServicePlanEntity) getFeatures() (*FeatureList, error) {
+	b.logger.V(1).Info("getFeatures")
+	list, err := b.client.ListServicePlanFeatures(b.obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service plan [%s] get features: %w", b.obj.SystemName, err)
+	}
+
+	return list, nil
+}
+
+func (b *ServicePlanEntity) CreateFeature(featureID int64) error {
+	b.logger.V(1).Info("CreateFeature", "featureID", featureID)
+	err := b.client.CreateServicePlanFeature(b.obj.ID, featureID)
+	if err != nil {
+		return fmt.Errorf("service plan [%s] create feature: %w", b.obj.SystemName, err)
+	}
+	b.resetFeatures()
+	return nil
+}
+
+func (b *ServicePlanEntity) DeleteFeature(featureID int64) error {
+	b.logger.V(1).Info("DeleteFeature", "featureID", featureID)
+	err := b.client.DeleteServicePlanFeature(b.obj.ID, featureID)
+	if err != nil {
+		return fmt.Errorf("service plan [%s] delete feature: %w", b.obj.SystemName, err)
+	}
+	b.resetFeatures()
+	return nil
+}
+
+func (b *ServicePlanEntity) resetFeatures() {
+	b.features = nil
+}
@@ -0,0 +1,83 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestServicePlanEntityBasics(t *testing.T) {
+	var productID int64 = 1293
+	planItem := PlanItem{
+		ID:               4567,
+		Name:             "some plan",
+		ApprovalRequired: true,
+		TrialPeriodDays:  3,
+		SetupFee:         5.67,
+		CostPerMonth:     8.67,
+		State:            "hidden",
+		Default:          true,
+	}
+
+	planEntity := NewServicePlanEntity(productID, planItem, nil, logr.Discard())
+	equals(t, planEntity.ID(), planItem.ID)
+	equals(t, planEntity.Name(), planItem.Name)
+	equals(t, planEntity.ApprovalRequired(), planItem.ApprovalRequired)
+	equals(t, planEntity.TrialPeriodDays(), planItem.TrialPeriodDays)
+	equals(t, planEntity.SetupFee(), planItem.SetupFee)
+	equals(t, planEntity.CostPerMonth(), planItem.CostPerMonth)
+	equals(t, planEntity.State(), planItem.State)
+	equals(t, planEntity.IsDefault(), planItem.Default)
+}
+
+func TestServicePlanEntityFeatures(t *testing.T) {
+	var productID int64 = 1293
+	listCalls := 0
+
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		var respObject interface{}
+		statusCode := http.StatusOK
+
+		switch req.Method {
+		case http.MethodGet:
+			listCalls++
+			respObject = FeatureList{Features: []Feature{{Element: FeatureItem{ID: 1, SystemName: "feature01"}}}}
+		case http.MethodPost:
+			ok(t, req.ParseForm())
+			equals(t, "2", req.PostForm.Get("feature_id"))
+			statusCode = http.StatusCreated
+			respObject = Feature{Element: FeatureItem{ID: 2, SystemName: "feature02"}}
+		}
+
+		responseBodyBytes, err := json.Marshal(respObject)
+		ok(t, err)
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBodyBytes)),
+			Header:     make(http.Header),
+		}
+	})
+
+	planEntity := NewServicePlanEntity(productID, PlanItem{ID: 4567}, client, logr.Discard())
+	features, err := planEntity.Features()
+	ok(t, err)
+	equals(t, 1, len(features.Features))
+
+	// cached
+	_, err = planEntity.Features()
+	ok(t, err)
+	equals(t, 1, listCalls)
+
+	err = planEntity.CreateFeature(2)
+	ok(t, err)
+
+	// cache reset after changes
+	_, err = planEntity.Features()
+	ok(t, err)
+	equals(t, 2, listCalls)
+}
@@ -3,7 +3,7 @@ package helper
 import (
 	"context"
 
-	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -18,13 +18,13 @@ RetrieveTenantCR retrieves tenantCR of a tenant that matches the provider accoun
 If the tenantList is empty it will return nil, nil
 If tenantCR for given providerAccount org is not present, it will return nil, nil
 */
-func RetrieveTenantCR(providerAccount *ProviderAccount, client k8sclient.Client, logger logr.Logger, namespace string) (*capabilitiesv1alpha1.Tenant, error) {
+func RetrieveTenantCR(providerAccount *ProviderAccount, client k8sclient.Client, logger logr.Logger, namespace string) (*capabilitiesv1beta1.Tenant, error) {
 	// Retrieve all product CRs that are under the same ns as the backend CR
 	opts := k8sclient.ListOptions{
 		Namespace: namespace,
 	}
 
-	tenantList := &capabilitiesv1alpha1.TenantList{}
+	tenantList := &capabilitiesv1beta1.TenantList{}
 	err := client.List(context.TODO(), tenantList, &opts)
 	if err != nil {
 		return nil, err
@@ -54,7 +54,7 @@ retrieveTenantSecret retrieves tenants secret
 - k8client
 - tenantCR
 */
-func retrieveTenantSecret(client k8sclient.Client, tenantCR *capabilitiesv1alpha1.Tenant) (*corev1.Secret, error) {
+func retrieveTenantSecret(client k8sclient.Client, tenantCR *capabilitiesv1beta1.Tenant) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
 
 	err := client.Get(context.TODO(), k8sclient.ObjectKey{Name: tenantCR.Spec.TenantSecretRef.Name, Namespace: tenantCR.Spec.TenantSecretRef.Namespace}, secret)
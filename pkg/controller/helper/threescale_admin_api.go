@@ -0,0 +1,141 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// AdminAPIClient implements the 3scale Account Management API endpoints
+// not available in porta_client.ThreeScaleClient.
+// Requests and responses are JSON encoded.
+type AdminAPIClient struct {
+	adminURL   string
+	token      string
+	httpClient *http.Client
+}
+
+// AdminAPIError is returned when 3scale responds with an unexpected status code
+type AdminAPIError struct {
+	code    int
+	message string
+}
+
+func (e *AdminAPIError) Error() string {
+	return fmt.Sprintf("error calling 3scale system - reason: %s - code: %d", e.message, e.code)
+}
+
+func (e *AdminAPIError) Code() int {
+	return e.code
+}
+
+// IsAdminAPINotFound returns true when the error is a not found error
+// returned either by AdminAPIClient or porta_client.ThreeScaleClient
+func IsAdminAPINotFound(err error) bool {
+	var apiErr *AdminAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code() == http.StatusNotFound
+	}
+
+	return threescaleapi.IsNotFound(err)
+}
+
+// AdminClient instantiates AdminAPIClient from ProviderAccount object
+func AdminClient(providerAccount *ProviderAccount, insecureSkipVerify bool) (*AdminAPIClient, error) {
+	return AdminClientFromURLString(providerAccount.AdminURLStr, providerAccount.Token, insecureSkipVerify)
+}
+
+// AdminClientFromURLString instantiates AdminAPIClient from url string
+func AdminClientFromURLString(adminURLStr, token string, insecureSkipVerify bool) (*AdminAPIClient, error) {
+	adminURL, err := url.Parse(adminURLStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if adminURL.Scheme != "http" && adminURL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported schema %s passed to admin API client", adminURL.Scheme)
+	}
+
+	if adminURL.Hostname() == "" {
+		return nil, errors.New("admin API client: hostname empty after parsing")
+	}
+
+	return NewAdminAPIClient(adminURL, token, threescaleHTTPClient(insecureSkipVerify)), nil
+}
+
+func NewAdminAPIClient(adminURL *url.URL, token string, httpClient *http.Client) *AdminAPIClient {
+	return &AdminAPIClient{
+		adminURL:   fmt.Sprintf("%s://%s", adminURL.Scheme, adminURL.Host),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// do sends the request to the given endpoint and decodes the JSON response into decodeInto.
+// params are sent as query string for GET and DELETE requests and as form values otherwise.
+func (c *AdminAPIClient) do(method, endpoint string, params threescaleapi.Params, expectCode int, decodeInto interface{}) error {
+	values := url.Values{}
+	for k, v := range params {
+		values.Add(k, v)
+	}
+
+	return c.doValues(method, endpoint, values, expectCode, decodeInto)
+}
+
+// doValues is like do, for requests with multi-valued params
+func (c *AdminAPIClient) doValues(method, endpoint string, values url.Values, expectCode int, decodeInto interface{}) error {
+	var body io.Reader
+	rawURL := c.adminURL + endpoint
+	if method == http.MethodGet || method == http.MethodDelete {
+		if len(values) > 0 {
+			rawURL = fmt.Sprintf("%s?%s", rawURL, values.Encode())
+		}
+	} else {
+		body = strings.NewReader(values.Encode())
+	}
+
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return c.send(req, expectCode, decodeInto)
+}
+
+// send adds the authentication headers to the request and decodes the JSON response into decodeInto
+func (c *AdminAPIClient) send(req *http.Request, expectCode int, decodeInto interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+c.token)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectCode {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &AdminAPIError{code: resp.StatusCode, message: string(respBody)}
+	}
+
+	if decodeInto == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(decodeInto); err != nil {
+		return &AdminAPIError{code: resp.StatusCode, message: fmt.Sprintf("decoding error - %s", err.Error())}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	userAccessTokenCreate     = "/admin/api/users/%d/access_tokens.json"
+	personalAccessTokenDelete = "/admin/api/personal/access_tokens/%s.json"
+)
+
+const (
+	// AccessTokenPermissionReadOnly is the permission of read only access tokens
+	AccessTokenPermissionReadOnly = "ro"
+
+	// AccessTokenPermissionReadWrite is the permission of read & write access tokens
+	AccessTokenPermissionReadWrite = "rw"
+)
+
+// AccessToken holds an access token serialized/unserialized in json format
+type AccessToken struct {
+	Element threescaleapi.AccessToken `json:"access_token"`
+}
+
+// CreateAccessToken creates an access token of a user of the provider account
+func (c *AdminAPIClient) CreateAccessToken(userID int64, name, permission string, scopes []string) (*AccessToken, error) {
+	values := url.Values{}
+	values.Set("name", name)
+	values.Set("permission", permission)
+	for _, scope := range scopes {
+		values.Add("scopes[]", scope)
+	}
+
+	obj := &AccessToken{}
+	err := c.doValues(http.MethodPost, fmt.Sprintf(userAccessTokenCreate, userID), values, http.StatusCreated, obj)
+	return obj, err
+}
+
+// DeleteAccessToken deletes an access token, by ID or value, of the user owning the client token
+func (c *AdminAPIClient) DeleteAccessToken(idOrValue string) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(personalAccessTokenDelete, url.PathEscape(idOrValue)), nil, http.StatusOK, nil)
+}
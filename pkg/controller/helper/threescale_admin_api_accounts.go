@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	accountReadUpdate = "/admin/api/accounts/%d.json"
+	accountStateEvent = "/admin/api/accounts/%d/%s.json"
+)
+
+const (
+	// AccountStateEventApprove approves a pending or rejected developer account
+	AccountStateEventApprove = "approve"
+
+	// AccountStateEventReject rejects a pending or approved developer account
+	AccountStateEventReject = "reject"
+
+	// AccountStateEventMakePending resets an approved or rejected developer account to pending
+	AccountStateEventMakePending = "make_pending"
+
+	// AccountStateEventSuspend suspends an approved developer account
+	AccountStateEventSuspend = "suspend"
+
+	// AccountStateEventResume resumes a suspended developer account. Resumed accounts are approved
+	AccountStateEventResume = "resume"
+)
+
+// FieldsDefinitionTargetAccount is the fields definition target of developer accounts
+const FieldsDefinitionTargetAccount = "Account"
+
+// AccountFields reads the string fields of a developer account, extra fields included
+func (c *AdminAPIClient) AccountFields(accountID int64) (map[string]string, error) {
+	obj := struct {
+		Account map[string]interface{} `json:"account"`
+	}{}
+	err := c.do(http.MethodGet, fmt.Sprintf(accountReadUpdate, accountID), nil, http.StatusOK, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{}
+	for name, value := range obj.Account {
+		if str, ok := value.(string); ok {
+			fields[name] = str
+		}
+	}
+
+	// extra fields may also be nested
+	if extraFields, ok := obj.Account["extra_fields"].(map[string]interface{}); ok {
+		for name, value := range extraFields {
+			if str, ok := value.(string); ok {
+				fields[name] = str
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+// UpdateAccountFields updates fields of a developer account. Params are keyed by field name
+func (c *AdminAPIClient) UpdateAccountFields(accountID int64, params threescaleapi.Params) error {
+	return c.do(http.MethodPut, fmt.Sprintf(accountReadUpdate, accountID), params, http.StatusOK, nil)
+}
+
+// FireAccountStateEvent changes the state of a developer account. Returns the updated account
+func (c *AdminAPIClient) FireAccountStateEvent(accountID int64, event string) (*threescaleapi.DeveloperAccount, error) {
+	obj := &threescaleapi.DeveloperAccount{}
+	err := c.do(http.MethodPut, fmt.Sprintf(accountStateEvent, accountID, event), nil, http.StatusOK, obj)
+	return obj, err
+}
@@ -0,0 +1,113 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	applicationRead                     = "/admin/api/accounts/%d/applications/%d.json"
+	applicationReferrerFilterListCreate = "/admin/api/accounts/%d/applications/%d/referrer_filters.json"
+	applicationReferrerFilterDelete     = "/admin/api/accounts/%d/applications/%d/referrer_filters/%d.json"
+	fieldsDefinitionList                = "/admin/api/fields_definitions.json"
+)
+
+// FieldsDefinitionTargetApplication is the fields definition target of applications
+const FieldsDefinitionTargetApplication = "Cinstance"
+
+// ReferrerFilterItem holds the attributes of an application referrer filter
+type ReferrerFilterItem struct {
+	ID            int64  `json:"id"`
+	Value         string `json:"value"`
+	ApplicationID int64  `json:"application_id"`
+}
+
+// ReferrerFilter holds a referrer filter serialized/unserialized in json format
+type ReferrerFilter struct {
+	Element ReferrerFilterItem `json:"referrer_filter"`
+}
+
+// ReferrerFilterList holds a list of referrer filters serialized/unserialized in json format
+type ReferrerFilterList struct {
+	ReferrerFilters []ReferrerFilter `json:"referrer_filters"`
+}
+
+// FieldsDefinitionItem holds the attributes of a fields definition
+type FieldsDefinitionItem struct {
+	ID       int64    `json:"id"`
+	Target   string   `json:"target"`
+	Name     string   `json:"name"`
+	Label    string   `json:"label"`
+	Required bool     `json:"required"`
+	Hidden   bool     `json:"hidden"`
+	ReadOnly bool     `json:"read_only"`
+	Choices  []string `json:"choices"`
+}
+
+// FieldsDefinition holds a fields definition serialized/unserialized in json format
+type FieldsDefinition struct {
+	Element FieldsDefinitionItem `json:"field_definition"`
+}
+
+// FieldsDefinitionList holds a list of fields definitions serialized/unserialized in json format
+type FieldsDefinitionList struct {
+	FieldsDefinitions []FieldsDefinition `json:"fields_definitions"`
+}
+
+// ApplicationFields reads the string fields of an application, extra fields included
+func (c *AdminAPIClient) ApplicationFields(accountID, applicationID int64) (map[string]string, error) {
+	obj := struct {
+		Application map[string]interface{} `json:"application"`
+	}{}
+	err := c.do(http.MethodGet, fmt.Sprintf(applicationRead, accountID, applicationID), nil, http.StatusOK, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{}
+	for name, value := range obj.Application {
+		if str, ok := value.(string); ok {
+			fields[name] = str
+		}
+	}
+
+	// extra fields may also be nested
+	if extraFields, ok := obj.Application["extra_fields"].(map[string]interface{}); ok {
+		for name, value := range extraFields {
+			if str, ok := value.(string); ok {
+				fields[name] = str
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+// ListApplicationReferrerFilters lists the referrer filters of an application
+func (c *AdminAPIClient) ListApplicationReferrerFilters(accountID, applicationID int64) (*ReferrerFilterList, error) {
+	list := &ReferrerFilterList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(applicationReferrerFilterListCreate, accountID, applicationID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateApplicationReferrerFilter adds a referrer filter to an application
+func (c *AdminAPIClient) CreateApplicationReferrerFilter(accountID, applicationID int64, value string) (*ReferrerFilter, error) {
+	obj := &ReferrerFilter{}
+	params := threescaleapi.Params{"referrer_filter": value}
+	err := c.do(http.MethodPost, fmt.Sprintf(applicationReferrerFilterListCreate, accountID, applicationID), params, http.StatusCreated, obj)
+	return obj, err
+}
+
+// DeleteApplicationReferrerFilter deletes a referrer filter of an application
+func (c *AdminAPIClient) DeleteApplicationReferrerFilter(accountID, applicationID, id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(applicationReferrerFilterDelete, accountID, applicationID, id), nil, http.StatusOK, nil)
+}
+
+// ListFieldsDefinitions lists the fields definitions of the provider account
+func (c *AdminAPIClient) ListFieldsDefinitions() (*FieldsDefinitionList, error) {
+	list := &FieldsDefinitionList{}
+	err := c.do(http.MethodGet, fieldsDefinitionList, nil, http.StatusOK, list)
+	return list, err
+}
@@ -0,0 +1,294 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	cmsTemplateListCreate  = "/admin/api/cms/templates.json"
+	cmsTemplateReadUpdate  = "/admin/api/cms/templates/%d.json"
+	cmsTemplatePublish     = "/admin/api/cms/templates/%d/publish.json"
+	cmsSectionListCreate   = "/admin/api/cms/sections.json"
+	cmsSectionReadUpdate   = "/admin/api/cms/sections/%d.json"
+	cmsFileListCreate      = "/admin/api/cms/files.json"
+	cmsFileReadUpdate      = "/admin/api/cms/files/%d.json"
+	cmsListPageSize        = 100
+	cmsFileAttachmentField = "attachment"
+)
+
+const (
+	// CMS template types
+	CMSTemplateTypePage           = "page"
+	CMSTemplateTypePartial        = "partial"
+	CMSTemplateTypeLayout         = "layout"
+	CMSTemplateTypeBuiltinPage    = "builtin_page"
+	CMSTemplateTypeBuiltinPartial = "builtin_partial"
+	CMSTemplateTypeEmailTemplate  = "email_template"
+
+	// CMSRootSectionSystemName is the system name of the section every section and page belongs to
+	CMSRootSectionSystemName = "root"
+)
+
+// CMSListMetadata holds the pagination of CMS lists
+type CMSListMetadata struct {
+	TotalPages  int `json:"total_pages"`
+	CurrentPage int `json:"current_page"`
+}
+
+// CMSEmailTemplateHeaders holds the headers of an email template
+type CMSEmailTemplateHeaders struct {
+	Subject string `json:"subject"`
+	From    string `json:"from"`
+	ReplyTo string `json:"reply_to"`
+	Cc      string `json:"cc"`
+	Bcc     string `json:"bcc"`
+}
+
+// CMSTemplate holds the attributes of a developer portal page, partial, layout or email template
+type CMSTemplate struct {
+	ID            int64  `json:"id"`
+	Type          string `json:"type"`
+	SystemName    string `json:"system_name"`
+	Title         string `json:"title"`
+	Path          string `json:"path"`
+	SectionID     int64  `json:"section_id"`
+	LayoutID      int64  `json:"layout_id"`
+	ContentType   string `json:"content_type"`
+	Handler       string `json:"handler"`
+	LiquidEnabled bool   `json:"liquid_enabled"`
+	Draft         string `json:"draft"`
+	Published     string `json:"published"`
+
+	// Headers are only set for email templates
+	Headers *CMSEmailTemplateHeaders `json:"headers,omitempty"`
+}
+
+// IsBuiltin returns true for the pages and partials provided by 3scale, which can be updated but not deleted
+func (t *CMSTemplate) IsBuiltin() bool {
+	return t.Type == CMSTemplateTypeBuiltinPage || t.Type == CMSTemplateTypeBuiltinPartial
+}
+
+// CMSTemplateList holds a page of CMS templates serialized/unserialized in json format
+type CMSTemplateList struct {
+	Templates []CMSTemplate   `json:"collection"`
+	Metadata  CMSListMetadata `json:"metadata"`
+}
+
+// CMSSection holds the attributes of a developer portal section
+type CMSSection struct {
+	ID          int64  `json:"id"`
+	SystemName  string `json:"system_name"`
+	Title       string `json:"title"`
+	PartialPath string `json:"partial_path"`
+	Public      bool   `json:"public"`
+	ParentID    int64  `json:"parent_id"`
+}
+
+// CMSSectionList holds a page of CMS sections serialized/unserialized in json format
+type CMSSectionList struct {
+	Sections []CMSSection    `json:"collection"`
+	Metadata CMSListMetadata `json:"metadata"`
+}
+
+// CMSFile holds the attributes of a developer portal file
+type CMSFile struct {
+	ID           int64  `json:"id"`
+	Path         string `json:"path"`
+	SectionID    int64  `json:"section_id"`
+	Downloadable bool   `json:"downloadable"`
+	URL          string `json:"url"`
+}
+
+// CMSFileList holds a page of CMS files serialized/unserialized in json format
+type CMSFileList struct {
+	Files    []CMSFile       `json:"collection"`
+	Metadata CMSListMetadata `json:"metadata"`
+}
+
+// ListCMSTemplates returns the pages, partials, layouts and email templates of the developer portal, with their draft and published content
+func (c *AdminAPIClient) ListCMSTemplates() ([]CMSTemplate, error) {
+	templates := []CMSTemplate{}
+	for page := 1; ; page++ {
+		list := &CMSTemplateList{}
+		err := c.do(http.MethodGet, cmsTemplateListCreate, cmsListParams(page, threescaleapi.Params{"content": "true"}), http.StatusOK, list)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, list.Templates...)
+		if len(list.Templates) == 0 || page >= list.Metadata.TotalPages {
+			return templates, nil
+		}
+	}
+}
+
+// CreateCMSTemplate creates a page, partial or layout
+func (c *AdminAPIClient) CreateCMSTemplate(params threescaleapi.Params) (*CMSTemplate, error) {
+	template := &CMSTemplate{}
+	err := c.do(http.MethodPost, cmsTemplateListCreate, params, http.StatusCreated, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// UpdateCMSTemplate updates a page, partial or layout. Content is updated in the draft
+func (c *AdminAPIClient) UpdateCMSTemplate(id int64, params threescaleapi.Params) (*CMSTemplate, error) {
+	template := &CMSTemplate{}
+	err := c.do(http.MethodPut, fmt.Sprintf(cmsTemplateReadUpdate, id), params, http.StatusOK, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// PublishCMSTemplate publishes the draft of a page, partial or layout
+func (c *AdminAPIClient) PublishCMSTemplate(id int64) (*CMSTemplate, error) {
+	template := &CMSTemplate{}
+	err := c.do(http.MethodPut, fmt.Sprintf(cmsTemplatePublish, id), nil, http.StatusOK, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteCMSTemplate deletes a page, partial or layout
+func (c *AdminAPIClient) DeleteCMSTemplate(id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(cmsTemplateReadUpdate, id), nil, http.StatusOK, nil)
+}
+
+// ListCMSSections returns the sections of the developer portal
+func (c *AdminAPIClient) ListCMSSections() ([]CMSSection, error) {
+	sections := []CMSSection{}
+	for page := 1; ; page++ {
+		list := &CMSSectionList{}
+		err := c.do(http.MethodGet, cmsSectionListCreate, cmsListParams(page, threescaleapi.Params{}), http.StatusOK, list)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, list.Sections...)
+		if len(list.Sections) == 0 || page >= list.Metadata.TotalPages {
+			return sections, nil
+		}
+	}
+}
+
+// CreateCMSSection creates a section
+func (c *AdminAPIClient) CreateCMSSection(params threescaleapi.Params) (*CMSSection, error) {
+	section := &CMSSection{}
+	err := c.do(http.MethodPost, cmsSectionListCreate, params, http.StatusCreated, section)
+	if err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// UpdateCMSSection updates a section
+func (c *AdminAPIClient) UpdateCMSSection(id int64, params threescaleapi.Params) (*CMSSection, error) {
+	section := &CMSSection{}
+	err := c.do(http.MethodPut, fmt.Sprintf(cmsSectionReadUpdate, id), params, http.StatusOK, section)
+	if err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// DeleteCMSSection deletes a section
+func (c *AdminAPIClient) DeleteCMSSection(id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(cmsSectionReadUpdate, id), nil, http.StatusOK, nil)
+}
+
+// ListCMSFiles returns the files of the developer portal
+func (c *AdminAPIClient) ListCMSFiles() ([]CMSFile, error) {
+	files := []CMSFile{}
+	for page := 1; ; page++ {
+		list := &CMSFileList{}
+		err := c.do(http.MethodGet, cmsFileListCreate, cmsListParams(page, threescaleapi.Params{}), http.StatusOK, list)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, list.Files...)
+		if len(list.Files) == 0 || page >= list.Metadata.TotalPages {
+			return files, nil
+		}
+	}
+}
+
+// CreateCMSFile uploads a file
+func (c *AdminAPIClient) CreateCMSFile(params threescaleapi.Params, fileName string, content []byte) (*CMSFile, error) {
+	file := &CMSFile{}
+	err := c.doMultipart(http.MethodPost, cmsFileListCreate, params, fileName, content, http.StatusCreated, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// UpdateCMSFile updates a file. The attachment is replaced when content is not nil
+func (c *AdminAPIClient) UpdateCMSFile(id int64, params threescaleapi.Params, fileName string, content []byte) (*CMSFile, error) {
+	file := &CMSFile{}
+	err := c.doMultipart(http.MethodPut, fmt.Sprintf(cmsFileReadUpdate, id), params, fileName, content, http.StatusOK, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// DeleteCMSFile deletes a file
+func (c *AdminAPIClient) DeleteCMSFile(id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(cmsFileReadUpdate, id), nil, http.StatusOK, nil)
+}
+
+// doMultipart sends params and the file content as multipart form data, the way attachments are uploaded
+func (c *AdminAPIClient) doMultipart(method, endpoint string, params threescaleapi.Params, fileName string, content []byte, expectCode int, decodeInto interface{}) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for k, v := range params {
+		if err := writer.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+
+	if content != nil {
+		part, err := writer.CreateFormFile(cmsFileAttachmentField, fileName)
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(content); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, c.adminURL+endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return c.send(req, expectCode, decodeInto)
+}
+
+func cmsListParams(page int, params threescaleapi.Params) threescaleapi.Params {
+	params["page"] = strconv.Itoa(page)
+	params["per_page"] = strconv.Itoa(cmsListPageSize)
+	return params
+}
@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	invitationListCreate = "/admin/api/accounts/%d/invitations.json"
+	invitationReadDelete = "/admin/api/accounts/%d/invitations/%d.json"
+)
+
+// InvitationItem is the invitation of a user to join a developer account.
+// 3scale sends the invitation email with the activation link and creates the user when it is accepted
+type InvitationItem struct {
+	ID         int64      `json:"id"`
+	Email      string     `json:"email"`
+	SentAt     *time.Time `json:"sent_at,omitempty"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UserID     *int64     `json:"user_id,omitempty"`
+}
+
+// Invitation holds an invitation serialized/unserialized in json format
+type Invitation struct {
+	Element InvitationItem `json:"invitation"`
+}
+
+// InvitationList holds a list of invitations serialized/unserialized in json format
+type InvitationList struct {
+	Invitations []Invitation `json:"invitations"`
+}
+
+// IsAccepted returns true when the invited user has accepted the invitation
+func (i *Invitation) IsAccepted() bool {
+	return i.Element.AcceptedAt != nil
+}
+
+// CreateInvitation invites a user to join the developer account. 3scale emails the invitation
+func (c *AdminAPIClient) CreateInvitation(accountID int64, email string) (*Invitation, error) {
+	obj := &Invitation{}
+	err := c.do(http.MethodPost, fmt.Sprintf(invitationListCreate, accountID), threescaleapi.Params{"email": email}, http.StatusCreated, obj)
+	return obj, err
+}
+
+// ListInvitations lists the invitations of the developer account, accepted invitations included
+func (c *AdminAPIClient) ListInvitations(accountID int64) (*InvitationList, error) {
+	list := &InvitationList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(invitationListCreate, accountID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// Invitation reads an invitation of the developer account
+func (c *AdminAPIClient) Invitation(accountID, invitationID int64) (*Invitation, error) {
+	obj := &Invitation{}
+	err := c.do(http.MethodGet, fmt.Sprintf(invitationReadDelete, accountID, invitationID), nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// DeleteInvitation deletes an invitation of the developer account. Deleted invitations cannot be accepted
+func (c *AdminAPIClient) DeleteInvitation(accountID, invitationID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(invitationReadDelete, accountID, invitationID), nil, http.StatusOK, nil)
+}
@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	notificationPreferencesReadUpdate = "/admin/api/users/%d/notification_preferences.json"
+)
+
+// NotificationPreferencesItem holds the email notifications of a provider user, enabled or not, by name
+type NotificationPreferencesItem struct {
+	Preferences map[string]bool `json:"preferences"`
+}
+
+// NotificationPreferences holds notification preferences serialized/unserialized in json format
+type NotificationPreferences struct {
+	Element NotificationPreferencesItem `json:"notification_preferences"`
+}
+
+// ReadNotificationPreferences reads the email notifications of a provider user
+func (c *AdminAPIClient) ReadNotificationPreferences(userID int64) (*NotificationPreferences, error) {
+	obj := &NotificationPreferences{}
+	err := c.do(http.MethodGet, fmt.Sprintf(notificationPreferencesReadUpdate, userID), nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// UpdateNotificationPreferences enables or disables email notifications of a provider user.
+// Notifications not given are left unchanged
+func (c *AdminAPIClient) UpdateNotificationPreferences(userID int64, preferences map[string]bool) (*NotificationPreferences, error) {
+	params := threescaleapi.Params{}
+	for notification, enabled := range preferences {
+		params[fmt.Sprintf("preferences[%s]", notification)] = strconv.FormatBool(enabled)
+	}
+
+	obj := &NotificationPreferences{}
+	err := c.do(http.MethodPut, fmt.Sprintf(notificationPreferencesReadUpdate, userID), params, http.StatusOK, obj)
+	return obj, err
+}
@@ -0,0 +1,261 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	applicationPlanFeatureList   = "/admin/api/application_plans/%d/features.json"
+	applicationPlanFeatureDelete = "/admin/api/application_plans/%d/features/%d.json"
+
+	servicePlanListCreate    = "/admin/api/services/%d/service_plans.json"
+	servicePlanUpdateDelete  = "/admin/api/services/%d/service_plans/%d.json"
+	servicePlanSetDefault    = "/admin/api/services/%d/service_plans/%d/default.json"
+	servicePlanFeatureList   = "/admin/api/service_plans/%d/features.json"
+	servicePlanFeatureDelete = "/admin/api/service_plans/%d/features/%d.json"
+	serviceFeatureListCreate = "/admin/api/services/%d/features.json"
+	serviceFeatureUpdate     = "/admin/api/services/%d/features/%d.json"
+
+	accountPlanListCreate    = "/admin/api/account_plans.json"
+	accountPlanUpdateDelete  = "/admin/api/account_plans/%d.json"
+	accountPlanSetDefault    = "/admin/api/account_plans/%d/default.json"
+	accountPlanFeatureList   = "/admin/api/account_plans/%d/features.json"
+	accountPlanFeatureDelete = "/admin/api/account_plans/%d/features/%d.json"
+	accountFeatureListCreate = "/admin/api/features.json"
+	accountFeatureUpdate     = "/admin/api/features/%d.json"
+)
+
+const (
+	// FeatureScopeApplicationPlan is the scope of features enabled in application plans
+	FeatureScopeApplicationPlan = "ApplicationPlan"
+
+	// FeatureScopeServicePlan is the scope of features enabled in service plans
+	FeatureScopeServicePlan = "ServicePlan"
+
+	// FeatureScopeAccountPlan is the scope of features enabled in account plans
+	FeatureScopeAccountPlan = "AccountPlan"
+)
+
+// PlanItem holds the attributes shared by service plans and account plans
+type PlanItem struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	SystemName       string  `json:"system_name"`
+	State            string  `json:"state"`
+	SetupFee         float64 `json:"setup_fee"`
+	CostPerMonth     float64 `json:"cost_per_month"`
+	TrialPeriodDays  int     `json:"trial_period_days"`
+	ApprovalRequired bool    `json:"approval_required"`
+	Default          bool    `json:"default"`
+}
+
+// ServicePlan holds a service plan serialized/unserialized in json format
+type ServicePlan struct {
+	Element PlanItem `json:"service_plan"`
+}
+
+// ServicePlanList holds a list of service plans serialized/unserialized in json format
+type ServicePlanList struct {
+	Plans []ServicePlan `json:"plans"`
+}
+
+// AccountPlan holds an account plan serialized/unserialized in json format
+type AccountPlan struct {
+	Element PlanItem `json:"account_plan"`
+}
+
+// AccountPlanList holds a list of account plans serialized/unserialized in json format
+type AccountPlanList struct {
+	Plans []AccountPlan `json:"plans"`
+}
+
+// FeatureItem holds the attributes of a plan feature
+type FeatureItem struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	SystemName  string `json:"system_name"`
+	Description string `json:"description"`
+	Scope       string `json:"scope"`
+	Visible     bool   `json:"visible"`
+}
+
+// Feature holds a feature serialized/unserialized in json format
+type Feature struct {
+	Element FeatureItem `json:"feature"`
+}
+
+// FeatureList holds a list of features serialized/unserialized in json format
+type FeatureList struct {
+	Features []Feature `json:"features"`
+}
+
+// ListServicePlans lists the service plans of a product
+func (c *AdminAPIClient) ListServicePlans(productID int64) (*ServicePlanList, error) {
+	list := &ServicePlanList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(servicePlanListCreate, productID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateServicePlan creates a service plan in a product
+func (c *AdminAPIClient) CreateServicePlan(productID int64, params threescaleapi.Params) (*ServicePlan, error) {
+	obj := &ServicePlan{}
+	err := c.do(http.MethodPost, fmt.Sprintf(servicePlanListCreate, productID), params, http.StatusCreated, obj)
+	return obj, err
+}
+
+// UpdateServicePlan updates a service plan of a product
+func (c *AdminAPIClient) UpdateServicePlan(productID, id int64, params threescaleapi.Params) (*ServicePlan, error) {
+	obj := &ServicePlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(servicePlanUpdateDelete, productID, id), params, http.StatusOK, obj)
+	return obj, err
+}
+
+// DeleteServicePlan deletes a service plan of a product
+func (c *AdminAPIClient) DeleteServicePlan(productID, id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(servicePlanUpdateDelete, productID, id), nil, http.StatusOK, nil)
+}
+
+// SetDefaultServicePlan makes the service plan the default one of the product
+func (c *AdminAPIClient) SetDefaultServicePlan(productID, id int64) (*ServicePlan, error) {
+	obj := &ServicePlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(servicePlanSetDefault, productID, id), nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// ListApplicationPlanFeatures lists the features enabled in an application plan
+func (c *AdminAPIClient) ListApplicationPlanFeatures(planID int64) (*FeatureList, error) {
+	list := &FeatureList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(applicationPlanFeatureList, planID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateApplicationPlanFeature enables a feature in an application plan
+func (c *AdminAPIClient) CreateApplicationPlanFeature(planID, featureID int64) error {
+	params := threescaleapi.Params{"feature_id": strconv.FormatInt(featureID, 10)}
+	return c.do(http.MethodPost, fmt.Sprintf(applicationPlanFeatureList, planID), params, http.StatusCreated, nil)
+}
+
+// DeleteApplicationPlanFeature disables a feature in an application plan
+func (c *AdminAPIClient) DeleteApplicationPlanFeature(planID, featureID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(applicationPlanFeatureDelete, planID, featureID), nil, http.StatusOK, nil)
+}
+
+// ListServicePlanFeatures lists the features enabled in a service plan
+func (c *AdminAPIClient) ListServicePlanFeatures(planID int64) (*FeatureList, error) {
+	list := &FeatureList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(servicePlanFeatureList, planID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateServicePlanFeature enables a feature in a service plan
+func (c *AdminAPIClient) CreateServicePlanFeature(planID, featureID int64) error {
+	params := threescaleapi.Params{"feature_id": strconv.FormatInt(featureID, 10)}
+	return c.do(http.MethodPost, fmt.Sprintf(servicePlanFeatureList, planID), params, http.StatusCreated, nil)
+}
+
+// DeleteServicePlanFeature disables a feature in a service plan
+func (c *AdminAPIClient) DeleteServicePlanFeature(planID, featureID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(servicePlanFeatureDelete, planID, featureID), nil, http.StatusOK, nil)
+}
+
+// ListServiceFeatures lists the features of a product
+func (c *AdminAPIClient) ListServiceFeatures(productID int64) (*FeatureList, error) {
+	list := &FeatureList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(serviceFeatureListCreate, productID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateServiceFeature creates a feature in a product
+func (c *AdminAPIClient) CreateServiceFeature(productID int64, params threescaleapi.Params) (*Feature, error) {
+	obj := &Feature{}
+	err := c.do(http.MethodPost, fmt.Sprintf(serviceFeatureListCreate, productID), params, http.StatusCreated, obj)
+	return obj, err
+}
+
+// UpdateServiceFeature updates a feature of a product
+func (c *AdminAPIClient) UpdateServiceFeature(productID, id int64, params threescaleapi.Params) (*Feature, error) {
+	obj := &Feature{}
+	err := c.do(http.MethodPut, fmt.Sprintf(serviceFeatureUpdate, productID, id), params, http.StatusOK, obj)
+	return obj, err
+}
+
+// DeleteServiceFeature deletes a feature of a product
+func (c *AdminAPIClient) DeleteServiceFeature(productID, id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(serviceFeatureUpdate, productID, id), nil, http.StatusOK, nil)
+}
+
+// ListAccountPlans lists the account plans of the provider account
+func (c *AdminAPIClient) ListAccountPlans() (*AccountPlanList, error) {
+	list := &AccountPlanList{}
+	err := c.do(http.MethodGet, accountPlanListCreate, nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateAccountPlan creates an account plan
+func (c *AdminAPIClient) CreateAccountPlan(params threescaleapi.Params) (*AccountPlan, error) {
+	obj := &AccountPlan{}
+	err := c.do(http.MethodPost, accountPlanListCreate, params, http.StatusCreated, obj)
+	return obj, err
+}
+
+// UpdateAccountPlan updates an account plan
+func (c *AdminAPIClient) UpdateAccountPlan(id int64, params threescaleapi.Params) (*AccountPlan, error) {
+	obj := &AccountPlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(accountPlanUpdateDelete, id), params, http.StatusOK, obj)
+	return obj, err
+}
+
+// DeleteAccountPlan deletes an account plan
+func (c *AdminAPIClient) DeleteAccountPlan(id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(accountPlanUpdateDelete, id), nil, http.StatusOK, nil)
+}
+
+// SetDefaultAccountPlan makes the account plan the default one of the provider account
+func (c *AdminAPIClient) SetDefaultAccountPlan(id int64) (*AccountPlan, error) {
+	obj := &AccountPlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(accountPlanSetDefault, id), nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// ListAccountPlanFeatures lists the features enabled in an account plan
+func (c *AdminAPIClient) ListAccountPlanFeatures(planID int64) (*FeatureList, error) {
+	list := &FeatureList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(accountPlanFeatureList, planID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateAccountPlanFeature enables a feature in an account plan
+func (c *AdminAPIClient) CreateAccountPlanFeature(planID, featureID int64) error {
+	params := threescaleapi.Params{"feature_id": strconv.FormatInt(featureID, 10)}
+	return c.do(http.MethodPost, fmt.Sprintf(accountPlanFeatureList, planID), params, http.StatusCreated, nil)
+}
+
+// DeleteAccountPlanFeature disables a feature in an account plan
+func (c *AdminAPIClient) DeleteAccountPlanFeature(planID, featureID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(accountPlanFeatureDelete, planID, featureID), nil, http.StatusOK, nil)
+}
+
+// ListAccountFeatures lists the account level features of the provider account
+func (c *AdminAPIClient) ListAccountFeatures() (*FeatureList, error) {
+	list := &FeatureList{}
+	err := c.do(http.MethodGet, accountFeatureListCreate, nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateAccountFeature creates an account level feature
+func (c *AdminAPIClient) CreateAccountFeature(params threescaleapi.Params) (*Feature, error) {
+	obj := &Feature{}
+	err := c.do(http.MethodPost, accountFeatureListCreate, params, http.StatusCreated, obj)
+	return obj, err
+}
+
+// UpdateAccountFeature updates an account level feature
+func (c *AdminAPIClient) UpdateAccountFeature(id int64, params threescaleapi.Params) (*Feature, error) {
+	obj := &Feature{}
+	err := c.do(http.MethodPut, fmt.Sprintf(accountFeatureUpdate, id), params, http.StatusOK, obj)
+	return obj, err
+}
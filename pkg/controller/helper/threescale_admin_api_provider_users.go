@@ -0,0 +1,150 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	providerUserListCreate  = "/admin/api/users.json"
+	providerUserReadUpdate  = "/admin/api/users/%d.json"
+	providerUserSuspend     = "/admin/api/users/%d/suspend.json"
+	providerUserUnsuspend   = "/admin/api/users/%d/unsuspend.json"
+	providerUserActivate    = "/admin/api/users/%d/activate.json"
+	providerUserRole        = "/admin/api/users/%d/%s.json"
+	providerUserPermissions = "/admin/api/users/%d/permissions.json"
+)
+
+const (
+	// ProviderUserRoleAdmin is the role of provider users with access to every admin portal section and product
+	ProviderUserRoleAdmin = "admin"
+
+	// ProviderUserRoleMember is the role of provider users with access restricted by their member permissions
+	ProviderUserRoleMember = "member"
+)
+
+// ProviderUserItem holds the attributes of a user of the provider account
+type ProviderUserItem struct {
+	ID       int64  `json:"id"`
+	State    string `json:"state"`
+	Role     string `json:"role"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// ProviderUser holds a provider user serialized/unserialized in json format
+type ProviderUser struct {
+	Element ProviderUserItem `json:"user"`
+}
+
+// ProviderUserList holds a list of provider users serialized/unserialized in json format
+type ProviderUserList struct {
+	Users []ProviderUser `json:"users"`
+}
+
+// MemberPermissionsItem holds the admin portal sections and services a member user has access to.
+// Nil AllowedServiceIDs means access to all services
+type MemberPermissionsItem struct {
+	UserID            int64    `json:"user_id"`
+	Role              string   `json:"role"`
+	AllowedServiceIDs []int64  `json:"allowed_service_ids"`
+	AllowedSections   []string `json:"allowed_sections"`
+}
+
+// MemberPermissions holds member permissions serialized/unserialized in json format
+type MemberPermissions struct {
+	Element MemberPermissionsItem `json:"permissions"`
+}
+
+// ListProviderUsers lists the users of the provider account
+func (c *AdminAPIClient) ListProviderUsers() (*ProviderUserList, error) {
+	obj := &ProviderUserList{}
+	err := c.do(http.MethodGet, providerUserListCreate, nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// ReadProviderUser reads a user of the provider account
+func (c *AdminAPIClient) ReadProviderUser(userID int64) (*ProviderUser, error) {
+	obj := &ProviderUser{}
+	err := c.do(http.MethodGet, fmt.Sprintf(providerUserReadUpdate, userID), nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// CreateProviderUser creates a user of the provider account. New users are pending until activated
+func (c *AdminAPIClient) CreateProviderUser(params threescaleapi.Params) (*ProviderUser, error) {
+	obj := &ProviderUser{}
+	err := c.do(http.MethodPost, providerUserListCreate, params, http.StatusCreated, obj)
+	return obj, err
+}
+
+// UpdateProviderUser updates a user of the provider account
+func (c *AdminAPIClient) UpdateProviderUser(userID int64, params threescaleapi.Params) (*ProviderUser, error) {
+	obj := &ProviderUser{}
+	err := c.do(http.MethodPut, fmt.Sprintf(providerUserReadUpdate, userID), params, http.StatusOK, obj)
+	return obj, err
+}
+
+// ActivateProviderUser activates a pending user of the provider account
+func (c *AdminAPIClient) ActivateProviderUser(userID int64) (*ProviderUser, error) {
+	return c.providerUserAction(fmt.Sprintf(providerUserActivate, userID))
+}
+
+// SuspendProviderUser suspends an active user of the provider account
+func (c *AdminAPIClient) SuspendProviderUser(userID int64) (*ProviderUser, error) {
+	return c.providerUserAction(fmt.Sprintf(providerUserSuspend, userID))
+}
+
+// UnsuspendProviderUser reactivates a suspended user of the provider account
+func (c *AdminAPIClient) UnsuspendProviderUser(userID int64) (*ProviderUser, error) {
+	return c.providerUserAction(fmt.Sprintf(providerUserUnsuspend, userID))
+}
+
+// ChangeProviderUserRole changes the role, admin or member, of a user of the provider account
+func (c *AdminAPIClient) ChangeProviderUserRole(userID int64, role string) (*ProviderUser, error) {
+	return c.providerUserAction(fmt.Sprintf(providerUserRole, userID, role))
+}
+
+func (c *AdminAPIClient) providerUserAction(endpoint string) (*ProviderUser, error) {
+	obj := &ProviderUser{}
+	err := c.do(http.MethodPut, endpoint, nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// ReadMemberPermissions reads the member permissions of a user of the provider account
+func (c *AdminAPIClient) ReadMemberPermissions(userID int64) (*MemberPermissions, error) {
+	obj := &MemberPermissions{}
+	err := c.do(http.MethodGet, fmt.Sprintf(providerUserPermissions, userID), nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// UpdateMemberPermissions sets the admin portal sections and services a member user has access to.
+// Nil allowedServiceIDs gives access to all services, current and future. Empty allowedServiceIDs to none
+func (c *AdminAPIClient) UpdateMemberPermissions(userID int64, allowedSections []string, allowedServiceIDs []int64) (*MemberPermissions, error) {
+	values := url.Values{}
+
+	// 3scale expects the '[]' value to disable all
+	if len(allowedSections) == 0 {
+		values.Set("allowed_sections", "[]")
+	}
+	for _, section := range allowedSections {
+		values.Add("allowed_sections[]", section)
+	}
+
+	switch {
+	case allowedServiceIDs == nil:
+		values.Set("allowed_service_ids[]", "")
+	case len(allowedServiceIDs) == 0:
+		values.Set("allowed_service_ids", "[]")
+	}
+	for _, serviceID := range allowedServiceIDs {
+		values.Add("allowed_service_ids[]", strconv.FormatInt(serviceID, 10))
+	}
+
+	obj := &MemberPermissions{}
+	err := c.doValues(http.MethodPut, fmt.Sprintf(providerUserPermissions, userID), values, http.StatusOK, obj)
+	return obj, err
+}
@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	accountPlanRead   = "/admin/api/accounts/%d/plan.json"
+	tenantPlanUpgrade = "/master/api/providers/%d/plan_upgrade.json"
+)
+
+// ReadAccountPlan reads the account plan an account is subscribed to.
+// Used with the master account, it reads the account plan of a tenant
+func (c *AdminAPIClient) ReadAccountPlan(accountID int64) (*AccountPlan, error) {
+	obj := &AccountPlan{}
+	err := c.do(http.MethodGet, fmt.Sprintf(accountPlanRead, accountID), nil, http.StatusOK, obj)
+	return obj, err
+}
+
+// UpgradeTenantPlan changes the account plan of a tenant. Requires the master account
+func (c *AdminAPIClient) UpgradeTenantPlan(tenantID, planID int64) error {
+	params := threescaleapi.Params{"plan_id": strconv.FormatInt(planID, 10)}
+	return c.do(http.MethodPut, fmt.Sprintf(tenantPlanUpgrade, tenantID), params, http.StatusOK, nil)
+}
@@ -0,0 +1,341 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func newTestAdminAPIClient(t *testing.T, fn RoundTripFunc) *AdminAPIClient {
+	t.Helper()
+	adminURL, err := url.Parse("https://www.test.com:443")
+	ok(t, err)
+	return NewAdminAPIClient(adminURL, "12345", NewTestClient(fn))
+}
+
+func TestAdminClientFromURLString(t *testing.T) {
+	_, err := AdminClientFromURLString("ftp://www.test.com", "12345", false)
+	assert(t, err != nil, "unsupported schema should return error")
+
+	_, err = AdminClientFromURLString("https://", "12345", false)
+	assert(t, err != nil, "empty hostname should return error")
+
+	_, err = AdminClientFromURLString("https://www.test.com", "12345", false)
+	ok(t, err)
+}
+
+func TestAdminAPIClientRequest(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPost, req.Method)
+		equals(t, "/admin/api/services/3/service_plans.json", req.URL.Path)
+		equals(t, "application/json", req.Header.Get("Accept"))
+		equals(t, "Basic "+base64.StdEncoding.EncodeToString([]byte(":12345")), req.Header.Get("Authorization"))
+		ok(t, req.ParseForm())
+		equals(t, "basic", req.PostForm.Get("system_name"))
+
+		responseBodyBytes, err := json.Marshal(ServicePlan{Element: PlanItem{ID: 7, SystemName: "basic"}})
+		ok(t, err)
+
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBodyBytes)),
+			Header:     make(http.Header),
+		}
+	})
+
+	obj, err := client.CreateServicePlan(3, threescaleapi.Params{"system_name": "basic"})
+	ok(t, err)
+	equals(t, int64(7), obj.Element.ID)
+	equals(t, "basic", obj.Element.SystemName)
+}
+
+func TestAdminAPIClientNotFound(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"status":"Not found"}`)),
+			Header:     make(http.Header),
+		}
+	})
+
+	err := client.DeleteAccountPlan(4)
+	assert(t, err != nil, "not found response should return error")
+	assert(t, IsAdminAPINotFound(err), "expected not found error, got %v", err)
+}
+
+func TestAdminAPIClientApplicationFields(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodGet, req.Method)
+		equals(t, "/admin/api/accounts/3/applications/5.json", req.URL.Path)
+
+		responseBody := `{"application":{"id":5,"name":"app","cost_centre":"cc01","extra_fields":{"environment":"prod"}}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	fields, err := client.ApplicationFields(3, 5)
+	ok(t, err)
+	equals(t, "app", fields["name"])
+	equals(t, "cc01", fields["cost_centre"])
+	equals(t, "prod", fields["environment"])
+}
+
+func TestAdminAPIClientCreateApplicationReferrerFilter(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPost, req.Method)
+		equals(t, "/admin/api/accounts/3/applications/5/referrer_filters.json", req.URL.Path)
+		ok(t, req.ParseForm())
+		equals(t, "*.example.com", req.PostForm.Get("referrer_filter"))
+
+		responseBodyBytes, err := json.Marshal(ReferrerFilter{Element: ReferrerFilterItem{ID: 9, Value: "*.example.com", ApplicationID: 5}})
+		ok(t, err)
+
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewBuffer(responseBodyBytes)),
+			Header:     make(http.Header),
+		}
+	})
+
+	obj, err := client.CreateApplicationReferrerFilter(3, 5, "*.example.com")
+	ok(t, err)
+	equals(t, int64(9), obj.Element.ID)
+}
+
+func TestAdminAPIClientUpgradeTenantPlan(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPut, req.Method)
+		equals(t, "/master/api/providers/3/plan_upgrade.json", req.URL.Path)
+		ok(t, req.ParseForm())
+		equals(t, "8", req.PostForm.Get("plan_id"))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+			Header:     make(http.Header),
+		}
+	})
+
+	ok(t, client.UpgradeTenantPlan(3, 8))
+}
+
+func TestAdminAPIClientCreateAccessToken(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPost, req.Method)
+		equals(t, "/admin/api/users/4/access_tokens.json", req.URL.Path)
+		ok(t, req.ParseForm())
+		equals(t, "ro", req.PostForm.Get("permission"))
+		equals(t, []string{"account_management", "stats"}, req.PostForm["scopes[]"])
+
+		responseBody := `{"access_token":{"id":9,"name":"read-only","permission":"ro","scopes":["account_management","stats"],"value":"abc"}}`
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	obj, err := client.CreateAccessToken(4, "read-only", AccessTokenPermissionReadOnly, []string{"account_management", "stats"})
+	ok(t, err)
+	equals(t, int64(9), obj.Element.ID)
+	equals(t, "abc", obj.Element.Value)
+}
+
+func TestAdminAPIClientUpdateMemberPermissions(t *testing.T) {
+	tests := []struct {
+		name              string
+		allowedSections   []string
+		allowedServiceIDs []int64
+		expected          url.Values
+	}{
+		{"all services", []string{"portal", "monitoring"}, nil,
+			url.Values{"allowed_sections[]": {"portal", "monitoring"}, "allowed_service_ids[]": {""}}},
+		{"no services", nil, []int64{},
+			url.Values{"allowed_sections": {"[]"}, "allowed_service_ids": {"[]"}}},
+		{"some services", []string{"plans"}, []int64{3, 5},
+			url.Values{"allowed_sections[]": {"plans"}, "allowed_service_ids[]": {"3", "5"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			client := newTestAdminAPIClient(subT, func(req *http.Request) *http.Response {
+				equals(subT, http.MethodPut, req.Method)
+				equals(subT, "/admin/api/users/4/permissions.json", req.URL.Path)
+				ok(subT, req.ParseForm())
+				equals(subT, tt.expected, req.PostForm)
+
+				responseBody := `{"permissions":{"user_id":4,"role":"member","allowed_service_ids":null,"allowed_sections":["portal"]}}`
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+					Header:     make(http.Header),
+				}
+			})
+
+			obj, err := client.UpdateMemberPermissions(4, tt.allowedSections, tt.allowedServiceIDs)
+			ok(subT, err)
+			equals(subT, int64(4), obj.Element.UserID)
+			assert(subT, obj.Element.AllowedServiceIDs == nil, "null allowed services expected")
+		})
+	}
+}
+
+func TestAdminAPIClientListCMSTemplates(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodGet, req.Method)
+		equals(t, "/admin/api/cms/templates.json", req.URL.Path)
+		equals(t, "true", req.URL.Query().Get("content"))
+
+		responseBody := `{"collection":[{"id":1,"type":"layout","system_name":"main_layout"}],"metadata":{"total_pages":2,"current_page":1}}`
+		if req.URL.Query().Get("page") == "2" {
+			responseBody = `{"collection":[{"id":2,"type":"page","path":"/about","layout_id":1}],"metadata":{"total_pages":2,"current_page":2}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	templates, err := client.ListCMSTemplates()
+	ok(t, err)
+	equals(t, 2, len(templates))
+	equals(t, "main_layout", templates[0].SystemName)
+	equals(t, int64(1), templates[1].LayoutID)
+}
+
+func TestAdminAPIClientCreateCMSFile(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPost, req.Method)
+		equals(t, "/admin/api/cms/files.json", req.URL.Path)
+		ok(t, req.ParseMultipartForm(1<<20))
+		equals(t, "/images/logo.png", req.FormValue("path"))
+
+		attachment, header, err := req.FormFile("attachment")
+		ok(t, err)
+		defer attachment.Close()
+		content, err := io.ReadAll(attachment)
+		ok(t, err)
+		equals(t, "logo.png", header.Filename)
+		equals(t, "PNG", string(content))
+
+		responseBody := `{"id":5,"path":"/images/logo.png","section_id":1}`
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	file, err := client.CreateCMSFile(threescaleapi.Params{"path": "/images/logo.png"}, "logo.png", []byte("PNG"))
+	ok(t, err)
+	equals(t, int64(5), file.ID)
+}
+
+func TestAdminAPIClientUpdateNotificationPreferences(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPut, req.Method)
+		equals(t, "/admin/api/users/7/notification_preferences.json", req.URL.Path)
+		ok(t, req.ParseForm())
+		equals(t, "true", req.PostForm.Get("preferences[account_created]"))
+		equals(t, "false", req.PostForm.Get("preferences[application_created]"))
+
+		responseBody := `{"notification_preferences":{"preferences":{"account_created":true,"application_created":false}}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	preferences, err := client.UpdateNotificationPreferences(7, map[string]bool{"account_created": true, "application_created": false})
+	ok(t, err)
+	equals(t, map[string]bool{"account_created": true, "application_created": false}, preferences.Element.Preferences)
+}
+
+func TestAdminAPIClientAccountFields(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodGet, req.Method)
+		equals(t, "/admin/api/accounts/3.json", req.URL.Path)
+
+		responseBody := `{"account":{"id":3,"telephone_number":"555","extra_fields":{"partner_id":"p-1","tier":null}}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	fields, err := client.AccountFields(3)
+	ok(t, err)
+	equals(t, map[string]string{"telephone_number": "555", "partner_id": "p-1"}, fields)
+}
+
+func TestAdminAPIClientFireAccountStateEvent(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodPut, req.Method)
+		equals(t, "/admin/api/accounts/3/make_pending.json", req.URL.Path)
+
+		responseBody := `{"account":{"id":3,"state":"pending"}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	account, err := client.FireAccountStateEvent(3, AccountStateEventMakePending)
+	ok(t, err)
+	equals(t, "pending", *account.Element.State)
+}
+
+func TestAdminAPIClientInvitation(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodGet, req.Method)
+		equals(t, "/admin/api/accounts/3/invitations/7.json", req.URL.Path)
+
+		responseBody := `{"invitation":{"id":7,"email":"dev@example.com","sent_at":"2021-03-01T10:00:00Z","accepted_at":"2021-03-02T10:00:00Z","user_id":5}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	invitation, err := client.Invitation(3, 7)
+	ok(t, err)
+	equals(t, true, invitation.IsAccepted())
+	equals(t, int64(5), *invitation.Element.UserID)
+	equals(t, "2021-03-01T10:00:00Z", invitation.Element.SentAt.Format(time.RFC3339))
+}
+
+func TestAdminAPIClientListInvitations(t *testing.T) {
+	client := newTestAdminAPIClient(t, func(req *http.Request) *http.Response {
+		equals(t, http.MethodGet, req.Method)
+		equals(t, "/admin/api/accounts/3/invitations.json", req.URL.Path)
+
+		responseBody := `{"invitations":[{"invitation":{"id":7,"email":"dev@example.com","sent_at":"2021-03-01T10:00:00Z"}},{"invitation":{"id":8,"email":"other@example.com","accepted_at":"2021-03-02T10:00:00Z","user_id":5}}]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			Header:     make(http.Header),
+		}
+	})
+
+	list, err := client.ListInvitations(3)
+	ok(t, err)
+	equals(t, 2, len(list.Invitations))
+	equals(t, "dev@example.com", list.Invitations[0].Element.Email)
+	equals(t, false, list.Invitations[0].IsAccepted())
+	equals(t, true, list.Invitations[1].IsAccepted())
+}
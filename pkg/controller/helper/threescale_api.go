@@ -40,6 +40,10 @@ func PortaClientFromURL(url *url.URL, token string, insecureSkipVerify bool) (*t
 		return nil, err
 	}
 
+	return threescaleapi.NewThreeScale(adminPortal, token, threescaleHTTPClient(insecureSkipVerify)), nil
+}
+
+func threescaleHTTPClient(insecureSkipVerify bool) *http.Client {
 	// Activated by some env var or Spec param
 	var transport http.RoundTripper = &http.Transport{
 		Proxy:           http.ProxyFromEnvironment,
@@ -50,7 +54,7 @@ func PortaClientFromURL(url *url.URL, token string, insecureSkipVerify bool) (*t
 		transport = &helper.Transport{Transport: transport}
 	}
 
-	return threescaleapi.NewThreeScale(adminPortal, token, &http.Client{Transport: transport}), nil
+	return &http.Client{Transport: transport}
 }
 
 // GetInsecureSkipVerifyAnnotation extracts the insecure_skip_verify annotation from an object
@@ -0,0 +1,301 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Authentication modes the OpenAPI global security requirements are mapped to
+const (
+	OpenAPIAuthenticationUserKey     = "UserKey"
+	OpenAPIAuthenticationAppIDAppKey = "AppIDAppKey"
+	OpenAPIAuthenticationOIDC        = "OIDC"
+)
+
+// Credentials of the x-3scale-credential security scheme extension
+const (
+	OasCredentialUserKey = "user_key"
+	OasCredentialAppID   = "app_id"
+	OasCredentialAppKey  = "app_key"
+)
+
+// OpenAPIAuthentication is the 3scale authentication mapped from the OpenAPI global security requirements
+type OpenAPIAuthentication struct {
+	Mode string
+	// SecurityRequirement is the index of the global security requirement the authentication is read from.
+	// -1 when the document does not declare global security requirements
+	SecurityRequirement int
+	UserKey             *ExtendedSecurityRequirement
+	AppID               *ExtendedSecurityRequirement
+	AppKey              *ExtendedSecurityRequirement
+	// Basic is the http basic scheme with the app_id as username and the app_key as password
+	Basic *ExtendedSecurityRequirement
+	OIDC  *ExtendedSecurityRequirement
+	// Reason describes the rule the authentication was chosen by
+	Reason string
+}
+
+// SecuritySchemes returns the security requirements the authentication is read from
+func (a *OpenAPIAuthentication) SecuritySchemes() []*ExtendedSecurityRequirement {
+	secRequirements := []*ExtendedSecurityRequirement{}
+	for _, secReq := range []*ExtendedSecurityRequirement{a.UserKey, a.AppID, a.AppKey, a.Basic, a.OIDC} {
+		if secReq != nil {
+			secRequirements = append(secRequirements, secReq)
+		}
+	}
+	return secRequirements
+}
+
+// SecuritySchemeNames returns the names of the security schemes the authentication is read from
+func (a *OpenAPIAuthentication) SecuritySchemeNames() []string {
+	names := []string{}
+	for _, secReq := range a.SecuritySchemes() {
+		names = append(names, secReq.Name)
+	}
+	return names
+}
+
+// CredentialsLocation returns the 3scale credentials location: query, headers or authorization.
+// Cookies are read from headers
+func (a *OpenAPIAuthentication) CredentialsLocation() string {
+	if a.Basic != nil {
+		return "authorization"
+	}
+	for _, secReq := range []*ExtendedSecurityRequirement{a.UserKey, a.AppID, a.AppKey} {
+		if secReq != nil {
+			return oasAPIKeyCredentialsLocation(secReq.Value.In)
+		}
+	}
+	return "query"
+}
+
+// CookieCredentials returns the apiKey security schemes located in cookies
+func (a *OpenAPIAuthentication) CookieCredentials() []*ExtendedSecurityRequirement {
+	secRequirements := []*ExtendedSecurityRequirement{}
+	for _, secReq := range []*ExtendedSecurityRequirement{a.UserKey, a.AppID, a.AppKey} {
+		if secReq != nil && secReq.Value.In == openapi3.ParameterInCookie {
+			secRequirements = append(secRequirements, secReq)
+		}
+	}
+	return secRequirements
+}
+
+// Description summarizes the authentication mode and credentials
+func (a *OpenAPIAuthentication) Description() string {
+	switch {
+	case a.Mode == OpenAPIAuthenticationOIDC:
+		return fmt.Sprintf("%s %s", a.Mode, a.OIDC.Value.Type)
+	case a.Basic != nil:
+		return fmt.Sprintf("%s in authorization", a.Mode)
+	}
+
+	credentials := []string{}
+	for _, secReq := range []*ExtendedSecurityRequirement{a.UserKey, a.AppID, a.AppKey} {
+		if secReq != nil {
+			credentials = append(credentials, fmt.Sprintf("%s in %s", secReq.Value.Name, secReq.Value.In))
+		}
+	}
+	if len(credentials) == 0 {
+		return fmt.Sprintf("%s user_key in query", a.Mode)
+	}
+	return fmt.Sprintf("%s %s", a.Mode, strings.Join(credentials, ", "))
+}
+
+// OasSecuritySchemeCredential returns the x-3scale-credential extension of the security scheme
+func OasSecuritySchemeCredential(scheme *openapi3.SecurityScheme) (string, error) {
+	type OasSecuritySchemeObject struct {
+		Credential string `json:"x-3scale-credential,omitempty"`
+	}
+
+	data, err := scheme.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	var x OasSecuritySchemeObject
+	if err = json.Unmarshal(data, &x); err != nil {
+		return "", err
+	}
+
+	return x.Credential, nil
+}
+
+// OpenAPIAuthenticationFromOpenAPI maps the OpenAPI global security requirements to a 3scale authentication.
+// Security requirements are alternatives, the first one that can be mapped is used:
+//   - one oauth2 or openIdConnect scheme: OpenID Connect
+//   - one http basic scheme: app_id and app_key in the authorization header
+//   - one apiKey scheme: user_key, or app_id when it is the app_id credential
+//   - two apiKey schemes, the app_id and the app_key credentials, in the same location: app_id and app_key
+//
+// apiKey schemes are the app_id or app_key credentials by the x-3scale-credential extension or by the name of the key or scheme.
+// Keys located in cookies are read from headers.
+// Without global security requirements, the authentication is user_key in query.
+func OpenAPIAuthenticationFromOpenAPI(obj *openapi3.T) (*OpenAPIAuthentication, error) {
+	if len(obj.Security) == 0 {
+		return &OpenAPIAuthentication{
+			Mode:                OpenAPIAuthenticationUserKey,
+			SecurityRequirement: -1,
+			Reason:              "no global security requirement, defaults to user_key in query",
+		}, nil
+	}
+
+	skipped := []string{}
+	for idx, secReq := range obj.Security {
+		names := sortedSecuritySchemeNames(secReq)
+		secRequirements := make([]*ExtendedSecurityRequirement, 0, len(names))
+		for _, name := range names {
+			secScheme, ok := obj.Components.SecuritySchemes[name]
+			if !ok || secScheme.Value == nil {
+				// should never happen. OpenAPI validation should detect this issue
+				continue
+			}
+			secRequirements = append(secRequirements, NewExtendedSecurityRequirement(name, secScheme, secReq[name]))
+		}
+
+		authentication, rule, err := openAPIAuthenticationFromSecurityRequirement(secRequirements)
+		if err != nil {
+			return nil, err
+		}
+
+		requirementDescription := fmt.Sprintf("security requirement %d [%s]", idx, strings.Join(names, ", "))
+		if authentication == nil {
+			skipped = append(skipped, fmt.Sprintf("%s skipped: %s", requirementDescription, rule))
+			continue
+		}
+
+		authentication.SecurityRequirement = idx
+		authentication.Reason = fmt.Sprintf("%s: %s", requirementDescription, rule)
+		if len(skipped) > 0 {
+			authentication.Reason = fmt.Sprintf("%s; %s", authentication.Reason, strings.Join(skipped, "; "))
+		}
+		return authentication, nil
+	}
+
+	return nil, fmt.Errorf("no supported security requirement: %s", strings.Join(skipped, "; "))
+}
+
+// openAPIAuthenticationFromSecurityRequirement maps the schemes of one security requirement.
+// Returns nil authentication and the reason when the requirement cannot be mapped
+func openAPIAuthenticationFromSecurityRequirement(secRequirements []*ExtendedSecurityRequirement) (*OpenAPIAuthentication, string, error) {
+	if len(secRequirements) == 0 {
+		return nil, "anonymous access is not supported", nil
+	}
+
+	for _, secReq := range secRequirements {
+		switch secReq.Value.Type {
+		case "oauth2", "openIdConnect":
+			if len(secRequirements) > 1 {
+				return nil, fmt.Sprintf("%s scheme %s can not be combined with other schemes", secReq.Value.Type, secReq.Name), nil
+			}
+			return &OpenAPIAuthentication{Mode: OpenAPIAuthenticationOIDC, OIDC: secReq}, fmt.Sprintf("%s scheme %s maps to OpenID Connect", secReq.Value.Type, secReq.Name), nil
+		case "http":
+			if !strings.EqualFold(secReq.Value.Scheme, "basic") {
+				return nil, fmt.Sprintf("http %s scheme %s is not supported", secReq.Value.Scheme, secReq.Name), nil
+			}
+			if len(secRequirements) > 1 {
+				return nil, fmt.Sprintf("http basic scheme %s can not be combined with other schemes", secReq.Name), nil
+			}
+			return &OpenAPIAuthentication{Mode: OpenAPIAuthenticationAppIDAppKey, Basic: secReq}, fmt.Sprintf("http basic scheme %s maps to app_id and app_key in the authorization header", secReq.Name), nil
+		case "apiKey":
+		default:
+			return nil, fmt.Sprintf("%s scheme %s is not supported", secReq.Value.Type, secReq.Name), nil
+		}
+	}
+
+	// apiKey schemes
+	credentials := map[string]*ExtendedSecurityRequirement{}
+	for _, secReq := range secRequirements {
+		credential, err := oasAPIKeyCredential(secReq)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, ok := credentials[credential]; ok {
+			return nil, fmt.Sprintf("more than one %s credential", credential), nil
+		}
+		credentials[credential] = secReq
+	}
+
+	location := ""
+	for _, secReq := range secRequirements {
+		credentialsLocation := oasAPIKeyCredentialsLocation(secReq.Value.In)
+		if location != "" && location != credentialsLocation {
+			return nil, "apiKey schemes must be located in query, or in headers and cookies", nil
+		}
+		location = credentialsLocation
+	}
+
+	cookieNote := ""
+	for _, secReq := range secRequirements {
+		if secReq.Value.In == openapi3.ParameterInCookie {
+			cookieNote = ", cookies are copied to headers"
+		}
+	}
+
+	authentication := &OpenAPIAuthentication{
+		UserKey: credentials[OasCredentialUserKey],
+		AppID:   credentials[OasCredentialAppID],
+		AppKey:  credentials[OasCredentialAppKey],
+	}
+
+	switch {
+	case len(secRequirements) == 1 && authentication.UserKey != nil:
+		authentication.Mode = OpenAPIAuthenticationUserKey
+		return authentication, fmt.Sprintf("apiKey scheme %s maps to user_key in %s%s", authentication.UserKey.Name, location, cookieNote), nil
+	case len(secRequirements) == 1 && authentication.AppID != nil:
+		authentication.Mode = OpenAPIAuthenticationAppIDAppKey
+		return authentication, fmt.Sprintf("apiKey scheme %s is the app_id credential, maps to app_id and app_key in %s%s", authentication.AppID.Name, location, cookieNote), nil
+	case len(secRequirements) == 2 && authentication.AppID != nil && authentication.AppKey != nil:
+		authentication.Mode = OpenAPIAuthenticationAppIDAppKey
+		return authentication, fmt.Sprintf("apiKey schemes %s and %s are the app_id and app_key credentials, map to app_id and app_key in %s%s", authentication.AppID.Name, authentication.AppKey.Name, location, cookieNote), nil
+	}
+
+	return nil, "apiKey schemes must be one user_key or app_id credential, or the app_id and app_key credentials", nil
+}
+
+// oasAPIKeyCredential returns the credential of the apiKey scheme, from the x-3scale-credential extension
+// or from the name of the key or of the scheme: app_id, X-App-Id, appId and similar names are the app_id credential
+func oasAPIKeyCredential(secReq *ExtendedSecurityRequirement) (string, error) {
+	credential, err := OasSecuritySchemeCredential(secReq.Value)
+	if err != nil {
+		return "", err
+	}
+
+	switch credential {
+	case OasCredentialUserKey, OasCredentialAppID, OasCredentialAppKey:
+		return credential, nil
+	case "":
+	default:
+		return "", fmt.Errorf("security scheme %s: unexpected x-3scale-credential %s, supported values: %s, %s, %s",
+			secReq.Name, credential, OasCredentialUserKey, OasCredentialAppID, OasCredentialAppKey)
+	}
+
+	for _, name := range []string{secReq.Value.Name, secReq.Name} {
+		normalizedName := strings.ToLower(NonAlphanumRegexp.ReplaceAllString(name, ""))
+		switch {
+		case strings.HasSuffix(normalizedName, "appid"):
+			return OasCredentialAppID, nil
+		case strings.HasSuffix(normalizedName, "appkey"):
+			return OasCredentialAppKey, nil
+		}
+	}
+
+	return OasCredentialUserKey, nil
+}
+
+func oasAPIKeyCredentialsLocation(in string) string {
+	if in == openapi3.ParameterInQuery {
+		return "query"
+	}
+	// header and cookie
+	return "headers"
+}
+
+func sortedSecuritySchemeNames(secReq openapi3.SecurityRequirement) []string {
+	names := MapKeys(secReq)
+	sort.Strings(names)
+	return names
+}
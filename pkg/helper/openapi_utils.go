@@ -38,8 +38,39 @@ type OasMappingRuleExtension struct {
 	Last *bool `json:"last,omitempty"`
 }
 
+// OasOperationLimitExtension is a limit of the operation method in an application plan
+type OasOperationLimitExtension struct {
+	Period string `json:"period"`
+	Value  int    `json:"value"`
+}
+
+// OasOperationPricingRuleExtension is a pricing rule of the operation method in an application plan
+type OasOperationPricingRuleExtension struct {
+	From         int    `json:"from"`
+	To           int    `json:"to"`
+	PricePerUnit string `json:"pricePerUnit"`
+}
+
 type OasOperationExtension struct {
 	MappingRule OasMappingRuleExtension `json:"mappingRule,omitempty"`
+	// Limits of the operation method. Map: application plan system name -> limits
+	Limits map[string][]OasOperationLimitExtension `json:"limits,omitempty"`
+	// Pricing rules of the operation method. Map: application plan system name -> pricing rules
+	PricingRules map[string][]OasOperationPricingRuleExtension `json:"pricingRules,omitempty"`
+	// RequestValidation set to false excludes the operation from the request validation policy
+	RequestValidation *bool `json:"requestValidation,omitempty"`
+}
+
+// OasBackendExtension is one of the backends of the x-3scale-backends root extension.
+// Operations are routed to the backend with the longest path prefix matching the operation path.
+type OasBackendExtension struct {
+	SystemName  string `json:"systemName"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Path prefix of the operations of the backend. It is also the path of the backend usage
+	Path           string                                    `json:"path"`
+	PrivateBaseURL string                                    `json:"privateBaseURL"`
+	Metrics        map[string]capabilitiesv1beta1.MetricSpec `json:"metrics,omitempty"`
 }
 
 func NewOasRootProductExtension(oas *openapi3.T) (*OasRootProductExtension, error) {
@@ -78,6 +109,52 @@ func NewOasOperationExtension(oas *openapi3.Operation) (*OasOperationExtension,
 	return x.Operation, nil
 }
 
+func NewOasBackendsExtension(oas *openapi3.T) ([]OasBackendExtension, error) {
+	type OasRootBackendsObject struct {
+		Backends []OasBackendExtension `json:"x-3scale-backends,omitempty"`
+	}
+
+	data, err := oas.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var x OasRootBackendsObject
+	if err = json.Unmarshal(data, &x); err != nil {
+		return nil, err
+	}
+
+	return x.Backends, nil
+}
+
+// OasBackendForPath returns the backend with the longest path prefix matching the path.
+// Prefixes match whole path segments. Returns nil when no backend matches.
+func OasBackendForPath(backends []OasBackendExtension, path string) *OasBackendExtension {
+	var selected *OasBackendExtension
+	for idx := range backends {
+		prefix := strings.TrimSuffix(backends[idx].Path, "/")
+		if prefix != "" && path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+
+		if selected == nil || len(prefix) > len(strings.TrimSuffix(selected.Path, "/")) {
+			selected = &backends[idx]
+		}
+	}
+
+	return selected
+}
+
+// OasBackendRelativePath returns the path relative to the path prefix of the backend
+func OasBackendRelativePath(backend *OasBackendExtension, path string) string {
+	relativePath := strings.TrimPrefix(path, strings.TrimSuffix(backend.Path, "/"))
+	if !strings.HasPrefix(relativePath, "/") {
+		relativePath = "/" + relativePath
+	}
+
+	return relativePath
+}
+
 func SystemNameFromOpenAPITitle(obj *openapi3.T) string {
 	openapiTitle := obj.Info.Title
 	return NonWordCharRegexp.ReplaceAllString(openapiTitle, "_")
@@ -157,12 +234,16 @@ func RenderOpenAPIServerURL(server *openapi3.Server) (*url.URL, error) {
 type ExtendedSecurityRequirement struct {
 	*openapi3.SecuritySchemeRef
 
+	// Name of the security scheme in the document components
+	Name string
+
 	Scopes []string
 }
 
-func NewExtendedSecurityRequirement(secSchemeRef *openapi3.SecuritySchemeRef, scopes []string) *ExtendedSecurityRequirement {
+func NewExtendedSecurityRequirement(name string, secSchemeRef *openapi3.SecuritySchemeRef, scopes []string) *ExtendedSecurityRequirement {
 	return &ExtendedSecurityRequirement{
 		SecuritySchemeRef: secSchemeRef,
+		Name:              name,
 		Scopes:            scopes,
 	}
 }
@@ -178,7 +259,7 @@ func OpenAPIGlobalSecurityRequirements(openapiObj *openapi3.T) []*ExtendedSecuri
 				continue
 			}
 
-			extendedSecRequirements = append(extendedSecRequirements, NewExtendedSecurityRequirement(secScheme, scopes))
+			extendedSecRequirements = append(extendedSecRequirements, NewExtendedSecurityRequirement(secReqItemName, secScheme, scopes))
 		}
 	}
 
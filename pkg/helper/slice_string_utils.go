@@ -1,5 +1,7 @@
 package helper
 
+import "sort"
+
 func ArrayStringDifference(a, b []string) []string {
 	target := map[string]bool{}
 	for _, x := range b {
@@ -77,3 +79,31 @@ func StringSliceEqualWithoutOrder(x, y []string) bool {
 
 	return len(diff) == 0
 }
+
+// ArrayStringManaged returns the sorted keys managed from a spec, nil when empty.
+// Until the spec is synchronized, previously managed keys are kept so they are deleted when removed from the spec
+func ArrayStringManaged(desired, previous []string, synchronized bool) []string {
+	managed := append([]string{}, desired...)
+	if !synchronized {
+		managed = append(managed, ArrayStringDifference(previous, desired)...)
+	}
+
+	if len(managed) == 0 {
+		return nil
+	}
+
+	sort.Strings(managed)
+	return managed
+}
+
+// ArrayStringNotDesired returns the existing keys to be deleted.
+// Keys are only managed when declared in the spec or previously managed:
+// when all the keys are removed from the spec, only the previously managed keys are deleted
+func ArrayStringNotDesired(existing, desired, managed []string) []string {
+	notDesired := ArrayStringDifference(existing, desired)
+	if len(desired) == 0 {
+		notDesired = ArrayStringIntersection(notDesired, managed)
+	}
+
+	return notDesired
+}
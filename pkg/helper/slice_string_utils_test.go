@@ -80,3 +80,54 @@ func TestStringSliceEqualWithoutOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestArrayStringManaged(t *testing.T) {
+	cases := []struct {
+		name            string
+		desired         []string
+		previous        []string
+		synchronized    bool
+		expectedManaged []string
+	}{
+		{"nothing managed", nil, nil, true, nil},
+		{"desired sorted", []string{"B", "A"}, nil, true, []string{"A", "B"}},
+		{"synchronized", []string{"A"}, []string{"A", "B"}, true, []string{"A"}},
+		{"not synchronized", []string{"A"}, []string{"A", "B"}, false, []string{"A", "B"}},
+		{"all removed and synchronized", nil, []string{"A"}, true, nil},
+		{"all removed and not synchronized", nil, []string{"A"}, false, []string{"A"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			managed := ArrayStringManaged(tc.desired, tc.previous, tc.synchronized)
+			if !reflect.DeepEqual(managed, tc.expectedManaged) {
+				diff := cmp.Diff(managed, tc.expectedManaged)
+				subT.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
+
+func TestArrayStringNotDesired(t *testing.T) {
+	cases := []struct {
+		name               string
+		existing           []string
+		desired            []string
+		managed            []string
+		expectedNotDesired []string
+	}{
+		{"not desired", []string{"A", "B", "C"}, []string{"A"}, nil, []string{"B", "C"}},
+		{"all removed", []string{"A", "B", "C"}, []string{}, []string{"A", "B"}, []string{"A", "B"}},
+		{"never managed", []string{"A", "B", "C"}, []string{}, nil, []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			notDesired := ArrayStringNotDesired(tc.existing, tc.desired, tc.managed)
+			if !reflect.DeepEqual(notDesired, tc.expectedNotDesired) {
+				diff := cmp.Diff(notDesired, tc.expectedNotDesired)
+				subT.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
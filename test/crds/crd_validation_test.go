@@ -9,7 +9,6 @@ import (
 	"testing"
 
 	apps "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
-	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/RHsyseng/operator-utils/pkg/validation"
 	"sigs.k8s.io/yaml"
@@ -30,6 +29,20 @@ const (
 	systemSearchdPVCResourceRequestsPath             = "/spec/system/searchdSpec/persistentVolumeClaim/resources/requests"
 	productPoliciesConfigurationPath                 = "/spec/policies/configuration"
 	policyConfigurationPath                          = "/spec/schema/configuration"
+	openAPIRefreshIntervalPath                       = "/spec/openapiRef/refreshInterval"
+	activeDocOpenAPIRefreshIntervalPath              = "/spec/activeDocOpenAPIRef/refreshInterval"
+	sourceFetchTimePath                              = "/status/sourceFetchTime"
+	tenantAccessTokensRotationIntervalPath           = "/spec/accessTokens/rotationInterval"
+	tenantAccessTokensLastRotationTimePath           = "/status/accessTokens/lastRotationTime"
+	tenantAccessTokensNextRotationTimePath           = "/status/accessTokens/nextRotationTime"
+	tenantDeletionGracePeriodPath                    = "/spec/deletion/gracePeriod"
+	tenantDeletionTimePath                           = "/status/deletionTime"
+	developerPortalContentRefreshIntervalPath        = "/spec/refreshInterval"
+	developerAccountStateTransitionTimePath          = "/status/stateTransitions/transitionTime"
+	developerUserInvitationExpiresAfterPath          = "/spec/invitation/expiresAfter"
+	developerUserInvitationSentTimePath              = "/status/invitation/sentTime"
+	developerUserInvitationAcceptedTimePath          = "/status/invitation/acceptedTime"
+	developerUserInvitationExpirationTimePath        = "/status/invitation/expirationTime"
 	resourceClaimsRegex                              = "^/([a-zA-Z]+)/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/claims.*"
 	podAffinityMatchLabelKeysRegex                   = "^/([a-zA-Z]+)/([a-zA-Z]+)/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?/.*DuringSchedulingIgnoredDuringExecution/(?:podAffinityTerm/)?(mis)?matchLabelKeys"
 	topologySpreadConstraintsMatchLabelKeysRegex     = "^/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/.*[tT]opologySpreadConstraints/matchLabelKeys$"
@@ -63,8 +76,8 @@ func TestSampleCustomResources(t *testing.T) {
 			apiVersion: apps.GroupVersion.Version,
 		},
 		"capabilities.3scale.net_tenants.yaml": {
-			crPrefix:   "capabilities_v1alpha1_tenant",
-			apiVersion: capabilitiesv1alpha1.GroupVersion.Version,
+			crPrefix:   "capabilities_v1beta1_tenant.yaml",
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
 		"capabilities.3scale.net_backends.yaml": {
 			crPrefix:   "capabilities_v1beta1_backend",
@@ -87,13 +100,25 @@ func TestSampleCustomResources(t *testing.T) {
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
 		"capabilities.3scale.net_developeraccounts.yaml": {
-			crPrefix:   "capabilities_v1beta1_developeraccount",
+			crPrefix:   "capabilities_v1beta1_developeraccount.yaml",
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
 		"capabilities.3scale.net_developerusers.yaml": {
 			crPrefix:   "capabilities_v1beta1_developeruser",
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_accountplans.yaml": {
+			crPrefix:   "capabilities_v1beta1_accountplan",
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
+		"capabilities.3scale.net_tenantmessagings.yaml": {
+			crPrefix:   "capabilities_v1beta1_tenantmessaging",
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
+		"capabilities.3scale.net_developeraccountsets.yaml": {
+			crPrefix:   "capabilities_v1beta1_developeraccountset",
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 	}
 
 	for crd, elem := range crdCrMap {
@@ -143,8 +168,8 @@ func TestCompleteCRD(t *testing.T) {
 			apiVersion: apps.GroupVersion.Version,
 		},
 		"capabilities.3scale.net_tenants.yaml": {
-			obj:        &capabilitiesv1alpha1.Tenant{},
-			apiVersion: capabilitiesv1alpha1.GroupVersion.Version,
+			obj:        &capabilitiesv1beta1.Tenant{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
 		"capabilities.3scale.net_backends.yaml": {
 			obj:        &capabilitiesv1beta1.Backend{},
@@ -174,6 +199,26 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &capabilitiesv1beta1.DeveloperUser{},
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_accountplans.yaml": {
+			obj:        &capabilitiesv1beta1.AccountPlan{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
+		"capabilities.3scale.net_tenantmessagings.yaml": {
+			obj:        &capabilitiesv1beta1.TenantMessaging{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
+		"capabilities.3scale.net_developeraccountsets.yaml": {
+			obj:        &capabilitiesv1beta1.DeveloperAccountSet{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
+		"capabilities.3scale.net_providerusers.yaml": {
+			obj:        &capabilitiesv1beta1.ProviderUser{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
+		"capabilities.3scale.net_developerportalcontents.yaml": {
+			obj:        &capabilitiesv1beta1.DeveloperPortalContent{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 	}
 
 	pathOmissions := []string{
@@ -186,6 +231,20 @@ func TestCompleteCRD(t *testing.T) {
 		systemPostgreSQLPVCResourceRequestsPath,
 		productPoliciesConfigurationPath,
 		policyConfigurationPath,
+		openAPIRefreshIntervalPath,
+		activeDocOpenAPIRefreshIntervalPath,
+		sourceFetchTimePath,
+		tenantAccessTokensRotationIntervalPath,
+		tenantAccessTokensLastRotationTimePath,
+		tenantAccessTokensNextRotationTimePath,
+		tenantDeletionGracePeriodPath,
+		tenantDeletionTimePath,
+		developerPortalContentRefreshIntervalPath,
+		developerAccountStateTransitionTimePath,
+		developerUserInvitationExpiresAfterPath,
+		developerUserInvitationSentTimePath,
+		developerUserInvitationAcceptedTimePath,
+		developerUserInvitationExpirationTimePath,
 		systemSearchdResourceRequestsPath,
 		systemSearchdPVCResourceRequestsPath,
 	}